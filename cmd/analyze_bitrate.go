@@ -0,0 +1,165 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/spf13/cobra"
+)
+
+var analyzeBitrateCmd = &cobra.Command{
+	Use:   "analyze-bitrate [input]",
+	Short: "Graph bitrate over time and highlight spikes",
+	Long: `Sample the bitrate of a media file for every second of playback and draw
+it as a graph in the terminal. Seconds that exceed the average by the spike
+factor are highlighted, since such peaks cause buffering when streaming.
+
+Packets are read without decoding, so even long files are analyzed quickly.
+
+Examples:
+  transcoder analyze-bitrate input.mp4
+  transcoder analyze-bitrate stream.mkv --spike 1.5 --width 120`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAnalyzeBitrate,
+}
+
+var (
+	analyzeBitrateWidth int
+	analyzeBitrateSpike float64
+)
+
+// sparkLevels are the block characters used to draw the graph, lowest first
+var sparkLevels = []rune("▁▂▃▄▅▆▇█")
+
+// maxListedSpikes limits how many spikes are listed below the graph
+const maxListedSpikes = 10
+
+func init() {
+	rootCmd.AddCommand(analyzeBitrateCmd)
+
+	analyzeBitrateCmd.Flags().IntVar(&analyzeBitrateWidth, "width", 80,
+		"graph width in columns")
+
+	analyzeBitrateCmd.Flags().Float64Var(&analyzeBitrateSpike, "spike", 2.0,
+		"highlight seconds above this multiple of the average bitrate")
+}
+
+func runAnalyzeBitrate(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file path
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if analyzeBitrateWidth < 10 || analyzeBitrateWidth > 500 {
+		return fmt.Errorf("invalid width %d (must be between 10 and 500)", analyzeBitrateWidth)
+	}
+	if analyzeBitrateSpike <= 1 {
+		return fmt.Errorf("invalid spike factor %.2f (must be greater than 1)", analyzeBitrateSpike)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	// Check if ffprobe is available
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	profile, err := analyzer.AnalyzeBitrate(cmd.Context(), inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to analyze bitrate: %w", err)
+	}
+
+	spikes := profile.Spikes(analyzeBitrateSpike)
+	graph, secondsPerColumn := renderBitrateGraph(profile, analyzeBitrateWidth, analyzeBitrateSpike)
+
+	if quiet {
+		fmt.Println(graph)
+		return nil
+	}
+
+	duration := time.Duration(len(profile.PerSecond)) * time.Second
+	color.Cyan("📈 Bitrate Over Time: %s", inputFile)
+	fmt.Println()
+	fmt.Printf("   Duration: %s (1 column = %ds)\n", formatDuration(duration), secondsPerColumn)
+	fmt.Printf("   Average:  %s\n", formatBitrate(profile.Average))
+	fmt.Printf("   Peak:     %s at %s\n", formatBitrate(profile.Peak),
+		formatDuration(time.Duration(profile.PeakAt)*time.Second))
+	fmt.Println()
+
+	peakLabel := formatBitrate(profile.Peak)
+	fmt.Printf("   %s ┤%s\n", peakLabel, graph)
+	startLabel, endLabel := "00:00:00", formatDuration(duration)
+	columns := (len(profile.PerSecond) + secondsPerColumn - 1) / secondsPerColumn
+	padding := max(columns-len(startLabel)-len(endLabel), 1)
+	fmt.Printf("   %s  %s%s%s\n", strings.Repeat(" ", len(peakLabel)), startLabel, strings.Repeat(" ", padding), endLabel)
+	fmt.Println()
+
+	displayBitrateSpikes(profile, spikes)
+	return nil
+}
+
+// renderBitrateGraph draws the per-second bitrate as a sparkline no wider than width.
+// Each column shows the highest second it covers so short spikes stay visible.
+func renderBitrateGraph(profile *analyzer.BitrateProfile, width int, spikeFactor float64) (string, int) {
+	secondsPerColumn := (len(profile.PerSecond) + width - 1) / width
+	threshold := int64(float64(profile.Average) * spikeFactor)
+
+	var graph strings.Builder
+	for start := 0; start < len(profile.PerSecond); start += secondsPerColumn {
+		end := min(start+secondsPerColumn, len(profile.PerSecond))
+
+		var bits int64
+		for _, value := range profile.PerSecond[start:end] {
+			bits = max(bits, value)
+		}
+
+		level := 0
+		if profile.Peak > 0 {
+			level = int(float64(bits) / float64(profile.Peak) * float64(len(sparkLevels)-1))
+		}
+		block := string(sparkLevels[level])
+
+		if bits > threshold {
+			block = color.RedString(block)
+		}
+		graph.WriteString(block)
+	}
+
+	return graph.String(), secondsPerColumn
+}
+
+// displayBitrateSpikes lists the highest seconds above the spike threshold
+func displayBitrateSpikes(profile *analyzer.BitrateProfile, spikes []int) {
+	threshold := int64(float64(profile.Average) * analyzeBitrateSpike)
+	if len(spikes) == 0 {
+		color.Green("✅ No spikes above %.1fx the average (%s)", analyzeBitrateSpike, formatBitrate(threshold))
+		return
+	}
+
+	color.Yellow("⚠️  %d second(s) above %.1fx the average (%s):", len(spikes), analyzeBitrateSpike, formatBitrate(threshold))
+
+	// Show the largest spikes first
+	sort.Slice(spikes, func(i, j int) bool {
+		return profile.PerSecond[spikes[i]] > profile.PerSecond[spikes[j]]
+	})
+	for _, second := range spikes[:min(len(spikes), maxListedSpikes)] {
+		fmt.Printf("   %s  %s\n", formatDuration(time.Duration(second)*time.Second), formatBitrate(profile.PerSecond[second]))
+	}
+	if len(spikes) > maxListedSpikes {
+		fmt.Printf("   ... and %d more\n", len(spikes)-maxListedSpikes)
+	}
+	fmt.Println()
+	fmt.Println("   Peaks like these can stall playback on connections sized for the average bitrate.")
+}
@@ -0,0 +1,202 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+// attachmentsCmd represents the attachments command
+var attachmentsCmd = &cobra.Command{
+	Use:   "attachments",
+	Short: "List and extract files attached to MKV containers",
+	Long: `Matroska files can carry attached files: the fonts ASS subtitles are drawn
+with, cover images and other extras. Players use them, but they are lost when
+the video is converted or remuxed into a container without attachments.
+
+Examples:
+  transcoder attachments list input.mkv
+  transcoder attachments extract input.mkv fonts/`,
+}
+
+var attachmentsListCmd = &cobra.Command{
+	Use:   "list [input]",
+	Short: "List the attached files of a video",
+	Long: `List the files attached to a video with their stream index, MIME type and
+size.
+
+Examples:
+  transcoder attachments list input.mkv`,
+	Args: cobra.ExactArgs(1),
+	RunE: runAttachmentsList,
+}
+
+var attachmentsExtractCmd = &cobra.Command{
+	Use:   "extract [input] [output-dir]",
+	Short: "Write the attached files of a video to a directory",
+	Long: `Write every file attached to a video into the output directory, under the
+name stored with it. Attachments without a usable name are named after their
+stream index (attachment5.ttf). The directory is created if needed.
+
+Use --fonts to extract only the fonts, for example to install them before
+burning in ASS subtitles or to keep them for a remux.
+
+Examples:
+  transcoder attachments extract input.mkv attachments/
+  transcoder attachments extract anime.mkv fonts/ --fonts`,
+	Args: cobra.ExactArgs(2),
+	RunE: runAttachmentsExtract,
+}
+
+var (
+	attachmentsFonts bool
+	attachmentsForce bool
+)
+
+func init() {
+	rootCmd.AddCommand(attachmentsCmd)
+	attachmentsCmd.AddCommand(attachmentsListCmd)
+	attachmentsCmd.AddCommand(attachmentsExtractCmd)
+
+	attachmentsExtractCmd.Flags().BoolVar(&attachmentsFonts, "fonts", false,
+		"extract only font attachments")
+
+	attachmentsExtractCmd.Flags().BoolVarP(&attachmentsForce, "force", "f", false,
+		"overwrite files that already exist in the output directory")
+}
+
+func runAttachmentsList(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+
+	// Security validation for file paths
+	if err := security.NewDefaultSecurityPolicy().ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	// Validate input file exists
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	attachments, err := transcoder.ListAttachments(cmd.Context(), inputFile)
+	if err != nil {
+		return fmt.Errorf("listing attachments failed: %w", err)
+	}
+
+	if len(attachments) == 0 {
+		if !quiet {
+			fmt.Printf("No attachments in %s\n", inputFile)
+		}
+		return nil
+	}
+
+	// Quiet mode prints only the names, for scripts
+	if quiet {
+		for _, attachment := range attachments {
+			fmt.Println(valueOrDash(attachment.Filename))
+		}
+		return nil
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "STREAM\tFILENAME\tTYPE\tSIZE")
+	for _, attachment := range attachments {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", attachment.Index, valueOrDash(attachment.Filename),
+			valueOrDash(attachment.MimeType), attachmentSize(attachment.Size))
+	}
+	table.Flush()
+	return nil
+}
+
+func runAttachmentsExtract(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputDir, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputDir); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	// Validate input file exists
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if fileExists(outputDir) && !isDirectory(outputDir) {
+		return fmt.Errorf("output must be a directory: %s", outputDir)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("📎 Extracting Attachments")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputDir)
+		if attachmentsFonts {
+			fmt.Println("   Only:    fonts")
+		}
+		fmt.Println()
+	}
+
+	extracted, err := transcoder.ExtractAttachments(cmd.Context(), transcoder.AttachmentParams{
+		InputFile: inputFile,
+		OutputDir: outputDir,
+		FontsOnly: attachmentsFonts,
+		Overwrite: attachmentsForce,
+		Verbose:   useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("attachment extraction failed: %w", err)
+	}
+
+	if quiet {
+		return nil
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	for _, attachment := range extracted {
+		fmt.Fprintf(table, "   %s\t%s\t%s\n", attachment.OutputFile, valueOrDash(attachment.MimeType), formatBytes(attachment.Size))
+	}
+	table.Flush()
+	fmt.Println()
+
+	color.Green("✅ %d attachment(s) extracted successfully!", len(extracted))
+	fmt.Printf("Output saved to: %s\n", outputDir)
+	return nil
+}
+
+// attachmentSize renders the size of an attachment, which ffprobe does not always report
+func attachmentSize(size int64) string {
+	if size <= 0 {
+		return "-"
+	}
+	return formatBytes(size)
+}
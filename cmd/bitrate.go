@@ -0,0 +1,126 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var bitrateCmd = &cobra.Command{
+	Use:   "bitrate [input]",
+	Short: "Calculate the video bitrate needed to hit a target file size",
+	Long: `Calculate the video bitrate that makes a conversion land on a target file
+size, and print ready-to-paste convert flags.
+
+The duration is given with --duration or read from an input file.
+Sizes use 1024-based units (1GB = 1024MB), like the sizes shown by info,
+and 2% of the target is reserved for container overhead.
+
+Examples:
+  transcoder bitrate --duration 1h23m --target-size 2GB --audio 192k
+  transcoder bitrate --duration 01:23:00 --target-size 700MB
+  transcoder bitrate movie.mkv --target-size 4GB`,
+	Args: cobra.MaximumNArgs(1),
+	RunE: runBitrate,
+}
+
+var (
+	bitrateDuration   string
+	bitrateTargetSize string
+	bitrateAudio      string
+)
+
+func init() {
+	rootCmd.AddCommand(bitrateCmd)
+
+	bitrateCmd.Flags().StringVar(&bitrateDuration, "duration", "",
+		"media duration (e.g., 1h23m, 90s, 01:23:00)")
+
+	bitrateCmd.Flags().StringVar(&bitrateTargetSize, "target-size", "",
+		"target file size (e.g., 2GB, 700MB)")
+
+	bitrateCmd.Flags().StringVar(&bitrateAudio, "audio", "192k",
+		"audio bitrate (e.g., 192k, 128k)")
+}
+
+func runBitrate(cmd *cobra.Command, args []string) error {
+	if bitrateTargetSize == "" {
+		return fmt.Errorf("--target-size is required")
+	}
+	if (len(args) == 1) == (bitrateDuration != "") {
+		return fmt.Errorf("give either an input file or --duration")
+	}
+
+	securityPolicy := security.NewDefaultSecurityPolicy()
+	if err := securityPolicy.ValidateBitrate(bitrateAudio); err != nil {
+		return fmt.Errorf("invalid audio bitrate: %w", err)
+	}
+
+	duration, err := resolveBitrateDuration(cmd.Context(), args)
+	if err != nil {
+		return err
+	}
+
+	targetSize, err := transcoder.ParseSize(bitrateTargetSize)
+	if err != nil {
+		return err
+	}
+
+	audioBitrate, err := transcoder.ParseBitrate(bitrateAudio)
+	if err != nil {
+		return fmt.Errorf("invalid audio bitrate: %w", err)
+	}
+
+	videoBitrate, err := transcoder.CalculateVideoBitrate(duration, targetSize, audioBitrate)
+	if err != nil {
+		return err
+	}
+
+	flags := fmt.Sprintf("--video-bitrate %dk --audio-bitrate %s", videoBitrate/1000, bitrateAudio)
+	if quiet {
+		fmt.Println(flags)
+		return nil
+	}
+
+	color.Cyan("🧮 Bitrate Calculation")
+	fmt.Println()
+	fmt.Printf("   Duration:     %s\n", formatDuration(duration))
+	fmt.Printf("   Target Size:  %s\n", formatBytes(targetSize))
+	fmt.Printf("   Audio:        %s\n", formatBitrate(audioBitrate))
+	fmt.Printf("   Video:        %s\n", formatBitrate(videoBitrate))
+	fmt.Println()
+	color.Green("📋 Convert flags:")
+	fmt.Printf("   %s\n", flags)
+	return nil
+}
+
+// resolveBitrateDuration returns the --duration value or the duration of the input file
+func resolveBitrateDuration(ctx context.Context, args []string) (time.Duration, error) {
+	if bitrateDuration != "" {
+		return transcoder.ParseDuration(bitrateDuration)
+	}
+
+	securityPolicy := security.NewDefaultSecurityPolicy()
+	if err := securityPolicy.ValidateFilePath(args[0]); err != nil {
+		return 0, fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := analyzer.CheckFFProbe(ctx); err != nil {
+		return 0, fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	info, err := analyzer.AnalyzeMedia(ctx, args[0])
+	if err != nil {
+		return 0, fmt.Errorf("failed to analyze media: %w", err)
+	}
+	if info.Duration <= 0 {
+		return 0, fmt.Errorf("could not determine the duration of %s (use --duration)", args[0])
+	}
+	return info.Duration, nil
+}
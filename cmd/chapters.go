@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+// chaptersCmd represents the chapters command
+var chaptersCmd = &cobra.Command{
+	Use:   "chapters",
+	Short: "Edit the chapter marks of media files",
+	Long: `Edit the chapter marks players use to jump between the parts of a video.
+
+Examples:
+  transcoder chapters set video.mkv chapters.txt`,
+}
+
+var chaptersSetCmd = &cobra.Command{
+	Use:   "set [input] [chapters] [output]",
+	Short: "Add or replace the chapters of a video without re-encoding",
+	Long: `Replace the chapters of a video with those of a chapters file. Every stream
+and the metadata are copied without re-encoding; chapters already in the
+file are dropped.
+
+Without an output the input is replaced once the new file is complete.
+
+The chapters file is either an FFmetadata file (as written by
+'ffmpeg -i video.mkv -f ffmetadata chapters.txt') or a list with one chapter
+per line, as used in YouTube descriptions:
+
+  00:00 Intro
+  01:30 - Setting up
+  1:02:15.500 Questions
+
+Each chapter runs until the next one starts, the last until the end of the
+video. Chapters can be written to MKV, MP4, MOV and WebM files.
+
+Examples:
+  transcoder chapters set video.mkv chapters.txt
+  transcoder chapters set lecture.mp4 chapters.txt lecture-chapters.mp4
+  transcoder chapters set movie.mkv movie.ffmetadata movie.mp4 --force`,
+	Args: cobra.RangeArgs(2, 3),
+	RunE: runChaptersSet,
+}
+
+var chaptersForce bool
+
+func init() {
+	rootCmd.AddCommand(chaptersCmd)
+	chaptersCmd.AddCommand(chaptersSetCmd)
+
+	chaptersSetCmd.Flags().BoolVarP(&chaptersForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runChaptersSet(cmd *cobra.Command, args []string) error {
+	inputFile, chaptersFile := args[0], args[1]
+	outputFile := ""
+	if len(args) == 3 {
+		var err error
+		if outputFile, err = resolveOutputPath(args[2]); err != nil {
+			return err
+		}
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(chaptersFile); err != nil {
+		return fmt.Errorf("security validation failed for chapters path: %w", err)
+	}
+
+	if outputFile != "" {
+		if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+			return fmt.Errorf("security validation failed for output path: %w", err)
+		}
+	}
+
+	// Validate input files exist
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if !fileExists(chaptersFile) {
+		return fmt.Errorf("chapters %w: %s", analyzer.ErrFileNotFound, chaptersFile)
+	}
+
+	// Check if output file exists and handle overwrite
+	if outputFile != "" && fileExists(outputFile) && !chaptersForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if outputFile != "" && sameFile(inputFile, outputFile) {
+		return fmt.Errorf("output is the input; leave out the output to replace the input")
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("📑 Setting Chapters")
+		fmt.Println()
+		fmt.Printf("   Input:    %s\n", inputFile)
+		fmt.Printf("   Chapters: %s\n", chaptersFile)
+		if outputFile != "" {
+			fmt.Printf("   Output:   %s\n", outputFile)
+		} else {
+			fmt.Printf("   Output:   %s (replaced)\n", inputFile)
+		}
+		fmt.Println()
+	}
+
+	chapters, err := transcoder.SetChapters(cmd.Context(), transcoder.ChapterParams{
+		InputFile:    inputFile,
+		ChaptersFile: chaptersFile,
+		OutputFile:   outputFile,
+		Verbose:      useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("setting chapters failed: %w", err)
+	}
+
+	if quiet {
+		return nil
+	}
+
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "#\tSTART\tEND\tTITLE")
+	for i, chapter := range chapters {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", i+1, formatSceneTime(chapter.Start), formatSceneTime(chapter.End), chapter.Title)
+	}
+	table.Flush()
+	fmt.Println()
+
+	color.Green("✅ %d chapter(s) written successfully!", len(chapters))
+	if outputFile == "" {
+		outputFile = inputFile
+	}
+	fmt.Printf("Output saved to: %s\n", outputFile)
+	return nil
+}
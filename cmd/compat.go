@@ -0,0 +1,92 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+// compatCmd represents the compat command
+var compatCmd = &cobra.Command{
+	Use:   "compat [format]",
+	Short: "Show which codecs are stream copied or re-encoded for a container",
+	Long: `Display the codec compatibility matrix for an output container.
+
+The matrix is the same table the convert command uses to decide whether
+streams can be copied without re-encoding, so you can predict the
+behavior of a conversion before running it.
+
+Examples:
+  transcoder compat mp4
+  transcoder compat webm`,
+	Args: cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runCompat(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(compatCmd)
+}
+
+func runCompat(format string) error {
+	format = strings.ToLower(strings.TrimPrefix(format, "."))
+
+	compat, ok, err := transcoder.GetContainerCompatibility(format)
+	if err != nil {
+		return fmt.Errorf("failed to load codec compatibility data: %w", err)
+	}
+	if !ok || !transcoder.SupportedFormats[format] {
+		return fmt.Errorf("unsupported format: %s (supported: %s)", format, strings.Join(supportedFormatList(), ", "))
+	}
+
+	displayCompatibility(format, compat)
+	return nil
+}
+
+// supportedFormatList returns the supported output formats in sorted order
+func supportedFormatList() []string {
+	formats := make([]string, 0, len(transcoder.SupportedFormats))
+	for format := range transcoder.SupportedFormats {
+		formats = append(formats, format)
+	}
+	sort.Strings(formats)
+	return formats
+}
+
+// displayCompatibility renders the compatibility matrix for a container
+func displayCompatibility(format string, compat transcoder.ContainerCompatibility) {
+	color.Cyan("📦 Container Compatibility: %s", strings.ToUpper(format))
+	fmt.Println()
+
+	color.Green("🎥 Video:")
+	displayCodecCompatibility(compat.VideoCodecs, compat.DefaultVideoCodec)
+
+	color.Magenta("🔊 Audio:")
+	displayCodecCompatibility(compat.AudioCodecs, compat.DefaultAudioCodec)
+
+	color.Blue("💬 Subtitles:")
+	if len(compat.SubtitleCodecs) > 0 {
+		fmt.Printf("   Supported: %s\n", transcoder.FormatCodecEntries(compat.SubtitleCodecs))
+	} else {
+		fmt.Println("   Supported: none (subtitle streams are dropped)")
+	}
+	fmt.Println()
+
+	color.Yellow("ℹ️  Notes:")
+	fmt.Println("   Stream copy is used only when both the first video and audio streams")
+	fmt.Println("   are compatible and no preset or custom parameters are given.")
+	fmt.Printf("   Override this table with: %s\n", transcoder.CompatibilityOverridePath())
+	fmt.Println()
+}
+
+// displayCodecCompatibility renders the copy/re-encode split for one stream type
+func displayCodecCompatibility(codecs []transcoder.CodecEntry, defaultCodec string) {
+	fmt.Printf("   Stream copy: %s\n", transcoder.FormatCodecEntries(codecs))
+	fmt.Printf("   Re-encode:   %s (used when stream copy is not possible)\n", defaultCodec)
+	fmt.Println()
+}
@@ -0,0 +1,346 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/config"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// configOutputDir is the configured directory for outputs given as a bare file name
+var configOutputDir string
+
+// configCmd represents the config command
+var configCmd = &cobra.Command{
+	Use:   "config",
+	Short: "View and edit settings and per-command default flags",
+	Long: `View and edit the configuration file (~/.config/transcoder/config.yaml on Linux).
+
+General settings:
+  output_dir     directory for outputs given as a bare file name (e.g., out.mp4)
+  verbosity      quiet, normal or verbose, unless --verbose or --quiet is given
+  ffmpeg_path    FFmpeg executable to use instead of the one in PATH
+  ffprobe_path   FFprobe executable to use instead of the one in PATH
+
+Default flags have keys of the form command.flag. A configured default is used
+whenever the flag is not given on the command line; explicit flags always win.
+
+Examples:
+  transcoder config set output_dir ~/Videos/transcoded
+  transcoder config set verbosity normal
+  transcoder config set ffmpeg_path /opt/ffmpeg/bin/ffmpeg
+  transcoder config set convert.preset high
+  transcoder config set extract.quality high
+  transcoder config set extract.channels 2
+  transcoder config get extract.quality
+  transcoder config list
+  transcoder config unset extract.channels`,
+}
+
+var configSetCmd = &cobra.Command{
+	Use:   "set [setting|command.flag] [value]",
+	Short: "Set a setting or a default flag value for a command",
+	Args:  cobra.ExactArgs(2),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigSet(args[0], args[1])
+	},
+}
+
+var configGetCmd = &cobra.Command{
+	Use:   "get [setting|command.flag]",
+	Short: "Show a setting or the default flag value for a command",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigGet(args[0])
+	},
+}
+
+var configUnsetCmd = &cobra.Command{
+	Use:   "unset [setting|command.flag]",
+	Short: "Remove a setting or a default flag value for a command",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigUnset(args[0])
+	},
+}
+
+var configListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all configured settings and default flag values",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runConfigList()
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(configCmd)
+	configCmd.AddCommand(configSetCmd, configGetCmd, configUnsetCmd, configListCmd)
+}
+
+func runConfigSet(key, value string) error {
+	if config.IsSetting(key) {
+		return runConfigSetSetting(key, value)
+	}
+
+	commandPath, flagName, err := config.SplitKey(key)
+	if err != nil {
+		return err
+	}
+
+	target, err := findConfigurableCommand(commandPath)
+	if err != nil {
+		return err
+	}
+
+	flag := target.Flags().Lookup(flagName)
+	if flag == nil {
+		flag = target.InheritedFlags().Lookup(flagName)
+	}
+	if flag == nil {
+		return fmt.Errorf("unknown flag '%s' for command '%s'", flagName, commandPath)
+	}
+
+	// Parse the value with the flag's own type so mistakes surface now, not on the next run
+	if err := flag.Value.Set(value); err != nil {
+		return fmt.Errorf("invalid value for %s: %w", key, err)
+	}
+
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	cfg.Set(commandPath, flagName, value)
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	if !quiet {
+		color.Green("✅ %s = %s", key, value)
+	}
+	return nil
+}
+
+func runConfigGet(key string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	value, ok := lookupConfigKey(cfg, key)
+	if !ok {
+		return fmt.Errorf("nothing configured for %s", key)
+	}
+
+	fmt.Println(value)
+	return nil
+}
+
+func runConfigUnset(key string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	removed := false
+	if config.IsSetting(key) {
+		removed = cfg.UnsetSetting(key)
+	} else {
+		commandPath, flagName, err := config.SplitKey(key)
+		if err != nil {
+			return err
+		}
+		removed = cfg.Unset(commandPath, flagName)
+	}
+	if !removed {
+		return fmt.Errorf("nothing configured for %s", key)
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	if !quiet {
+		color.Green("✅ Removed %s", key)
+	}
+	return nil
+}
+
+func runConfigList() error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	path, err := config.Path()
+	if err != nil {
+		return err
+	}
+
+	keys := cfg.Keys()
+	if !quiet {
+		color.Cyan("⚙️  Configuration: %s", path)
+		fmt.Println()
+	}
+
+	if len(keys) == 0 {
+		if !quiet {
+			fmt.Println("   Nothing configured")
+		}
+		return nil
+	}
+
+	for _, key := range keys {
+		value, _ := lookupConfigKey(cfg, key)
+		fmt.Printf("   %s = %s\n", key, value)
+	}
+	return nil
+}
+
+// runConfigSetSetting stores a general setting
+func runConfigSetSetting(key, value string) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+	if err := cfg.SetSetting(key, value); err != nil {
+		return err
+	}
+	if err := cfg.Save(); err != nil {
+		return err
+	}
+
+	if !quiet {
+		color.Green("✅ %s = %s", key, value)
+	}
+	return nil
+}
+
+// lookupConfigKey returns the value of a general setting or a "command.flag" default
+func lookupConfigKey(cfg *config.Config, key string) (string, bool) {
+	if config.IsSetting(key) {
+		return cfg.GetSetting(key)
+	}
+	commandPath, flagName, err := config.SplitKey(key)
+	if err != nil {
+		return "", false
+	}
+	return cfg.Get(commandPath, flagName)
+}
+
+// commandKey returns the configuration key for a command (e.g., "extract")
+func commandKey(cmd *cobra.Command) string {
+	path := strings.TrimPrefix(cmd.CommandPath(), cmd.Root().Name())
+	return strings.Join(strings.Fields(path), ".")
+}
+
+// findConfigurableCommand resolves a dotted command path (e.g., "extract") to a command
+func findConfigurableCommand(commandPath string) (*cobra.Command, error) {
+	target, _, err := rootCmd.Find(strings.Split(commandPath, "."))
+	if err != nil || target == rootCmd || commandKey(target) != commandPath {
+		return nil, fmt.Errorf("unknown command: %s", commandPath)
+	}
+	if target == configCmd || target.Parent() == configCmd {
+		return nil, fmt.Errorf("defaults cannot be configured for the config command")
+	}
+	return target, nil
+}
+
+// applyConfigDefaults applies the general settings and sets configured default flag values
+// that were not given on the command line
+func applyConfigDefaults(cmd *cobra.Command) error {
+	cfg, err := config.Load()
+	if err != nil {
+		return err
+	}
+
+	if err := applyConfigSettings(cmd, cfg); err != nil {
+		return err
+	}
+
+	if cmd == configCmd || cmd.Parent() == configCmd {
+		return nil
+	}
+
+	// Remember the flags given on the command line, which also win over a --profile preset
+	explicit := make(map[string]bool)
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		explicit[flag.Name] = true
+	})
+
+	for flagName, value := range cfg.Defaults[commandKey(cmd)] {
+		flag := cmd.Flags().Lookup(flagName)
+		if flag == nil {
+			return fmt.Errorf("config default %s.%s: unknown flag", commandKey(cmd), flagName)
+		}
+		if flag.Changed {
+			continue // Explicit command line flags win
+		}
+		if err := cmd.Flags().Set(flagName, value); err != nil {
+			return fmt.Errorf("config default %s.%s: %w", commandKey(cmd), flagName, err)
+		}
+	}
+
+	return applyProfile(cmd, explicit)
+}
+
+// applyConfigSettings applies the general settings; --verbose and --quiet override the verbosity
+func applyConfigSettings(cmd *cobra.Command, cfg *config.Config) error {
+	if cfg.FFmpegPath != "" {
+		path, err := config.ExpandHome(cfg.FFmpegPath)
+		if err != nil {
+			return err
+		}
+		analyzer.FFmpegPath = path
+	}
+	if cfg.FFprobePath != "" {
+		path, err := config.ExpandHome(cfg.FFprobePath)
+		if err != nil {
+			return err
+		}
+		analyzer.FFprobePath = path
+	}
+
+	if cfg.OutputDir != "" {
+		dir, err := config.ExpandHome(cfg.OutputDir)
+		if err != nil {
+			return err
+		}
+		configOutputDir = dir
+	}
+
+	if cfg.Verbosity != "" && !cmd.Flags().Changed("verbose") && !cmd.Flags().Changed("quiet") &&
+		!cmd.Flags().Changed("log-level") {
+		switch cfg.Verbosity {
+		case "quiet":
+			verbose, quiet = false, true
+		case "normal":
+			verbose, quiet = false, false
+		case "verbose":
+			verbose, quiet = true, false
+		default:
+			return fmt.Errorf("invalid verbosity in config: %s (valid: %s)",
+				cfg.Verbosity, strings.Join(config.Verbosities, ", "))
+		}
+	}
+
+	return nil
+}
+
+// resolveOutputPath places an output given as a bare file name in the configured output
+// directory, creating the directory if needed; other paths are returned unchanged
+func resolveOutputPath(path string) (string, error) {
+	if configOutputDir == "" || transcoder.IsStdoutPath(path) || filepath.Base(path) != path {
+		return path, nil
+	}
+	if err := os.MkdirAll(configOutputDir, 0o755); err != nil {
+		return "", fmt.Errorf("creating output directory: %w", err)
+	}
+	return filepath.Join(configOutputDir, path), nil
+}
@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var contactSheetCmd = &cobra.Command{
+	Use:   "contactsheet [input] [output]",
+	Short: "Tile evenly spaced frames into one overview image",
+	Long: `Take evenly spaced frames of a video and tile them into a single image,
+each labeled with its time, for media catalogs and a quick look at what a
+file contains. Each frame is taken from the middle of an equal share of the
+video, so the sheet is not opened and closed by black fades.
+
+The extension of the output selects the image format: jpg, png or webp.
+Timestamps need an FFmpeg built with the drawtext filter (libfreetype);
+use --no-timestamps with builds that lack it.
+
+Examples:
+  transcoder contactsheet input.mp4 sheet.jpg --cols 5 --rows 4
+
+  # Larger thumbnails without timestamps
+  transcoder contactsheet movie.mkv movie.png --cols 4 --rows 3 --width 480 --no-timestamps`,
+	Args: cobra.ExactArgs(2),
+	RunE: runContactSheet,
+}
+
+var (
+	contactSheetColumns      int
+	contactSheetRows         int
+	contactSheetWidth        int
+	contactSheetNoTimestamps bool
+	contactSheetForce        bool
+)
+
+func init() {
+	rootCmd.AddCommand(contactSheetCmd)
+
+	contactSheetCmd.Flags().IntVar(&contactSheetColumns, "cols", 5,
+		"thumbnails per row")
+
+	contactSheetCmd.Flags().IntVar(&contactSheetRows, "rows", 4,
+		"rows of thumbnails")
+
+	contactSheetCmd.Flags().IntVar(&contactSheetWidth, "width", 320,
+		"thumbnail width in pixels")
+
+	contactSheetCmd.Flags().BoolVar(&contactSheetNoTimestamps, "no-timestamps", false,
+		"leave out the time of each frame")
+
+	contactSheetCmd.Flags().BoolVarP(&contactSheetForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runContactSheet(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if fileExists(outputFile) && !contactSheetForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🗂️  Creating Contact Sheet")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputFile)
+		fmt.Printf("   Grid:    %d × %d\n", contactSheetColumns, contactSheetRows)
+		fmt.Println()
+	}
+
+	if _, err := transcoder.CreateContactSheet(cmd.Context(), transcoder.ContactSheetParams{
+		InputFile:  inputFile,
+		OutputFile: outputFile,
+		Columns:    contactSheetColumns,
+		Rows:       contactSheetRows,
+		ThumbWidth: contactSheetWidth,
+		Timestamps: !contactSheetNoTimestamps,
+		Verbose:    useVerbose,
+	}); err != nil {
+		return fmt.Errorf("contact sheet failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Contact sheet created successfully!")
+		fmt.Printf("Output saved to: %s\n", outputFile)
+	}
+
+	return nil
+}
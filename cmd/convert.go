@@ -4,8 +4,10 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
 	"github.com/rishad1234/term-video-transcoder/internal/security"
 	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
 	"github.com/spf13/cobra"
@@ -13,8 +15,9 @@ import (
 
 var (
 	// Convert command flags
-	preset string
-	force  bool
+	preset  string
+	profile string
+	force   bool
 
 	// Phase 2: Custom Parameters
 	videoCodec   string
@@ -23,23 +26,114 @@ var (
 	audioBitrate string
 	resolution   string
 	framerate    string
+	volume       string
+	cfr          bool
+
+	// Stream selection
+	audioStream   string
+	audioLanguage string
+	noAudio       bool
+	addAudio      []string
+
+	// Timestamp repair
+	fixTimestamps bool
+
+	// Lip sync
+	audioDelay time.Duration
+
+	// MP4 layout
+	webOptimized bool
+	fragmented   bool
+
+	// Growing input files
+	follow       bool
+	followSettle time.Duration
+
+	// Piped input and output
+	container     string
+	inputDuration time.Duration
+
+	// Segmented encoding
+	resumable bool
+
+	// Retrying failed encodes
+	retryFallback bool
+
+	// Preview encodes
+	previewLength time.Duration
+	previewAt     time.Duration
+
+	// Extra FFmpeg arguments
+	ffmpegArgs string
+	unsafeArgs bool
+
+	// Platform preset
+	target string
+
+	// Lossless preservation output
+	archival bool
+
+	// Text overlay
+	textOverlay  string
+	textPosition string
+	textSize     int
+	textColor    string
+	textFont     string
+
+	// Timecode overlay
+	burnTimecode bool
+
+	// Letterboxing
+	padTo    string
+	padColor string
+
+	// Color grading
+	lut string
+
+	// Anonymization
+	blurRegions []string
+
+	// CPU usage
+	threads int
+
+	// Directory conversion
+	convertRecursive bool
+
+	// Cover art for audio outputs
+	coverArt string
+
+	// Metadata
+	stripMetadata bool
 )
 
 // convertCmd represents the convert command
 var convertCmd = &cobra.Command{
 	Use:   "convert [input] [output]",
-	Short: "Convert video files between different formats",
-	Long: `Convert video files between common formats with automatic codec selection.
+	Short: "Convert video and audio files between different formats",
+	Long: `Convert video and audio files between common formats with automatic codec selection.
 
 Supported formats: MP4, AVI, MKV, WebM, MOV
+Audio formats:     MP3, M4A, AAC, FLAC, WAV, OGG
 
 The transcoder automatically selects the best codecs for the target format
 and applies intelligent optimizations like stream copying when possible.
 
+An audio output format converts the audio alone (from an audio file or the
+audio of a video), keeping its tags; the codec follows the format. Audio
+that already has that codec is copied unless --preset or --audio-bitrate
+is given. A directory input converts every audio file in it into the
+output directory, in the format given by --container.
+
 Examples:
   # Basic conversion with presets
   transcoder convert input.avi output.mp4
   transcoder convert movie.mkv movie.webm --preset high
+
+  # Named preset saved with 'transcoder preset save'
+  transcoder convert in.mkv out.mp4 --profile web-720p
+
+  # Platform upload settings (youtube, instagram-reel, tiktok, twitter)
+  transcoder convert clip.mov tiktok.mp4 --target tiktok
   
   # Custom codec selection
   transcoder convert input.mp4 output.webm --video-codec libvpx-vp9 --audio-codec libopus
@@ -50,6 +144,87 @@ Examples:
   # Resolution and frame rate
   transcoder convert input.mkv output.mp4 --resolution 1920x1080 --framerate 30
   
+  # Constant frame rate for editors (rate detected from the input)
+  transcoder convert screen-recording.mp4 edit.mp4 --cfr
+  
+  # Audio to audio, with the tags kept
+  transcoder convert album.flac album.m4a
+  transcoder convert track.wav track.mp3 --audio-bitrate 256k
+  
+  # With cover art that players show as the album art
+  transcoder convert album.wav album.mp3 --cover front.jpg
+  
+  # A whole album folder (and its subfolders) to MP3
+  transcoder convert music/flac music/mp3 --container mp3 --recursive
+  
+  # Volume adjustment (multiplier or decibels)
+  transcoder convert input.mp4 output.mkv --volume 1.5
+  transcoder convert input.mp4 output.mkv --volume +3dB
+  
+  # Pick the second audio track, or the Japanese one
+  transcoder convert movie.mkv movie.mp4 --audio-stream 2
+  transcoder convert movie.mkv movie.mp4 --audio-language jpn
+  
+  # Drop all audio (video is still stream copied when compatible)
+  transcoder convert input.mp4 silent.mp4 --no-audio
+  
+  # Add a commentary track and a German dub next to the original audio
+  transcoder convert movie.mkv movie.mp4 --add-audio commentary.flac:eng --add-audio dub.m4a:deu
+  
+  # Keep the index at the end of an MP4 (faststart is on by default)
+  transcoder convert input.mkv output.mp4 --web-optimized=false
+  
+  # Fragmented MP4 for MSE players and CMAF workflows
+  transcoder convert input.mkv output.mp4 --fragmented
+  
+  # Repair broken or negative timestamps while converting
+  transcoder convert capture.ts capture.mp4 --fix-timestamps
+  
+  # Fix lip sync by playing the audio 300ms later (use -300ms for earlier)
+  transcoder convert input.mkv output.mp4 --audio-delay 300ms
+  
+  # Convert an OBS recording once it has finished being written
+  transcoder convert recording.mkv recording.mp4 --follow
+  
+  # Pipe the output into a player (status messages are suppressed)
+  transcoder convert input.mkv - --container mp4 | mpv -
+  
+  # Multi-hour encode that can be interrupted and picked up again
+  transcoder convert movie.mkv movie.mp4 --video-codec libx265 --resumable
+  
+  # Read the input from stdin; --input-duration enables the percentage progress bar
+  curl -s https://example.com/talk.mkv | transcoder convert - talk.mp4 --input-duration 45m
+  
+  # Try the settings on 30 seconds from the middle of a film, with a size estimate
+  transcoder convert movie.mkv preview.mp4 --video-codec libx265 --preview 30s --preview-at 45m
+  
+  # Lossless preservation copy of a digitized tape (FFV1 + FLAC in MKV)
+  transcoder convert capture.avi capture.mkv --archival
+  
+  # Watermark the video with a copyright notice
+  transcoder convert input.mkv output.mp4 --text-overlay "© 2024 MyChannel" --text-position top-left --text-size 24
+  
+  # Review copy of a camera file with its timecode burned in
+  transcoder convert A001C003.mov review.mp4 --burn-timecode --resolution 1280x720
+  
+  # Letterbox mixed portrait and landscape clips into a uniform 1920x1080 delivery
+  transcoder convert clip.mov delivery.mp4 --pad-to 1920x1080
+  
+  # Convert S-Log3 camera footage to Rec.709 with the manufacturer's LUT
+  transcoder convert C0001.mp4 graded.mp4 --lut SLog3_to_709.cube
+  
+  # Blur a license plate between 12 and 18 seconds
+  transcoder convert dashcam.mp4 shared.mp4 --blur-region 860,740,200,60,12,18
+  
+  # Retry a failed encode with safer settings (software encoder, yuv420p, genpts)
+  transcoder convert camera.mov edit.mp4 --retry-fallback
+  
+  # Encoder options the CLI does not model (allowlisted options only)
+  transcoder convert input.mkv output.mp4 --video-codec libx264 --ffmpeg-args "-crf 20 -tune film"
+  
+  # Any FFmpeg option, unchecked
+  transcoder convert input.mkv output.mp4 --ffmpeg-args "-filter:v hqdn3d" --unsafe
+  
   # Combined custom parameters
   transcoder convert input.avi output.mp4 --video-codec libx264 --video-bitrate 4M --resolution 1280x720`,
 	Args: cobra.ExactArgs(2),
@@ -63,6 +238,7 @@ func init() {
 
 	// Basic flags
 	convertCmd.Flags().StringVarP(&preset, "preset", "p", "medium", "quality preset (low, medium, high)")
+	convertCmd.Flags().StringVar(&profile, "profile", "", "apply a named preset saved with 'transcoder preset save'; flags given here override it")
 	convertCmd.Flags().BoolVarP(&force, "force", "f", false, "overwrite output file if it exists")
 
 	// Phase 2: Custom Parameters
@@ -72,23 +248,147 @@ func init() {
 	convertCmd.Flags().StringVar(&audioBitrate, "audio-bitrate", "", "audio bitrate (e.g., 192k, 128k)")
 	convertCmd.Flags().StringVar(&resolution, "resolution", "", "output resolution (e.g., 1920x1080, 1280x720)")
 	convertCmd.Flags().StringVar(&framerate, "framerate", "", "output frame rate (e.g., 30, 24, 60)")
+	convertCmd.Flags().BoolVar(&cfr, "cfr", false, "force constant frame rate output; the rate is detected from the input unless --framerate is set")
+	convertCmd.Flags().StringVar(&volume, "volume", "", "audio volume adjustment (e.g., 1.5, 0.5, +3dB, -6dB)")
+
+	// Stream selection
+	convertCmd.Flags().StringVar(&audioStream, "audio-stream", "", "audio stream number to use, as listed by info (e.g., 2)")
+	convertCmd.Flags().StringVar(&audioLanguage, "audio-language", "", "use the first audio stream with this language (e.g., jpn, eng)")
+	convertCmd.Flags().BoolVar(&noAudio, "no-audio", false, "remove all audio streams (video-only output)")
+	convertCmd.Flags().StringArrayVar(&addAudio, "add-audio", nil, "mux an extra audio track, optionally tagged with a language (e.g., commentary.flac:eng); repeatable")
+
+	// Timestamp repair
+	convertCmd.Flags().BoolVar(&fixTimestamps, "fix-timestamps", false, "regenerate missing timestamps and shift negative ones to zero (fixes \"non-monotonous DTS\" errors)")
+
+	// Lip sync
+	convertCmd.Flags().DurationVar(&audioDelay, "audio-delay", 0, "play the audio later (e.g., 300ms) or earlier (e.g., -300ms) than the video to fix lip sync; works with stream copy")
+
+	// MP4 layout
+	convertCmd.Flags().BoolVar(&fragmented, "fragmented", false, "write fragmented MP4 for Media Source Extensions and CMAF (.mp4 only)")
+	convertCmd.Flags().BoolVar(&webOptimized, "web-optimized", true, "move the MP4/MOV index to the front so playback starts while downloading (on for .mp4 and .mov outputs; disable with --web-optimized=false)")
+
+	// Growing input files
+	convertCmd.Flags().BoolVar(&follow, "follow", false, "wait for an input that is still being written to stop growing before converting")
+	convertCmd.Flags().DurationVar(&followSettle, "settle", defaultFollowSettle, "with --follow, how long the input must stop growing (e.g., 5s, 1m)")
+
+	// Piped input and output
+	convertCmd.Flags().StringVar(&container, "container", "", "container format when the output is - (stdout) or a directory, e.g., mp4, mkv, m4a")
+	convertCmd.Flags().DurationVar(&inputDuration, "input-duration", 0, "duration of input read from - (stdin), which cannot be probed in full; used for the progress bar (e.g., 45m)")
+
+	// Segmented encoding
+	convertCmd.Flags().BoolVar(&resumable, "resumable", false, "encode the video in segments so an interrupted conversion resumes where it stopped when run again")
+
+	// Retrying failed encodes
+	convertCmd.Flags().BoolVar(&retryFallback, "retry-fallback", false, "if the encode fails, retry it with safer settings: software encoder, then yuv420p pixel format, then regenerated timestamps")
+
+	// Preview encodes
+	convertCmd.Flags().DurationVar(&previewLength, "preview", 0, "encode only this much of the input (e.g., 30s) and estimate the size and time of the full encode")
+	convertCmd.Flags().DurationVar(&previewAt, "preview-at", 0, "with --preview, where in the input the preview starts (e.g., 10m)")
+
+	// Extra FFmpeg arguments
+	convertCmd.Flags().StringVar(&ffmpegArgs, "ffmpeg-args", "", "extra FFmpeg output options, added right before the output (e.g., \"-crf 20 -tune film\"); only allowlisted options unless --unsafe")
+	convertCmd.Flags().BoolVar(&unsafeArgs, "unsafe", false, "pass --ffmpeg-args to FFmpeg without checking them against the allowlist")
+
+	// CPU usage
+	convertCmd.Flags().IntVar(&threads, "threads", 0, "number of threads FFmpeg may use per encode, to cap CPU usage on shared machines (0 = FFmpeg decides)")
+
+	// Cover art for audio outputs
+	convertCmd.Flags().StringVar(&coverArt, "cover", "", "embed this JPEG or PNG as the cover art of an audio output (mp3, m4a, flac)")
+
+	// Metadata
+	convertCmd.Flags().BoolVar(&stripMetadata, "strip-metadata", false, "drop the metadata of the input (title, creation date, stream languages and other tags) instead of keeping it")
+
+	// Directory conversion
+	convertCmd.Flags().BoolVarP(&convertRecursive, "recursive", "r", false, "when the input is a directory, also convert the files in its subdirectories, mirroring them in the output")
+
+	// Platform preset
+	convertCmd.Flags().StringVar(&target, "target", "", "encode for a platform's upload recommendations ("+strings.Join(transcoder.PlatformTargetNames(), ", ")+")")
+
+	// Lossless preservation output
+	convertCmd.Flags().BoolVar(&archival, "archival", false, "lossless preservation copy: FFV1 video (intra-only, sliced, with CRCs) and FLAC audio in MKV (.mkv only)")
+
+	// Text overlay
+	convertCmd.Flags().StringVar(&textOverlay, "text-overlay", "", "draw this text onto the video, e.g., a copyright notice (letters, digits, spaces and . , : ! ? @ # + - _ / © ® ™)")
+	convertCmd.Flags().StringVar(&textPosition, "text-position", transcoder.DefaultTextPosition, "where --text-overlay is drawn ("+strings.Join(transcoder.TextPositions, ", ")+")")
+	convertCmd.Flags().IntVar(&textSize, "text-size", transcoder.DefaultTextSize, "font size of --text-overlay in pixels")
+	convertCmd.Flags().StringVar(&textColor, "text-color", transcoder.DefaultTextColor, "color of --text-overlay: a name (e.g., yellow) or RRGGBB/RRGGBBAA hex")
+	convertCmd.Flags().StringVar(&textFont, "text-font", "", "font file (.ttf, .otf, .ttc) for --text-overlay and --burn-timecode; a common installed font is used otherwise")
+
+	// Timecode overlay
+	convertCmd.Flags().BoolVar(&burnTimecode, "burn-timecode", false, "burn a running HH:MM:SS:FF timecode into the picture, starting at the input's own timecode, for review copies and dailies")
+
+	// Letterboxing
+	convertCmd.Flags().StringVar(&padTo, "pad-to", "", "fit the video into an aspect ratio (16:9) or resolution (1920x1080) without distortion, padding the rest with bars")
+	convertCmd.Flags().StringVar(&padColor, "pad-color", transcoder.DefaultPadColor, "color of the --pad-to bars: a name (e.g., white) or RRGGBB hex")
+
+	// Color grading
+	convertCmd.Flags().StringVar(&lut, "lut", "", "apply a 3D LUT file (.cube, .3dl) to the picture, e.g., to convert log footage to Rec.709 or bake in a creative grade")
+
+	// Anonymization
+	convertCmd.Flags().StringArrayVar(&blurRegions, "blur-region", nil, "blur out a region to hide a face or license plate: x,y,w,h in pixels, optionally with ,start,end to blur it only then (repeatable)")
 }
 
 func runConvert(cmd *cobra.Command, inputPath, outputPath string) error {
+	// A directory of audio files is converted file by file
+	if isDirectory(inputPath) {
+		return runConvertAudioBatch(cmd, inputPath, outputPath)
+	}
+	if convertRecursive {
+		return &usageError{err: fmt.Errorf("--recursive only applies when the input is a directory")}
+	}
+
+	outputPath, err := resolveOutputPath(outputPath)
+	if err != nil {
+		return err
+	}
+
+	if err := checkStdinInput(inputPath, inputDuration); err != nil {
+		return err
+	}
+
+	if err := prepareStdoutOutput(outputPath, container); err != nil {
+		return err
+	}
+
 	if err := performSecurityValidation(inputPath, outputPath); err != nil {
 		return err
 	}
 
+	audioOutput := transcoder.IsAudioFormat(outputFormat(outputPath))
+	if audioOutput {
+		if err := checkAudioConversionFlags(cmd); err != nil {
+			return err
+		}
+	} else if coverArt != "" {
+		return &usageError{err: fmt.Errorf("--cover only applies to audio outputs (%s)", strings.Join(transcoder.CoverFormats, ", "))}
+	}
+
 	if err := validateConversionParameters(); err != nil {
 		return err
 	}
+	if unsafeArgs && !quiet {
+		color.Yellow("⚠️  Passing unchecked FFmpeg arguments: %s", ffmpegArgs)
+	}
 
 	if err := handleOutputFileCheck(outputPath); err != nil {
 		return err
 	}
 
+	// Recordings and downloads still being written are converted once they stop growing
+	if follow && analyzer.IsStdinPath(inputPath) {
+		return &usageError{err: fmt.Errorf("--follow cannot be used with stdin input")}
+	}
+	if follow {
+		if _, err := waitForGrowingFile(cmd.Context(), inputPath, followSettle); err != nil {
+			return err
+		}
+	}
+
 	displayConversionProgress(inputPath, outputPath, preset)
 
+	if audioOutput {
+		return executeAudioConversion(cmd, inputPath, outputPath)
+	}
 	return executeConversion(cmd, inputPath, outputPath)
 }
 
@@ -100,6 +400,14 @@ func performSecurityValidation(inputPath, outputPath string) error {
 		return fmt.Errorf("security validation failed for input path: %w", err)
 	}
 
+	// Output written to stdout has no path, only a container
+	if transcoder.IsStdoutPath(outputPath) {
+		if err := securityPolicy.ValidateFormat(container); err != nil {
+			return fmt.Errorf("security validation failed for container: %w", err)
+		}
+		return nil
+	}
+
 	if err := securityPolicy.ValidateFilePath(outputPath); err != nil {
 		return fmt.Errorf("security validation failed for output path: %w", err)
 	}
@@ -111,14 +419,15 @@ func performSecurityValidation(inputPath, outputPath string) error {
 	return nil
 }
 
-// validateConversionParameters validates preset and custom parameters
+// validateConversionParameters validates preset and custom parameters; every error is a usage
+// error, as only flag values and combinations are checked
 func validateConversionParameters() error {
 	if !isValidPreset(preset) {
-		return fmt.Errorf("invalid preset '%s'. Valid options: low, medium, high", preset)
+		return &usageError{err: fmt.Errorf("invalid preset '%s'. Valid options: low, medium, high", preset)}
 	}
 
 	if err := validateCustomParameters(); err != nil {
-		return err
+		return &usageError{err: err}
 	}
 
 	return nil
@@ -147,19 +456,48 @@ func executeConversion(cmd *cobra.Command, inputPath, outputPath string) error {
 	customParamsSet := hasCustomParameters()
 	useVerbose := verbose && !quiet
 
-	customParams := buildCustomParameters()
+	customParams, err := buildCustomParameters()
+	if err != nil {
+		return err
+	}
+
+	customParams.WebOptimized, err = resolveWebOptimized(cmd, outputPath)
+	if err != nil {
+		return err
+	}
 
-	err := transcoder.ConvertVideoWithCustomParams(inputPath, outputPath, preset, presetExplicit, customParamsSet, customParams, useVerbose)
+	started := time.Now()
+	err = transcoder.ConvertVideoWithCustomParams(cmd.Context(), inputPath, outputPath, preset, presetExplicit, customParamsSet, customParams, useVerbose)
 	if err != nil {
 		return fmt.Errorf("conversion failed: %w", err)
 	}
 
+	elapsed := time.Since(started)
 	displaySuccessMessage(outputPath)
+	showConversionSummary(cmd.Context(), inputPath, outputPath, elapsed)
+	if previewLength > 0 {
+		showPreviewEstimate(cmd.Context(), inputPath, outputPath, elapsed)
+	}
 	return nil
 }
 
 // buildCustomParameters creates the custom parameters struct
-func buildCustomParameters() transcoder.CustomParameters {
+func buildCustomParameters() (transcoder.CustomParameters, error) {
+	tracks, err := parseAddedAudioTracks()
+	if err != nil {
+		return transcoder.CustomParameters{}, err
+	}
+
+	regions, err := parseBlurRegions()
+	if err != nil {
+		return transcoder.CustomParameters{}, err
+	}
+
+	extraArgs, err := transcoder.ParseFFmpegArgs(ffmpegArgs)
+	if err != nil {
+		return transcoder.CustomParameters{}, err
+	}
+
 	return transcoder.CustomParameters{
 		VideoCodec:   videoCodec,
 		AudioCodec:   audioCodec,
@@ -167,7 +505,90 @@ func buildCustomParameters() transcoder.CustomParameters {
 		AudioBitrate: audioBitrate,
 		Resolution:   resolution,
 		Framerate:    framerate,
+		Volume:       volume,
+
+		ConstantFrameRate: cfr,
+
+		AudioStream:   audioStream,
+		AudioLanguage: audioLanguage,
+		NoAudio:       noAudio,
+		AddAudio:      tracks,
+		AudioDelay:    audioDelay,
+
+		FixTimestamps: fixTimestamps,
+		Fragmented:    fragmented,
+		Container:     container,
+		InputDuration: inputDuration,
+		Resumable:     resumable,
+		RetryFallback: retryFallback,
+		Preview:       previewLength,
+		PreviewAt:     previewAt,
+
+		ExtraArgs:       extraArgs,
+		UnsafeExtraArgs: unsafeArgs,
+
+		Target:        target,
+		Archival:      archival,
+		TextOverlay:   textOverlay,
+		TextPosition:  textPosition,
+		TextSize:      textSize,
+		TextColor:     textColor,
+		TextFont:      textFont,
+		BurnTimecode:  burnTimecode,
+		PadTo:         padTo,
+		PadColor:      padColor,
+		LUT:           lut,
+		BlurRegions:   regions,
+		Threads:       threads,
+		StripMetadata: stripMetadata,
+	}, nil
+}
+
+// resolveWebOptimized enables faststart for MP4 and MOV outputs unless --web-optimized=false or --fragmented is given
+func resolveWebOptimized(cmd *cobra.Command, outputPath string) (bool, error) {
+	extension := strings.ToLower(getFileExtension(outputPath))
+	supported := extension == "mp4" || extension == "mov"
+
+	// Fragmented files already start with their (empty) index
+	if !cmd.Flags().Changed("web-optimized") {
+		return supported && !fragmented, nil
+	}
+	if webOptimized && transcoder.IsStdoutPath(outputPath) {
+		return false, fmt.Errorf("--web-optimized needs a seekable output and cannot be used with stdout")
+	}
+	if webOptimized && !supported {
+		return false, fmt.Errorf("--web-optimized only applies to MP4 and MOV outputs")
+	}
+	if webOptimized && fragmented {
+		return false, &usageError{err: fmt.Errorf("--web-optimized cannot be combined with --fragmented")}
+	}
+	return webOptimized, nil
+}
+
+// parseAddedAudioTracks parses the --add-audio values (file or file:lang)
+func parseAddedAudioTracks() ([]transcoder.AudioTrack, error) {
+	tracks := make([]transcoder.AudioTrack, 0, len(addAudio))
+	for _, spec := range addAudio {
+		track, err := transcoder.ParseAudioTrack(spec)
+		if err != nil {
+			return nil, fmt.Errorf("invalid added audio track: %w", err)
+		}
+		tracks = append(tracks, track)
 	}
+	return tracks, nil
+}
+
+// parseBlurRegions parses the --blur-region values (x,y,w,h[,start,end])
+func parseBlurRegions() ([]transcoder.BlurRegion, error) {
+	regions := make([]transcoder.BlurRegion, 0, len(blurRegions))
+	for _, spec := range blurRegions {
+		region, err := transcoder.ParseBlurRegion(spec)
+		if err != nil {
+			return nil, err
+		}
+		regions = append(regions, region)
+	}
+	return regions, nil
 }
 
 // displaySuccessMessage shows completion message unless in quiet mode
@@ -196,17 +617,30 @@ func checkOutputFile(outputPath string) error {
 }
 
 func displayConversionInfo(inputPath, outputPath, preset string) {
-	color.Cyan("🔄 Starting Video Conversion")
+	if transcoder.IsAudioFormat(outputFormat(outputPath)) {
+		color.Cyan("🔄 Starting Audio Conversion")
+	} else {
+		color.Cyan("🔄 Starting Video Conversion")
+	}
 	fmt.Println()
 	fmt.Printf("   Input:   %s\n", inputPath)
 	fmt.Printf("   Output:  %s\n", outputPath)
 	fmt.Printf("   Preset:  %s\n", strings.ToUpper(preset))
-	fmt.Printf("   Format:  %s → %s\n",
-		strings.ToUpper(getFileExtension(inputPath)),
-		strings.ToUpper(getFileExtension(outputPath)))
+	if profile != "" {
+		fmt.Printf("   Profile: %s\n", profile)
+	}
+	fmt.Printf("   Format:  %s → %s\n", displayFormat(inputPath), displayFormat(outputPath))
 	fmt.Println()
 }
 
+// displayFormat names the format of a path for display; media on stdin is only identified by probing
+func displayFormat(path string) string {
+	if analyzer.IsStdinPath(path) {
+		return "STDIN"
+	}
+	return strings.ToUpper(getFileExtension(path))
+}
+
 func getFileExtension(filename string) string {
 	ext := filepath.Ext(filename)
 	if len(ext) > 1 {
@@ -261,11 +695,86 @@ func validateCustomParameters() error {
 		}
 	}
 
+	// Validate volume adjustment
+	if volume != "" {
+		if err := securityPolicy.ValidateVolume(volume); err != nil {
+			return fmt.Errorf("invalid volume: %w", err)
+		}
+	}
+
+	// Validate audio stream selection
+	if audioStream != "" && audioLanguage != "" {
+		return fmt.Errorf("--audio-stream and --audio-language cannot be used together")
+	}
+	if err := securityPolicy.ValidateStreamNumber(audioStream); err != nil {
+		return fmt.Errorf("invalid audio stream: %w", err)
+	}
+	if err := securityPolicy.ValidateLanguageCode(audioLanguage); err != nil {
+		return fmt.Errorf("invalid audio language: %w", err)
+	}
+
+	// Frame rate conversion re-encodes the video
+	if cfr && videoCodec == "copy" {
+		return fmt.Errorf("--cfr requires video re-encoding and cannot be used with video codec 'copy'")
+	}
+
+	// Audio options make no sense when audio is removed
+	if noAudio && (audioCodec != "" || audioBitrate != "" || volume != "" || audioStream != "" || audioLanguage != "" || len(addAudio) > 0 || audioDelay != 0) {
+		return fmt.Errorf("--no-audio cannot be combined with audio options")
+	}
+
+	// Validate added audio tracks
+	if _, err := parseAddedAudioTracks(); err != nil {
+		return err
+	}
+
+	// The text options only style an overlay
+	if textOverlay == "" && (textPosition != transcoder.DefaultTextPosition || textSize != transcoder.DefaultTextSize ||
+		textColor != transcoder.DefaultTextColor) {
+		return fmt.Errorf("--text-position, --text-size and --text-color require --text-overlay")
+	}
+	if textFont != "" && textOverlay == "" && !burnTimecode {
+		return fmt.Errorf("--text-font requires --text-overlay or --burn-timecode")
+	}
+
+	// The bar color only applies to padding
+	if padTo == "" && padColor != transcoder.DefaultPadColor {
+		return fmt.Errorf("--pad-color requires --pad-to")
+	}
+
+	// Validate blur regions
+	if _, err := parseBlurRegions(); err != nil {
+		return err
+	}
+
+	// A preview start without a preview length would convert everything from that point
+	if previewLength < 0 || previewAt < 0 {
+		return fmt.Errorf("--preview and --preview-at must not be negative")
+	}
+	if previewAt > 0 && previewLength == 0 {
+		return fmt.Errorf("--preview-at requires --preview")
+	}
+
+	// Validate extra FFmpeg arguments (unchecked only with the explicit --unsafe opt-in)
+	if unsafeArgs && ffmpegArgs == "" {
+		return fmt.Errorf("--unsafe only applies to --ffmpeg-args")
+	}
+	extraArgs, err := transcoder.ParseFFmpegArgs(ffmpegArgs)
+	if err != nil {
+		return err
+	}
+	if !unsafeArgs {
+		if err := securityPolicy.ValidateExtraArgs(extraArgs); err != nil {
+			return fmt.Errorf("invalid ffmpeg arguments: %w (use --unsafe to pass them unchecked)", err)
+		}
+	}
+
 	return nil
 }
 
 // hasCustomParameters checks if any custom parameters were set
 func hasCustomParameters() bool {
 	return videoCodec != "" || audioCodec != "" || videoBitrate != "" ||
-		audioBitrate != "" || resolution != "" || framerate != ""
+		audioBitrate != "" || resolution != "" || framerate != "" || volume != "" || cfr || ffmpegArgs != "" || target != "" || archival ||
+		textOverlay != "" || burnTimecode || padTo != "" || lut != "" || len(blurRegions) > 0
 }
@@ -0,0 +1,231 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+// videoOnlyConvertFlags are convert flags that do not apply to audio outputs
+var videoOnlyConvertFlags = []string{
+	"video-codec", "video-bitrate", "resolution", "framerate", "cfr", "no-audio", "add-audio",
+	"fix-timestamps", "audio-delay", "fragmented", "web-optimized", "resumable", "retry-fallback", "preview",
+	"preview-at", "ffmpeg-args", "unsafe", "target", "archival",
+	"text-overlay", "text-position", "text-size", "text-color", "text-font", "burn-timecode", "pad-to", "pad-color", "lut", "blur-region",
+}
+
+// audioFileExtensions lists the extensions picked up when converting a directory
+var audioFileExtensions = map[string]bool{
+	"mp3": true, "wav": true, "aac": true, "flac": true, "ogg": true, "opus": true, "m4a": true,
+	"adts": true, "ac3": true, "eac3": true, "dts": true,
+}
+
+// outputFormat is the format written to outputPath: the --container for stdout, otherwise the extension
+func outputFormat(outputPath string) string {
+	if transcoder.IsStdoutPath(outputPath) {
+		return strings.ToLower(container)
+	}
+	return strings.ToLower(getFileExtension(outputPath))
+}
+
+// checkAudioConversionFlags rejects video options given for an audio output
+func checkAudioConversionFlags(cmd *cobra.Command) error {
+	for _, name := range videoOnlyConvertFlags {
+		if cmd.Flags().Changed(name) {
+			return &usageError{err: fmt.Errorf("--%s does not apply to audio outputs", name)}
+		}
+	}
+	return nil
+}
+
+// audioConversionParams maps the convert flags onto audio extraction parameters. The audio is
+// copied when it already has the codec of the output format, unless a preset or bitrate is given.
+func audioConversionParams(cmd *cobra.Command, inputPath, outputPath string) transcoder.AudioExtractionParams {
+	return transcoder.AudioExtractionParams{
+		InputFile:     inputPath,
+		OutputFile:    outputPath,
+		Quality:       preset,
+		Bitrate:       audioBitrate,
+		Codec:         audioCodec,
+		Volume:        volume,
+		Stream:        audioStream,
+		Language:      audioLanguage,
+		Container:     container,
+		Threads:       threads,
+		Cover:         coverArt,
+		Verbose:       verbose && !quiet,
+		InputDuration: inputDuration,
+		CopyMatching:  !cmd.Flags().Changed("preset"),
+		StripMetadata: stripMetadata,
+	}
+}
+
+// executeAudioConversion converts an audio file (or the audio of a video) to an audio format
+func executeAudioConversion(cmd *cobra.Command, inputPath, outputPath string) error {
+	started := time.Now()
+	if err := transcoder.ExtractAudio(cmd.Context(), audioConversionParams(cmd, inputPath, outputPath)); err != nil {
+		return fmt.Errorf("conversion failed: %w", err)
+	}
+
+	// In verbose mode the extraction reports its own success
+	if !verbose {
+		displaySuccessMessage(outputPath)
+	}
+	showConversionSummary(cmd.Context(), inputPath, outputPath, time.Since(started))
+	return nil
+}
+
+// runConvertAudioBatch converts the audio files of inputDir into outputDir, in the format given
+// by --container. Subdirectories are mirrored with --recursive. Existing outputs are skipped
+// unless --force is given, and a failed file does not stop the others.
+func runConvertAudioBatch(cmd *cobra.Command, inputDir, outputDir string) error {
+	outputDir, err := resolveOutputPath(outputDir)
+	if err != nil {
+		return err
+	}
+	format := strings.ToLower(container)
+	if format == "" {
+		return fmt.Errorf("converting a directory requires --container for the output format (e.g., --container m4a)")
+	}
+	if !transcoder.IsAudioFormat(format) {
+		return fmt.Errorf("converting a directory is only supported for audio formats (%s)",
+			strings.Join(transcoder.AudioFormats, ", "))
+	}
+	if transcoder.IsStdoutPath(outputDir) || (fileExists(outputDir) && !isDirectory(outputDir)) {
+		return fmt.Errorf("output must be a directory when the input is a directory: %s", outputDir)
+	}
+	if follow || inputDuration != 0 {
+		return fmt.Errorf("--follow and --input-duration cannot be used when converting a directory")
+	}
+	if err := checkAudioConversionFlags(cmd); err != nil {
+		return err
+	}
+	if err := validateConversionParameters(); err != nil {
+		return err
+	}
+
+	securityPolicy := security.NewDefaultSecurityPolicy()
+	if err := securityPolicy.ValidateFilePath(inputDir); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if err := securityPolicy.ValidateFilePath(outputDir); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	files, err := collectAudioFiles(inputDir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no audio files found in %s", inputDir)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	if !quiet {
+		color.Cyan("🔄 Starting Audio Conversion")
+		fmt.Println()
+		fmt.Printf("   Input:   %s (%d file(s))\n", inputDir, len(files))
+		fmt.Printf("   Output:  %s\n", outputDir)
+		fmt.Printf("   Preset:  %s\n", strings.ToUpper(preset))
+		fmt.Printf("   Format:  %s\n", strings.ToUpper(format))
+		fmt.Println()
+	}
+
+	// The format comes from the output extension, so --container must not reach the engine
+	params := audioConversionParams(cmd, "", "")
+	params.Container = ""
+
+	converted, skipped := 0, 0
+	var failed []string
+	for i, file := range files {
+		relative, err := filepath.Rel(inputDir, file)
+		if err != nil {
+			return fmt.Errorf("resolving output path for %s: %w", file, err)
+		}
+		outputFile := filepath.Join(outputDir, strings.TrimSuffix(relative, filepath.Ext(relative))+"."+format)
+
+		if !quiet {
+			fmt.Printf("[%d/%d] %s → %s\n", i+1, len(files), file, outputFile)
+		}
+		if sameFile(file, outputFile) {
+			failed = append(failed, fmt.Sprintf("%s: output would overwrite the input", file))
+			continue
+		}
+		if fileExists(outputFile) && !force {
+			skipped++
+			if !quiet {
+				fmt.Println("   already exists, skipped (use --force to overwrite)")
+			}
+			continue
+		}
+		if err := os.MkdirAll(filepath.Dir(outputFile), 0o755); err != nil {
+			return fmt.Errorf("creating output directory: %w", err)
+		}
+
+		params.InputFile, params.OutputFile = file, outputFile
+		if err := transcoder.ExtractAudio(cmd.Context(), params); err != nil {
+			if cmd.Context().Err() != nil {
+				return err
+			}
+			failed = append(failed, fmt.Sprintf("%s: %v", file, err))
+			if !quiet {
+				color.Red("   ❌ %v", err)
+			}
+			continue
+		}
+		converted++
+	}
+
+	if !quiet {
+		fmt.Println()
+		color.Green("✅ Converted %d file(s), skipped %d", converted, skipped)
+		fmt.Printf("Output saved to: %s\n", outputDir)
+	}
+	if len(failed) > 0 {
+		err := fmt.Errorf("%d file(s) failed:\n   %s", len(failed), strings.Join(failed, "\n   "))
+		if converted > 0 {
+			return &partialFailureError{written: converted, err: err}
+		}
+		return err
+	}
+	return nil
+}
+
+// collectAudioFiles lists the audio files of a directory, and of its subdirectories with --recursive
+func collectAudioFiles(dir string) ([]string, error) {
+	media, err := collectMediaFiles([]string{dir}, convertRecursive)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]string, 0, len(media))
+	for _, file := range media {
+		if audioFileExtensions[strings.ToLower(getFileExtension(file))] {
+			files = append(files, file)
+		}
+	}
+	return files, nil
+}
+
+// sameFile reports whether two paths name the same existing file
+func sameFile(a, b string) bool {
+	statA, errA := os.Stat(a)
+	statB, errB := os.Stat(b)
+	if errA != nil || errB != nil {
+		return false
+	}
+	return os.SameFile(statA, statB)
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var dashCmd = &cobra.Command{
+	Use:   "dash [input] [output-dir]",
+	Short: "Package a video as MPEG-DASH (MPD manifest and segments)",
+	Long: `Package a video for adaptive streaming with MPEG-DASH. The output directory
+receives a manifest.mpd and fragmented MP4 segments that DASH players such
+as dash.js and Shaka Player can stream.
+
+Video and audio are copied when their codecs are DASH-compatible (video:
+h264, hevc, av1, vp9; audio: aac, opus, flac, ac3, eac3) and encoded to
+H.264/AAC otherwise. Use --conform to always encode to H.264/AAC, the
+baseline every DASH player supports. Copied video is cut at its existing
+keyframes, so segment lengths may vary.
+
+Examples:
+  transcoder dash movie.mp4 dash/
+  transcoder dash lecture.mkv dash/ --segment-duration 6 --conform`,
+	Args: cobra.ExactArgs(2),
+	RunE: runDash,
+}
+
+var (
+	dashSegmentDuration int
+	dashConform         bool
+	dashPreset          string
+	dashForce           bool
+)
+
+func init() {
+	rootCmd.AddCommand(dashCmd)
+
+	dashCmd.Flags().IntVar(&dashSegmentDuration, "segment-duration", 4,
+		"target segment duration in seconds")
+
+	dashCmd.Flags().BoolVar(&dashConform, "conform", false,
+		"always encode to H.264/AAC for maximum player compatibility")
+
+	dashCmd.Flags().StringVarP(&dashPreset, "preset", "p", "medium",
+		"quality preset used when encoding (low, medium, high)")
+
+	dashCmd.Flags().BoolVarP(&dashForce, "force", "f", false,
+		"overwrite an existing manifest in the output directory")
+}
+
+func runDash(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputDir, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputDir); err != nil {
+		return fmt.Errorf("security validation failed for output directory: %w", err)
+	}
+
+	if !isValidPreset(dashPreset) {
+		return fmt.Errorf("invalid preset '%s'. Valid options: low, medium, high", dashPreset)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	manifest := filepath.Join(outputDir, transcoder.DashManifestName)
+	if fileExists(manifest) && !dashForce {
+		return fmt.Errorf("output already exists: %s (use --force to overwrite)", manifest)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("📡 Packaging MPEG-DASH")
+		fmt.Println()
+		fmt.Printf("   Input:    %s\n", inputFile)
+		fmt.Printf("   Output:   %s\n", outputDir)
+		fmt.Printf("   Segments: %ds\n", dashSegmentDuration)
+		fmt.Println()
+	}
+
+	manifestPath, err := transcoder.PackageDash(cmd.Context(), transcoder.DashParams{
+		InputFile:       inputFile,
+		OutputDir:       outputDir,
+		SegmentDuration: dashSegmentDuration,
+		Conform:         dashConform,
+		Preset:          dashPreset,
+		Verbose:         useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("DASH packaging failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ DASH packaging completed successfully!")
+		fmt.Printf("Manifest saved to: %s\n", manifestPath)
+	}
+
+	return nil
+}
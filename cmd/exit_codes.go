@@ -0,0 +1,175 @@
+package cmd
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"os/signal"
+	"syscall"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/logging"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+// Exit codes, so scripts can tell why a job failed
+const (
+	ExitOK             = 0   // The job succeeded
+	ExitError          = 1   // Any failure not covered below
+	ExitUsage          = 2   // Invalid flags, arguments or option values
+	ExitFFmpegMissing  = 3   // ffmpeg or ffprobe is not installed or cannot run
+	ExitInputNotFound  = 4   // An input file does not exist
+	ExitFFmpegFailed   = 5   // FFmpeg ran and failed
+	ExitPartialFailure = 6   // A job with several outputs produced only some of them
+	ExitCancelled      = 130 // Interrupted with Ctrl+C or SIGTERM
+)
+
+// exitCodes describes each exit code for the exit-codes command
+var exitCodes = []struct {
+	code        int
+	name        string
+	description string
+}{
+	{ExitOK, "ok", "The job succeeded"},
+	{ExitError, "error", "Any failure not covered below (e.g., an unreadable config file)"},
+	{ExitUsage, "usage", "Invalid flags, arguments or option values; nothing was run"},
+	{ExitFFmpegMissing, "ffmpeg-missing", "ffmpeg or ffprobe is not installed or cannot run"},
+	{ExitInputNotFound, "input-not-found", "An input file does not exist"},
+	{ExitFFmpegFailed, "ffmpeg-failed", "FFmpeg ran and failed (see the error for the cause)"},
+	{ExitPartialFailure, "partial-failure", "Only some outputs were written (extract --all-tracks, --cue or --split-on-silence; convert of a directory)"},
+	{ExitCancelled, "cancelled", "Interrupted with Ctrl+C or SIGTERM"},
+}
+
+// exitCodesCmd represents the exit-codes command
+var exitCodesCmd = &cobra.Command{
+	Use:   "exit-codes",
+	Short: "List the exit codes transcoder returns",
+	Long: `List the exit codes transcoder returns, so scripts can react to the
+cause of a failure instead of only seeing that the job failed.
+
+Examples:
+  transcoder exit-codes
+
+  # React to a missing input in a script
+  transcoder convert in.mkv out.mp4 -q
+  if [ $? -eq 4 ]; then echo "in.mkv is missing"; fi`,
+	Args: cobra.NoArgs,
+	Run: func(cmd *cobra.Command, args []string) {
+		color.Cyan("Exit codes:")
+		for _, exitCode := range exitCodes {
+			fmt.Printf("  %-4d %-16s %s\n", exitCode.code, exitCode.name, exitCode.description)
+		}
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(exitCodesCmd)
+}
+
+// ExitCode returns the exit code for the error a job failed with
+func ExitCode(err error) int {
+	var (
+		usageErr      *usageError
+		validationErr *security.ValidationError
+		missingErr    *analyzer.MissingToolError
+		partialErr    *partialFailureError
+		ffmpegErr     *transcoder.FFmpegError
+		exitErr       *exec.ExitError
+	)
+
+	switch {
+	case err == nil:
+		return ExitOK
+	case errors.Is(err, context.Canceled):
+		return ExitCancelled
+	case errors.As(err, &partialErr):
+		return ExitPartialFailure
+	case errors.As(err, &missingErr), errors.Is(err, exec.ErrNotFound):
+		return ExitFFmpegMissing
+	case errors.Is(err, analyzer.ErrFileNotFound):
+		return ExitInputNotFound
+	case errors.As(err, &usageErr), errors.As(err, &validationErr):
+		return ExitUsage
+	case errors.As(err, &ffmpegErr), errors.As(err, &exitErr):
+		return ExitFFmpegFailed
+	}
+	return ExitError
+}
+
+// usageError reports flags or arguments cobra rejected
+type usageError struct {
+	err error
+}
+
+func (e *usageError) Error() string {
+	return e.err.Error()
+}
+
+func (e *usageError) Unwrap() error {
+	return e.err
+}
+
+// partialFailureError reports a job that wrote some of its outputs before failing
+type partialFailureError struct {
+	written int
+	err     error
+}
+
+func (e *partialFailureError) Error() string {
+	return fmt.Sprintf("failed after writing %d output(s): %v", e.written, e.err)
+}
+
+func (e *partialFailureError) Unwrap() error {
+	return e.err
+}
+
+// markUsageErrors makes cobra's flag and argument errors usageErrors, for every command
+func markUsageErrors(command *cobra.Command) {
+	command.SetFlagErrorFunc(func(_ *cobra.Command, err error) error {
+		return &usageError{err: err}
+	})
+
+	if validateArgs := command.Args; validateArgs != nil {
+		command.Args = func(cmd *cobra.Command, args []string) error {
+			if err := validateArgs(cmd, args); err != nil {
+				return &usageError{err: err}
+			}
+			return nil
+		}
+	}
+
+	for _, sub := range command.Commands() {
+		markUsageErrors(sub)
+	}
+}
+
+// cancelGracePeriod is how long a cancelled job may take to stop FFmpeg and clean up
+const cancelGracePeriod = 5 * time.Second
+
+// cancelOnInterrupt returns a context cancelled on Ctrl+C or SIGTERM, which stops FFmpeg
+// and ends the job with ExitCancelled. A second signal, or a job still running after
+// cancelGracePeriod, exits at once.
+func cancelOnInterrupt() (context.Context, context.CancelFunc) {
+	ctx, cancel := context.WithCancel(context.Background())
+	signals := make(chan os.Signal, 2)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+
+	go func() {
+		sig := <-signals
+		logging.Logger().Warn("job cancelled", "signal", sig.String())
+		cancel()
+
+		select {
+		case <-signals:
+		case <-time.After(cancelGracePeriod):
+		}
+		os.Exit(ExitCancelled)
+	}()
+	return ctx, cancel
+}
@@ -1,11 +1,16 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
+	"text/tabwriter"
+	"time"
 
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
 	"github.com/rishad1234/term-video-transcoder/internal/security"
 	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
 	"github.com/spf13/cobra"
@@ -16,7 +21,12 @@ var extractCmd = &cobra.Command{
 	Short: "Extract audio from video files",
 	Long: `Extract audio tracks from video files and convert to various audio formats.
 
-Supported output formats: MP3, WAV, AAC, FLAC, OGG, M4A
+Supported output formats: MP3, WAV, AAC, ADTS, FLAC, OGG, OPUS, M4A, AC3, EAC3, DTS
+
+M4A outputs can hold lossless ALAC (--codec alac). AC3 and EAC3 outputs are
+encoded as Dolby Digital (Plus) for TVs and receivers; DTS outputs copy DTS
+audio without transcoding. OPUS outputs are Ogg files with Opus audio; AAC and
+ADTS outputs are raw AAC streams.
 
 The tool automatically detects the desired output format from the file extension
 and applies appropriate codec selection and quality settings.
@@ -32,7 +42,56 @@ Examples:
   transcoder extract input.avi output.mp3 --bitrate 320k
   
   # Specific audio codec
-  transcoder extract video.webm audio.ogg --codec libvorbis`,
+  transcoder extract video.webm audio.ogg --codec libvorbis
+  
+  # Opus, the most compact format for voice and podcasts
+  transcoder extract talk.webm talk.opus
+  
+  # Extract the Japanese track from a multi-audio MKV
+  transcoder extract anime.mkv japanese.flac --audio-language jpn
+  
+  # Every audio track to its own file (movie.track1.eng.mp3, movie.track2.jpn.mp3, ...)
+  transcoder extract movie.mkv movie.mp3 --all-tracks
+  
+  # Split a single-file album rip into tagged tracks (01 - Title.flac, ...)
+  transcoder extract album.flac --cue album.cue outdir/
+  transcoder extract album.flac --cue album.cue outdir/ --container mp3
+  
+  # Cut a long recording into parts at its pauses (memo.part01.mp3, ...)
+  transcoder extract memo.m4a memo.mp3 --split-on-silence
+  transcoder extract podcast.wav podcast.mp3 --split-on-silence --min-segment 5m --silence-threshold -40
+  
+  # Embed cover art that players show as the album art
+  transcoder extract concert.mkv concert.m4a --cover poster.jpg
+  
+  # Lossless ALAC for Apple devices
+  transcoder extract concert.flac concert.m4a --codec alac
+  
+  # Dolby Digital for a TV, and the DTS track of a film as it is
+  transcoder extract movie.mkv movie.ac3 --quality high
+  transcoder extract movie.mkv movie.dts --audio-language eng
+  
+  # 24-bit WAV for mastering
+  transcoder extract session.mov master.wav --sample-format s24
+  
+  # CD sample rate, converted with the high-quality soxr resampler
+  transcoder extract concert.mkv concert.flac --sample-rate 44100 --resampler soxr
+  
+  # Keep only the front pair of a 5.1 track, or fix swapped channels
+  transcoder extract movie.mkv front.flac --channel-map FL-FR
+  transcoder extract interview.mov interview.wav --channel-map FR-FL
+  
+  # Boost quiet audio
+  transcoder extract lecture.mp4 lecture.mp3 --volume +6dB
+  
+  # Check the extracted audio against podcast and streaming loudness specs
+  transcoder extract episode.mkv episode.mp3 --loudness
+  
+  # Pipe the audio into another program (status messages are suppressed)
+  transcoder extract podcast.mp4 - --container mp3 | ffplay -nodisp -
+  
+  # Read the input from stdin
+  cat recording.mkv | transcoder extract - recording.flac`,
 	Args: cobra.ExactArgs(2),
 	RunE: runExtract,
 }
@@ -43,7 +102,28 @@ var (
 	extractCodec      string
 	extractSampleRate string
 	extractChannels   string
+	extractSampleFmt  string
+	extractResampler  string
+	extractChannelMap string
+	extractVolume     string
+	extractStream     string
+	extractLanguage   string
+	extractAllTracks  bool
+	extractTrackName  string
+	extractContainer  string
+	extractDuration   time.Duration
+	extractThreads    int
+	extractLoudness   bool
+	extractCue        string
+	extractCover      string
+	extractStrip      bool
 	extractForce      bool
+
+	// Splitting at silences
+	extractSplitOnSilence   bool
+	extractSilenceThreshold float64
+	extractSilenceLength    time.Duration
+	extractMinSegment       time.Duration
 )
 
 func init() {
@@ -63,9 +143,74 @@ func init() {
 	extractCmd.Flags().StringVarP(&extractSampleRate, "sample-rate", "s", "",
 		"sample rate (e.g., 44100, 48000)")
 
+	extractCmd.Flags().StringVar(&extractResampler, "resampler", "auto",
+		"sample rate converter for --sample-rate: soxr (high quality), swr (FFmpeg's own) or auto (soxr when FFmpeg has it)")
+
 	extractCmd.Flags().StringVar(&extractChannels, "channels", "",
 		"number of channels (1=mono, 2=stereo, 6=5.1)")
 
+	extractCmd.Flags().StringVar(&extractChannelMap, "channel-map", "",
+		"source channel of each output channel, e.g., FL-FR (front pair of 5.1), FR-FL (swap), FL-FL (left on both sides)")
+
+	extractCmd.Flags().StringVar(&extractSampleFmt, "sample-format", "",
+		"sample format of wav, flac and alac outputs: s16, s24, s32 or flt (32-bit float, wav only)")
+
+	extractCmd.Flags().StringVar(&extractVolume, "volume", "",
+		"volume adjustment (e.g., 1.5, 0.5, +3dB, -6dB)")
+
+	// Stream selection
+	extractCmd.Flags().StringVar(&extractStream, "audio-stream", "",
+		"audio stream number to extract, as listed by info (e.g., 2)")
+
+	extractCmd.Flags().StringVar(&extractLanguage, "audio-language", "",
+		"extract the first audio stream with this language (e.g., jpn, eng)")
+
+	extractCmd.Flags().BoolVar(&extractAllTracks, "all-tracks", false,
+		"extract every audio stream into its own file")
+
+	extractCmd.Flags().StringVar(&extractTrackName, "track-name", transcoder.DefaultTrackNameTemplate,
+		"file name template for --all-tracks ({name}, {index}, {lang})")
+
+	// Cover art
+	extractCmd.Flags().StringVar(&extractCover, "cover", "",
+		"embed this JPEG or PNG as the cover art (mp3, m4a and flac outputs)")
+
+	// Metadata
+	extractCmd.Flags().BoolVar(&extractStrip, "strip-metadata", false,
+		"drop the tags of the input (title, artist, album, ...) instead of keeping them")
+
+	// Splitting album rips
+	extractCmd.Flags().StringVar(&extractCue, "cue", "",
+		"split the input into the tracks of this CUE sheet, written to the output directory")
+
+	// Splitting at silences
+	extractCmd.Flags().BoolVar(&extractSplitOnSilence, "split-on-silence", false,
+		"cut the audio into separate files at its silences (output.part01.mp3, ...)")
+
+	extractCmd.Flags().Float64Var(&extractSilenceThreshold, "silence-threshold", transcoder.DefaultSilenceThreshold,
+		"with --split-on-silence, level in dB below which audio counts as silent")
+
+	extractCmd.Flags().DurationVar(&extractSilenceLength, "silence-length", transcoder.DefaultSilenceMinLength,
+		"with --split-on-silence, shortest pause to cut at")
+
+	extractCmd.Flags().DurationVar(&extractMinSegment, "min-segment", transcoder.DefaultMinSegmentLength,
+		"with --split-on-silence, shortest part to cut the audio into")
+
+	// Piped output
+	extractCmd.Flags().StringVar(&extractContainer, "container", "",
+		"audio format when the output is - (stdout) or a --cue directory, e.g., mp3, flac")
+
+	extractCmd.Flags().DurationVar(&extractDuration, "input-duration", 0,
+		"duration of input read from - (stdin), which cannot be probed in full; used for progress (e.g., 45m)")
+
+	// CPU usage
+	extractCmd.Flags().IntVar(&extractThreads, "threads", 0,
+		"number of threads FFmpeg may use, to cap CPU usage on shared machines (0 = FFmpeg decides)")
+
+	// Loudness report
+	extractCmd.Flags().BoolVar(&extractLoudness, "loudness", false,
+		"measure the loudness of the extracted audio and check it against delivery specs")
+
 	// Force overwrite flag
 	extractCmd.Flags().BoolVarP(&extractForce, "force", "f", false,
 		"overwrite output file if it exists")
@@ -73,7 +218,41 @@ func init() {
 
 func runExtract(cmd *cobra.Command, args []string) error {
 	inputFile := args[0]
-	outputFile := args[1]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+	if extractCue != "" {
+		return runExtractCue(cmd.Context(), inputFile, outputFile)
+	}
+	toStdout := transcoder.IsStdoutPath(outputFile)
+
+	if err := checkStdinInput(inputFile, extractDuration); err != nil {
+		return err
+	}
+
+	if err := prepareStdoutOutput(outputFile, extractContainer); err != nil {
+		return err
+	}
+	if toStdout && extractAllTracks {
+		return &usageError{err: fmt.Errorf("--all-tracks writes several files and cannot be used with stdout")}
+	}
+	if analyzer.IsStdinPath(inputFile) && extractAllTracks {
+		return &usageError{err: fmt.Errorf("--all-tracks reads the input once per track and cannot be used with stdin")}
+	}
+	if toStdout && extractLoudness {
+		return &usageError{err: fmt.Errorf("--loudness measures the output file and cannot be used with stdout")}
+	}
+	if !extractSplitOnSilence && (cmd.Flags().Changed("silence-threshold") || cmd.Flags().Changed("silence-length") ||
+		cmd.Flags().Changed("min-segment")) {
+		return &usageError{err: fmt.Errorf("--silence-threshold, --silence-length and --min-segment require --split-on-silence")}
+	}
+	if extractSplitOnSilence && (toStdout || analyzer.IsStdinPath(inputFile)) {
+		return &usageError{err: fmt.Errorf("--split-on-silence cannot be used with stdin or stdout")}
+	}
+	if extractSplitOnSilence && extractAllTracks {
+		return &usageError{err: fmt.Errorf("--split-on-silence cannot be combined with --all-tracks")}
+	}
 
 	// Initialize security policy
 	securityPolicy := security.NewDefaultSecurityPolicy()
@@ -83,26 +262,73 @@ func runExtract(cmd *cobra.Command, args []string) error {
 		return fmt.Errorf("security validation failed for input path: %w", err)
 	}
 
-	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
-		return fmt.Errorf("security validation failed for output path: %w", err)
-	}
+	// Output written to stdout has no path, only a container
+	if toStdout {
+		if err := securityPolicy.ValidateFormat(extractContainer); err != nil {
+			return fmt.Errorf("security validation failed for container: %w", err)
+		}
+	} else {
+		if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+			return fmt.Errorf("security validation failed for output path: %w", err)
+		}
 
-	if err := securityPolicy.ValidateFileFormat(outputFile); err != nil {
-		return fmt.Errorf("security validation failed for output format: %w", err)
+		if err := securityPolicy.ValidateFileFormat(outputFile); err != nil {
+			return fmt.Errorf("security validation failed for output format: %w", err)
+		}
 	}
 
 	// Validate input file exists
-	if !fileExists(inputFile) {
-		return fmt.Errorf("input file does not exist: %s", inputFile)
+	if !analyzer.IsStdinPath(inputFile) && !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
 	}
 
 	// Check if output file exists and handle overwrite
-	if fileExists(outputFile) && !extractForce {
+	// (with --all-tracks and --split-on-silence the output only provides the base name and extension)
+	if fileExists(outputFile) && !extractForce && !extractAllTracks && !extractSplitOnSilence {
 		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
 	}
 
 	// Create audio extraction parameters
-	params := transcoder.AudioExtractionParams{
+	params := extractionParams(inputFile, outputFile)
+
+	// Validate parameters
+	if err := validateAudioParams(params); err != nil {
+		return fmt.Errorf("invalid parameters: %v", err)
+	}
+
+	// Display extraction info
+	if verbose {
+		displayExtractionInfo(params)
+	}
+
+	// Extract every audio track into its own file
+	if extractAllTracks {
+		return runExtractAllTracks(cmd.Context(), params)
+	}
+
+	// Cut the audio into parts at its silences
+	if extractSplitOnSilence {
+		return runExtractSplitOnSilence(cmd.Context(), params)
+	}
+
+	// Perform audio extraction
+	started := time.Now()
+	if err := transcoder.ExtractAudio(cmd.Context(), params); err != nil {
+		return err
+	}
+	showConversionSummary(cmd.Context(), inputFile, outputFile, time.Since(started))
+	if extractLoudness {
+		if !quiet {
+			fmt.Println()
+		}
+		return showLoudness(cmd.Context(), outputFile)
+	}
+	return nil
+}
+
+// extractionParams builds the audio extraction parameters from the flags
+func extractionParams(inputFile, outputFile string) transcoder.AudioExtractionParams {
+	return transcoder.AudioExtractionParams{
 		InputFile:  inputFile,
 		OutputFile: outputFile,
 		Quality:    extractQuality,
@@ -110,21 +336,198 @@ func runExtract(cmd *cobra.Command, args []string) error {
 		Codec:      extractCodec,
 		SampleRate: extractSampleRate,
 		Channels:   extractChannels,
+		Volume:     extractVolume,
+		Stream:     extractStream,
+		Language:   extractLanguage,
+		Container:  extractContainer,
+		Threads:    extractThreads,
+		Cover:      extractCover,
 		Verbose:    verbose,
+
+		InputDuration: extractDuration,
+		StripMetadata: extractStrip,
+		SampleFormat:  extractSampleFmt,
+		Resampler:     extractResampler,
+		ChannelMap:    extractChannelMap,
 	}
+}
 
-	// Validate parameters
+// showLoudness measures the loudness of an extracted file and prints it with the delivery
+// spec checks
+func showLoudness(ctx context.Context, path string) error {
+	stats, err := analyzer.AnalyzeLoudness(ctx, path)
+	if err != nil {
+		return fmt.Errorf("failed to analyze loudness of %s: %w", path, err)
+	}
+	displayLoudnessStats(stats, false, os.Stdout)
+	return nil
+}
+
+// showLoudnessEach prints the loudness of several extracted files, each under its name
+func showLoudnessEach(ctx context.Context, paths []string) error {
+	for _, path := range paths {
+		fmt.Printf("\n%s\n", path)
+		if err := showLoudness(ctx, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runExtractSplitOnSilence cuts the audio into parts at its silences, named after the output
+func runExtractSplitOnSilence(ctx context.Context, params transcoder.AudioExtractionParams) error {
+	segments, err := transcoder.SplitOnSilence(ctx, transcoder.SilenceParams{
+		InputFile: params.InputFile,
+		Threshold: extractSilenceThreshold,
+		MinLength: extractSilenceLength,
+		Verbose:   params.Verbose,
+	}, extractMinSegment, params, extractForce)
+	if err != nil && len(segments) == 0 {
+		return err
+	}
+
+	outputs := make([]string, 0, len(segments))
+	for _, segment := range segments {
+		outputs = append(outputs, segment.OutputFile)
+	}
+
+	if !quiet {
+		fmt.Printf("✂️  Split into %d part(s):\n", len(segments))
+		table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		for _, segment := range segments {
+			fmt.Fprintf(table, "   %s\t%s - %s\t%s\n", segment.OutputFile, formatSceneTime(segment.Start),
+				formatSceneTime(segment.End), formatSceneTime(segment.End-segment.Start))
+		}
+		table.Flush()
+	}
+
+	if extractLoudness {
+		if loudnessErr := showLoudnessEach(ctx, outputs); loudnessErr != nil {
+			return loudnessErr
+		}
+	}
+
+	if err != nil {
+		return &partialFailureError{written: len(segments), err: err}
+	}
+	return nil
+}
+
+// runExtractAllTracks extracts each audio stream using the track naming template
+func runExtractAllTracks(ctx context.Context, params transcoder.AudioExtractionParams) error {
+	outputs, err := transcoder.ExtractAllAudioTracks(ctx, params, extractTrackName, extractForce)
+	if err != nil && len(outputs) == 0 {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("🎵 Extracted %d audio track(s):\n", len(outputs))
+		for _, output := range outputs {
+			fmt.Printf("   %s\n", output)
+		}
+	}
+
+	if extractLoudness {
+		if loudnessErr := showLoudnessEach(ctx, outputs); loudnessErr != nil {
+			return loudnessErr
+		}
+	}
+
+	if err != nil {
+		return &partialFailureError{written: len(outputs), err: err}
+	}
+	return nil
+}
+
+// runExtractCue splits the input into the tracks of the --cue sheet, written to outputDir in
+// the format of --container, or of the input when it is an audio file
+func runExtractCue(ctx context.Context, inputFile, outputDir string) error {
+	if analyzer.IsStdinPath(inputFile) || transcoder.IsStdoutPath(outputDir) {
+		return fmt.Errorf("--cue cannot be used with stdin or stdout")
+	}
+	if extractAllTracks || extractSplitOnSilence {
+		return fmt.Errorf("--cue cannot be combined with --all-tracks or --split-on-silence")
+	}
+	if extractDuration != 0 {
+		return fmt.Errorf("--input-duration only applies when the input is - (stdin)")
+	}
+	if fileExists(outputDir) && !isDirectory(outputDir) {
+		return fmt.Errorf("with --cue the output must be a directory: %s", outputDir)
+	}
+
+	format := strings.ToLower(extractContainer)
+	if format == "" {
+		format = strings.ToLower(strings.TrimPrefix(filepath.Ext(inputFile), "."))
+		if !transcoder.IsAudioFormat(format) {
+			return fmt.Errorf("--cue needs --container for the track format when the input is not an audio file (e.g., --container flac)")
+		}
+	}
+
+	securityPolicy := security.NewDefaultSecurityPolicy()
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+	if !fileExists(extractCue) {
+		return fmt.Errorf("cue sheet %w: %s", analyzer.ErrFileNotFound, extractCue)
+	}
+
+	sheet, err := transcoder.ParseCueSheet(extractCue)
+	if err != nil {
+		return err
+	}
+
+	// The extension of the first track stands in for the output when validating the settings
+	params := extractionParams(inputFile, filepath.Join(outputDir, transcoder.CueTrackFileName(sheet.Tracks[0], len(sheet.Tracks), format)))
+	params.Container = ""
+	params.Verbose = verbose && !quiet
 	if err := validateAudioParams(params); err != nil {
 		return fmt.Errorf("invalid parameters: %v", err)
 	}
 
-	// Display extraction info
-	if verbose {
-		displayExtractionInfo(params)
+	if params.Verbose {
+		fmt.Println("💿 Splitting by Cue Sheet")
+		fmt.Println("========================")
+		fmt.Printf("📹 Input:   %s\n", inputFile)
+		fmt.Printf("📄 Sheet:   %s\n", extractCue)
+		fmt.Printf("📁 Output:  %s\n", outputDir)
+		if sheet.Title != "" {
+			fmt.Printf("💿 Album:   %s\n", sheet.Title)
+		}
+		if sheet.Performer != "" {
+			fmt.Printf("🎤 Artist:  %s\n", sheet.Performer)
+		}
+		fmt.Printf("🎵 Tracks:  %d, as %s\n", len(sheet.Tracks), strings.ToUpper(format))
+		fmt.Println()
+	}
+	if !quiet && sheet.File != "" && !strings.EqualFold(filepath.Base(sheet.File), filepath.Base(inputFile)) {
+		color.Yellow("⚠️  The cue sheet describes %s, not %s; check that the track times match", sheet.File, filepath.Base(inputFile))
 	}
 
-	// Perform audio extraction
-	return transcoder.ExtractAudio(params)
+	outputs, err := transcoder.SplitByCueSheet(ctx, params, sheet, outputDir, format, extractForce)
+	if err != nil && len(outputs) == 0 {
+		return err
+	}
+
+	if !quiet {
+		fmt.Printf("💿 Split into %d track(s):\n", len(outputs))
+		for _, output := range outputs {
+			fmt.Printf("   %s\n", output)
+		}
+	}
+
+	if extractLoudness {
+		if loudnessErr := showLoudnessEach(ctx, outputs); loudnessErr != nil {
+			return loudnessErr
+		}
+	}
+
+	if err != nil {
+		return &partialFailureError{written: len(outputs), err: err}
+	}
+	return nil
 }
 
 func validateAudioParams(params transcoder.AudioExtractionParams) error {
@@ -159,9 +562,33 @@ func validateAudioParams(params transcoder.AudioExtractionParams) error {
 		}
 	}
 
-	// Validate output format based on extension
+	// Validate volume adjustment if provided
+	if params.Volume != "" {
+		if err := security.NewDefaultSecurityPolicy().ValidateVolume(params.Volume); err != nil {
+			return err
+		}
+	}
+
+	// Validate audio stream selection if provided
+	if params.Stream != "" && params.Language != "" {
+		return fmt.Errorf("--audio-stream and --audio-language cannot be used together")
+	}
+	if extractAllTracks && (params.Stream != "" || params.Language != "") {
+		return fmt.Errorf("--all-tracks cannot be combined with --audio-stream or --audio-language")
+	}
+	if err := security.NewDefaultSecurityPolicy().ValidateStreamNumber(params.Stream); err != nil {
+		return err
+	}
+	if err := security.NewDefaultSecurityPolicy().ValidateLanguageCode(params.Language); err != nil {
+		return err
+	}
+
+	// Validate output format based on extension (or the container when writing to stdout)
 	ext := strings.ToLower(filepath.Ext(params.OutputFile))
-	supportedFormats := []string{".mp3", ".wav", ".aac", ".flac", ".ogg", ".m4a"}
+	if transcoder.IsStdoutPath(params.OutputFile) {
+		ext = "." + strings.ToLower(params.Container)
+	}
+	supportedFormats := []string{".mp3", ".wav", ".aac", ".adts", ".flac", ".ogg", ".opus", ".m4a", ".ac3", ".eac3", ".dts"}
 	if !contains(supportedFormats, ext) {
 		return fmt.Errorf("unsupported output format: %s (supported: %s)",
 			ext, strings.Join(supportedFormats, ", "))
@@ -189,6 +616,21 @@ func displayExtractionInfo(params transcoder.AudioExtractionParams) {
 	if params.Channels != "" {
 		fmt.Printf("🔊 Channels: %s\n", params.Channels)
 	}
+	if params.Volume != "" {
+		fmt.Printf("🔉 Volume:  %s\n", params.Volume)
+	}
+	if params.Stream != "" {
+		fmt.Printf("🎚️  Stream:  %s\n", params.Stream)
+	}
+	if params.Language != "" {
+		fmt.Printf("🌐 Language: %s\n", params.Language)
+	}
+	if params.Threads > 0 {
+		fmt.Printf("🧵 Threads: %d\n", params.Threads)
+	}
+	if params.Cover != "" {
+		fmt.Printf("🖼️  Cover:   %s\n", params.Cover)
+	}
 
 	fmt.Println()
 }
@@ -0,0 +1,129 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var fixRotationCmd = &cobra.Command{
+	Use:   "fix-rotation [input] [output]",
+	Short: "Apply or rewrite the rotation flag of phone videos",
+	Long: `Phone videos are usually stored sideways with a rotation flag that tells
+players how to turn them. Some players and editors ignore the flag.
+
+By default the frames are physically rotated and the flag is cleared, so the
+video plays upright everywhere. This re-encodes the video; audio is copied.
+
+With --metadata-only the streams are copied unchanged and only the flag is
+rewritten: cleared by default, or set with --rotation. Use this when the
+flag is wrong, for example on a video that was already rotated. This mode
+requires FFmpeg 6.1 or newer.
+
+Examples:
+  transcoder fix-rotation phone.mp4 upright.mp4
+  transcoder fix-rotation rotated-twice.mp4 fixed.mp4 --metadata-only
+  transcoder fix-rotation sideways.mp4 fixed.mp4 --metadata-only --rotation 90`,
+	Args: cobra.ExactArgs(2),
+	RunE: runFixRotation,
+}
+
+var (
+	fixRotationForce        bool
+	fixRotationMetadataOnly bool
+	fixRotationDegrees      int
+)
+
+func init() {
+	rootCmd.AddCommand(fixRotationCmd)
+
+	fixRotationCmd.Flags().BoolVarP(&fixRotationForce, "force", "f", false,
+		"overwrite output file if it exists")
+
+	fixRotationCmd.Flags().BoolVar(&fixRotationMetadataOnly, "metadata-only", false,
+		"rewrite the rotation flag without re-encoding")
+
+	fixRotationCmd.Flags().IntVar(&fixRotationDegrees, "rotation", 0,
+		"with --metadata-only, clockwise rotation to store (0, 90, 180, 270)")
+}
+
+func runFixRotation(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFileFormat(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output format: %w", err)
+	}
+
+	if cmd.Flags().Changed("rotation") && !fixRotationMetadataOnly {
+		return fmt.Errorf("--rotation can only be used with --metadata-only")
+	}
+
+	// Validate input file exists
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	// Check if output file exists and handle overwrite
+	if fileExists(outputFile) && !fixRotationForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("📱 Fixing Video Rotation")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputFile)
+		fmt.Println()
+	}
+
+	rotation, err := transcoder.FixRotation(cmd.Context(), transcoder.RotationParams{
+		InputFile:    inputFile,
+		OutputFile:   outputFile,
+		MetadataOnly: fixRotationMetadataOnly,
+		Rotation:     fixRotationDegrees,
+		Verbose:      useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("rotation fix failed: %w", err)
+	}
+
+	if !quiet {
+		if fixRotationMetadataOnly {
+			color.Green("✅ Rotation flag changed from %d° to %d°", rotation, fixRotationDegrees)
+		} else {
+			color.Green("✅ Frames rotated by %d° and rotation flag cleared", rotation)
+		}
+		fmt.Printf("Output saved to: %s\n", outputFile)
+	}
+
+	return nil
+}
@@ -0,0 +1,41 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// defaultFollowSettle is how long a followed file must stop growing before it is processed
+const defaultFollowSettle = 5 * time.Second
+
+// waitForGrowingFile waits until a file that is still being written stops growing,
+// showing the current size unless in quiet mode
+func waitForGrowingFile(ctx context.Context, path string, settle time.Duration) (*analyzer.MediaInfo, error) {
+	if !quiet {
+		color.Blue("⏳ Following %s until it stops growing for %s...", path, settle)
+	}
+
+	info, err := analyzer.WaitForStableMedia(ctx, path, settle, func(size int64) {
+		if !quiet {
+			fmt.Printf("\r   Current size: %-12s", formatBytes(size))
+		}
+	})
+
+	if !quiet {
+		fmt.Printf("\r%s\r", strings.Repeat(" ", 40))
+	}
+	if err != nil {
+		return nil, fmt.Errorf("following %s: %w", path, err)
+	}
+
+	if !quiet {
+		color.Green("✅ File is complete (%s)", formatBytes(info.Size))
+		fmt.Println()
+	}
+	return info, nil
+}
@@ -0,0 +1,147 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var framesCmd = &cobra.Command{
+	Use:   "frames [input] [output-dir]",
+	Short: "Extract frames of a video as images",
+	Long: `Extract frames of a video as JPEG, PNG or WebP images into a directory.
+
+Choose how many frames with one of:
+  --fps N       N frames per second of video (default: 1)
+  --every D     one frame per interval (e.g., 5s, 1m)
+  --all         every frame, as decoded
+
+Images are named with --name, a template in which {name} is the name of the
+input and {n} the frame number, counting from 1 and padded to five digits.
+The default {name}_{n} writes movie_00001.jpg, movie_00002.jpg, ...
+
+Examples:
+  transcoder frames input.mp4 outdir/ --fps 1
+
+  # A PNG every 5 seconds
+  transcoder frames lecture.mkv slides/ --every 5s --format png
+
+  # Every frame, for frame-by-frame work
+  transcoder frames clip.mp4 frames/ --all --name "shot_{n}"`,
+	Args: cobra.ExactArgs(2),
+	RunE: runFrames,
+}
+
+var (
+	framesFPS      float64
+	framesEvery    time.Duration
+	framesAll      bool
+	framesFormat   string
+	framesTemplate string
+	framesForce    bool
+)
+
+func init() {
+	rootCmd.AddCommand(framesCmd)
+
+	framesCmd.Flags().Float64Var(&framesFPS, "fps", 1,
+		"frames per second of video to extract")
+
+	framesCmd.Flags().DurationVar(&framesEvery, "every", 0,
+		"extract one frame per interval (e.g., 5s, 1m)")
+
+	framesCmd.Flags().BoolVar(&framesAll, "all", false,
+		"extract every frame")
+
+	framesCmd.Flags().StringVar(&framesFormat, "format", "jpg",
+		fmt.Sprintf("image format (%s)", strings.Join(transcoder.FrameFormats, ", ")))
+
+	framesCmd.Flags().StringVar(&framesTemplate, "name", transcoder.DefaultFrameTemplate,
+		"image name template: {name} is the input name, {n} the frame number")
+
+	framesCmd.Flags().BoolVarP(&framesForce, "force", "f", false,
+		"overwrite existing images in the output directory")
+}
+
+func runFrames(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputDir, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	selected := 0
+	for _, name := range []string{"fps", "every", "all"} {
+		if cmd.Flags().Changed(name) {
+			selected++
+		}
+	}
+	if selected > 1 {
+		return fmt.Errorf("use only one of --fps, --every and --all")
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputDir); err != nil {
+		return fmt.Errorf("security validation failed for output directory: %w", err)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	firstImage := fmt.Sprintf(transcoder.FramePattern(inputFile, outputDir, framesTemplate, framesFormat), 1)
+	if fileExists(firstImage) && !framesForce {
+		return fmt.Errorf("output already exists: %s (use --force to overwrite)", firstImage)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🖼️  Extracting Frames")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputDir)
+		fmt.Println()
+	}
+
+	count, err := transcoder.ExtractFrames(cmd.Context(), transcoder.FramesParams{
+		InputFile: inputFile,
+		OutputDir: outputDir,
+		FPS:       framesFPS,
+		Every:     framesEvery,
+		All:       framesAll,
+		Format:    framesFormat,
+		Template:  framesTemplate,
+		Verbose:   useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("frame extraction failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Extracted %d frames successfully!", count)
+		fmt.Printf("Output saved to: %s\n", outputDir)
+	}
+
+	return nil
+}
@@ -0,0 +1,137 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var gifCmd = &cobra.Command{
+	Use:   "gif [input] [output]",
+	Short: "Turn a clip of a video into an animated GIF, WebP or AVIF",
+	Long: `Cut a short clip from a video and encode it as a looping animated image.
+The extension of the output selects the format:
+
+  .gif   Plays everywhere; a palette is built from the clip to keep colors
+         faithful within GIF's 256
+  .webp  Animated WebP, typically a third of the size of the GIF
+         (needs FFmpeg with libwebp)
+  .avif  Animated AVIF, smaller still (needs FFmpeg 6.0 or newer with
+         libsvtav1 or libaom)
+
+Browsers play all three in an <img> tag, so WebP and AVIF make much lighter
+web previews than GIF.
+
+Examples:
+  transcoder gif in.mp4 out.gif
+
+  # A 3-second WebP preview from 1:30
+  transcoder gif in.mp4 out.webp --start 1m30s --duration 3s
+
+  # A smaller, smoother AVIF
+  transcoder gif in.mp4 out.avif --width 320 --fps 24`,
+	Args: cobra.ExactArgs(2),
+	RunE: runGIF,
+}
+
+var (
+	gifStart    time.Duration
+	gifDuration time.Duration
+	gifFPS      int
+	gifWidth    int
+	gifForce    bool
+)
+
+func init() {
+	rootCmd.AddCommand(gifCmd)
+
+	gifCmd.Flags().DurationVar(&gifStart, "start", 0,
+		"start of the clip in the input (e.g., 10s, 1m30s)")
+
+	gifCmd.Flags().DurationVar(&gifDuration, "duration", 5*time.Second,
+		"length of the clip, at most 1m")
+
+	gifCmd.Flags().IntVar(&gifFPS, "fps", 12,
+		"frame rate of the animation")
+
+	gifCmd.Flags().IntVar(&gifWidth, "width", 480,
+		"width in pixels; the height keeps the aspect ratio")
+
+	gifCmd.Flags().BoolVarP(&gifForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runGIF(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if fileExists(outputFile) && !gifForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🎞️  Creating Animation")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputFile)
+		fmt.Printf("   Clip:    %s from %s\n", gifDuration, formatDuration(gifStart))
+		fmt.Println()
+	}
+
+	if err := transcoder.CreateAnimation(cmd.Context(), transcoder.AnimationParams{
+		InputFile:  inputFile,
+		OutputFile: outputFile,
+		Start:      gifStart,
+		Duration:   gifDuration,
+		FPS:        gifFPS,
+		Width:      gifWidth,
+		Verbose:    useVerbose,
+	}); err != nil {
+		return fmt.Errorf("animation failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Animation created successfully!")
+		if stat, err := os.Stat(outputFile); err == nil {
+			fmt.Printf("Output saved to: %s (%s)\n", outputFile, formatBytes(stat.Size()))
+		} else {
+			fmt.Printf("Output saved to: %s\n", outputFile)
+		}
+	}
+
+	return nil
+}
@@ -1,45 +1,112 @@
 package cmd
 
 import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
 	"io"
 	"os"
 	"path/filepath"
 	"strconv"
 	"strings"
+	"text/tabwriter"
 	"time"
 
 	"github.com/fatih/color"
 	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
 	"github.com/rishad1234/term-video-transcoder/internal/security"
 	"github.com/spf13/cobra"
+	"gopkg.in/yaml.v3"
 )
 
 // infoCmd represents the info command
 var infoCmd = &cobra.Command{
-	Use:   "info [file]",
-	Short: "Display detailed information about a media file",
+	Use:   "info [file...]",
+	Short: "Display detailed information about media files",
 	Long: `Analyze and display comprehensive information about a media file including:
 - Format and container information
 - Video streams (codec, resolution, frame rate, bitrate)
 - Audio streams (codec, sample rate, channels, bitrate)
+- Subtitle streams (codec, language, default/forced flags)
 - Duration and file size
 - Metadata
 
 Example:
   transcoder info video.mp4
-  transcoder info movie.mkv`,
-	Args: cobra.ExactArgs(1),
+  transcoder info movie.mkv
+
+  # Wait for a recording that is still being written to finish
+  transcoder info obs-recording.mkv --follow
+
+  # Machine-readable output (csv has one row per stream)
+  transcoder info movie.mkv --format json
+  transcoder info movie.mkv --format csv -o movie.csv
+
+  # Several files or a whole directory as a compact table with totals
+  transcoder info *.mkv
+  transcoder info ./media --recursive
+  transcoder info ./media --recursive --format csv -o library.csv
+
+  # Keyframe interval (GOP) statistics for seeking and HLS segmenting
+  transcoder info movie.mp4 --keyframes
+
+  # Loudness (LUFS, true peak, range) against podcast and streaming specs
+  transcoder info episode.mp3 --loudness
+
+  # Audio/video offset and drift, with a suggested convert --audio-delay
+  transcoder info interview.mkv --sync
+
+  # Side-by-side comparison, e.g. to verify a conversion
+  transcoder info --compare original.mkv converted.mp4`,
+	Args: cobra.MinimumNArgs(1),
 	RunE: func(cmd *cobra.Command, args []string) error {
-		return runInfo(args[0])
+		if infoCompare {
+			return runInfoCompare(cmd.Context(), args)
+		}
+		if len(args) == 1 && !isDirectory(args[0]) {
+			return runInfo(cmd.Context(), args[0])
+		}
+		return runInfoBatch(cmd.Context(), args)
 	},
 }
 
+var (
+	infoFollow    bool
+	infoSettle    time.Duration
+	infoFormat    string
+	infoRecursive bool
+	infoCompare   bool
+	infoKeyframes bool
+	infoLoudness  bool
+	infoSync      bool
+)
+
+// infoFormats lists the supported info output formats
+var infoFormats = []string{"text", "json", "yaml", "csv"}
+
 func init() {
 	rootCmd.AddCommand(infoCmd)
+
+	infoCmd.Flags().BoolVar(&infoFollow, "follow", false,
+		"wait for a file that is still being written to stop growing before analyzing it")
+	infoCmd.Flags().DurationVar(&infoSettle, "settle", defaultFollowSettle,
+		"with --follow, how long the file must stop growing (e.g., 5s, 1m)")
+	infoCmd.Flags().StringVar(&infoFormat, "format", "text",
+		"output format (text, json, yaml, csv)")
+	infoCmd.Flags().BoolVarP(&infoRecursive, "recursive", "r", false,
+		"include media files in subdirectories")
+	infoCmd.Flags().BoolVar(&infoCompare, "compare", false,
+		"compare two files side by side and highlight differences")
+	infoCmd.Flags().BoolVar(&infoKeyframes, "keyframes", false,
+		"analyze keyframe intervals (GOP length) of the video stream")
+	infoCmd.Flags().BoolVar(&infoLoudness, "loudness", false,
+		"measure loudness (LUFS, true peak, range) and check it against delivery specs")
+	infoCmd.Flags().BoolVar(&infoSync, "sync", false,
+		"compare audio and video timing for an offset or drift and suggest an --audio-delay correction")
 }
 
-func runInfo(filepath string) error {
+func runInfo(ctx context.Context, filepath string) error {
 	// Initialize security policy
 	securityPolicy := security.NewDefaultSecurityPolicy()
 
@@ -48,43 +115,550 @@ func runInfo(filepath string) error {
 		return fmt.Errorf("security validation failed for file path: %w", err)
 	}
 
+	if !contains(infoFormats, infoFormat) {
+		return fmt.Errorf("invalid format '%s'. Valid options: %s", infoFormat, strings.Join(infoFormats, ", "))
+	}
+
 	// Check if ffprobe is available
-	if err := analyzer.CheckFFProbe(); err != nil {
+	if err := analyzer.CheckFFProbe(ctx); err != nil {
 		return fmt.Errorf("ffprobe check failed: %w", err)
 	}
 
-	// Analyze the media file
-	info, err := analyzer.AnalyzeMedia(filepath)
+	// Analyze the media file, waiting for it to finish growing when following
+	var info *analyzer.MediaInfo
+	var err error
+	if infoFollow {
+		info, err = waitForGrowingFile(ctx, filepath, infoSettle)
+	} else {
+		info, err = analyzer.AnalyzeMedia(ctx, filepath)
+	}
 	if err != nil {
 		return fmt.Errorf("failed to analyze media: %w", err)
 	}
 
-	// Determine output destination
-	var writer io.Writer = os.Stdout
-	var outputFile *os.File
+	// Frame-level keyframe analysis reads every video packet, so it only runs on request
+	if infoKeyframes {
+		if len(info.VideoStreams) == 0 {
+			return fmt.Errorf("keyframe analysis requires a video stream")
+		}
+		info.Keyframes, err = analyzer.AnalyzeKeyframes(ctx, filepath)
+		if err != nil {
+			return fmt.Errorf("failed to analyze keyframes: %w", err)
+		}
+	}
+
+	// Loudness analysis decodes the whole audio stream, so it only runs on request
+	if infoLoudness {
+		if len(info.AudioStreams) == 0 {
+			return fmt.Errorf("loudness analysis requires an audio stream")
+		}
+		if err := analyzer.CheckFFMpeg(ctx); err != nil {
+			return fmt.Errorf("ffmpeg check failed: %w", err)
+		}
+		info.Loudness, err = analyzer.AnalyzeLoudness(ctx, filepath)
+		if err != nil {
+			return fmt.Errorf("failed to analyze loudness: %w", err)
+		}
+	}
 
-	if output != "" {
-		outputFile, err = os.Create(output)
+	// Sync analysis reads every packet of the file, so it only runs on request
+	if infoSync {
+		if len(info.VideoStreams) == 0 || len(info.AudioStreams) == 0 {
+			return fmt.Errorf("sync analysis requires a video and an audio stream")
+		}
+		info.Sync, err = analyzer.AnalyzeSync(ctx, filepath, info.VideoStreams[0].Index, info.AudioStreams[0].Index)
 		if err != nil {
-			return fmt.Errorf("failed to create output file: %w", err)
+			return fmt.Errorf("failed to analyze sync: %w", err)
 		}
-		defer outputFile.Close()
-		writer = outputFile
 	}
 
+	// Determine output destination
+	writer, closeWriter, err := openInfoWriter()
+	if err != nil {
+		return err
+	}
+	defer closeWriter()
+
 	// Determine verbosity: quiet overrides verbose
 	useVerbose := verbose && !quiet
 
 	// Display the information with verbosity consideration
-	displayMediaInfo(info, useVerbose, writer)
+	if err := writeMediaInfo(info, infoFormat, useVerbose, writer); err != nil {
+		return fmt.Errorf("failed to write media information: %w", err)
+	}
+
+	if output != "" && !quiet {
+		fmt.Printf("Media information saved to: %s\n", output)
+	}
+
+	return nil
+}
+
+// runInfoBatch analyzes several files and directories and prints a compact table with totals
+func runInfoBatch(ctx context.Context, paths []string) error {
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	for _, path := range paths {
+		if err := securityPolicy.ValidateFilePath(path); err != nil {
+			return fmt.Errorf("security validation failed for file path: %w", err)
+		}
+	}
+
+	if !contains(infoFormats, infoFormat) {
+		return fmt.Errorf("invalid format '%s'. Valid options: %s", infoFormat, strings.Join(infoFormats, ", "))
+	}
+
+	if infoFollow || infoKeyframes || infoLoudness || infoSync {
+		return fmt.Errorf("--follow, --keyframes, --loudness and --sync can only be used with a single file")
+	}
+
+	// Check if ffprobe is available
+	if err := analyzer.CheckFFProbe(ctx); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	files, err := collectMediaFiles(paths, infoRecursive)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no media files found")
+	}
+
+	// Analyze every file, remembering failures instead of stopping at the first one
+	infos := make([]*analyzer.MediaInfo, 0, len(files))
+	failures := make(map[string]error)
+	for _, file := range files {
+		info, err := analyzer.AnalyzeMedia(ctx, file)
+		if err != nil {
+			failures[file] = err
+			continue
+		}
+		infos = append(infos, info)
+	}
+
+	// Determine output destination
+	writer, closeWriter, err := openInfoWriter()
+	if err != nil {
+		return err
+	}
+	defer closeWriter()
+
+	if err := writeMediaInfoList(infos, files, failures, infoFormat, writer); err != nil {
+		return fmt.Errorf("failed to write media information: %w", err)
+	}
 
 	if output != "" && !quiet {
 		fmt.Printf("Media information saved to: %s\n", output)
 	}
 
+	if len(infos) == 0 {
+		return fmt.Errorf("none of the %d file(s) could be analyzed", len(files))
+	}
+	return nil
+}
+
+// runInfoCompare analyzes two files and shows their properties side by side
+func runInfoCompare(ctx context.Context, paths []string) error {
+	if len(paths) != 2 {
+		return fmt.Errorf("--compare requires exactly two files")
+	}
+	if infoFormat != "text" || infoFollow || infoRecursive || infoKeyframes || infoLoudness || infoSync {
+		return fmt.Errorf("--compare cannot be combined with --format, --follow, --recursive, --keyframes, --loudness or --sync")
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	for _, path := range paths {
+		if err := securityPolicy.ValidateFilePath(path); err != nil {
+			return fmt.Errorf("security validation failed for file path: %w", err)
+		}
+	}
+
+	// Check if ffprobe is available
+	if err := analyzer.CheckFFProbe(ctx); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	left, err := analyzer.AnalyzeMedia(ctx, paths[0])
+	if err != nil {
+		return fmt.Errorf("failed to analyze %s: %w", paths[0], err)
+	}
+	right, err := analyzer.AnalyzeMedia(ctx, paths[1])
+	if err != nil {
+		return fmt.Errorf("failed to analyze %s: %w", paths[1], err)
+	}
+
+	// Determine output destination
+	writer, closeWriter, err := openInfoWriter()
+	if err != nil {
+		return err
+	}
+	defer closeWriter()
+
+	differences := displayMediaComparison(left, right, writer)
+
+	if output != "" && !quiet {
+		fmt.Printf("Comparison saved to: %s\n", output)
+	}
+	if !quiet && output == "" {
+		if differences == 0 {
+			color.Green("✅ No differences found")
+		} else {
+			color.Yellow("⚠️  %d difference(s) found", differences)
+		}
+	}
 	return nil
 }
 
+// comparisonRow is one compared property of two files
+type comparisonRow struct {
+	label string
+	left  string
+	right string
+}
+
+// mediaComparisonRows lists the compared properties of two files
+func mediaComparisonRows(left, right *analyzer.MediaInfo) []comparisonRow {
+	rows := []comparisonRow{
+		{"File", left.Filename, right.Filename},
+		{"Format", left.Format, right.Format},
+		{"Duration", formatDuration(left.Duration), formatDuration(right.Duration)},
+		{"Size", formatBytes(left.Size), formatBytes(right.Size)},
+		{"Bitrate", formatOptionalBitrate(left.Bitrate), formatOptionalBitrate(right.Bitrate)},
+		{"Video Streams", strconv.Itoa(len(left.VideoStreams)), strconv.Itoa(len(right.VideoStreams))},
+		{"Audio Streams", strconv.Itoa(len(left.AudioStreams)), strconv.Itoa(len(right.AudioStreams))},
+		{"Subtitle Streams", strconv.Itoa(len(left.SubtitleStreams)), strconv.Itoa(len(right.SubtitleStreams))},
+	}
+
+	leftVideo, rightVideo := firstVideoStream(left), firstVideoStream(right)
+	rows = append(rows,
+		comparisonRow{"Video Codec", leftVideo.Codec, rightVideo.Codec},
+		comparisonRow{"Video Profile", leftVideo.Profile, rightVideo.Profile},
+		comparisonRow{"Resolution", formatResolution(leftVideo), formatResolution(rightVideo)},
+		comparisonRow{"Frame Rate", leftVideo.FrameRate, rightVideo.FrameRate},
+		comparisonRow{"Pixel Format", leftVideo.PixelFormat, rightVideo.PixelFormat},
+		comparisonRow{"Video Bitrate", formatOptionalBitrate(leftVideo.Bitrate), formatOptionalBitrate(rightVideo.Bitrate)},
+	)
+
+	leftAudio, rightAudio := firstAudioStream(left), firstAudioStream(right)
+	rows = append(rows,
+		comparisonRow{"Audio Codec", leftAudio.Codec, rightAudio.Codec},
+		comparisonRow{"Sample Rate", formatOptionalInt(leftAudio.SampleRate, " Hz"), formatOptionalInt(rightAudio.SampleRate, " Hz")},
+		comparisonRow{"Channels", formatOptionalInt(leftAudio.Channels, ""), formatOptionalInt(rightAudio.Channels, "")},
+		comparisonRow{"Audio Bitrate", formatOptionalBitrate(leftAudio.Bitrate), formatOptionalBitrate(rightAudio.Bitrate)},
+		comparisonRow{"Audio Language", leftAudio.Language, rightAudio.Language},
+	)
+
+	return rows
+}
+
+// displayMediaComparison renders the side-by-side table and returns the number of differences
+func displayMediaComparison(left, right *analyzer.MediaInfo, writer io.Writer) int {
+	isFile := writer != os.Stdout
+	if isFile {
+		fmt.Fprintln(writer, "Media Comparison:")
+	} else {
+		color.Cyan("🔀 Media Comparison:")
+	}
+	fmt.Fprintln(writer)
+
+	rows := mediaComparisonRows(left, right)
+	differences := 0
+
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	for i, row := range rows {
+		leftValue, rightValue := valueOrDash(row.left), valueOrDash(row.right)
+		marker := " "
+		// The file names always differ; only count real property differences
+		if i > 0 && leftValue != rightValue {
+			differences++
+			marker = "≠"
+			if !isFile {
+				rightValue = color.YellowString(rightValue)
+			}
+		}
+		fmt.Fprintf(table, " %s %s:\t%s\t%s\n", marker, row.label, leftValue, rightValue)
+	}
+	table.Flush()
+	fmt.Fprintln(writer)
+
+	return differences
+}
+
+// firstVideoStream returns the first video stream or an empty one
+func firstVideoStream(info *analyzer.MediaInfo) analyzer.VideoStream {
+	if len(info.VideoStreams) == 0 {
+		return analyzer.VideoStream{}
+	}
+	return info.VideoStreams[0]
+}
+
+// firstAudioStream returns the first audio stream or an empty one
+func firstAudioStream(info *analyzer.MediaInfo) analyzer.AudioStream {
+	if len(info.AudioStreams) == 0 {
+		return analyzer.AudioStream{}
+	}
+	return info.AudioStreams[0]
+}
+
+// formatResolution renders a video stream's resolution, or empty when unknown
+func formatResolution(stream analyzer.VideoStream) string {
+	if stream.Width == 0 || stream.Height == 0 {
+		return ""
+	}
+	return fmt.Sprintf("%dx%d", stream.Width, stream.Height)
+}
+
+// formatOptionalBitrate renders a bitrate, or empty when unknown
+func formatOptionalBitrate(bitrate int64) string {
+	if bitrate <= 0 {
+		return ""
+	}
+	return formatBitrate(bitrate)
+}
+
+// formatOptionalInt renders a number with a unit, or empty when unknown
+func formatOptionalInt(value int, unit string) string {
+	if value == 0 {
+		return ""
+	}
+	return strconv.Itoa(value) + unit
+}
+
+// valueOrDash replaces empty values with a dash for display
+func valueOrDash(value string) string {
+	if value == "" {
+		return "-"
+	}
+	return value
+}
+
+// openInfoWriter returns the destination for info output (stdout or the --output file)
+func openInfoWriter() (io.Writer, func(), error) {
+	if output == "" {
+		return os.Stdout, func() {}, nil
+	}
+
+	outputFile, err := os.Create(output)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to create output file: %w", err)
+	}
+	return outputFile, func() { outputFile.Close() }, nil
+}
+
+// mediaFileExtensions lists extensions picked up when scanning directories
+var mediaFileExtensions = map[string]bool{
+	"mp4": true, "m4v": true, "mkv": true, "avi": true, "mov": true, "webm": true,
+	"ts": true, "m2ts": true, "mpg": true, "mpeg": true, "flv": true, "wmv": true,
+	"3gp": true, "ogv": true, "mxf": true,
+	"mp3": true, "wav": true, "aac": true, "flac": true, "ogg": true, "opus": true, "m4a": true,
+	"adts": true, "ac3": true, "eac3": true, "dts": true,
+}
+
+// isDirectory reports whether a path is an existing directory
+func isDirectory(path string) bool {
+	stat, err := os.Stat(path)
+	return err == nil && stat.IsDir()
+}
+
+// collectMediaFiles expands directories into the media files they contain.
+// Files given explicitly are always included; directory entries are filtered by extension.
+func collectMediaFiles(paths []string, recursive bool) ([]string, error) {
+	files := make([]string, 0)
+	for _, path := range paths {
+		if !isDirectory(path) {
+			files = append(files, path)
+			continue
+		}
+
+		err := filepath.WalkDir(path, func(entryPath string, entry os.DirEntry, err error) error {
+			if err != nil {
+				return err
+			}
+			if entry.IsDir() {
+				if entryPath != path && !recursive {
+					return filepath.SkipDir
+				}
+				return nil
+			}
+			if mediaFileExtensions[strings.ToLower(strings.TrimPrefix(filepath.Ext(entryPath), "."))] {
+				files = append(files, entryPath)
+			}
+			return nil
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scanning %s: %w", path, err)
+		}
+	}
+	return files, nil
+}
+
+// writeMediaInfoList renders information for several files in the requested output format
+func writeMediaInfoList(infos []*analyzer.MediaInfo, files []string, failures map[string]error, format string, writer io.Writer) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(infos)
+	case "yaml":
+		encoder := yaml.NewEncoder(writer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(infos); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case "csv":
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(mediaInfoCSVHeader); err != nil {
+			return err
+		}
+		for _, info := range infos {
+			if err := csvWriter.WriteAll(mediaInfoCSVRows(info)); err != nil {
+				return err
+			}
+		}
+		return csvWriter.Error()
+	default:
+		displayMediaInfoTable(infos, files, failures, writer)
+		return nil
+	}
+}
+
+// displayMediaInfoTable renders a compact one-line-per-file table with a totals footer
+func displayMediaInfoTable(infos []*analyzer.MediaInfo, files []string, failures map[string]error, writer io.Writer) {
+	byFile := make(map[string]*analyzer.MediaInfo, len(infos))
+	for _, info := range infos {
+		byFile[info.Filename] = info
+	}
+
+	table := tabwriter.NewWriter(writer, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "FILE\tDURATION\tRESOLUTION\tVIDEO\tAUDIO\tSIZE")
+
+	var totalDuration time.Duration
+	var totalSize int64
+	for _, file := range files {
+		info, ok := byFile[file]
+		if !ok {
+			continue
+		}
+
+		resolution, videoCodec := "-", "-"
+		if len(info.VideoStreams) > 0 {
+			resolution = fmt.Sprintf("%dx%d", info.VideoStreams[0].Width, info.VideoStreams[0].Height)
+			videoCodec = info.VideoStreams[0].Codec
+		}
+
+		audioCodecs := make([]string, 0, len(info.AudioStreams))
+		for _, stream := range info.AudioStreams {
+			audioCodecs = append(audioCodecs, stream.Codec)
+		}
+		audioCodec := "-"
+		if len(audioCodecs) > 0 {
+			audioCodec = strings.Join(audioCodecs, ",")
+		}
+
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\t%s\t%s\n", file, formatDuration(info.Duration),
+			resolution, videoCodec, audioCodec, formatBytes(info.Size))
+
+		totalDuration += info.Duration
+		totalSize += info.Size
+	}
+	table.Flush()
+
+	fmt.Fprintln(writer)
+	fmt.Fprintf(writer, "Total: %d file(s), %s, %s\n", len(infos), formatDuration(totalDuration), formatBytes(totalSize))
+	if len(failures) > 0 {
+		fmt.Fprintf(writer, "Failed: %d file(s) could not be analyzed\n", len(failures))
+		for _, file := range files {
+			if err, ok := failures[file]; ok {
+				fmt.Fprintf(writer, "   %s: %v\n", file, err)
+			}
+		}
+	}
+}
+
+// writeMediaInfo renders media information in the requested output format
+func writeMediaInfo(info *analyzer.MediaInfo, format string, verbose bool, writer io.Writer) error {
+	switch format {
+	case "json":
+		encoder := json.NewEncoder(writer)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(info)
+	case "yaml":
+		encoder := yaml.NewEncoder(writer)
+		encoder.SetIndent(2)
+		if err := encoder.Encode(info); err != nil {
+			return err
+		}
+		return encoder.Close()
+	case "csv":
+		csvWriter := csv.NewWriter(writer)
+		if err := csvWriter.Write(mediaInfoCSVHeader); err != nil {
+			return err
+		}
+		if err := csvWriter.WriteAll(mediaInfoCSVRows(info)); err != nil {
+			return err
+		}
+		return csvWriter.Error()
+	default:
+		displayMediaInfo(info, verbose, writer)
+		return nil
+	}
+}
+
+// mediaInfoCSVHeader lists the CSV columns; stream fields that do not apply are left empty
+var mediaInfoCSVHeader = []string{
+	"file", "format", "duration_seconds", "size_bytes", "stream_index", "stream_type",
+	"codec", "profile", "width", "height", "frame_rate", "pixel_format",
+	"sample_rate", "channels", "language", "bitrate", "default", "forced",
+}
+
+// mediaInfoCSVRows returns one CSV row per stream (or a single row for files without streams)
+func mediaInfoCSVRows(info *analyzer.MediaInfo) [][]string {
+	base := []string{
+		info.Filename,
+		info.Format,
+		strconv.FormatFloat(info.Duration.Seconds(), 'f', 3, 64),
+		strconv.FormatInt(info.Size, 10),
+	}
+	row := func(fields ...string) []string {
+		return append(append([]string{}, base...), fields...)
+	}
+	itoa := func(n int64) string {
+		if n == 0 {
+			return ""
+		}
+		return strconv.FormatInt(n, 10)
+	}
+
+	rows := make([][]string, 0)
+	for _, stream := range info.VideoStreams {
+		rows = append(rows, row(strconv.Itoa(stream.Index), "video", stream.Codec, stream.Profile,
+			itoa(int64(stream.Width)), itoa(int64(stream.Height)), stream.FrameRate, stream.PixelFormat,
+			"", "", "", itoa(stream.Bitrate), "", ""))
+	}
+	for _, stream := range info.AudioStreams {
+		rows = append(rows, row(strconv.Itoa(stream.Index), "audio", stream.Codec, stream.Profile,
+			"", "", "", "", itoa(int64(stream.SampleRate)), itoa(int64(stream.Channels)),
+			stream.Language, itoa(stream.Bitrate), "", ""))
+	}
+	for _, stream := range info.SubtitleStreams {
+		rows = append(rows, row(strconv.Itoa(stream.Index), "subtitle", stream.Codec, "",
+			"", "", "", "", "", "", stream.Language, "",
+			strconv.FormatBool(stream.Default), strconv.FormatBool(stream.Forced)))
+	}
+
+	if len(rows) == 0 {
+		rows = append(rows, row(make([]string, len(mediaInfoCSVHeader)-len(base))...))
+	}
+	return rows
+}
+
 func displayMediaInfo(info *analyzer.MediaInfo, verbose bool, writer io.Writer) {
 	isFile := writer != os.Stdout
 
@@ -92,6 +666,10 @@ func displayMediaInfo(info *analyzer.MediaInfo, verbose bool, writer io.Writer)
 	displayFileInfo(info, verbose, isFile, writer)
 	displayVideoStreams(info.VideoStreams, verbose, isFile, writer)
 	displayAudioStreams(info.AudioStreams, verbose, isFile, writer)
+	displaySubtitleStreams(info.SubtitleStreams, verbose, isFile, writer)
+	displayKeyframeStats(info.Keyframes, isFile, writer)
+	displayLoudnessStats(info.Loudness, isFile, writer)
+	displaySyncStats(info.Sync, isFile, writer)
 	displayTechnicalSummary(info, verbose, isFile, writer)
 }
 
@@ -138,6 +716,9 @@ func displayFileInfo(info *analyzer.MediaInfo, verbose, isFile bool, writer io.W
 	if info.Bitrate > 0 {
 		fmt.Fprintf(writer, "   Overall Bitrate: %s\n", formatBitrate(info.Bitrate))
 	}
+	if info.Timecode != "" {
+		fmt.Fprintf(writer, "   Timecode: %s\n", info.Timecode)
+	}
 
 	if verbose {
 		displayVerboseFileInfo(info, writer)
@@ -181,8 +762,15 @@ func displayVideoStream(stream analyzer.VideoStream, streamNum int, verbose bool
 
 	fmt.Fprintf(writer, "     Codec: %s\n", stream.Codec)
 	fmt.Fprintf(writer, "     Resolution: %dx%d\n", stream.Width, stream.Height)
-	fmt.Fprintf(writer, "     Frame Rate: %s\n", stream.FrameRate)
+	if stream.IsVariableFrameRate() {
+		fmt.Fprintf(writer, "     Frame Rate: %s (variable, avg %.2f fps)\n", stream.FrameRate, analyzer.ParseFrameRate(stream.AvgFrameRate))
+	} else {
+		fmt.Fprintf(writer, "     Frame Rate: %s\n", stream.FrameRate)
+	}
 	fmt.Fprintf(writer, "     Pixel Format: %s\n", stream.PixelFormat)
+	if stream.Rotation != 0 {
+		fmt.Fprintf(writer, "     Rotation: %d°\n", stream.Rotation)
+	}
 
 	if stream.Bitrate > 0 {
 		fmt.Fprintf(writer, "     Bitrate: %s\n", formatBitrate(stream.Bitrate))
@@ -258,6 +846,135 @@ func displayAudioLanguage(stream analyzer.AudioStream, verbose bool, writer io.W
 	}
 }
 
+// displaySubtitleStreams renders subtitle stream information
+func displaySubtitleStreams(streams []analyzer.SubtitleStream, verbose, isFile bool, writer io.Writer) {
+	if len(streams) == 0 {
+		return
+	}
+
+	if isFile {
+		fmt.Fprintln(writer, "Subtitle Streams:")
+	} else {
+		color.Blue("💬 Subtitle Streams:")
+	}
+
+	for i, stream := range streams {
+		displaySubtitleStream(stream, i+1, verbose, writer)
+	}
+}
+
+// displaySubtitleStream renders a single subtitle stream
+func displaySubtitleStream(stream analyzer.SubtitleStream, streamNum int, verbose bool, writer io.Writer) {
+	fmt.Fprintf(writer, "   Stream %d:\n", streamNum)
+
+	if verbose {
+		fmt.Fprintf(writer, "     Stream Index: %d\n", stream.Index)
+	}
+
+	fmt.Fprintf(writer, "     Codec: %s\n", stream.Codec)
+
+	if stream.Language != "" && stream.Language != "und" {
+		fmt.Fprintf(writer, "     Language: %s\n", stream.Language)
+	} else if verbose {
+		fmt.Fprintf(writer, "     Language: %s (undefined)\n", stream.Language)
+	}
+
+	if stream.Title != "" {
+		fmt.Fprintf(writer, "     Title: %s\n", stream.Title)
+	}
+
+	flags := make([]string, 0, 2)
+	if stream.Default {
+		flags = append(flags, "default")
+	}
+	if stream.Forced {
+		flags = append(flags, "forced")
+	}
+	if len(flags) > 0 {
+		fmt.Fprintf(writer, "     Flags: %s\n", strings.Join(flags, ", "))
+	}
+	fmt.Fprintln(writer)
+}
+
+// displayKeyframeStats renders keyframe interval statistics when they were analyzed
+func displayKeyframeStats(stats *analyzer.KeyframeStats, isFile bool, writer io.Writer) {
+	if stats == nil {
+		return
+	}
+
+	if isFile {
+		fmt.Fprintln(writer, "Keyframes:")
+	} else {
+		color.Yellow("🔑 Keyframes:")
+	}
+
+	fmt.Fprintf(writer, "   Keyframes: %d of %d frames\n", stats.Keyframes, stats.Frames)
+	fmt.Fprintf(writer, "   GOP Length: min %d / avg %.1f / max %d frames\n", stats.MinGOP, stats.AvgGOP, stats.MaxGOP)
+	if stats.AvgIntervalSecs > 0 {
+		fmt.Fprintf(writer, "   Keyframe Interval: avg %.2fs / max %.2fs\n", stats.AvgIntervalSecs, stats.MaxIntervalSecs)
+	}
+	if stats.MinGOP == stats.MaxGOP {
+		fmt.Fprintln(writer, "   Structure: fixed GOP (segments cut cleanly)")
+	} else {
+		fmt.Fprintln(writer, "   Structure: variable GOP (segment lengths will vary)")
+	}
+	fmt.Fprintln(writer)
+}
+
+// displayLoudnessStats renders loudness measurements and delivery spec checks when they were analyzed
+func displayLoudnessStats(stats *analyzer.LoudnessStats, isFile bool, writer io.Writer) {
+	if stats == nil {
+		return
+	}
+
+	if isFile {
+		fmt.Fprintln(writer, "Loudness:")
+	} else {
+		color.Yellow("🔊 Loudness:")
+	}
+
+	fmt.Fprintf(writer, "   Integrated: %.1f LUFS\n", stats.Integrated)
+	fmt.Fprintf(writer, "   True Peak: %.1f dBTP\n", stats.TruePeak)
+	fmt.Fprintf(writer, "   Loudness Range: %.1f LU\n", stats.Range)
+	for _, spec := range stats.Specs {
+		verdict := "meets spec"
+		if !spec.Passes {
+			verdict = "fails (" + spec.Issue + ")"
+		}
+		fmt.Fprintf(writer, "   %s, %.0f LUFS: %s\n", spec.Name, spec.Target, verdict)
+	}
+	fmt.Fprintln(writer)
+}
+
+// displaySyncStats renders the audio/video offset and drift when they were analyzed
+func displaySyncStats(stats *analyzer.SyncStats, isFile bool, writer io.Writer) {
+	if stats == nil {
+		return
+	}
+
+	if isFile {
+		fmt.Fprintln(writer, "A/V Sync:")
+	} else {
+		color.Yellow("🎬 A/V Sync:")
+	}
+
+	fmt.Fprintf(writer, "   Video: starts %.3fs, lasts %.3fs\n", stats.VideoStartSecs, stats.VideoDurationSecs)
+	fmt.Fprintf(writer, "   Audio: starts %.3fs, lasts %.3fs\n", stats.AudioStartSecs, stats.AudioDurationSecs)
+	fmt.Fprintf(writer, "   Offset: %+.0fms at the start, %+.0fms at the end\n", stats.StartOffsetSecs*1000, stats.EndOffsetSecs*1000)
+	switch stats.Status {
+	case "drift":
+		fmt.Fprintf(writer, "   Status: audio drifts %+.0fms over the file; a constant delay only halves it\n", stats.DriftSecs*1000)
+	case "offset":
+		fmt.Fprintln(writer, "   Status: constant offset")
+	default:
+		fmt.Fprintln(writer, "   Status: in sync")
+	}
+	if stats.SuggestedDelay != "" {
+		fmt.Fprintf(writer, "   Suggested Fix: transcoder convert --audio-delay %s\n", stats.SuggestedDelay)
+	}
+	fmt.Fprintln(writer)
+}
+
 // displayTechnicalSummary renders technical summary in verbose mode
 func displayTechnicalSummary(info *analyzer.MediaInfo, verbose, isFile bool, writer io.Writer) {
 	if !verbose {
@@ -270,13 +987,21 @@ func displayTechnicalSummary(info *analyzer.MediaInfo, verbose, isFile bool, wri
 		color.Blue("🔧 Technical Summary:")
 	}
 
-	totalStreams := len(info.VideoStreams) + len(info.AudioStreams)
+	totalStreams := len(info.VideoStreams) + len(info.AudioStreams) + len(info.SubtitleStreams)
 	fmt.Fprintf(writer, "   Total Streams: %d\n", totalStreams)
 	fmt.Fprintf(writer, "   Video Streams: %d\n", len(info.VideoStreams))
 	fmt.Fprintf(writer, "   Audio Streams: %d\n", len(info.AudioStreams))
+	fmt.Fprintf(writer, "   Subtitle Streams: %d\n", len(info.SubtitleStreams))
+	if len(info.Attachments) > 0 {
+		fmt.Fprintf(writer, "   Attachments: %d (see 'transcoder attachments list')\n", len(info.Attachments))
+	}
 
 	if len(info.VideoStreams) > 0 && info.Duration > 0 {
-		fps := parseFrameRate(info.VideoStreams[0].FrameRate)
+		stream := info.VideoStreams[0]
+		fps := analyzer.ParseFrameRate(stream.FrameRate)
+		if stream.IsVariableFrameRate() {
+			fps = analyzer.ParseFrameRate(stream.AvgFrameRate)
+		}
 		totalFrames := int(info.Duration.Seconds() * fps)
 		fmt.Fprintf(writer, "   Estimated Total Frames: %d\n", totalFrames)
 	}
@@ -340,20 +1065,3 @@ func getChannelLayout(channels int) string {
 		return fmt.Sprintf("%d channels", channels)
 	}
 }
-
-// parseFrameRate extracts FPS from frame rate string (e.g., "30/1" -> 30.0)
-func parseFrameRate(frameRate string) float64 {
-	parts := strings.Split(frameRate, "/")
-	if len(parts) != 2 {
-		return 0
-	}
-
-	numerator, err1 := strconv.ParseFloat(parts[0], 64)
-	denominator, err2 := strconv.ParseFloat(parts[1], 64)
-
-	if err1 != nil || err2 != nil || denominator == 0 {
-		return 0
-	}
-
-	return numerator / denominator
-}
@@ -0,0 +1,149 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var ladderCmd = &cobra.Command{
+	Use:   "ladder [input] [output-dir]",
+	Short: "Encode an adaptive bitrate (HLS) ladder in one pass",
+	Long: `Encode several renditions of a video for adaptive bitrate streaming and
+write an HLS master playlist that lets players switch between them.
+
+All renditions are encoded in a single FFmpeg run: the source is decoded
+once and split into one scaled copy per rendition. Each rendition gets its
+own directory with a playlist and segments, and keyframes are aligned to
+segment boundaries so players can switch cleanly. Renditions taller than
+the source are skipped.
+
+With --encrypt, segments are encrypted with AES-128 using a generated key
+(enc.key). Players fetch the key from the output directory unless --key-uri
+points them elsewhere, such as a key server that checks access.
+
+Examples:
+  transcoder ladder input.mp4 hls/
+  transcoder ladder input.mp4 hls/ --renditions 1080p:5M,720p:3M,480p:1M
+  transcoder ladder talk.mkv hls/ --renditions 720p:2M,360p:600k --audio-bitrate 96k
+  transcoder ladder course.mp4 hls/ --encrypt --key-uri https://keys.example.com/course.key`,
+	Args: cobra.ExactArgs(2),
+	RunE: runLadder,
+}
+
+var (
+	ladderRenditions      string
+	ladderSegmentDuration int
+	ladderAudioBitrate    string
+	ladderEncrypt         bool
+	ladderKeyURI          string
+	ladderForce           bool
+)
+
+func init() {
+	rootCmd.AddCommand(ladderCmd)
+
+	ladderCmd.Flags().StringVar(&ladderRenditions, "renditions", "1080p:5M,720p:3M,480p:1M",
+		"comma-separated height:bitrate renditions")
+
+	ladderCmd.Flags().IntVar(&ladderSegmentDuration, "segment-duration", 6,
+		"target segment duration in seconds")
+
+	ladderCmd.Flags().StringVar(&ladderAudioBitrate, "audio-bitrate", "128k",
+		"audio bitrate for every rendition")
+
+	ladderCmd.Flags().BoolVar(&ladderEncrypt, "encrypt", false,
+		"encrypt segments with AES-128 using a generated key")
+
+	ladderCmd.Flags().StringVar(&ladderKeyURI, "key-uri", "",
+		"with --encrypt, URI players fetch the key from (default: the key in the output directory)")
+
+	ladderCmd.Flags().BoolVarP(&ladderForce, "force", "f", false,
+		"overwrite an existing master playlist in the output directory")
+}
+
+func runLadder(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputDir, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputDir); err != nil {
+		return fmt.Errorf("security validation failed for output directory: %w", err)
+	}
+
+	if ladderKeyURI != "" && !ladderEncrypt {
+		return fmt.Errorf("--key-uri can only be used with --encrypt")
+	}
+
+	renditions, err := transcoder.ParseRenditions(ladderRenditions)
+	if err != nil {
+		return err
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	masterPlaylist := filepath.Join(outputDir, transcoder.LadderMasterPlaylistName)
+	if fileExists(masterPlaylist) && !ladderForce {
+		return fmt.Errorf("output already exists: %s (use --force to overwrite)", masterPlaylist)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("📶 Building Bitrate Ladder")
+		fmt.Println()
+		fmt.Printf("   Input:    %s\n", inputFile)
+		fmt.Printf("   Output:   %s\n", outputDir)
+		fmt.Printf("   Segments: %ds\n", ladderSegmentDuration)
+		fmt.Println()
+	}
+
+	masterPath, err := transcoder.EncodeLadder(cmd.Context(), transcoder.LadderParams{
+		InputFile:       inputFile,
+		OutputDir:       outputDir,
+		Renditions:      renditions,
+		SegmentDuration: ladderSegmentDuration,
+		AudioBitrate:    ladderAudioBitrate,
+		Encrypt:         ladderEncrypt,
+		KeyURI:          ladderKeyURI,
+		Verbose:         useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("ladder encoding failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Bitrate ladder created successfully!")
+		fmt.Printf("Master playlist saved to: %s\n", masterPath)
+		if ladderEncrypt && ladderKeyURI != "" {
+			color.Yellow("🔐 Upload %s to %s; players fetch the key from there",
+				filepath.Join(outputDir, transcoder.HLSKeyName), ladderKeyURI)
+		}
+	}
+
+	return nil
+}
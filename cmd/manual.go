@@ -36,16 +36,51 @@ QUICK REFERENCE
 ===============
 
 COMMANDS:
-  info     Analyze media files (duration, codecs, metadata)
-  convert  Convert between video formats with custom options
-  extract  Extract audio from videos to various formats
-  manual   Show this manual
+  info       Analyze media files (duration, codecs, metadata)
+  convert    Convert between video or audio formats with custom options
+  extract    Extract audio from videos to various formats
+  compat     Show codec copy/re-encode matrix for a container
+  recommend  Suggest a convert command for a goal, with reasons
+  bitrate    Calculate the video bitrate for a target file size
+  analyze-bitrate  Graph bitrate over time and highlight spikes
+  quality    Score an encode against its source (VMAF, PSNR, SSIM)
+  sample     Join clips from several points into one review encode
+  benchmark  Compare encoder speed, size and VMAF on one segment
+  scenes     Detect scene changes and split into one file per scene
+  merge      Join several videos, re-encoding only when they differ
+  frames     Extract frames as JPEG, PNG or WebP images
+  contactsheet  Tile evenly spaced frames into one overview image
+  gif        Animated GIF, WebP or AVIF preview of a clip
+  poster     Pick a representative, sharp frame as a poster
+  waveform   Draw the waveform of an audio track as an image
+  spectrogram  Spectrogram image, to spot lossy sources
+  silence    Detect silence, or trim it from the start and end
+  remux      Change the container without re-encoding
+  chapters   Add or replace chapter marks (chapters set in.mkv ch.txt)
+  attachments  List or extract MKV attachments (fonts, cover images)
+  subtitles  Shift or scale SRT/WebVTT timing (subtitles shift s.srt --offset 2s)
+  dash       Package as MPEG-DASH (manifest + segments)
+  ladder     Encode an HLS adaptive bitrate ladder
+  storyboard Seek preview sprite sheets + WebVTT
+  stream     Stream live to an RTMP or SRT server
+  repair     Recover damaged or truncated recordings
+  fix-rotation  Apply or rewrite phone video rotation
+  timelapse  Build a time-lapse from long recordings
+  config     Set general settings and per-command default flags
+  preset     Save named presets for convert --profile
+  exit-codes List the exit codes scripts can check
+  manual     Show this manual
 
 GLOBAL OPTIONS:
   -h, --help      Show help
   -v, --verbose   Verbose output (default)
   -q, --quiet     Quiet mode
   -o, --output    Output file/directory
+  --background    Low CPU/I-O priority for long encodes
+  --progress-format json  Newline-delimited JSON progress events
+  --progress-file   Write JSON progress events to a file
+  --log-level     debug, info, warn or error
+  --log-file      Append a JSON log of the job and FFmpeg runs
   --version       Show version
 
 CONVERT COMMAND
@@ -63,13 +98,53 @@ CUSTOM VIDEO OPTIONS:
   --video-bitrate    Bitrate (2M, 1500k, 4M)
   --resolution       Resolution (1920x1080, 1280x720, 640x360)
   --framerate        Frame rate (30, 24, 60)
+  --cfr              Constant frame rate (VFR screen/phone recordings)
 
 CUSTOM AUDIO OPTIONS:
   --audio-codec      Audio codec (aac, libopus, libmp3lame)
   --audio-bitrate    Bitrate (192k, 128k, 256k)
+  --volume           Volume adjustment (1.5, 0.5, +3dB, -6dB)
+
+STREAM SELECTION:
+  --audio-stream     Audio stream number from info (1, 2, 3)
+  --audio-language   Audio stream language (eng, jpn, deu)
+  --no-audio         Remove all audio (video-only output)
+  --add-audio        Mux an extra audio track (commentary.flac:eng)
+  --audio-delay      Shift the audio to fix lip sync (300ms, -300ms)
 
 OTHER OPTIONS:
   -f, --force        Overwrite existing files
+  --web-optimized    MP4/MOV faststart (default on; =false to disable)
+  --fragmented       Fragmented MP4 for MSE/CMAF (.mp4 only)
+  --fix-timestamps   Fix broken/negative timestamps (non-monotonous DTS)
+  --follow           Wait for a growing input to finish (OBS, downloads)
+  --settle           Time the input must stop growing (default 5s)
+  --resumable        Encode in segments; rerun to resume after interruption
+  --retry-fallback   Retry a failed encode with safer settings
+  --preview          Encode only the first N seconds and estimate the rest (30s)
+  --preview-at       Where the preview starts (--preview 30s --preview-at 45m)
+  --threads          Cap FFmpeg threads per encode (0 = auto)
+  --target           Platform preset (youtube, instagram-reel, tiktok, twitter)
+  --archival         Lossless FFV1/FLAC preservation copy (.mkv only)
+  --text-overlay     Draw text onto the video ("© 2024 MyChannel")
+  --text-position    Where the text goes (top-left ... bottom-right)
+  --text-size        Font size in pixels (default 24)
+  --text-color       Text color, name or hex (default white)
+  --text-font        Font file; a common installed font otherwise
+  --burn-timecode    Burn in a running HH:MM:SS:FF timecode (dailies)
+  --pad-to           Fit into 16:9 or 1920x1080 with bars, no distortion
+  --pad-color        Color of the --pad-to bars (default black)
+  --lut              Apply a 3D LUT (.cube) to grade log footage
+  --blur-region      Blur x,y,w,h[,start,end] to hide faces or plates
+  --profile          Named preset saved with preset save (web-720p)
+  --ffmpeg-args      Extra allowlisted FFmpeg options ("-crf 20 -tune film")
+  --unsafe           Pass --ffmpeg-args without the allowlist check
+  --container        Format for output to stdout (convert in.mkv - --container mp4)
+                     or for a directory (convert flac/ mp3/ --container mp3)
+  -r, --recursive    Include subdirectories when converting a directory
+  --cover            Embed cover art in an mp3/m4a/flac output (front.jpg)
+  --strip-metadata   Drop titles, dates, languages and other tags of the input
+  --input-duration   Length of input read from stdin (convert - out.mp4)
 
 SUPPORTED VIDEO FORMATS:
   MP4     Most compatible, web-friendly
@@ -77,6 +152,16 @@ SUPPORTED VIDEO FORMATS:
   MKV     High quality, multiple tracks
   WebM    Modern web format, efficient
   MOV     Apple format, high quality
+  TS      MPEG transport stream, broadcast and streaming (H.264/AAC)
+  FLV     Flash video, RTMP ingest (H.264/AAC)
+  3GP     Older phones (baseline H.264/AAC, stereo)
+  OGV     Ogg video, open codecs (Theora/Vorbis)
+  MXF     Broadcast exchange (MPEG-2 4:2:2, 24-bit 48 kHz PCM)
+
+SUPPORTED AUDIO FORMATS:
+  MP3, M4A, AAC, ADTS, FLAC, WAV, OGG, OPUS, AC3, EAC3, DTS
+  Audio outputs convert the audio alone, keeping its tags
+  (convert album.flac album.m4a)
 
 EXTRACT COMMAND
 ===============
@@ -92,7 +177,25 @@ CUSTOM OPTIONS:
   -b, --bitrate      Audio bitrate (320k, 192k, 128k)
   -c, --codec        Audio codec (libmp3lame, flac, aac)
   -s, --sample-rate  Sample rate (44100, 48000)
+  --resampler        Converter for --sample-rate (auto, soxr, swr)
   --channels         Channels (1=mono, 2=stereo)
+  --channel-map      Pick, swap or copy channels (FL-FR, FR-FL, FL-FL)
+  --sample-format    Bit depth of wav/flac/alac (s16, s24, s32, flt)
+  --volume           Volume adjustment (1.5, +3dB, -6dB)
+  --audio-stream     Audio stream number from info (1, 2, 3)
+  --audio-language   Audio stream language (eng, jpn, deu)
+  --all-tracks       One output per audio stream (movie.track1.eng.mp3)
+  --track-name       Name template ({name}.track{index}.{lang})
+  --cover            Embed cover art in mp3/m4a/flac (--cover front.jpg)
+  --strip-metadata   Drop the tags of the input (title, artist, album)
+  --cue              Split an album rip by its CUE sheet (--cue album.cue outdir/)
+  --split-on-silence Cut a recording into parts at its pauses (memo.part01.mp3)
+  --silence-threshold Level in dB counted as silent (default -50)
+  --silence-length   Shortest pause to cut at (default 2s)
+  --min-segment      Shortest part to cut into (default 30s)
+  --container        Format for output to stdout (extract in.mp4 - --container mp3)
+                     or of --cue tracks
+  --input-duration   Length of input read from stdin (extract - out.mp3)
   -f, --force        Overwrite existing files
 
 SUPPORTED AUDIO FORMATS:
@@ -101,7 +204,12 @@ SUPPORTED AUDIO FORMATS:
   AAC     Modern, efficient compression
   FLAC    Lossless compression
   OGG     Open format, good compression
-  M4A     Apple format, AAC container
+  OPUS    Ogg Opus, smallest files for speech
+  ADTS    Raw AAC stream (same as AAC)
+  M4A     Apple format, AAC or lossless ALAC (--codec alac)
+  AC3     Dolby Digital for TVs and receivers
+  EAC3    Dolby Digital Plus
+  DTS     Copies a DTS track as it is (no transcoding)
 
 COMMON EXAMPLES
 ===============
@@ -0,0 +1,162 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var mergeCmd = &cobra.Command{
+	Use:   "merge [input...] [output]",
+	Short: "Join several videos into one",
+	Long: `Join videos one after the other into a single file. The last argument is
+the output.
+
+Every input is analyzed first. When all of them share the same codecs,
+resolution, frame rate and audio layout (clips from one camera, parts of one
+recording) they are joined without re-encoding, which is fast and lossless.
+Otherwise each input is re-encoded to the resolution and frame rate of the first
+one and the default codecs of the output container, and the results are joined.
+Inputs of another aspect ratio are letterboxed, and inputs without audio get
+silence.
+
+With --transition each clip blends into the next (a crossfade of the picture
+and the sound) instead of cutting hard, for assembling highlight reels without a
+video editor. Transitions always re-encode, and each one overlaps the end of a
+clip with the start of the next, so the output is shorter than the inputs
+together by one transition per join.
+
+The first video and audio stream of each input are kept.
+
+Examples:
+  transcoder merge a.mp4 b.mp4 c.mp4 out.mp4
+
+  # Re-encode even when the inputs match, at high quality
+  transcoder merge part1.mkv part2.mkv full.mkv --reencode --preset high
+
+  # A highlight reel with one-second crossfades
+  transcoder merge goal1.mp4 goal2.mp4 goal3.mp4 reel.mp4 --transition crossfade --transition-duration 1s`,
+	Args: cobra.MinimumNArgs(3),
+	RunE: runMerge,
+}
+
+var (
+	mergePreset   string
+	mergeReencode bool
+	mergeForce    bool
+
+	mergeTransition         string
+	mergeTransitionDuration time.Duration
+)
+
+func init() {
+	rootCmd.AddCommand(mergeCmd)
+
+	mergeCmd.Flags().StringVarP(&mergePreset, "preset", "p", "medium",
+		"quality preset when re-encoding (low, medium, high)")
+
+	mergeCmd.Flags().BoolVar(&mergeReencode, "reencode", false,
+		"re-encode the inputs even when they could be joined as they are")
+
+	mergeCmd.Flags().StringVar(&mergeTransition, "transition", "",
+		fmt.Sprintf("blend each clip into the next (%s)", strings.Join(transcoder.MergeTransitionNames(), ", ")))
+
+	mergeCmd.Flags().DurationVar(&mergeTransitionDuration, "transition-duration", time.Second,
+		"length of each transition")
+
+	mergeCmd.Flags().BoolVarP(&mergeForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runMerge(cmd *cobra.Command, args []string) error {
+	inputFiles := args[:len(args)-1]
+	outputFile, err := resolveOutputPath(args[len(args)-1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	for _, inputFile := range inputFiles {
+		if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+			return fmt.Errorf("security validation failed for input path: %w", err)
+		}
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFileFormat(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output format: %w", err)
+	}
+
+	for _, inputFile := range inputFiles {
+		if !fileExists(inputFile) {
+			return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+		}
+	}
+
+	if fileExists(outputFile) && !mergeForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🔗 Merging Videos")
+		fmt.Println()
+		for i, inputFile := range inputFiles {
+			fmt.Printf("   Input %d: %s\n", i+1, inputFile)
+		}
+		fmt.Printf("   Output:  %s\n", outputFile)
+		if mergeTransition != "" {
+			fmt.Printf("   Transition: %s (%s)\n", mergeTransition, mergeTransitionDuration)
+		}
+		fmt.Println()
+	}
+
+	report, err := transcoder.MergeVideos(cmd.Context(), transcoder.MergeParams{
+		InputFiles: inputFiles,
+		OutputFile: outputFile,
+		Preset:     mergePreset,
+		Reencode:   mergeReencode,
+
+		Transition:         mergeTransition,
+		TransitionDuration: mergeTransitionDuration,
+
+		Verbose: useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("merge failed: %w", err)
+	}
+
+	if !quiet {
+		method := "without re-encoding"
+		switch {
+		case mergeTransition != "":
+			method = "with " + mergeTransition + " transitions"
+		case report.Reencoded:
+			method = "after re-encoding to a common format"
+		}
+		color.Green("✅ Merged %d inputs %s!", len(inputFiles), method)
+		fmt.Printf("Output saved to: %s (%s)\n", outputFile, formatDuration(report.Duration))
+	}
+
+	return nil
+}
@@ -0,0 +1,60 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+)
+
+// checkStdinInput checks the --input-duration flag against the input path.
+// Input read from stdin (-) must be piped in rather than typed at a terminal.
+func checkStdinInput(inputPath string, duration time.Duration) error {
+	if !analyzer.IsStdinPath(inputPath) {
+		if duration != 0 {
+			return fmt.Errorf("--input-duration only applies when the input is - (stdin)")
+		}
+		return nil
+	}
+
+	if duration < 0 {
+		return fmt.Errorf("invalid input duration: %s", duration)
+	}
+	if isTerminal(os.Stdin) {
+		return fmt.Errorf("no media piped into stdin; pipe the input from another program or a file")
+	}
+	return nil
+}
+
+// prepareStdoutOutput checks the --container flag against the output path. For output written
+// to stdout (-) it also silences status messages, which would corrupt the media on stdout.
+func prepareStdoutOutput(outputPath, container string) error {
+	if !transcoder.IsStdoutPath(outputPath) {
+		if container != "" {
+			return fmt.Errorf("--container only applies when the output is - (stdout)")
+		}
+		return nil
+	}
+
+	if container == "" {
+		return fmt.Errorf("writing to stdout (-) requires --container (e.g., --container mkv)")
+	}
+	if progressFormat == "json" && progressFile == "" {
+		return fmt.Errorf("json progress events cannot share stdout with the output; use --progress-file")
+	}
+	if isTerminal(os.Stdout) {
+		return fmt.Errorf("refusing to write media to a terminal; pipe the output into another program")
+	}
+
+	quiet = true
+	verbose = false
+	return nil
+}
+
+// isTerminal reports whether a standard stream is attached to a terminal rather than a pipe or file
+func isTerminal(file *os.File) bool {
+	info, err := file.Stat()
+	return err == nil && info.Mode()&os.ModeCharDevice != 0
+}
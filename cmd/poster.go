@@ -0,0 +1,118 @@
+package cmd
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var posterCmd = &cobra.Command{
+	Use:   "poster [input] [output]",
+	Short: "Pick a representative frame of a video as its poster image",
+	Long: `Pick a representative frame of a video and save it as a JPEG or PNG poster,
+instead of taking the first frame, which is often black or a title card.
+
+Candidate frames are taken from evenly spaced points of the video, skipping
+the opening and the credits. At each point FFmpeg's thumbnail filter picks
+the most typical frame of the next two seconds, passing over flashes and
+transitions. Blank candidates (black, white or a flat color) are dropped
+and the sharpest of the rest becomes the poster.
+
+Examples:
+  transcoder poster input.mp4 poster.jpg
+
+  # Compare more candidates and scale the poster down
+  transcoder poster movie.mkv poster.png --candidates 20 --width 1280`,
+	Args: cobra.ExactArgs(2),
+	RunE: runPoster,
+}
+
+var (
+	posterCandidates int
+	posterWidth      int
+	posterForce      bool
+)
+
+func init() {
+	rootCmd.AddCommand(posterCmd)
+
+	posterCmd.Flags().IntVar(&posterCandidates, "candidates", 10,
+		"number of points of the video to take a candidate frame from")
+
+	posterCmd.Flags().IntVar(&posterWidth, "width", 0,
+		"width in pixels; the height keeps the aspect ratio (default: size of the video)")
+
+	posterCmd.Flags().BoolVarP(&posterForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runPoster(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if fileExists(outputFile) && !posterForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🖼️  Picking Poster Frame")
+		fmt.Println()
+		fmt.Printf("   Input:       %s\n", inputFile)
+		fmt.Printf("   Output:      %s\n", outputFile)
+		fmt.Printf("   Candidates:  %d\n", posterCandidates)
+		fmt.Println()
+	}
+
+	result, err := transcoder.CreatePoster(cmd.Context(), transcoder.PosterParams{
+		InputFile:  inputFile,
+		OutputFile: outputFile,
+		Candidates: posterCandidates,
+		Width:      posterWidth,
+		Verbose:    useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("poster failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Poster created successfully!")
+		fmt.Printf("Frame from around %s, best of %d candidates\n",
+			formatDuration(result.Time.Truncate(time.Second)), result.Candidates)
+		fmt.Printf("Output saved to: %s\n", outputFile)
+	}
+
+	return nil
+}
@@ -0,0 +1,251 @@
+package cmd
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/config"
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+)
+
+// presetFlags are the convert flags a named preset can store; flags that depend on
+// a particular input or output (e.g., --audio-stream, --container) are left out
+var presetFlags = []string{
+	"preset", "video-codec", "audio-codec", "video-bitrate", "audio-bitrate",
+	"resolution", "framerate", "cfr", "volume", "audio-language", "no-audio",
+	"fix-timestamps", "fragmented", "web-optimized", "resumable", "retry-fallback",
+	"ffmpeg-args", "target", "archival", "threads", "text-overlay", "text-position",
+	"text-size", "text-color", "text-font", "burn-timecode", "pad-to", "pad-color", "lut",
+}
+
+// presetCmd represents the preset command
+var presetCmd = &cobra.Command{
+	Use:   "preset",
+	Short: "Manage named conversion presets",
+	Long: `Save combinations of convert flags under a name and reuse them with
+convert --profile. Presets are stored in presets.yaml in the configuration
+directory (~/.config/transcoder on Linux).
+
+Flags given on the command line override the values stored in the preset,
+which in turn override configured defaults (see transcoder config).
+
+Examples:
+  # Save a preset
+  transcoder preset save web-720p --video-codec libx264 --video-bitrate 3M --resolution 1280x720
+
+  # Use it
+  transcoder convert in.mkv out.mp4 --profile web-720p
+
+  # Use it with a different bitrate
+  transcoder convert in.mkv out.mp4 --profile web-720p --video-bitrate 2M
+
+  # Show, list and delete presets
+  transcoder preset show web-720p
+  transcoder preset list
+  transcoder preset delete web-720p`,
+}
+
+var presetSaveCmd = &cobra.Command{
+	Use:   "save [name] [convert flags]",
+	Short: "Save convert flags as a named preset",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPresetSave(cmd, args[0])
+	},
+}
+
+var presetShowCmd = &cobra.Command{
+	Use:   "show [name]",
+	Short: "Show the flags stored in a preset",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPresetShow(args[0])
+	},
+}
+
+var presetListCmd = &cobra.Command{
+	Use:   "list",
+	Short: "List all named presets",
+	Args:  cobra.NoArgs,
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPresetList()
+	},
+}
+
+var presetDeleteCmd = &cobra.Command{
+	Use:   "delete [name]",
+	Short: "Delete a named preset",
+	Args:  cobra.ExactArgs(1),
+	RunE: func(cmd *cobra.Command, args []string) error {
+		return runPresetDelete(args[0])
+	},
+}
+
+func init() {
+	rootCmd.AddCommand(presetCmd)
+	presetCmd.AddCommand(presetSaveCmd, presetShowCmd, presetListCmd, presetDeleteCmd)
+
+	// preset save accepts the same flags as convert
+	for _, name := range presetFlags {
+		presetSaveCmd.Flags().AddFlag(convertCmd.Flags().Lookup(name))
+	}
+}
+
+func runPresetSave(cmd *cobra.Command, name string) error {
+	if err := config.ValidatePresetName(name); err != nil {
+		return err
+	}
+
+	flags := make(map[string]string)
+	cmd.Flags().Visit(func(flag *pflag.Flag) {
+		if contains(presetFlags, flag.Name) {
+			flags[flag.Name] = flag.Value.String()
+		}
+	})
+	if len(flags) == 0 {
+		return fmt.Errorf("no convert flags given to save (e.g., --video-codec libx264 --resolution 1280x720)")
+	}
+
+	presets, err := config.LoadPresets()
+	if err != nil {
+		return err
+	}
+	_, replaced := presets.Get(name)
+	presets.Set(name, flags)
+	if err := presets.Save(); err != nil {
+		return err
+	}
+
+	if !quiet {
+		if replaced {
+			color.Green("✅ Updated preset %s: %s", name, formatPresetFlags(flags))
+		} else {
+			color.Green("✅ Saved preset %s: %s", name, formatPresetFlags(flags))
+		}
+	}
+	return nil
+}
+
+func runPresetShow(name string) error {
+	presets, err := config.LoadPresets()
+	if err != nil {
+		return err
+	}
+
+	flags, ok := presets.Get(name)
+	if !ok {
+		return fmt.Errorf("unknown preset: %s (see transcoder preset list)", name)
+	}
+
+	fmt.Println(formatPresetFlags(flags))
+	return nil
+}
+
+func runPresetList() error {
+	presets, err := config.LoadPresets()
+	if err != nil {
+		return err
+	}
+
+	path, err := config.PresetsPath()
+	if err != nil {
+		return err
+	}
+
+	names := presets.Names()
+	if !quiet {
+		color.Cyan("🎛️  Presets: %s", path)
+		fmt.Println()
+	}
+
+	if len(names) == 0 {
+		if !quiet {
+			fmt.Println("   No presets saved")
+		}
+		return nil
+	}
+
+	for _, name := range names {
+		flags, _ := presets.Get(name)
+		fmt.Printf("   %-16s %s\n", name, formatPresetFlags(flags))
+	}
+	return nil
+}
+
+func runPresetDelete(name string) error {
+	presets, err := config.LoadPresets()
+	if err != nil {
+		return err
+	}
+
+	if !presets.Delete(name) {
+		return fmt.Errorf("unknown preset: %s", name)
+	}
+	if err := presets.Save(); err != nil {
+		return err
+	}
+
+	if !quiet {
+		color.Green("✅ Deleted preset %s", name)
+	}
+	return nil
+}
+
+// applyProfile sets the flags stored in the preset named by --profile, except flags given
+// on the command line (explicit)
+func applyProfile(cmd *cobra.Command, explicit map[string]bool) error {
+	profileFlag := cmd.Flags().Lookup("profile")
+	if profileFlag == nil || profileFlag.Value.String() == "" {
+		return nil
+	}
+	name := profileFlag.Value.String()
+
+	presets, err := config.LoadPresets()
+	if err != nil {
+		return err
+	}
+
+	flags, ok := presets.Get(name)
+	if !ok {
+		return fmt.Errorf("unknown profile: %s (see transcoder preset list)", name)
+	}
+
+	for flagName, value := range flags {
+		if !contains(presetFlags, flagName) {
+			return fmt.Errorf("profile %s: flag --%s cannot be stored in a preset", name, flagName)
+		}
+		if explicit[flagName] {
+			continue // Explicit command line flags win
+		}
+		if cmd.Flags().Lookup(flagName) == nil {
+			continue // sample takes only the encoding settings, not e.g. --resumable
+		}
+		if err := cmd.Flags().Set(flagName, value); err != nil {
+			return fmt.Errorf("profile %s: --%s: %w", name, flagName, err)
+		}
+	}
+
+	return nil
+}
+
+// formatPresetFlags renders preset flags as command line flags, sorted by name
+func formatPresetFlags(flags map[string]string) string {
+	names := make([]string, 0, len(flags))
+	for name := range flags {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	parts := make([]string, 0, len(names))
+	for _, name := range names {
+		value := flags[name]
+		if strings.ContainsAny(value, " \t") {
+			value = fmt.Sprintf("%q", value)
+		}
+		parts = append(parts, fmt.Sprintf("--%s=%s", name, value))
+	}
+	return strings.Join(parts, " ")
+}
@@ -0,0 +1,34 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+)
+
+// progressBarWidth is the number of cells in the progress bar
+const progressBarWidth = 30
+
+// showProgressBar renders progress updates as a progress bar on one terminal line,
+// clearing it once FFmpeg exits
+func showProgressBar(progress transcoder.Progress) {
+	if progress.Done {
+		fmt.Printf("\r%s\r", strings.Repeat(" ", 100))
+		return
+	}
+
+	if progress.Duration <= 0 {
+		// Without a known duration only the position can be shown
+		fmt.Printf("\r📊 %s processed - %.1fx speed", formatDuration(progress.Position), progress.Speed)
+		return
+	}
+
+	filled := int(progress.Percent / 100 * progressBarWidth)
+	bar := strings.Repeat("█", filled) + strings.Repeat("░", progressBarWidth-filled)
+	eta := ""
+	if progress.ETA > 0 {
+		eta = fmt.Sprintf(" (ETA: %s)", formatDuration(progress.ETA))
+	}
+	fmt.Printf("\r📊 [%s] %.1f%% - %.1fx speed%s", bar, progress.Percent, progress.Speed, eta)
+}
@@ -0,0 +1,207 @@
+package cmd
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+// qualityCmd represents the quality command
+var qualityCmd = &cobra.Command{
+	Use:   "quality",
+	Short: "Measure the visual quality of encodes",
+	Long: `Measure how close an encode looks to its source, to check whether a preset,
+bitrate or codec choice keeps enough quality.
+
+Examples:
+  transcoder quality score source.mkv encoded.mp4`,
+}
+
+var qualityScoreCmd = &cobra.Command{
+	Use:   "score [reference] [encoded]",
+	Short: "Score an encode against its source with VMAF, PSNR or SSIM",
+	Long: `Compare every frame of an encode with the source it was made from. The
+encode is scaled to the resolution of the reference before comparing, and all
+chosen metrics are computed in a single pass.
+
+Metrics:
+  vmaf   Video Multi-Method Assessment Fusion (libvmaf), 0-100; around 93 and
+         above an encode is usually hard to tell apart from the source (default)
+  psnr   Peak signal-to-noise ratio in dB; above 40 dB is very good
+  ssim   Structural similarity, 0-1; above 0.98 is very good
+
+The 1% and 5% lows show whether a good mean hides badly encoded scenes.
+
+Models:
+  vmaf       1080p content watched on a TV (default)
+  vmaf-4k    4K content watched on a TV
+  vmaf-neg   ignores gains from sharpening and contrast enhancement
+
+Examples:
+  transcoder quality score source.mkv encoded.mp4
+  transcoder quality score source.mkv encoded-4k.mp4 --model vmaf-4k --threads 8
+
+  # All three metrics, with per-frame scores for plotting
+  transcoder quality score source.mkv encoded.mp4 --metric vmaf,psnr,ssim --csv frames.csv
+
+  # Print only the mean score
+  transcoder quality score source.mkv encoded.mp4 -q`,
+	Args: cobra.ExactArgs(2),
+	RunE: runQualityScore,
+}
+
+var (
+	qualityMetrics []string
+	qualityModel   string
+	qualityThreads int
+	qualityCSV     string
+)
+
+func init() {
+	rootCmd.AddCommand(qualityCmd)
+	qualityCmd.AddCommand(qualityScoreCmd)
+
+	qualityScoreCmd.Flags().StringSliceVar(&qualityMetrics, "metric", []string{"vmaf"},
+		"metrics to compute, comma-separated: "+strings.Join(transcoder.QualityMetricNames(), ", "))
+
+	qualityScoreCmd.Flags().StringVar(&qualityModel, "model", "vmaf",
+		"VMAF model: "+strings.Join(transcoder.VMAFModelNames(), ", "))
+
+	qualityScoreCmd.Flags().IntVar(&qualityThreads, "threads", 0,
+		"number of threads libvmaf may use (0 = libvmaf decides)")
+
+	qualityScoreCmd.Flags().StringVar(&qualityCSV, "csv", "",
+		"write the score of every frame to this CSV file, one column per metric")
+}
+
+func runQualityScore(cmd *cobra.Command, args []string) error {
+	referenceFile, encodedFile := args[0], args[1]
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	for _, path := range args {
+		if err := securityPolicy.ValidateFilePath(path); err != nil {
+			return fmt.Errorf("security validation failed for input path: %w", err)
+		}
+		if !fileExists(path) {
+			return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, path)
+		}
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("📏 Scoring Quality")
+		fmt.Println()
+		fmt.Printf("   Reference: %s\n", referenceFile)
+		fmt.Printf("   Encoded:   %s\n", encodedFile)
+		fmt.Printf("   Metrics:   %s\n", strings.Join(qualityMetrics, ", "))
+		if slices.Contains(qualityMetrics, "vmaf") {
+			fmt.Printf("   Model:     %s\n", qualityModel)
+		}
+		fmt.Println()
+	}
+
+	scores, err := transcoder.ScoreQuality(cmd.Context(), transcoder.QualityParams{
+		ReferenceFile: referenceFile,
+		EncodedFile:   encodedFile,
+		Metrics:       qualityMetrics,
+		Model:         qualityModel,
+		Threads:       qualityThreads,
+		CSVFile:       qualityCSV,
+		Verbose:       useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("quality scoring failed: %w", err)
+	}
+
+	if quiet {
+		for _, score := range scores {
+			if len(scores) > 1 {
+				fmt.Printf("%s ", score.Metric)
+			}
+			fmt.Printf("%.*f\n", qualityPrecision(score), score.Mean)
+		}
+		return nil
+	}
+
+	for _, score := range scores {
+		displayQualityScore(score)
+	}
+	if qualityCSV != "" {
+		fmt.Println()
+		fmt.Printf("Per-frame scores saved to: %s\n", qualityCSV)
+	}
+	return nil
+}
+
+// displayQualityScore prints the summary of a quality metric with a rating of the mean
+func displayQualityScore(score *transcoder.QualityScore) {
+	title := strings.ToUpper(score.Metric)
+	if score.Model != "" {
+		title += fmt.Sprintf(" (%s)", score.Model)
+	}
+
+	fmt.Println()
+	color.Cyan("📊 %s over %d frames", title, score.Frames)
+	fmt.Printf("   Mean:          %s  (%s)\n", formatQualityValue(score, score.Mean), rateQualityScore(score))
+	fmt.Printf("   Harmonic mean: %s\n", formatQualityValue(score, score.HarmonicMean))
+	fmt.Printf("   Median:        %s\n", formatQualityValue(score, score.Median))
+	fmt.Printf("   5%% low:        %s\n", formatQualityValue(score, score.P5))
+	fmt.Printf("   1%% low:        %s\n", formatQualityValue(score, score.P1))
+	fmt.Printf("   Min:           %s\n", formatQualityValue(score, score.Min))
+	fmt.Printf("   Max:           %s\n", formatQualityValue(score, score.Max))
+}
+
+// formatQualityValue formats a score with the precision and unit of its metric
+func formatQualityValue(score *transcoder.QualityScore, value float64) string {
+	formatted := fmt.Sprintf("%.*f", qualityPrecision(score), value)
+	if score.Unit != "" {
+		formatted += " " + score.Unit
+	}
+	return formatted
+}
+
+// qualityPrecision is the number of decimals shown for a metric; SSIM differences are small
+func qualityPrecision(score *transcoder.QualityScore) int {
+	if score.Metric == "ssim" {
+		return 4
+	}
+	return 2
+}
+
+// qualityRatings are the lowest mean scores of each rating, per metric
+var qualityRatings = map[string][3]float64{
+	"vmaf": {93, 80, 60},
+	"psnr": {40, 35, 30},
+	"ssim": {0.98, 0.95, 0.90},
+}
+
+// rateQualityScore describes the mean score of a metric in words
+func rateQualityScore(score *transcoder.QualityScore) string {
+	thresholds := qualityRatings[score.Metric]
+	switch {
+	case score.Mean >= thresholds[0]:
+		return color.GreenString("excellent, hard to tell from the source")
+	case score.Mean >= thresholds[1]:
+		return color.GreenString("good")
+	case score.Mean >= thresholds[2]:
+		return color.YellowString("fair, visible artifacts")
+	}
+	return color.RedString("poor")
+}
@@ -0,0 +1,122 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var recommendCmd = &cobra.Command{
+	Use:   "recommend [input]",
+	Short: "Recommend a convert command for a goal and explain each option",
+	Long: `Inspect a media file and the encoders available in your ffmpeg build,
+then print a complete convert command for the chosen goal together with
+an explanation of every option it uses.
+
+Goals:
+  smallest    Smallest file at acceptable quality
+  fastest     Finish as quickly as possible (stream copy when possible)
+  compatible  Plays on virtually every device, browser and editor
+
+The rules behind the recommendations can be customized with a
+recommendations.json file in the configuration directory.
+
+Examples:
+  transcoder recommend movie.mkv --goal smallest
+  transcoder recommend recording.mov --goal compatible -o recording.mp4`,
+	Args: cobra.ExactArgs(1),
+	RunE: runRecommend,
+}
+
+var recommendGoal string
+
+func init() {
+	rootCmd.AddCommand(recommendCmd)
+
+	recommendCmd.Flags().StringVar(&recommendGoal, "goal", "compatible",
+		"what to optimize for (smallest, fastest, compatible)")
+}
+
+func runRecommend(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if output != "" {
+		if err := securityPolicy.ValidateFilePath(output); err != nil {
+			return fmt.Errorf("security validation failed for output path: %w", err)
+		}
+	}
+
+	// Check dependencies
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	encoders, err := transcoder.AvailableEncoders(cmd.Context())
+	if err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	info, err := analyzer.AnalyzeMedia(cmd.Context(), inputFile)
+	if err != nil {
+		return fmt.Errorf("failed to analyze media: %w", err)
+	}
+
+	rec, err := transcoder.Recommend(info, recommendGoal, output, encoders)
+	if err != nil {
+		return err
+	}
+
+	if quiet {
+		fmt.Println(rec.Command(inputFile))
+		return nil
+	}
+
+	displayRecommendation(info, rec, inputFile)
+	return nil
+}
+
+// displayRecommendation renders the recommended command and the reasoning behind it
+func displayRecommendation(info *analyzer.MediaInfo, rec transcoder.Recommendation, inputFile string) {
+	color.Cyan("💡 Recommendation: %s", rec.Goal)
+	fmt.Printf("   %s\n", rec.Summary)
+	fmt.Println()
+
+	color.Blue("🔍 Source:")
+	video := info.VideoStreams[0]
+	fmt.Printf("   Video: %s %dx%d\n", video.Codec, video.Width, video.Height)
+	if len(info.AudioStreams) > 0 {
+		audio := info.AudioStreams[0]
+		fmt.Printf("   Audio: %s, %d channel(s)\n", audio.Codec, audio.Channels)
+	} else {
+		fmt.Println("   Audio: none")
+	}
+	fmt.Println()
+
+	color.Green("▶️  Command:")
+	fmt.Printf("   %s\n", rec.Command(inputFile))
+	fmt.Println()
+
+	color.Yellow("📖 Why:")
+	if rec.StreamCopy {
+		fmt.Printf("   %s\n", rec.CopyReason)
+		fmt.Println("   No codec options are needed; convert picks stream copy automatically.")
+	}
+	for _, option := range rec.Options {
+		fmt.Printf("   %-26s %s\n", option.Flag, option.Reason)
+	}
+	fmt.Println()
+	fmt.Printf("   Customize these rules with: %s\n", transcoder.RecommendationOverridePath())
+	fmt.Println()
+}
@@ -0,0 +1,104 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var remuxCmd = &cobra.Command{
+	Use:   "remux [input] [output]",
+	Short: "Change the container without re-encoding",
+	Long: `Copy every stream (video, audio and subtitles) into a new container
+without re-encoding. This is much faster than convert and loses no quality.
+
+Unlike convert, remux never falls back to re-encoding: if a stream cannot be
+carried by the output container the command fails before running FFmpeg and
+lists the incompatible streams. See 'transcoder compat' for what each
+container accepts.
+
+Examples:
+  transcoder remux input.mkv output.mp4
+  transcoder remux recording.mov recording.mkv --force`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRemux,
+}
+
+var remuxForce bool
+
+func init() {
+	rootCmd.AddCommand(remuxCmd)
+
+	remuxCmd.Flags().BoolVarP(&remuxForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runRemux(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFileFormat(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output format: %w", err)
+	}
+
+	// Validate input file exists
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	// Check if output file exists and handle overwrite
+	if fileExists(outputFile) && !remuxForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("📦 Remuxing Media File")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputFile)
+		fmt.Println()
+	}
+
+	if err := transcoder.RemuxMedia(cmd.Context(), transcoder.RemuxParams{
+		InputFile:  inputFile,
+		OutputFile: outputFile,
+		Verbose:    useVerbose,
+	}); err != nil {
+		return fmt.Errorf("remux failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Remux completed successfully!")
+		fmt.Printf("Output saved to: %s\n", outputFile)
+	}
+
+	return nil
+}
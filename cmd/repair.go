@@ -0,0 +1,103 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var repairCmd = &cobra.Command{
+	Use:   "repair [input] [output]",
+	Short: "Recover partially downloaded or crash-truncated recordings",
+	Long: `Attempt to recover a damaged media file by trying common repair strategies
+in order until one produces a readable output:
+
+  1. remux          Stream copy with regenerated timestamps (-fflags +genpts)
+  2. ignore-errors  Stream copy ignoring decode errors and corrupt packets
+  3. reencode       Error-resilient decode followed by a full re-encode
+
+Files whose index (MP4 moov atom) was never written cannot be rebuilt
+without a reference file; containers such as MKV and TS usually recover well.
+
+Examples:
+  transcoder repair broken.mp4 fixed.mp4
+  transcoder repair crashed-recording.mkv recovered.mkv --force`,
+	Args: cobra.ExactArgs(2),
+	RunE: runRepair,
+}
+
+var repairForce bool
+
+func init() {
+	rootCmd.AddCommand(repairCmd)
+
+	repairCmd.Flags().BoolVarP(&repairForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runRepair(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFileFormat(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output format: %w", err)
+	}
+
+	// Validate input file exists
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	// Check if output file exists and handle overwrite
+	if fileExists(outputFile) && !repairForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🩹 Repairing Media File")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputFile)
+		fmt.Println()
+	}
+
+	strategy, err := transcoder.RepairMedia(cmd.Context(), transcoder.RepairParams{
+		InputFile:  inputFile,
+		OutputFile: outputFile,
+		Verbose:    useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("repair failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Repair completed successfully using strategy: %s", strategy)
+		fmt.Printf("Output saved to: %s\n", outputFile)
+	}
+
+	return nil
+}
@@ -1,9 +1,18 @@
 package cmd
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
+	"os"
+	"time"
 
 	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/logging"
+	"github.com/rishad1234/term-video-transcoder/internal/priority"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
 	"github.com/spf13/cobra"
 )
 
@@ -12,9 +21,15 @@ var (
 	version = "1.0.0"
 
 	// Global flags
-	verbose bool
-	quiet   bool
-	output  string
+	verbose    bool
+	quiet      bool
+	output     string
+	background bool
+
+	progressFormat string
+	progressFile   string
+	logFile        string
+	logLevel       string
 )
 
 // rootCmd represents the base command when called without any subcommands
@@ -40,8 +55,29 @@ Examples:
 }
 
 // Execute adds all child commands to the root command and sets flags appropriately.
+// The exit code for a returned error is given by ExitCode.
 func Execute() error {
-	return rootCmd.Execute()
+	markUsageErrors(rootCmd)
+	ctx, cancel := cancelOnInterrupt()
+	defer cancel()
+
+	started := time.Now()
+	executed, err := rootCmd.ExecuteContextC(ctx)
+	if closeErr := closeProgressFile(executed); closeErr != nil && err == nil {
+		err = closeErr
+	}
+	if err != nil && ctx.Err() != nil && !errors.Is(err, context.Canceled) {
+		// FFmpeg got the Ctrl+C too and may have failed before the job noticed
+		err = fmt.Errorf("%w: %v", context.Canceled, err)
+	}
+
+	seconds := time.Since(started).Round(time.Millisecond).Seconds()
+	if err != nil {
+		logging.Logger().Error("job failed", "seconds", seconds, "error", err.Error(), "exit_code", ExitCode(err))
+	} else {
+		logging.Logger().Info("job finished", "seconds", seconds)
+	}
+	return err
 }
 
 func init() {
@@ -49,7 +85,138 @@ func init() {
 	rootCmd.PersistentFlags().BoolVarP(&verbose, "verbose", "v", true, "verbose output (enabled by default)")
 	rootCmd.PersistentFlags().BoolVarP(&quiet, "quiet", "q", false, "quiet mode (minimal output)")
 	rootCmd.PersistentFlags().StringVarP(&output, "output", "o", "", "output file or directory")
+	rootCmd.PersistentFlags().BoolVar(&background, "background", false, "run FFmpeg at low CPU and I/O priority so long encodes keep the machine responsive")
+	rootCmd.PersistentFlags().StringVar(&progressFormat, "progress-format", "text", "progress output: text (progress bar) or json (newline-delimited events)")
+	rootCmd.PersistentFlags().StringVar(&progressFile, "progress-file", "", "write json progress events to this file instead of stdout")
+	rootCmd.PersistentFlags().StringVar(&logLevel, "log-level", "info", "debug, info, warn or error: what --log-file records; on the console it picks the --verbose or --quiet mode")
+	rootCmd.PersistentFlags().StringVar(&logFile, "log-file", "", "append a JSON record of the job and every FFmpeg run (command, timing, stderr tail, outcome) to this file")
+
+	// Apply per-command defaults from the config file before any command runs
+	rootCmd.PersistentPreRunE = func(cmd *cobra.Command, args []string) error {
+		if err := applyConfigDefaults(cmd); err != nil {
+			return err
+		}
+
+		if err := applyLogLevel(cmd); err != nil {
+			return err
+		}
+
+		if err := openLogFile(cmd, args); err != nil {
+			return err
+		}
+
+		if err := configureProgressEvents(cmd); err != nil {
+			return err
+		}
+
+		// FFmpeg inherits the priority of the transcoder process
+		if background {
+			if err := priority.Lower(); err != nil {
+				return fmt.Errorf("--background: %w", err)
+			}
+		}
+		return nil
+	}
 
 	// Add version template
 	rootCmd.SetVersionTemplate(fmt.Sprintf("Terminal Video Transcoder %s\n", version))
 }
+
+// configureProgressEvents shows the progress bar, or sends JSON progress events to stdout or
+// --progress-file for --progress-format json, for the FFmpeg runs of the command
+func configureProgressEvents(cmd *cobra.Command) error {
+	switch progressFormat {
+	case "text":
+		if progressFile != "" {
+			return &usageError{err: fmt.Errorf("--progress-file requires --progress-format json")}
+		}
+		cmd.SetContext(transcoder.WithProgress(cmd.Context(), showProgressBar, nil))
+		return nil
+	case "json":
+	default:
+		return &usageError{err: fmt.Errorf("invalid progress format: %s (valid: text, json)", progressFormat)}
+	}
+
+	if progressFile == "" {
+		// Status messages would mix with the events on stdout
+		quiet = true
+		verbose = false
+		cmd.SetContext(transcoder.WithProgress(cmd.Context(), nil, os.Stdout))
+		return nil
+	}
+
+	if err := security.NewDefaultSecurityPolicy().ValidateFilePath(progressFile); err != nil {
+		return fmt.Errorf("security validation failed for progress file: %w", err)
+	}
+	file, err := os.Create(progressFile)
+	if err != nil {
+		return fmt.Errorf("creating progress file: %w", err)
+	}
+	ctx := context.WithValue(cmd.Context(), progressFileKey{}, file)
+	cmd.SetContext(transcoder.WithProgress(ctx, nil, file))
+	return nil
+}
+
+// progressFileKey is the context key of the open --progress-file
+type progressFileKey struct{}
+
+// closeProgressFile closes the --progress-file opened for the command, if any
+func closeProgressFile(cmd *cobra.Command) error {
+	if cmd == nil || cmd.Context() == nil {
+		return nil
+	}
+	file, ok := cmd.Context().Value(progressFileKey{}).(*os.File)
+	if !ok {
+		return nil
+	}
+	if err := file.Close(); err != nil {
+		return fmt.Errorf("closing progress file: %w", err)
+	}
+	return nil
+}
+
+// openLogFile starts recording the job in --log-file
+func openLogFile(cmd *cobra.Command, args []string) error {
+	if logFile != "" {
+		if err := security.NewDefaultSecurityPolicy().ValidateFilePath(logFile); err != nil {
+			return fmt.Errorf("security validation failed for log file: %w", err)
+		}
+		if err := logging.Open(logFile); err != nil {
+			return err
+		}
+	}
+
+	logging.Logger().Info("job started", "command", cmd.CommandPath(), "args", args,
+		"version", version, "pid", os.Getpid())
+	return nil
+}
+
+// applyLogLevel picks the console mode and sets the log records for --log-level. Only the log
+// records are filtered by level; the console keeps its three modes, which the level picks:
+// debug and info print everything (like --verbose), warn only progress, results and warnings
+// (like --verbose=false) and error nothing but errors (like --quiet). debug also records the
+// raw ffprobe JSON and the complete FFmpeg output, on stderr without --log-file.
+func applyLogLevel(cmd *cobra.Command) error {
+	level, err := logging.ParseLevel(logLevel)
+	if err != nil {
+		return &usageError{err: err}
+	}
+	logging.SetLevel(level)
+
+	if !cmd.Flags().Changed("log-level") {
+		return nil
+	}
+	if cmd.Flags().Changed("verbose") || cmd.Flags().Changed("quiet") {
+		return &usageError{err: fmt.Errorf("--log-level cannot be combined with --verbose or --quiet")}
+	}
+
+	switch {
+	case level <= slog.LevelInfo:
+		verbose, quiet = true, false
+	case level == slog.LevelWarn:
+		verbose, quiet = false, false
+	default:
+		verbose, quiet = false, true
+	}
+	return nil
+}
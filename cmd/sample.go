@@ -0,0 +1,168 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var sampleCmd = &cobra.Command{
+	Use:   "sample [input] [output]",
+	Short: "Encode clips from several points of a video into one review file",
+	Long: `Encode short clips from evenly spaced points of a video with the settings
+planned for the full encode, and join them into one file. Watching the sample
+shows how the settings hold up in dark, busy and calm scenes before committing
+to a multi-hour encode.
+
+The first 5% and last 10% of the input are left out, so opening and end credits
+(which compress far better than the film) do not make up part of the sample.
+
+The encoding flags are those of convert, including --profile. Without an output
+the sample is written next to the input as <name>.sample.<ext>.
+
+Examples:
+  # Five 10-second clips with the default settings
+  transcoder sample movie.mkv --points 5 --length 10s
+
+  # Review an x265 encode before running it on the whole film
+  transcoder sample movie.mkv review.mp4 --video-codec libx265 --video-bitrate 3M
+
+  # Review a saved preset
+  transcoder sample movie.mkv --profile web-720p --points 8`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSample,
+}
+
+var (
+	samplePoints int
+	sampleLength time.Duration
+	sampleForce  bool
+)
+
+// sampleSettingFlags are the convert flags that set how the clips of a sample are encoded
+var sampleSettingFlags = []string{
+	"preset", "profile", "video-codec", "audio-codec", "video-bitrate", "audio-bitrate",
+	"resolution", "framerate", "cfr", "volume", "audio-stream", "audio-language", "no-audio",
+	"fix-timestamps", "retry-fallback", "ffmpeg-args", "unsafe", "target", "threads",
+}
+
+func init() {
+	rootCmd.AddCommand(sampleCmd)
+
+	sampleCmd.Flags().IntVar(&samplePoints, "points", 5,
+		fmt.Sprintf("number of clips, taken at evenly spaced points (1-%d)", transcoder.MaxSamplePoints))
+
+	sampleCmd.Flags().DurationVar(&sampleLength, "length", 10*time.Second,
+		"length of each clip (e.g., 10s, 1m)")
+
+	sampleCmd.Flags().BoolVarP(&sampleForce, "force", "f", false,
+		"overwrite output file if it exists")
+
+	// The clips are encoded with the same flags as convert
+	for _, name := range sampleSettingFlags {
+		sampleCmd.Flags().AddFlag(convertCmd.Flags().Lookup(name))
+	}
+}
+
+func runSample(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile := defaultSampleOutput(inputFile)
+	if len(args) > 1 {
+		outputFile = args[1]
+	}
+	outputFile, err := resolveOutputPath(outputFile)
+	if err != nil {
+		return err
+	}
+
+	if analyzer.IsStdinPath(inputFile) || transcoder.IsStdoutPath(outputFile) {
+		return fmt.Errorf("sample needs an input file and an output file, not stdin or stdout")
+	}
+
+	if err := performSecurityValidation(inputFile, outputFile); err != nil {
+		return err
+	}
+
+	if err := validateConversionParameters(); err != nil {
+		return err
+	}
+	if unsafeArgs && !quiet {
+		color.Yellow("⚠️  Passing unchecked FFmpeg arguments: %s", ffmpegArgs)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if fileExists(outputFile) && !sampleForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	customParams, err := buildCustomParameters()
+	if err != nil {
+		return err
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🎬 Creating Sample")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputFile)
+		fmt.Printf("   Clips:   %d × %s\n", samplePoints, sampleLength)
+		fmt.Printf("   Preset:  %s\n", strings.ToUpper(preset))
+		if profile != "" {
+			fmt.Printf("   Profile: %s\n", profile)
+		}
+		fmt.Println()
+	}
+
+	offsets, err := transcoder.CreateSample(cmd.Context(), transcoder.SampleParams{
+		InputFile:       inputFile,
+		OutputFile:      outputFile,
+		Points:          samplePoints,
+		Length:          sampleLength,
+		Preset:          preset,
+		PresetExplicit:  cmd.Flags().Changed("preset"),
+		CustomParamsSet: hasCustomParameters(),
+		CustomParams:    customParams,
+		Verbose:         useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("sample failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Sample created successfully!")
+		fmt.Printf("Output saved to: %s\n", outputFile)
+		fmt.Println("Clips taken at:")
+		for i, offset := range offsets {
+			fmt.Printf("   %2d. %s\n", i+1, formatDuration(offset))
+		}
+	}
+	return nil
+}
+
+// defaultSampleOutput names the sample after its input (movie.mkv becomes movie.sample.mkv),
+// falling back to MP4 for input containers that cannot be written
+func defaultSampleOutput(inputFile string) string {
+	extension := strings.ToLower(getFileExtension(inputFile))
+	if !transcoder.SupportedFormats[extension] {
+		extension = "mp4"
+	}
+	return strings.TrimSuffix(inputFile, filepath.Ext(inputFile)) + ".sample." + extension
+}
@@ -0,0 +1,187 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var scenesCmd = &cobra.Command{
+	Use:   "scenes [input]",
+	Short: "Detect scene changes and optionally split a video into scenes",
+	Long: `Detect the scene changes of a video and print the start, end and length of
+every scene. The score of a cut is how different its first frame is from the
+frame before, in percent; cuts scoring below --threshold are ignored, as are
+cuts closer than --min-length to the previous one (flashes, strobe lights).
+
+With --split the input is also cut into one file per scene in the given
+directory, named <name>.scene001.<ext> and so on. The streams are copied, not
+re-encoded, so each file starts at the keyframe at or after its scene change.
+
+In quiet mode only the start of every scene is printed, in seconds, one per
+line, for use in scripts.
+
+Examples:
+  transcoder scenes input.mp4
+
+  # Fewer, harder cuts
+  transcoder scenes input.mp4 --threshold 30
+
+  # Split into one file per scene
+  transcoder scenes movie.mkv --split scenes/`,
+	Args: cobra.ExactArgs(1),
+	RunE: runScenes,
+}
+
+var (
+	scenesThreshold float64
+	scenesMinLength time.Duration
+	scenesSplitDir  string
+	scenesForce     bool
+)
+
+func init() {
+	rootCmd.AddCommand(scenesCmd)
+
+	scenesCmd.Flags().Float64Var(&scenesThreshold, "threshold", transcoder.DefaultSceneThreshold,
+		"scene change score in percent (0-100) a cut must exceed; lower finds more cuts")
+
+	scenesCmd.Flags().DurationVar(&scenesMinLength, "min-length", time.Second,
+		"ignore cuts closer than this to the previous one")
+
+	scenesCmd.Flags().StringVar(&scenesSplitDir, "split", "",
+		"split the input into one file per scene in this directory")
+
+	scenesCmd.Flags().BoolVarP(&scenesForce, "force", "f", false,
+		"overwrite existing scene files in the split directory")
+}
+
+func runScenes(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	splitDir := scenesSplitDir
+	if splitDir != "" {
+		var err error
+		if splitDir, err = resolveOutputPath(splitDir); err != nil {
+			return err
+		}
+		if err := securityPolicy.ValidateFilePath(splitDir); err != nil {
+			return fmt.Errorf("security validation failed for output directory: %w", err)
+		}
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if splitDir != "" && !scenesForce {
+		if first := firstSceneFile(inputFile, splitDir); fileExists(first) {
+			return fmt.Errorf("output already exists: %s (use --force to overwrite)", first)
+		}
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🎬 Detecting Scenes")
+		fmt.Println()
+		fmt.Printf("   Input:      %s\n", inputFile)
+		fmt.Printf("   Threshold:  %.3g%%\n", scenesThreshold)
+		if splitDir != "" {
+			fmt.Printf("   Split to:   %s\n", splitDir)
+		}
+		fmt.Println()
+	}
+
+	scenes, err := transcoder.DetectScenes(cmd.Context(), transcoder.SceneParams{
+		InputFile: inputFile,
+		Threshold: scenesThreshold,
+		MinLength: scenesMinLength,
+		Verbose:   useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("scene detection failed: %w", err)
+	}
+
+	if quiet {
+		for _, scene := range scenes {
+			fmt.Printf("%.3f\n", scene.Start.Seconds())
+		}
+	} else {
+		fmt.Println()
+		color.Cyan("🎬 %d scenes", len(scenes))
+		displaySceneTable(scenes)
+	}
+
+	if splitDir == "" {
+		return nil
+	}
+
+	outputs, err := transcoder.SplitScenes(cmd.Context(), transcoder.SceneSplitParams{
+		InputFile: inputFile,
+		OutputDir: splitDir,
+		Scenes:    scenes,
+		Verbose:   useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("scene split failed: %w", err)
+	}
+
+	if !quiet {
+		fmt.Println()
+		color.Green("✅ Split into %d files successfully!", len(outputs))
+		fmt.Printf("Output saved to: %s\n", splitDir)
+	}
+	return nil
+}
+
+// displaySceneTable prints one row per scene
+func displaySceneTable(scenes []transcoder.Scene) {
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "#\tSTART\tEND\tLENGTH\tSCORE")
+	for i, scene := range scenes {
+		score := "-"
+		if i > 0 {
+			score = fmt.Sprintf("%.1f", scene.Score)
+		}
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\t%s\n", i+1, formatSceneTime(scene.Start), formatSceneTime(scene.End),
+			formatSceneTime(scene.End-scene.Start), score)
+	}
+	table.Flush()
+}
+
+// formatSceneTime formats a scene time as HH:MM:SS.mmm, as cuts are often less than a second apart
+func formatSceneTime(d time.Duration) string {
+	return fmt.Sprintf("%s.%03d", formatDuration(d), d.Milliseconds()%1000)
+}
+
+// firstSceneFile is the file the first scene of a split is written to
+func firstSceneFile(inputFile, splitDir string) string {
+	extension := filepath.Ext(inputFile)
+	name := strings.TrimSuffix(filepath.Base(inputFile), extension)
+	return filepath.Join(splitDir, name+".scene001"+extension)
+}
@@ -0,0 +1,199 @@
+package cmd
+
+import (
+	"fmt"
+	"os"
+	"text/tabwriter"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var silenceCmd = &cobra.Command{
+	Use:   "silence [input] [output]",
+	Short: "Detect silent ranges, or trim leading and trailing silence",
+	Long: `Find the silent parts of the first audio track of a file with FFmpeg's
+silencedetect filter.
+
+  --detect   list every silent range (the default)
+  --trim     extract the audio to [output] without the silence at its start
+             and end; silence in the middle is kept
+
+Audio is silent when it stays below --threshold (in dB) for at least
+--min-length. Trimming keeps a quarter of a second next to the sound so the
+first and last words are not clipped. The trimmed audio is encoded as by
+'extract', with the codec chosen from the output extension.
+
+Examples:
+  transcoder silence input.mp4 --detect
+
+  # A lecture recording without the minutes before and after the talk
+  transcoder silence lecture.mp4 lecture.mp3 --trim
+
+  # Count quieter stretches of half a second as silence
+  transcoder silence podcast.wav --threshold -40 --min-length 500ms`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSilence,
+}
+
+var (
+	silenceDetect    bool
+	silenceTrim      bool
+	silenceThreshold float64
+	silenceMinLength time.Duration
+	silenceQuality   string
+	silenceForce     bool
+)
+
+func init() {
+	rootCmd.AddCommand(silenceCmd)
+
+	silenceCmd.Flags().BoolVar(&silenceDetect, "detect", false,
+		"list the silent ranges (default)")
+
+	silenceCmd.Flags().BoolVar(&silenceTrim, "trim", false,
+		"extract the audio to [output] without leading and trailing silence")
+
+	silenceCmd.Flags().Float64Var(&silenceThreshold, "threshold", transcoder.DefaultSilenceThreshold,
+		"level in dB below which audio counts as silent")
+
+	silenceCmd.Flags().DurationVar(&silenceMinLength, "min-length", transcoder.DefaultSilenceMinLength,
+		"shortest quiet stretch that counts as silence")
+
+	// No shorthand to avoid conflict with global -q
+	silenceCmd.Flags().StringVar(&silenceQuality, "quality", "medium",
+		"audio quality preset for --trim (low, medium, high)")
+
+	silenceCmd.Flags().BoolVarP(&silenceForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runSilence(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+
+	if silenceDetect && silenceTrim {
+		return fmt.Errorf("use only one of --detect and --trim")
+	}
+	if silenceTrim != (len(args) == 2) {
+		return fmt.Errorf("--trim needs an output file, and an output file needs --trim")
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	var outputFile string
+	if silenceTrim {
+		var err error
+		if outputFile, err = resolveOutputPath(args[1]); err != nil {
+			return err
+		}
+		if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+			return fmt.Errorf("security validation failed for output path: %w", err)
+		}
+		if !contains([]string{"low", "medium", "high"}, silenceQuality) {
+			return fmt.Errorf("invalid quality preset: %s (valid: low, medium, high)", silenceQuality)
+		}
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if silenceTrim && fileExists(outputFile) && !silenceForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🔇 Detecting Silence")
+		fmt.Println()
+		fmt.Printf("   Input:       %s\n", inputFile)
+		if silenceTrim {
+			fmt.Printf("   Output:      %s\n", outputFile)
+		}
+		fmt.Printf("   Threshold:   %gdB for %s\n", silenceThreshold, silenceMinLength)
+		fmt.Println()
+	}
+
+	params := transcoder.SilenceParams{
+		InputFile: inputFile,
+		Threshold: silenceThreshold,
+		MinLength: silenceMinLength,
+		Verbose:   useVerbose,
+	}
+
+	if silenceTrim {
+		trim, err := transcoder.TrimSilence(cmd.Context(), params, transcoder.AudioExtractionParams{
+			InputFile:  inputFile,
+			OutputFile: outputFile,
+			Quality:    silenceQuality,
+			Verbose:    useVerbose,
+		})
+		if err != nil {
+			return fmt.Errorf("silence trim failed: %w", err)
+		}
+
+		if !quiet {
+			if useVerbose {
+				fmt.Println()
+			}
+			color.Green("✅ Trimmed silence successfully!")
+			fmt.Printf("Removed %s at the start and %s at the end\n",
+				formatSceneTime(trim.Start), formatSceneTime(trim.Duration-trim.End))
+			fmt.Printf("Output saved to: %s\n", outputFile)
+		}
+		return nil
+	}
+
+	silences, err := transcoder.DetectSilence(cmd.Context(), params)
+	if err != nil {
+		return fmt.Errorf("silence detection failed: %w", err)
+	}
+
+	if quiet {
+		for _, silence := range silences {
+			fmt.Printf("%.3f %.3f\n", silence.Start.Seconds(), silence.End.Seconds())
+		}
+		return nil
+	}
+
+	fmt.Println()
+	if len(silences) == 0 {
+		color.Green("🔊 No silence below %gdB lasting %s or more", silenceThreshold, silenceMinLength)
+		return nil
+	}
+	color.Cyan("🔇 %d silent ranges", len(silences))
+	displaySilenceTable(silences)
+	return nil
+}
+
+// displaySilenceTable prints one row per silent range and their total length
+func displaySilenceTable(silences []transcoder.Silence) {
+	table := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "#\tSTART\tEND\tLENGTH")
+	var total time.Duration
+	for i, silence := range silences {
+		fmt.Fprintf(table, "%d\t%s\t%s\t%s\n", i+1, formatSceneTime(silence.Start), formatSceneTime(silence.End),
+			formatSceneTime(silence.End-silence.Start))
+		total += silence.End - silence.Start
+	}
+	table.Flush()
+	fmt.Printf("\nTotal silence: %s\n", formatSceneTime(total))
+}
@@ -0,0 +1,125 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var spectrogramCmd = &cobra.Command{
+	Use:   "spectrogram [input] [output]",
+	Short: "Draw the spectrogram of an audio track as an image",
+	Long: `Draw the frequency content of the whole first audio track of a file over
+time as a single image, with time and frequency axes.
+
+A spectrogram shows whether a "lossless" file really is: lossy encoders
+discard high frequencies, so a FLAC or WAV transcoded from MP3 or AAC has
+an empty band above a sharp horizontal line, typically at 16 kHz for
+128 kbps MP3 and 19-20 kHz for higher bitrates. A genuine CD rip has
+content up to about 22 kHz.
+
+The extension of the output selects the image format: png, webp or jpg.
+
+Examples:
+  transcoder spectrogram input.flac spec.png
+
+  # A larger image with one band per channel
+  transcoder spectrogram album.wav spec.png --width 2048 --height 1024 --split-channels`,
+	Args: cobra.ExactArgs(2),
+	RunE: runSpectrogram,
+}
+
+var (
+	spectrogramWidth         int
+	spectrogramHeight        int
+	spectrogramSplitChannels bool
+	spectrogramNoLegend      bool
+	spectrogramForce         bool
+)
+
+func init() {
+	rootCmd.AddCommand(spectrogramCmd)
+
+	spectrogramCmd.Flags().IntVar(&spectrogramWidth, "width", 1024,
+		"width of the spectrum in pixels (time)")
+
+	spectrogramCmd.Flags().IntVar(&spectrogramHeight, "height", 512,
+		"height of the spectrum in pixels (frequency)")
+
+	spectrogramCmd.Flags().BoolVar(&spectrogramSplitChannels, "split-channels", false,
+		"draw each channel in its own band")
+
+	spectrogramCmd.Flags().BoolVar(&spectrogramNoLegend, "no-legend", false,
+		"leave out the axes and color scale")
+
+	spectrogramCmd.Flags().BoolVarP(&spectrogramForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runSpectrogram(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if fileExists(outputFile) && !spectrogramForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("📈 Drawing Spectrogram")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputFile)
+		fmt.Println()
+	}
+
+	if err := transcoder.CreateSpectrogram(cmd.Context(), transcoder.SpectrogramParams{
+		InputFile:     inputFile,
+		OutputFile:    outputFile,
+		Width:         spectrogramWidth,
+		Height:        spectrogramHeight,
+		SplitChannels: spectrogramSplitChannels,
+		Legend:        !spectrogramNoLegend,
+		Verbose:       useVerbose,
+	}); err != nil {
+		return fmt.Errorf("spectrogram failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Spectrogram created successfully!")
+		fmt.Printf("Output saved to: %s\n", outputFile)
+	}
+
+	return nil
+}
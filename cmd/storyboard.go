@@ -0,0 +1,123 @@
+package cmd
+
+import (
+	"fmt"
+	"path/filepath"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var storyboardCmd = &cobra.Command{
+	Use:   "storyboard [input] [output-dir]",
+	Short: "Generate seek preview sprite sheets and a WebVTT map",
+	Long: `Extract a thumbnail at a fixed interval, tile the thumbnails into sprite
+sheets and write a WebVTT file that maps each time range to its thumbnail.
+Web players such as Video.js, Plyr and JW Player use this to show previews
+while seeking.
+
+The output directory receives sprite_001.jpg, sprite_002.jpg, ... and
+storyboard.vtt, whose cues point into the sheets with #xywh= coordinates.
+
+Examples:
+  transcoder storyboard movie.mp4 thumbs/
+  transcoder storyboard lecture.mkv thumbs/ --interval 5 --width 240 --columns 10 --rows 10`,
+	Args: cobra.ExactArgs(2),
+	RunE: runStoryboard,
+}
+
+var (
+	storyboardInterval int
+	storyboardWidth    int
+	storyboardColumns  int
+	storyboardRows     int
+	storyboardForce    bool
+)
+
+func init() {
+	rootCmd.AddCommand(storyboardCmd)
+
+	storyboardCmd.Flags().IntVar(&storyboardInterval, "interval", 10,
+		"seconds between thumbnails")
+
+	storyboardCmd.Flags().IntVar(&storyboardWidth, "width", 160,
+		"thumbnail width in pixels")
+
+	storyboardCmd.Flags().IntVar(&storyboardColumns, "columns", 5,
+		"thumbnails per sprite sheet row")
+
+	storyboardCmd.Flags().IntVar(&storyboardRows, "rows", 5,
+		"thumbnail rows per sprite sheet")
+
+	storyboardCmd.Flags().BoolVarP(&storyboardForce, "force", "f", false,
+		"overwrite an existing storyboard in the output directory")
+}
+
+func runStoryboard(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputDir, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputDir); err != nil {
+		return fmt.Errorf("security validation failed for output directory: %w", err)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	vtt := filepath.Join(outputDir, transcoder.StoryboardVTTName)
+	if fileExists(vtt) && !storyboardForce {
+		return fmt.Errorf("output already exists: %s (use --force to overwrite)", vtt)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🎞️  Generating Storyboard")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputDir)
+		fmt.Println()
+	}
+
+	vttPath, err := transcoder.CreateStoryboard(cmd.Context(), transcoder.StoryboardParams{
+		InputFile:  inputFile,
+		OutputDir:  outputDir,
+		Interval:   storyboardInterval,
+		ThumbWidth: storyboardWidth,
+		Columns:    storyboardColumns,
+		Rows:       storyboardRows,
+		Verbose:    useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("storyboard failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Storyboard created successfully!")
+		fmt.Printf("WebVTT saved to: %s\n", vttPath)
+	}
+
+	return nil
+}
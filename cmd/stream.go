@@ -0,0 +1,107 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var streamCmd = &cobra.Command{
+	Use:   "stream [input] [url]",
+	Short: "Stream a file live to an RTMP or SRT server",
+	Long: `Transcode a file to H.264/AAC and send it live to an RTMP, RTMPS or SRT
+ingest server, such as YouTube Live, Twitch or a self-hosted media server.
+
+Use --realtime to send the file at its native speed, as a live broadcast
+needs; without it the file is sent as fast as it can be encoded.
+
+When the connection drops, the stream reconnects with increasing delays and
+resumes from where it stopped. A status line shows the position, bitrate,
+frame rate and dropped frames while streaming. The stream key in the URL is
+masked in all output.
+
+Examples:
+  transcoder stream input.mp4 rtmp://live.example.com/app/STREAM-KEY --realtime
+  transcoder stream talk.mkv "srt://ingest.example.com:9000?streamid=KEY" --realtime --video-bitrate 3000k`,
+	Args: cobra.ExactArgs(2),
+	RunE: runStream,
+}
+
+var (
+	streamRealtime     bool
+	streamVideoBitrate string
+	streamAudioBitrate string
+	streamRetries      int
+)
+
+func init() {
+	rootCmd.AddCommand(streamCmd)
+
+	streamCmd.Flags().BoolVar(&streamRealtime, "realtime", false,
+		"send the input at its native frame rate (-re), as live broadcasts need")
+
+	streamCmd.Flags().StringVar(&streamVideoBitrate, "video-bitrate", "4500k",
+		"video bitrate (e.g., 4500k, 6M)")
+
+	streamCmd.Flags().StringVar(&streamAudioBitrate, "audio-bitrate", "160k",
+		"audio bitrate (e.g., 160k, 128k)")
+
+	streamCmd.Flags().IntVar(&streamRetries, "retries", 5,
+		"reconnect attempts after the connection drops")
+}
+
+func runStream(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	url := args[1]
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file path
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	if !quiet {
+		color.Cyan("📡 Streaming Live")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Server:  %s\n", transcoder.MaskStreamURL(url))
+		fmt.Println()
+	}
+
+	err := transcoder.StreamMedia(cmd.Context(), transcoder.StreamParams{
+		InputFile:    inputFile,
+		URL:          url,
+		Realtime:     streamRealtime,
+		VideoBitrate: streamVideoBitrate,
+		AudioBitrate: streamAudioBitrate,
+		Retries:      streamRetries,
+		Verbose:      verbose && !quiet,
+	})
+	if err != nil {
+		return fmt.Errorf("streaming failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Stream finished")
+	}
+
+	return nil
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+// subtitlesCmd represents the subtitles command
+var subtitlesCmd = &cobra.Command{
+	Use:   "subtitles",
+	Short: "Fix the timing of subtitle files",
+	Long: `Work on subtitle files directly. These commands read and write the text
+files themselves and need neither FFmpeg nor re-encoding.
+
+Examples:
+  transcoder subtitles shift subs.srt --offset 2.5s`,
+}
+
+var subtitlesShiftCmd = &cobra.Command{
+	Use:   "shift [input] [output]",
+	Short: "Move or stretch the timing of SRT and WebVTT subtitles",
+	Long: `Fix subtitles that are out of sync with the video.
+
+--offset moves every cue by a fixed amount: positive values show the
+subtitles later, negative values earlier. Use it when the subtitles are off
+by the same amount throughout.
+
+--scale multiplies every timestamp. Use it when the subtitles drift further
+apart over time, which happens when they were timed for a different frame
+rate:
+
+  1.001    timed for 24 or 25 fps video that plays at 23.976 or 24.975
+  1.0427   timed for 25 fps (PAL) video that plays at 23.976 fps
+  0.959    timed for 23.976 fps video that plays at 25 fps (PAL)
+
+Timestamps are scaled first and then offset. Cues moved before the start of
+the video begin at zero; cues that would end there are removed.
+
+Without an output the input is replaced. The text, numbering style and line
+endings are left as they are.
+
+Examples:
+  transcoder subtitles shift subs.srt --offset 2.5s
+  transcoder subtitles shift subs.srt fixed.srt --offset -1.2s
+  transcoder subtitles shift movie.vtt --scale 1.0427 --offset 500ms`,
+	Args: cobra.RangeArgs(1, 2),
+	RunE: runSubtitlesShift,
+}
+
+var (
+	subtitlesOffset time.Duration
+	subtitlesScale  float64
+	subtitlesForce  bool
+)
+
+func init() {
+	rootCmd.AddCommand(subtitlesCmd)
+	subtitlesCmd.AddCommand(subtitlesShiftCmd)
+
+	subtitlesShiftCmd.Flags().DurationVar(&subtitlesOffset, "offset", 0,
+		"move every cue by this much; negative values show the subtitles earlier (e.g., 2.5s, -800ms)")
+
+	subtitlesShiftCmd.Flags().Float64Var(&subtitlesScale, "scale", 1,
+		"multiply every timestamp by this factor to fix drift (e.g., 1.001, 1.0427)")
+
+	subtitlesShiftCmd.Flags().BoolVarP(&subtitlesForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runSubtitlesShift(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile := ""
+	if len(args) == 2 {
+		var err error
+		if outputFile, err = resolveOutputPath(args[1]); err != nil {
+			return err
+		}
+	}
+
+	if !cmd.Flags().Changed("offset") && !cmd.Flags().Changed("scale") {
+		return fmt.Errorf("nothing to change: give --offset, --scale or both")
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if outputFile != "" {
+		if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+			return fmt.Errorf("security validation failed for output path: %w", err)
+		}
+	}
+
+	// Validate input file exists
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	// Check if output file exists and handle overwrite
+	if outputFile != "" && fileExists(outputFile) && !subtitlesForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if outputFile != "" && sameFile(inputFile, outputFile) {
+		return fmt.Errorf("output is the input; leave out the output to replace the input")
+	}
+
+	result, err := transcoder.ShiftSubtitles(transcoder.SubtitleShiftParams{
+		InputFile:  inputFile,
+		OutputFile: outputFile,
+		Offset:     subtitlesOffset,
+		Scale:      subtitlesScale,
+	})
+	if err != nil {
+		return fmt.Errorf("subtitle shift failed: %w", err)
+	}
+
+	if quiet {
+		return nil
+	}
+
+	var changes []string
+	if subtitlesScale != 1 {
+		changes = append(changes, fmt.Sprintf("scaled by %g", subtitlesScale))
+	}
+	if subtitlesOffset != 0 {
+		changes = append(changes, fmt.Sprintf("moved by %+.3fs", subtitlesOffset.Seconds()))
+	}
+	if len(changes) == 0 {
+		changes = append(changes, "unchanged")
+	}
+
+	if result.Cues > 0 {
+		fmt.Printf("   Cues:    %d, %s\n", result.Cues, strings.Join(changes, ", "))
+		fmt.Printf("   Span:    %s - %s\n", formatSceneTime(result.First), formatSceneTime(result.Last))
+	}
+	if result.Dropped > 0 {
+		color.Yellow("⚠️  %d cue(s) ended before the start of the video and were removed", result.Dropped)
+	}
+	fmt.Println()
+
+	color.Green("✅ Subtitles retimed successfully!")
+	if outputFile == "" {
+		outputFile = inputFile
+	}
+	fmt.Printf("Output saved to: %s\n", outputFile)
+	return nil
+}
@@ -0,0 +1,89 @@
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+)
+
+// showConversionSummary prints how the output compares with the input and, with
+// --progress-format json, emits it as a summary event. Output written to stdout
+// cannot be read back and is not summarized.
+func showConversionSummary(ctx context.Context, inputPath, outputPath string, elapsed time.Duration) {
+	if transcoder.IsStdoutPath(outputPath) {
+		return
+	}
+
+	summary, err := transcoder.SummarizeConversion(ctx, inputPath, outputPath, elapsed)
+	if err != nil {
+		if verbose && !quiet {
+			color.Yellow("⚠️  Could not summarize the conversion: %v", err)
+		}
+		return
+	}
+
+	transcoder.EmitSummaryEvent(ctx, summary)
+	if quiet {
+		return
+	}
+
+	fmt.Println()
+	color.Cyan("📊 Summary")
+	if summary.InputSize > 0 && summary.OutputSize > 0 {
+		change := (float64(summary.OutputSize)/float64(summary.InputSize) - 1) * 100
+		fmt.Printf("   Size:    %s → %s (%.2f:1, %+.0f%%)\n", formatBytes(summary.InputSize),
+			formatBytes(summary.OutputSize), summary.CompressionRatio, change)
+	} else {
+		fmt.Printf("   Size:    %s\n", formatBytes(summary.OutputSize))
+	}
+	if summary.Speed > 0 {
+		fmt.Printf("   Time:    %s (%.1fx realtime)\n", formatDuration(elapsed), summary.Speed)
+	} else {
+		fmt.Printf("   Time:    %s\n", formatDuration(elapsed))
+	}
+	if summary.Bitrate > 0 {
+		fmt.Printf("   Bitrate: %s\n", formatBitrate(summary.Bitrate))
+	}
+	if summary.VideoCodec != "" {
+		fmt.Printf("   Video:   %s\n", formatCodecBitrate(summary.VideoCodec, summary.VideoBitrate))
+	}
+	if summary.AudioCodec != "" {
+		fmt.Printf("   Audio:   %s\n", formatCodecBitrate(summary.AudioCodec, summary.AudioBitrate))
+	}
+}
+
+// formatCodecBitrate formats a stream codec with its bitrate, when the container reports one
+func formatCodecBitrate(codec string, bitrate int64) string {
+	if bitrate <= 0 {
+		return codec
+	}
+	return fmt.Sprintf("%s @ %s", codec, formatBitrate(bitrate))
+}
+
+// showPreviewEstimate prints the size and encoding time the full conversion is expected to take,
+// extrapolated from a finished preview
+func showPreviewEstimate(ctx context.Context, inputPath, previewPath string, elapsed time.Duration) {
+	estimate, err := transcoder.EstimateFromPreview(ctx, inputPath, previewPath, elapsed)
+	if err != nil {
+		if !quiet {
+			color.Yellow("⚠️  Could not estimate the full conversion: %v", err)
+		}
+		return
+	}
+
+	if quiet {
+		fmt.Printf("%s %s\n", formatBytes(estimate.EstimatedSize), formatDuration(estimate.EstimatedTime))
+		return
+	}
+
+	fmt.Println()
+	color.Cyan("🔮 Full Conversion Estimate")
+	fmt.Printf("   Preview:  %s of %s, %s\n", formatDuration(estimate.PreviewDuration),
+		formatDuration(estimate.InputDuration), formatBytes(estimate.PreviewSize))
+	fmt.Printf("   Size:     ~%s\n", formatBytes(estimate.EstimatedSize))
+	fmt.Printf("   Time:     ~%s\n", formatDuration(estimate.EstimatedTime))
+	fmt.Println("   Sizes vary with scene complexity; preview a busy scene for a safer estimate")
+}
@@ -0,0 +1,120 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var timelapseCmd = &cobra.Command{
+	Use:   "timelapse [input] [output]",
+	Short: "Build a time-lapse from long recordings",
+	Long: `Turn hours-long footage into a smooth time-lapse.
+
+Only every Nth source frame is kept (N is derived from the source frame
+rate, the speed-up factor and the output frame rate), so long recordings
+are processed efficiently. Audio is removed.
+
+Examples:
+  # One hour becomes one minute at 30 fps
+  transcoder timelapse sunset.mp4 sunset-lapse.mp4 --speed 60x --fps 30
+
+  # Smoother motion by blending the skipped frames (long-exposure look)
+  transcoder timelapse traffic.mkv traffic.mp4 --speed 120x --motion-blur
+
+  # Lighter blur over half of each interval
+  transcoder timelapse clouds.mp4 clouds-lapse.mp4 --motion-blur=0.5`,
+	Args: cobra.ExactArgs(2),
+	RunE: runTimelapse,
+}
+
+var (
+	timelapseSpeed      string
+	timelapseFPS        string
+	timelapseMotionBlur float64
+	timelapsePreset     string
+	timelapseForce      bool
+)
+
+func init() {
+	rootCmd.AddCommand(timelapseCmd)
+
+	timelapseCmd.Flags().StringVar(&timelapseSpeed, "speed", "60x",
+		"speed-up factor (e.g., 60x, 120x)")
+
+	timelapseCmd.Flags().StringVar(&timelapseFPS, "fps", "30",
+		"output frame rate (e.g., 24, 30, 60)")
+
+	timelapseCmd.Flags().Float64Var(&timelapseMotionBlur, "motion-blur", 0,
+		"blend the skipped frames into each kept one for smoother motion; strength 0-1 (1 when given without a value)")
+	timelapseCmd.Flags().Lookup("motion-blur").NoOptDefVal = "1"
+
+	timelapseCmd.Flags().StringVarP(&timelapsePreset, "preset", "p", "medium",
+		"quality preset (low, medium, high)")
+
+	timelapseCmd.Flags().BoolVarP(&timelapseForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runTimelapse(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	if err := performSecurityValidation(inputFile, outputFile); err != nil {
+		return err
+	}
+
+	securityPolicy := security.NewDefaultSecurityPolicy()
+	if err := securityPolicy.ValidateSpeedFactor(timelapseSpeed); err != nil {
+		return fmt.Errorf("invalid speed: %w", err)
+	}
+	if err := securityPolicy.ValidateFramerate(timelapseFPS); err != nil {
+		return fmt.Errorf("invalid frame rate: %w", err)
+	}
+	if timelapseMotionBlur < 0 || timelapseMotionBlur > 1 {
+		return fmt.Errorf("invalid motion blur strength %g (use a value between 0 and 1)", timelapseMotionBlur)
+	}
+	if !isValidPreset(timelapsePreset) {
+		return fmt.Errorf("invalid preset '%s'. Valid options: low, medium, high", timelapsePreset)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if fileExists(outputFile) && !timelapseForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	err = transcoder.CreateTimelapse(cmd.Context(), transcoder.TimelapseParams{
+		InputFile:  inputFile,
+		OutputFile: outputFile,
+		Speed:      timelapseSpeed,
+		FPS:        timelapseFPS,
+		MotionBlur: timelapseMotionBlur,
+		Preset:     timelapsePreset,
+		Verbose:    useVerbose,
+	})
+	if err != nil {
+		return fmt.Errorf("time-lapse failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Time-lapse created successfully!")
+		fmt.Printf("Output saved to: %s\n", outputFile)
+	}
+
+	return nil
+}
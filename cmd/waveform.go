@@ -0,0 +1,124 @@
+package cmd
+
+import (
+	"fmt"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/security"
+	"github.com/rishad1234/term-video-transcoder/internal/transcoder"
+	"github.com/spf13/cobra"
+)
+
+var waveformCmd = &cobra.Command{
+	Use:   "waveform [input] [output]",
+	Short: "Draw the waveform of an audio track as an image",
+	Long: `Draw the waveform of the whole first audio track of a file as a single
+image, for podcast artwork and web audio players.
+
+The extension of the output selects the image format: png, webp or jpg.
+PNG and WebP keep the background transparent; JPEG has no transparency, so
+the background comes out black.
+
+Colors are hex (3498db or #3498db, with an optional alpha byte such as
+3498db80) or FFmpeg color names (white, orange, ...).
+
+Examples:
+  transcoder waveform input.mp3 wave.png --width 1200 --color 3498db
+
+  # One band per channel, in white
+  transcoder waveform interview.wav wave.png --split-channels --color white`,
+	Args: cobra.ExactArgs(2),
+	RunE: runWaveform,
+}
+
+var (
+	waveformWidth         int
+	waveformHeight        int
+	waveformColor         string
+	waveformSplitChannels bool
+	waveformForce         bool
+)
+
+func init() {
+	rootCmd.AddCommand(waveformCmd)
+
+	waveformCmd.Flags().IntVar(&waveformWidth, "width", 1200,
+		"image width in pixels")
+
+	waveformCmd.Flags().IntVar(&waveformHeight, "height", 240,
+		"image height in pixels")
+
+	waveformCmd.Flags().StringVar(&waveformColor, "color", "3498db",
+		"wave color: RRGGBB hex or a color name")
+
+	waveformCmd.Flags().BoolVar(&waveformSplitChannels, "split-channels", false,
+		"draw each channel in its own band")
+
+	waveformCmd.Flags().BoolVarP(&waveformForce, "force", "f", false,
+		"overwrite output file if it exists")
+}
+
+func runWaveform(cmd *cobra.Command, args []string) error {
+	inputFile := args[0]
+	outputFile, err := resolveOutputPath(args[1])
+	if err != nil {
+		return err
+	}
+
+	// Initialize security policy
+	securityPolicy := security.NewDefaultSecurityPolicy()
+
+	// Security validation for file paths
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	if !fileExists(inputFile) {
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputFile)
+	}
+
+	if fileExists(outputFile) && !waveformForce {
+		return fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+	}
+
+	if err := analyzer.CheckFFProbe(cmd.Context()); err != nil {
+		return fmt.Errorf("ffprobe check failed: %w", err)
+	}
+
+	if err := analyzer.CheckFFMpeg(cmd.Context()); err != nil {
+		return fmt.Errorf("ffmpeg check failed: %w", err)
+	}
+
+	useVerbose := verbose && !quiet
+	if useVerbose {
+		color.Cyan("🌊 Drawing Waveform")
+		fmt.Println()
+		fmt.Printf("   Input:   %s\n", inputFile)
+		fmt.Printf("   Output:  %s\n", outputFile)
+		fmt.Println()
+	}
+
+	if err := transcoder.CreateWaveform(cmd.Context(), transcoder.WaveformParams{
+		InputFile:     inputFile,
+		OutputFile:    outputFile,
+		Width:         waveformWidth,
+		Height:        waveformHeight,
+		Color:         waveformColor,
+		SplitChannels: waveformSplitChannels,
+		Verbose:       useVerbose,
+	}); err != nil {
+		return fmt.Errorf("waveform failed: %w", err)
+	}
+
+	if !quiet {
+		color.Green("✅ Waveform created successfully!")
+		fmt.Printf("Output saved to: %s\n", outputFile)
+	}
+
+	return nil
+}
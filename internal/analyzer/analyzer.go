@@ -1,56 +1,153 @@
 package analyzer
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"math"
 	"os"
 	"os/exec"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/rishad1234/term-video-transcoder/internal/logging"
 	"github.com/tidwall/gjson"
 )
 
+// FFmpeg and FFprobe executables, looked up in PATH unless configured otherwise
+var (
+	FFmpegPath  = "ffmpeg"
+	FFprobePath = "ffprobe"
+)
+
+// toolPathsKey is the context key of the executables set with WithToolPaths
+type toolPathsKey struct{}
+
+// toolPaths are the executables one caller runs
+type toolPaths struct {
+	ffmpeg  string
+	ffprobe string
+}
+
+// WithToolPaths returns a context under which the given executables run instead of FFmpegPath
+// and FFprobePath, so callers using different FFmpeg builds can run side by side
+func WithToolPaths(ctx context.Context, ffmpeg, ffprobe string) context.Context {
+	return context.WithValue(ctx, toolPathsKey{}, toolPaths{ffmpeg: ffmpeg, ffprobe: ffprobe})
+}
+
+// FFmpeg returns the ffmpeg executable to run under ctx
+func FFmpeg(ctx context.Context) string {
+	if paths, ok := ctx.Value(toolPathsKey{}).(toolPaths); ok {
+		return paths.ffmpeg
+	}
+	return FFmpegPath
+}
+
+// FFprobe returns the ffprobe executable to run under ctx
+func FFprobe(ctx context.Context) string {
+	if paths, ok := ctx.Value(toolPathsKey{}).(toolPaths); ok {
+		return paths.ffprobe
+	}
+	return FFprobePath
+}
+
+// ErrFileNotFound is wrapped by errors about input files that do not exist
+var ErrFileNotFound = errors.New("file does not exist")
+
+// MissingToolError reports that ffmpeg or ffprobe cannot be run
+type MissingToolError struct {
+	Tool string // "ffmpeg" or "ffprobe"
+	Err  error  // Error running the tool
+}
+
+func (e *MissingToolError) Error() string {
+	return fmt.Sprintf("%s not found or not working: %v", e.Tool, e.Err)
+}
+
+func (e *MissingToolError) Unwrap() error {
+	return e.Err
+}
+
 // MediaInfo holds comprehensive information about a media file
 type MediaInfo struct {
-	Filename     string        `json:"filename"`
-	Format       string        `json:"format"`
-	Duration     time.Duration `json:"duration"`
-	Size         int64         `json:"size"`
-	Bitrate      int64         `json:"bitrate"`
-	VideoStreams []VideoStream `json:"video_streams"`
-	AudioStreams []AudioStream `json:"audio_streams"`
+	Filename        string           `json:"filename" yaml:"filename"`
+	Format          string           `json:"format" yaml:"format"`
+	Duration        time.Duration    `json:"duration" yaml:"duration"`
+	Size            int64            `json:"size" yaml:"size"`
+	Bitrate         int64            `json:"bitrate" yaml:"bitrate"`
+	Timecode        string           `json:"timecode,omitempty" yaml:"timecode,omitempty"` // Start timecode stored by cameras and editors (e.g., 01:00:00:00)
+	VideoStreams    []VideoStream    `json:"video_streams" yaml:"video_streams"`
+	AudioStreams    []AudioStream    `json:"audio_streams" yaml:"audio_streams"`
+	SubtitleStreams []SubtitleStream `json:"subtitle_streams" yaml:"subtitle_streams"`
+	Attachments     []Attachment     `json:"attachments,omitempty" yaml:"attachments,omitempty"`
+	Keyframes       *KeyframeStats   `json:"keyframes,omitempty" yaml:"keyframes,omitempty"` // Only set when keyframe analysis was requested
+	Loudness        *LoudnessStats   `json:"loudness,omitempty" yaml:"loudness,omitempty"`   // Only set when loudness analysis was requested
+	Sync            *SyncStats       `json:"sync,omitempty" yaml:"sync,omitempty"`           // Only set when sync analysis was requested
 }
 
 // VideoStream represents a video stream in the media file
 type VideoStream struct {
-	Index       int    `json:"index"`
-	Codec       string `json:"codec"`
-	Width       int    `json:"width"`
-	Height      int    `json:"height"`
-	FrameRate   string `json:"frame_rate"`
-	PixelFormat string `json:"pixel_format"`
-	Bitrate     int64  `json:"bitrate"`
+	Index        int    `json:"index" yaml:"index"`
+	Codec        string `json:"codec" yaml:"codec"`
+	Profile      string `json:"profile" yaml:"profile"`
+	Width        int    `json:"width" yaml:"width"`
+	Height       int    `json:"height" yaml:"height"`
+	FrameRate    string `json:"frame_rate" yaml:"frame_rate"`
+	AvgFrameRate string `json:"avg_frame_rate" yaml:"avg_frame_rate"`
+	PixelFormat  string `json:"pixel_format" yaml:"pixel_format"`
+	Bitrate      int64  `json:"bitrate" yaml:"bitrate"`
+	Rotation     int    `json:"rotation" yaml:"rotation"` // Clockwise degrees players rotate by (0, 90, 180, 270)
+
+	// Shape of a pixel as width:height (e.g., "32:27" for anamorphic DVD), "" or "1:1" when square
+	SampleAspectRatio string `json:"sample_aspect_ratio,omitempty" yaml:"sample_aspect_ratio,omitempty"`
 }
 
 // AudioStream represents an audio stream in the media file
 type AudioStream struct {
-	Index      int    `json:"index"`
-	Codec      string `json:"codec"`
-	SampleRate int    `json:"sample_rate"`
-	Channels   int    `json:"channels"`
-	Bitrate    int64  `json:"bitrate"`
-	Language   string `json:"language"`
+	Index      int    `json:"index" yaml:"index"`
+	Codec      string `json:"codec" yaml:"codec"`
+	Profile    string `json:"profile" yaml:"profile"`
+	SampleRate int    `json:"sample_rate" yaml:"sample_rate"`
+	Channels   int    `json:"channels" yaml:"channels"`
+	Bitrate    int64  `json:"bitrate" yaml:"bitrate"`
+	Language   string `json:"language" yaml:"language"`
+}
+
+// SubtitleStream represents a subtitle stream in the media file
+type SubtitleStream struct {
+	Index    int    `json:"index" yaml:"index"`
+	Codec    string `json:"codec" yaml:"codec"`
+	Language string `json:"language" yaml:"language"`
+	Title    string `json:"title" yaml:"title"`
+	Default  bool   `json:"default" yaml:"default"`
+	Forced   bool   `json:"forced" yaml:"forced"`
+}
+
+// Attachment is a file stored in the container, such as a font for ASS subtitles or a cover
+// image in Matroska files
+type Attachment struct {
+	Index    int    `json:"index" yaml:"index"`
+	Filename string `json:"filename" yaml:"filename"`
+	MimeType string `json:"mime_type" yaml:"mime_type"`
+	Size     int64  `json:"size" yaml:"size"` // Bytes; zero when ffprobe does not report it
 }
 
-// AnalyzeMedia uses ffprobe to extract comprehensive media information
-func AnalyzeMedia(filepath string) (*MediaInfo, error) {
+// AnalyzeMedia uses ffprobe to extract comprehensive media information. ffprobe is
+// stopped when ctx is cancelled.
+func AnalyzeMedia(ctx context.Context, filepath string) (*MediaInfo, error) {
+	// Media piped in on stdin is probed from its buffered start
+	if IsStdinPath(filepath) {
+		return analyzeStdin(ctx)
+	}
+
 	// Check if file exists
 	if _, err := os.Stat(filepath); os.IsNotExist(err) {
-		return nil, fmt.Errorf("file does not exist: %s", filepath)
+		return nil, fmt.Errorf("%w: %s", ErrFileNotFound, filepath)
 	}
 
 	// Run ffprobe command
-	cmd := exec.Command("ffprobe",
+	cmd := exec.CommandContext(ctx, FFprobe(ctx),
 		"-v", "quiet",
 		"-print_format", "json",
 		"-show_format",
@@ -59,12 +156,65 @@ func AnalyzeMedia(filepath string) (*MediaInfo, error) {
 
 	output, err := cmd.Output()
 	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
 		return nil, fmt.Errorf("ffprobe failed: %w", err)
 	}
+	logging.Logger().Debug("ffprobe output", "file", filepath, "json", string(output))
 
 	return parseFFProbeOutput(string(output), filepath)
 }
 
+// WaitForStableMedia waits for a file that is still being written (an OBS recording, a download)
+// to stop growing for the settle period, then analyzes it. onWait, if set, is called with the
+// current size on every check while waiting. Waiting stops when ctx is cancelled.
+func WaitForStableMedia(ctx context.Context, filepath string, settle time.Duration, onWait func(size int64)) (*MediaInfo, error) {
+	if settle <= 0 {
+		return nil, fmt.Errorf("settle time must be positive")
+	}
+
+	interval := time.Second
+	if settle < interval {
+		interval = settle
+	}
+
+	lastSize := int64(-1)
+	lastChange := time.Now()
+	for {
+		stat, err := os.Stat(filepath)
+		switch {
+		case os.IsNotExist(err):
+			// The writer may not have created the file yet
+			lastSize = -1
+			lastChange = time.Now()
+		case err != nil:
+			return nil, fmt.Errorf("checking file: %w", err)
+		case stat.Size() != lastSize:
+			lastSize = stat.Size()
+			lastChange = time.Now()
+		case time.Since(lastChange) >= settle:
+			info, err := AnalyzeMedia(ctx, filepath)
+			if err != nil {
+				if ctx.Err() != nil {
+					return nil, ctx.Err()
+				}
+				return nil, fmt.Errorf("file stopped growing but could not be analyzed (try repair): %w", err)
+			}
+			return info, nil
+		}
+
+		if onWait != nil {
+			onWait(max(lastSize, 0))
+		}
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-time.After(interval):
+		}
+	}
+}
+
 // parseFFProbeOutput parses the JSON output from ffprobe
 func parseFFProbeOutput(jsonOutput, filepath string) (*MediaInfo, error) {
 	info := &MediaInfo{
@@ -78,10 +228,25 @@ func parseFFProbeOutput(jsonOutput, filepath string) (*MediaInfo, error) {
 	if err := parseStreamInformation(jsonOutput, info); err != nil {
 		return nil, fmt.Errorf("parsing stream information: %w", err)
 	}
+	info.Timecode = parseTimecode(jsonOutput)
 
 	return info, nil
 }
 
+// parseTimecode reads the start timecode from the format tags (MXF) or the tags of a stream,
+// such as the timecode (tmcd) track of MOV and MP4 files
+func parseTimecode(jsonOutput string) string {
+	if timecode := gjson.Get(jsonOutput, "format.tags.timecode").String(); timecode != "" {
+		return timecode
+	}
+	for _, stream := range gjson.Get(jsonOutput, "streams").Array() {
+		if timecode := stream.Get("tags.timecode").String(); timecode != "" {
+			return timecode
+		}
+	}
+	return ""
+}
+
 // parseFormatInformation extracts format-level metadata
 func parseFormatInformation(jsonOutput string, info *MediaInfo) error {
 	format := gjson.Get(jsonOutput, "format")
@@ -135,6 +300,10 @@ func parseStreamInformation(jsonOutput string, info *MediaInfo) error {
 			parseVideoStream(stream, info)
 		case "audio":
 			parseAudioStream(stream, info)
+		case "subtitle":
+			parseSubtitleStream(stream, info)
+		case "attachment":
+			parseAttachment(stream, info)
 		}
 	}
 	return nil
@@ -143,23 +312,41 @@ func parseStreamInformation(jsonOutput string, info *MediaInfo) error {
 // parseVideoStream extracts video stream metadata
 func parseVideoStream(stream gjson.Result, info *MediaInfo) {
 	videoStream := VideoStream{
-		Index:       int(stream.Get("index").Int()),
-		Codec:       stream.Get("codec_name").String(),
-		Width:       int(stream.Get("width").Int()),
-		Height:      int(stream.Get("height").Int()),
-		FrameRate:   stream.Get("r_frame_rate").String(),
-		PixelFormat: stream.Get("pix_fmt").String(),
+		Index:        int(stream.Get("index").Int()),
+		Codec:        stream.Get("codec_name").String(),
+		Profile:      stream.Get("profile").String(),
+		Width:        int(stream.Get("width").Int()),
+		Height:       int(stream.Get("height").Int()),
+		FrameRate:    stream.Get("r_frame_rate").String(),
+		AvgFrameRate: stream.Get("avg_frame_rate").String(),
+		PixelFormat:  stream.Get("pix_fmt").String(),
+
+		SampleAspectRatio: stream.Get("sample_aspect_ratio").String(),
 	}
 
 	parseStreamBitrate(stream, &videoStream.Bitrate)
+	videoStream.Rotation = parseRotation(stream)
 	info.VideoStreams = append(info.VideoStreams, videoStream)
 }
 
+// parseRotation reads the display rotation from the legacy rotate tag or the display matrix side data.
+// The display matrix stores counter-clockwise degrees, so it is negated to match the rotate tag.
+func parseRotation(stream gjson.Result) int {
+	rotation := 0
+	if tag := stream.Get("tags.rotate"); tag.Exists() {
+		rotation = int(tag.Int())
+	} else if matrix := stream.Get(`side_data_list.#(side_data_type=="Display Matrix").rotation`); matrix.Exists() {
+		rotation = -int(matrix.Int())
+	}
+	return ((rotation % 360) + 360) % 360
+}
+
 // parseAudioStream extracts audio stream metadata
 func parseAudioStream(stream gjson.Result, info *MediaInfo) {
 	audioStream := AudioStream{
 		Index:      int(stream.Get("index").Int()),
 		Codec:      stream.Get("codec_name").String(),
+		Profile:    stream.Get("profile").String(),
 		SampleRate: int(stream.Get("sample_rate").Int()),
 		Channels:   int(stream.Get("channels").Int()),
 		Language:   stream.Get("tags.language").String(),
@@ -169,6 +356,30 @@ func parseAudioStream(stream gjson.Result, info *MediaInfo) {
 	info.AudioStreams = append(info.AudioStreams, audioStream)
 }
 
+// parseSubtitleStream extracts subtitle stream metadata
+func parseSubtitleStream(stream gjson.Result, info *MediaInfo) {
+	subtitleStream := SubtitleStream{
+		Index:    int(stream.Get("index").Int()),
+		Codec:    stream.Get("codec_name").String(),
+		Language: stream.Get("tags.language").String(),
+		Title:    stream.Get("tags.title").String(),
+		Default:  stream.Get("disposition.default").Int() == 1,
+		Forced:   stream.Get("disposition.forced").Int() == 1,
+	}
+
+	info.SubtitleStreams = append(info.SubtitleStreams, subtitleStream)
+}
+
+// parseAttachment extracts the name, type and size of an attached file
+func parseAttachment(stream gjson.Result, info *MediaInfo) {
+	info.Attachments = append(info.Attachments, Attachment{
+		Index:    int(stream.Get("index").Int()),
+		Filename: stream.Get("tags.filename").String(),
+		MimeType: stream.Get("tags.mimetype").String(),
+		Size:     stream.Get("extradata_size").Int(),
+	})
+}
+
 // parseStreamBitrate extracts bitrate for individual streams
 func parseStreamBitrate(stream gjson.Result, bitrate *int64) {
 	if bitrateStr := stream.Get("bit_rate").String(); bitrateStr != "" {
@@ -178,20 +389,135 @@ func parseStreamBitrate(stream gjson.Result, bitrate *int64) {
 	}
 }
 
+// ParseFrameRate extracts FPS from frame rate string (e.g., "30/1" -> 30.0)
+func ParseFrameRate(frameRate string) float64 {
+	parts := strings.Split(frameRate, "/")
+	if len(parts) != 2 {
+		return 0
+	}
+
+	numerator, err1 := strconv.ParseFloat(parts[0], 64)
+	denominator, err2 := strconv.ParseFloat(parts[1], 64)
+
+	if err1 != nil || err2 != nil || denominator == 0 {
+		return 0
+	}
+
+	return numerator / denominator
+}
+
+// IsVariableFrameRate reports whether the average frame rate differs from the nominal one,
+// which is typical for screen recordings and phone videos
+func (s VideoStream) IsVariableFrameRate() bool {
+	nominal := ParseFrameRate(s.FrameRate)
+	average := ParseFrameRate(s.AvgFrameRate)
+	if nominal <= 0 || average <= 0 {
+		return false
+	}
+	return math.Abs(nominal-average)/nominal > 0.01
+}
+
 // CheckFFProbe verifies that ffprobe is available in the system
-func CheckFFProbe() error {
-	cmd := exec.Command("ffprobe", "-version")
+func CheckFFProbe(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, FFprobe(ctx), "-version")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("ffprobe not found or not working: %w", err)
+		return &MissingToolError{Tool: "ffprobe", Err: err}
 	}
 	return nil
 }
 
 // CheckFFMpeg verifies that ffmpeg is available in the system
-func CheckFFMpeg() error {
-	cmd := exec.Command("ffmpeg", "-version")
+func CheckFFMpeg(ctx context.Context) error {
+	cmd := exec.CommandContext(ctx, FFmpeg(ctx), "-version")
 	if err := cmd.Run(); err != nil {
-		return fmt.Errorf("ffmpeg not found or not working: %w", err)
+		return &MissingToolError{Tool: "ffmpeg", Err: err}
 	}
 	return nil
 }
+
+// KeyframeStats summarizes keyframe placement (GOP structure) of the first video stream
+type KeyframeStats struct {
+	Keyframes       int     `json:"keyframes" yaml:"keyframes"`
+	Frames          int     `json:"frames" yaml:"frames"`
+	MinGOP          int     `json:"min_gop" yaml:"min_gop"`
+	AvgGOP          float64 `json:"avg_gop" yaml:"avg_gop"`
+	MaxGOP          int     `json:"max_gop" yaml:"max_gop"`
+	AvgIntervalSecs float64 `json:"avg_interval_seconds" yaml:"avg_interval_seconds"`
+	MaxIntervalSecs float64 `json:"max_interval_seconds" yaml:"max_interval_seconds"`
+}
+
+// AnalyzeKeyframes reads packet flags of the first video stream to measure GOP lengths.
+// Packets are inspected without decoding, so this is reasonably fast even for long files.
+func AnalyzeKeyframes(ctx context.Context, filepath string) (*KeyframeStats, error) {
+	cmd := exec.CommandContext(ctx, FFprobe(ctx),
+		"-v", "quiet",
+		"-select_streams", "v:0",
+		"-show_entries", "packet=pts_time,flags",
+		"-of", "csv=p=0",
+		filepath)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe packet analysis failed: %w", err)
+	}
+
+	return parseKeyframeOutput(string(output))
+}
+
+// parseKeyframeOutput computes GOP statistics from "pts_time,flags" lines
+func parseKeyframeOutput(output string) (*KeyframeStats, error) {
+	stats := &KeyframeStats{}
+	gops := make([]int, 0)
+	keyTimes := make([]float64, 0)
+	currentGOP := 0
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) < 2 {
+			continue
+		}
+		stats.Frames++
+
+		if !strings.HasPrefix(fields[1], "K") {
+			currentGOP++
+			continue
+		}
+
+		// A keyframe closes the previous GOP
+		if stats.Keyframes > 0 {
+			gops = append(gops, currentGOP)
+		}
+		stats.Keyframes++
+		currentGOP = 1
+
+		if pts, err := strconv.ParseFloat(fields[0], 64); err == nil {
+			keyTimes = append(keyTimes, pts)
+		}
+	}
+
+	if stats.Keyframes == 0 {
+		return nil, fmt.Errorf("no keyframes found in the video stream")
+	}
+	// The trailing GOP is usually cut short by the end of the file, so only count it when it is the only one
+	if len(gops) == 0 {
+		gops = append(gops, currentGOP)
+	}
+
+	stats.MinGOP, stats.MaxGOP = gops[0], gops[0]
+	total := 0
+	for _, gop := range gops {
+		total += gop
+		stats.MinGOP = min(stats.MinGOP, gop)
+		stats.MaxGOP = max(stats.MaxGOP, gop)
+	}
+	stats.AvgGOP = float64(total) / float64(len(gops))
+
+	if len(keyTimes) > 1 {
+		for i := 1; i < len(keyTimes); i++ {
+			stats.MaxIntervalSecs = max(stats.MaxIntervalSecs, keyTimes[i]-keyTimes[i-1])
+		}
+		stats.AvgIntervalSecs = (keyTimes[len(keyTimes)-1] - keyTimes[0]) / float64(len(keyTimes)-1)
+	}
+
+	return stats, nil
+}
@@ -0,0 +1,108 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// BitrateProfile holds the bitrate of a media file for every second of playback
+type BitrateProfile struct {
+	PerSecond []int64 // Bits transferred in each second, from the first packet on
+	Average   int64   // Average bitrate in bits per second
+	Peak      int64   // Highest one-second bitrate
+	PeakAt    int     // Second at which the peak occurs
+}
+
+// AnalyzeBitrate sums packet sizes of all streams into one-second buckets.
+// Packets are read without decoding, so this is fast even for long files.
+func AnalyzeBitrate(ctx context.Context, filepath string) (*BitrateProfile, error) {
+	cmd := exec.CommandContext(ctx, FFprobe(ctx),
+		"-v", "quiet",
+		"-show_entries", "packet=pts_time,dts_time,size",
+		"-of", "csv=p=0",
+		filepath)
+
+	output, err := cmd.Output()
+	if err != nil {
+		return nil, fmt.Errorf("ffprobe packet analysis failed: %w", err)
+	}
+
+	return parseBitrateOutput(string(output))
+}
+
+// bitratePacket is the timestamp and size of one packet
+type bitratePacket struct {
+	timestamp float64
+	bits      int64
+}
+
+// parseBitrateOutput builds a bitrate profile from "pts_time,dts_time,size" lines. Seconds
+// count from the earliest packet, since MPEG-TS and other captures rarely start at zero.
+func parseBitrateOutput(output string) (*BitrateProfile, error) {
+	var packets []bitratePacket
+	start := math.Inf(1)
+
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) < 3 {
+			continue
+		}
+
+		// Some packets carry no pts (e.g., in raw streams); fall back to the dts
+		timestamp, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			if timestamp, err = strconv.ParseFloat(fields[1], 64); err != nil {
+				continue
+			}
+		}
+		size, err := strconv.ParseInt(fields[2], 10, 64)
+		if err != nil {
+			continue
+		}
+
+		packets = append(packets, bitratePacket{timestamp: timestamp, bits: size * 8})
+		start = min(start, timestamp)
+	}
+
+	if len(packets) == 0 {
+		return nil, fmt.Errorf("no packets with timestamps found")
+	}
+
+	perSecond := make([]int64, 0)
+	for _, packet := range packets {
+		second := int(math.Floor(packet.timestamp - start))
+		for len(perSecond) <= second {
+			perSecond = append(perSecond, 0)
+		}
+		perSecond[second] += packet.bits
+	}
+
+	profile := &BitrateProfile{PerSecond: perSecond}
+	var total int64
+	for second, bits := range perSecond {
+		total += bits
+		if bits > profile.Peak {
+			profile.Peak = bits
+			profile.PeakAt = second
+		}
+	}
+	profile.Average = total / int64(len(perSecond))
+
+	return profile, nil
+}
+
+// Spikes returns the seconds whose bitrate exceeds the average by the given factor
+func (p *BitrateProfile) Spikes(factor float64) []int {
+	spikes := make([]int, 0)
+	threshold := int64(float64(p.Average) * factor)
+	for second, bits := range p.PerSecond {
+		if bits > threshold {
+			spikes = append(spikes, second)
+		}
+	}
+	return spikes
+}
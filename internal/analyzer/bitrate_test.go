@@ -0,0 +1,82 @@
+package analyzer
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseBitrateOutput(t *testing.T) {
+	tests := []struct {
+		name      string
+		output    string
+		perSecond []int64
+		average   int64
+		peakAt    int
+		wantErr   bool
+	}{
+		{
+			name:      "starts at zero",
+			output:    "0.000000,0.000000,1000\n0.500000,0.500000,1000\n1.200000,1.200000,500\n",
+			perSecond: []int64{16000, 4000},
+			average:   10000,
+			peakAt:    0,
+		},
+		{
+			name:      "transport stream starting late",
+			output:    "1400.000000,1400.000000,100\n1400.900000,1400.900000,100\n1401.100000,1401.100000,1000\n",
+			perSecond: []int64{1600, 8000},
+			average:   4800,
+			peakAt:    1,
+		},
+		{
+			name:      "earliest packet comes later in decode order",
+			output:    "10.080000,10.000000,100\n10.040000,10.040000,100\n11.050000,11.050000,200\n",
+			perSecond: []int64{1600, 1600},
+			average:   1600,
+			peakAt:    0,
+		},
+		{
+			name:      "missing pts falls back to dts",
+			output:    "N/A,5.000000,100\nN/A,6.000000,300\n",
+			perSecond: []int64{800, 2400},
+			average:   1600,
+			peakAt:    1,
+		},
+		{
+			name:      "packets without timestamps are skipped",
+			output:    "N/A,N/A,100\n2.000000,2.000000,100\n",
+			perSecond: []int64{800},
+			average:   800,
+			peakAt:    0,
+		},
+		{
+			name:    "no packets",
+			output:  "N/A,N/A,100\n\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			profile, err := parseBitrateOutput(tt.output)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("expected an error, got %+v", profile)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !slices.Equal(profile.PerSecond, tt.perSecond) {
+				t.Errorf("PerSecond = %v, want %v", profile.PerSecond, tt.perSecond)
+			}
+			if profile.Average != tt.average {
+				t.Errorf("Average = %d, want %d", profile.Average, tt.average)
+			}
+			if profile.PeakAt != tt.peakAt {
+				t.Errorf("PeakAt = %d, want %d", profile.PeakAt, tt.peakAt)
+			}
+		})
+	}
+}
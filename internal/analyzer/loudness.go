@@ -0,0 +1,164 @@
+package analyzer
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// LoudnessStats holds the EBU R128 loudness of the first audio stream
+type LoudnessStats struct {
+	Integrated float64              `json:"integrated_lufs" yaml:"integrated_lufs"`     // Integrated loudness in LUFS
+	TruePeak   float64              `json:"true_peak_dbtp" yaml:"true_peak_dbtp"`       // Highest true peak in dBTP
+	Range      float64              `json:"loudness_range_lu" yaml:"loudness_range_lu"` // Loudness range in LU
+	Specs      []LoudnessCompliance `json:"specs" yaml:"specs"`
+}
+
+// LoudnessSpec is a delivery specification: a target integrated loudness with a tolerance
+// and a true peak ceiling
+type LoudnessSpec struct {
+	Name        string
+	Target      float64 // Integrated loudness in LUFS
+	Tolerance   float64 // Allowed distance from the target in LU
+	MaxTruePeak float64 // Highest allowed true peak in dBTP
+}
+
+// LoudnessCompliance tells whether measured loudness meets a delivery specification
+type LoudnessCompliance struct {
+	Name   string  `json:"name" yaml:"name"`
+	Target float64 `json:"target_lufs" yaml:"target_lufs"`
+	Passes bool    `json:"passes" yaml:"passes"`
+	Issue  string  `json:"issue,omitempty" yaml:"issue,omitempty"` // Why it fails, if it does
+}
+
+// loudnessFloor is the absolute gate of EBU R128 in LUFS; audio below it counts as silence.
+// Silent streams measure -inf, which stands in for it so the stats stay encodable as JSON.
+const loudnessFloor = -70.0
+
+// LoudnessSpecs are common delivery specifications
+var LoudnessSpecs = []LoudnessSpec{
+	{Name: "Podcast", Target: -16, Tolerance: 1, MaxTruePeak: -1},
+	{Name: "Streaming", Target: -14, Tolerance: 1, MaxTruePeak: -1},
+	{Name: "Broadcast (EBU R128)", Target: -23, Tolerance: 1, MaxTruePeak: -1},
+}
+
+// AnalyzeLoudness measures integrated loudness, true peak and loudness range of the first
+// audio stream with FFmpeg's ebur128 filter. The whole stream is decoded, so this takes a
+// while for long files.
+func AnalyzeLoudness(ctx context.Context, filepath string) (*LoudnessStats, error) {
+	cmd := exec.CommandContext(ctx, FFmpeg(ctx),
+		"-hide_banner",
+		"-nostats",
+		"-i", filepath,
+		"-map", "0:a:0",
+		"-af", "ebur128=peak=true",
+		"-f", "null", "-")
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return nil, fmt.Errorf("ffmpeg loudness analysis failed: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return nil, fmt.Errorf("ffmpeg loudness analysis failed: %w", err)
+	}
+
+	// Older builds log a line per 100ms of audio; only the summary at the end is kept
+	var summary strings.Builder
+	inSummary := false
+	scanner := bufio.NewScanner(stderr)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if strings.Contains(line, "Summary:") {
+			inSummary = true
+			summary.Reset()
+		}
+		if inSummary {
+			summary.WriteString(line)
+			summary.WriteByte('\n')
+		}
+	}
+
+	if err := cmd.Wait(); err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("ffmpeg loudness analysis failed: %w", err)
+	}
+
+	return parseLoudnessSummary(summary.String())
+}
+
+// parseLoudnessSummary reads the summary the ebur128 filter logs at the end:
+//
+//	Integrated loudness:
+//	  I:         -19.6 LUFS
+//	Loudness range:
+//	  LRA:         6.1 LU
+//	True peak:
+//	  Peak:       -1.2 dBFS
+func parseLoudnessSummary(summary string) (*LoudnessStats, error) {
+	values := make(map[string]float64)
+	for _, line := range strings.Split(summary, "\n") {
+		label, rest, ok := strings.Cut(strings.TrimSpace(line), ":")
+		if !ok {
+			continue
+		}
+		fields := strings.Fields(rest)
+		if len(fields) == 0 {
+			continue
+		}
+		value, err := strconv.ParseFloat(fields[0], 64)
+		if err != nil {
+			continue
+		}
+		if math.IsInf(value, -1) {
+			value = loudnessFloor
+		}
+		if _, seen := values[label]; !seen {
+			values[label] = value
+		}
+	}
+
+	integrated, ok := values["I"]
+	if !ok {
+		return nil, fmt.Errorf("no loudness summary in ffmpeg output")
+	}
+	stats := &LoudnessStats{
+		Integrated: integrated,
+		TruePeak:   values["Peak"],
+		Range:      values["LRA"],
+	}
+	for _, spec := range LoudnessSpecs {
+		stats.Specs = append(stats.Specs, spec.Check(stats))
+	}
+	return stats, nil
+}
+
+// Check tells whether the measured loudness meets the specification
+func (s LoudnessSpec) Check(stats *LoudnessStats) LoudnessCompliance {
+	compliance := LoudnessCompliance{Name: s.Name, Target: s.Target, Passes: true}
+
+	var issues []string
+	if stats.Integrated <= loudnessFloor {
+		issues = append(issues, "silent")
+	} else if difference := stats.Integrated - s.Target; math.Abs(difference) > s.Tolerance {
+		direction := "too loud"
+		if difference < 0 {
+			direction = "too quiet"
+		}
+		issues = append(issues, fmt.Sprintf("%.1f LU %s", math.Abs(difference), direction))
+	}
+	if stats.TruePeak > s.MaxTruePeak {
+		issues = append(issues, fmt.Sprintf("true peak above %.0f dBTP", s.MaxTruePeak))
+	}
+
+	if len(issues) > 0 {
+		compliance.Passes = false
+		compliance.Issue = strings.Join(issues, ", ")
+	}
+	return compliance
+}
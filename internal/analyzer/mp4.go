@@ -0,0 +1,53 @@
+package analyzer
+
+import (
+	"encoding/binary"
+	"fmt"
+	"io"
+	"os"
+)
+
+// IsFastStart reports whether an MP4/MOV file stores its index (moov atom) before the
+// media data (mdat atom), which lets playback start before the file is fully downloaded
+func IsFastStart(filepath string) (bool, error) {
+	file, err := os.Open(filepath)
+	if err != nil {
+		return false, err
+	}
+	defer file.Close()
+
+	// Walk the top-level atoms: a 32-bit size and a 4-character type, with a
+	// 64-bit size following when the 32-bit size is 1
+	var offset int64
+	header := make([]byte, 16)
+	for {
+		if _, err := file.ReadAt(header[:8], offset); err != nil {
+			if err == io.EOF {
+				return false, fmt.Errorf("no moov or mdat atom found")
+			}
+			return false, err
+		}
+
+		size := int64(binary.BigEndian.Uint32(header[:4]))
+		switch string(header[4:8]) {
+		case "moov":
+			return true, nil
+		case "mdat":
+			return false, nil
+		}
+
+		switch size {
+		case 0:
+			return false, fmt.Errorf("no moov or mdat atom found")
+		case 1:
+			if _, err := file.ReadAt(header[8:16], offset+8); err != nil {
+				return false, err
+			}
+			size = int64(binary.BigEndian.Uint64(header[8:16]))
+		}
+		if size < 8 {
+			return false, fmt.Errorf("invalid atom size %d at offset %d", size, offset)
+		}
+		offset += size
+	}
+}
@@ -0,0 +1,92 @@
+package analyzer
+
+import (
+	"encoding/binary"
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+// atom builds a top-level MP4 atom with a 32-bit size and payload bytes of zeros
+func atom(kind string, payload int) []byte {
+	data := make([]byte, 8+payload)
+	binary.BigEndian.PutUint32(data, uint32(len(data)))
+	copy(data[4:], kind)
+	return data
+}
+
+// largeAtom builds an atom whose size is given in the 64-bit field, as for media over 4 GB
+func largeAtom(kind string, payload int) []byte {
+	data := make([]byte, 16+payload)
+	binary.BigEndian.PutUint32(data, 1)
+	copy(data[4:], kind)
+	binary.BigEndian.PutUint64(data[8:], uint64(len(data)))
+	return data
+}
+
+func TestIsFastStart(t *testing.T) {
+	tests := []struct {
+		name    string
+		atoms   [][]byte
+		want    bool
+		wantErr bool
+	}{
+		{
+			name:  "index first",
+			atoms: [][]byte{atom("ftyp", 16), atom("moov", 64), atom("mdat", 128)},
+			want:  true,
+		},
+		{
+			name:  "index last",
+			atoms: [][]byte{atom("ftyp", 16), atom("mdat", 128), atom("moov", 64)},
+			want:  false,
+		},
+		{
+			name:  "free space before the index",
+			atoms: [][]byte{atom("ftyp", 16), atom("free", 32), atom("moov", 64), atom("mdat", 128)},
+			want:  true,
+		},
+		{
+			name:  "64-bit atom size",
+			atoms: [][]byte{atom("ftyp", 16), largeAtom("wide", 40), atom("moov", 64)},
+			want:  true,
+		},
+		{
+			name:    "neither atom",
+			atoms:   [][]byte{atom("ftyp", 16), atom("free", 32)},
+			wantErr: true,
+		},
+		{
+			name:    "corrupt atom size",
+			atoms:   [][]byte{{0, 0, 0, 4, 'f', 't', 'y', 'p'}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var data []byte
+			for _, a := range tt.atoms {
+				data = append(data, a...)
+			}
+			path := filepath.Join(t.TempDir(), "video.mp4")
+			if err := os.WriteFile(path, data, 0644); err != nil {
+				t.Fatal(err)
+			}
+
+			got, err := IsFastStart(path)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("IsFastStart() = %v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("IsFastStart() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("IsFastStart() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,85 @@
+package analyzer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"os/exec"
+	"sync"
+
+	"github.com/rishad1234/term-video-transcoder/internal/logging"
+)
+
+// StdinPath is the input path that reads the media from standard input
+const StdinPath = "-"
+
+// stdinProbeSize is how much of stdin is buffered for ffprobe, which cannot seek a pipe
+const stdinProbeSize = 8 << 20
+
+// The start of stdin, read once for probing and replayed to FFmpeg afterwards
+var (
+	stdinOnce   sync.Once
+	stdinPrefix []byte
+	stdinErr    error
+)
+
+// IsStdinPath reports whether an input path refers to standard input
+func IsStdinPath(path string) bool {
+	return path == StdinPath
+}
+
+// bufferStdin reads the start of stdin, once per process since a pipe cannot be rewound
+func bufferStdin() ([]byte, error) {
+	stdinOnce.Do(func() {
+		stdinPrefix, stdinErr = io.ReadAll(io.LimitReader(os.Stdin, stdinProbeSize))
+		if stdinErr == nil && len(stdinPrefix) == 0 {
+			stdinErr = fmt.Errorf("no data on stdin")
+		}
+	})
+	return stdinPrefix, stdinErr
+}
+
+// StdinReader returns the media on stdin: the part buffered for probing followed by the rest
+func StdinReader() io.Reader {
+	prefix, _ := bufferStdin()
+	return io.MultiReader(bytes.NewReader(prefix), os.Stdin)
+}
+
+// analyzeStdin probes the buffered start of stdin. Unless the whole input fit in the buffer,
+// ffprobe can only guess the duration from the part it saw, so the duration is left unknown (zero).
+func analyzeStdin(ctx context.Context) (*MediaInfo, error) {
+	prefix, err := bufferStdin()
+	if err != nil {
+		return nil, fmt.Errorf("reading stdin: %w", err)
+	}
+
+	cmd := exec.CommandContext(ctx, FFprobe(ctx),
+		"-v", "quiet",
+		"-print_format", "json",
+		"-show_format",
+		"-show_streams",
+		"pipe:0")
+	cmd.Stdin = bytes.NewReader(prefix)
+
+	output, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("ffprobe failed: %w", err)
+	}
+	logging.Logger().Debug("ffprobe output", "file", StdinPath, "json", string(output))
+
+	info, err := parseFFProbeOutput(string(output), StdinPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(prefix) >= stdinProbeSize {
+		info.Duration = 0
+		info.Size = 0
+	}
+	return info, nil
+}
@@ -0,0 +1,121 @@
+package analyzer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os/exec"
+	"strconv"
+	"strings"
+)
+
+// SyncStats compares the timing of the first video and first audio stream
+type SyncStats struct {
+	VideoStartSecs    float64 `json:"video_start_seconds" yaml:"video_start_seconds"`
+	AudioStartSecs    float64 `json:"audio_start_seconds" yaml:"audio_start_seconds"`
+	VideoDurationSecs float64 `json:"video_duration_seconds" yaml:"video_duration_seconds"`
+	AudioDurationSecs float64 `json:"audio_duration_seconds" yaml:"audio_duration_seconds"`
+	StartOffsetSecs   float64 `json:"start_offset_seconds" yaml:"start_offset_seconds"` // Audio start minus video start; positive when the audio starts later
+	EndOffsetSecs     float64 `json:"end_offset_seconds" yaml:"end_offset_seconds"`     // Audio end minus video end
+	DriftSecs         float64 `json:"drift_seconds" yaml:"drift_seconds"`               // How far the offset changes from start to end
+	Status            string  `json:"status" yaml:"status"`                             // "in sync", "offset" or "drift"
+	SuggestedDelay    string  `json:"suggested_audio_delay,omitempty" yaml:"suggested_audio_delay,omitempty"`
+}
+
+// syncTolerance is the offset in seconds below which audio and video count as in sync;
+// about one frame, well under what viewers notice
+const syncTolerance = 0.04
+
+// AnalyzeSync compares the first and last packet timestamps of a video and an audio stream
+// (given by their stream index) to find an offset between them at the start and a drift
+// that grows towards the end. Packets are inspected without decoding.
+func AnalyzeSync(ctx context.Context, filepath string, videoIndex, audioIndex int) (*SyncStats, error) {
+	cmd := exec.CommandContext(ctx, FFprobe(ctx),
+		"-v", "quiet",
+		"-show_entries", "packet=stream_index,pts_time,duration_time",
+		"-of", "csv=p=0",
+		filepath)
+
+	output, err := cmd.Output()
+	if err != nil {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		return nil, fmt.Errorf("ffprobe packet analysis failed: %w", err)
+	}
+
+	return parseSyncOutput(string(output), videoIndex, audioIndex)
+}
+
+// streamSpan is the time covered by the packets of one stream
+type streamSpan struct {
+	start, end float64
+	packets    int
+}
+
+// parseSyncOutput measures the spans of both streams from "stream_index,pts_time,duration_time"
+// lines and compares them
+func parseSyncOutput(output string, videoIndex, audioIndex int) (*SyncStats, error) {
+	spans := map[int]*streamSpan{videoIndex: {}, audioIndex: {}}
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Split(strings.TrimSpace(line), ",")
+		if len(fields) < 3 {
+			continue
+		}
+		index, err := strconv.Atoi(fields[0])
+		if err != nil {
+			continue
+		}
+		span, ok := spans[index]
+		if !ok {
+			continue
+		}
+		// Packets without a timestamp (N/A) say nothing about the span
+		pts, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil {
+			continue
+		}
+		duration, _ := strconv.ParseFloat(fields[2], 64)
+
+		if span.packets == 0 {
+			span.start, span.end = pts, pts+duration
+		}
+		span.start = min(span.start, pts)
+		span.end = max(span.end, pts+duration)
+		span.packets++
+	}
+
+	video, audio := spans[videoIndex], spans[audioIndex]
+	if video.packets == 0 {
+		return nil, fmt.Errorf("no timestamped packets in the video stream")
+	}
+	if audio.packets == 0 {
+		return nil, fmt.Errorf("no timestamped packets in the audio stream")
+	}
+
+	stats := &SyncStats{
+		VideoStartSecs:    video.start,
+		AudioStartSecs:    audio.start,
+		VideoDurationSecs: video.end - video.start,
+		AudioDurationSecs: audio.end - audio.start,
+		StartOffsetSecs:   audio.start - video.start,
+		EndOffsetSecs:     audio.end - video.end,
+	}
+	stats.DriftSecs = stats.EndOffsetSecs - stats.StartOffsetSecs
+
+	// A constant delay cannot undo drift, so it aims for the middle, halving the worst error
+	correction := -stats.StartOffsetSecs
+	switch {
+	case math.Abs(stats.DriftSecs) > syncTolerance:
+		stats.Status = "drift"
+		correction = -(stats.StartOffsetSecs + stats.EndOffsetSecs) / 2
+	case math.Abs(stats.StartOffsetSecs) > syncTolerance:
+		stats.Status = "offset"
+	default:
+		stats.Status = "in sync"
+	}
+	if milliseconds := math.Round(correction * 1000); math.Abs(correction) > syncTolerance {
+		stats.SuggestedDelay = strconv.FormatFloat(milliseconds, 'f', 0, 64) + "ms"
+	}
+	return stats, nil
+}
@@ -0,0 +1,213 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Config holds user configuration loaded from config.yaml
+type Config struct {
+	OutputDir   string `yaml:"output_dir,omitempty"`   // Directory for outputs given as a bare file name
+	Verbosity   string `yaml:"verbosity,omitempty"`    // quiet, normal or verbose
+	FFmpegPath  string `yaml:"ffmpeg_path,omitempty"`  // FFmpeg executable to run instead of the one in PATH
+	FFprobePath string `yaml:"ffprobe_path,omitempty"` // FFprobe executable to run instead of the one in PATH
+
+	// Defaults maps a command path (e.g., "extract") to flag defaults (e.g., "quality": "high")
+	Defaults map[string]map[string]string `yaml:"defaults,omitempty"`
+}
+
+// Dir returns the transcoder configuration directory (e.g., ~/.config/transcoder)
+func Dir() (string, error) {
+	configDir, err := os.UserConfigDir()
+	if err != nil {
+		return "", fmt.Errorf("locating user config directory: %w", err)
+	}
+	return filepath.Join(configDir, "transcoder"), nil
+}
+
+// Path returns the location of the configuration file
+func Path() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "config.yaml"), nil
+}
+
+// Load reads the configuration file, returning an empty config when it does not exist
+func Load() (*Config, error) {
+	path, err := Path()
+	if err != nil {
+		return nil, err
+	}
+
+	cfg := &Config{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return cfg, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, cfg); err != nil {
+		return nil, fmt.Errorf("parsing config file %s: %w", path, err)
+	}
+
+	return cfg, nil
+}
+
+// Save writes the configuration file, creating the config directory if needed
+func (c *Config) Save() error {
+	path, err := Path()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(c)
+	if err != nil {
+		return fmt.Errorf("encoding config: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing config file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Settings lists the keys of the general (not per-command) settings
+var Settings = []string{"ffmpeg_path", "ffprobe_path", "output_dir", "verbosity"}
+
+// Verbosity levels accepted by the verbosity setting
+var Verbosities = []string{"quiet", "normal", "verbose"}
+
+// IsSetting reports whether a key names a general setting rather than a command flag
+func IsSetting(key string) bool {
+	return slices.Contains(Settings, key)
+}
+
+// setting returns the field holding a general setting
+func (c *Config) setting(key string) *string {
+	switch key {
+	case "ffmpeg_path":
+		return &c.FFmpegPath
+	case "ffprobe_path":
+		return &c.FFprobePath
+	case "output_dir":
+		return &c.OutputDir
+	case "verbosity":
+		return &c.Verbosity
+	}
+	return nil
+}
+
+// GetSetting returns the value of a general setting
+func (c *Config) GetSetting(key string) (string, bool) {
+	field := c.setting(key)
+	if field == nil || *field == "" {
+		return "", false
+	}
+	return *field, true
+}
+
+// SetSetting stores a general setting, checking the verbosity level
+func (c *Config) SetSetting(key, value string) error {
+	field := c.setting(key)
+	if field == nil {
+		return fmt.Errorf("unknown setting: %s (valid: %s)", key, strings.Join(Settings, ", "))
+	}
+	if key == "verbosity" && !slices.Contains(Verbosities, value) {
+		return fmt.Errorf("invalid verbosity: %s (valid: %s)", value, strings.Join(Verbosities, ", "))
+	}
+	*field = value
+	return nil
+}
+
+// UnsetSetting clears a general setting, reporting whether it was set
+func (c *Config) UnsetSetting(key string) bool {
+	field := c.setting(key)
+	if field == nil || *field == "" {
+		return false
+	}
+	*field = ""
+	return true
+}
+
+// ExpandHome replaces a leading ~ in a configured path with the home directory
+func ExpandHome(path string) (string, error) {
+	if path != "~" && !strings.HasPrefix(path, "~/") {
+		return path, nil
+	}
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return "", fmt.Errorf("expanding %s: %w", path, err)
+	}
+	return filepath.Join(home, strings.TrimPrefix(path, "~")), nil
+}
+
+// SplitKey splits a "command.flag" key (e.g., "extract.quality") into command path and flag name
+func SplitKey(key string) (string, string, error) {
+	i := strings.LastIndex(key, ".")
+	if i <= 0 || i == len(key)-1 {
+		return "", "", fmt.Errorf("invalid key: %s (use command.flag, e.g., extract.quality)", key)
+	}
+	return key[:i], key[i+1:], nil
+}
+
+// Get returns the default value configured for a command flag
+func (c *Config) Get(command, flag string) (string, bool) {
+	value, ok := c.Defaults[command][flag]
+	return value, ok
+}
+
+// Set stores a default value for a command flag
+func (c *Config) Set(command, flag, value string) {
+	if c.Defaults == nil {
+		c.Defaults = make(map[string]map[string]string)
+	}
+	if c.Defaults[command] == nil {
+		c.Defaults[command] = make(map[string]string)
+	}
+	c.Defaults[command][flag] = value
+}
+
+// Unset removes a default value for a command flag, reporting whether it existed
+func (c *Config) Unset(command, flag string) bool {
+	if _, ok := c.Defaults[command][flag]; !ok {
+		return false
+	}
+	delete(c.Defaults[command], flag)
+	if len(c.Defaults[command]) == 0 {
+		delete(c.Defaults, command)
+	}
+	return true
+}
+
+// Keys returns all configured keys in sorted order: general settings, then "command.flag" defaults
+func (c *Config) Keys() []string {
+	keys := make([]string, 0)
+	for _, key := range Settings {
+		if _, ok := c.GetSetting(key); ok {
+			keys = append(keys, key)
+		}
+	}
+	flagKeys := make([]string, 0)
+	for command, flags := range c.Defaults {
+		for flag := range flags {
+			flagKeys = append(flagKeys, command+"."+flag)
+		}
+	}
+	sort.Strings(flagKeys)
+	return append(keys, flagKeys...)
+}
@@ -0,0 +1,116 @@
+package config
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"sort"
+
+	"gopkg.in/yaml.v3"
+)
+
+// presetNameRegex restricts preset names to characters that are safe in file names and shells
+var presetNameRegex = regexp.MustCompile(`^[A-Za-z0-9][A-Za-z0-9._-]{0,63}$`)
+
+// Presets holds user-defined named presets loaded from presets.yaml
+type Presets struct {
+	// Presets maps a preset name (e.g., "web-720p") to convert flag values (e.g., "resolution": "1280x720")
+	Presets map[string]map[string]string `yaml:"presets,omitempty"`
+}
+
+// PresetsPath returns the location of the presets file
+func PresetsPath() (string, error) {
+	dir, err := Dir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "presets.yaml"), nil
+}
+
+// ValidatePresetName checks that a preset name is usable
+func ValidatePresetName(name string) error {
+	if !presetNameRegex.MatchString(name) {
+		return fmt.Errorf("invalid preset name: %s (use letters, digits, '.', '_' and '-')", name)
+	}
+	return nil
+}
+
+// LoadPresets reads the presets file, returning no presets when it does not exist
+func LoadPresets() (*Presets, error) {
+	path, err := PresetsPath()
+	if err != nil {
+		return nil, err
+	}
+
+	presets := &Presets{}
+	data, err := os.ReadFile(path)
+	if os.IsNotExist(err) {
+		return presets, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading presets file %s: %w", path, err)
+	}
+
+	if err := yaml.Unmarshal(data, presets); err != nil {
+		return nil, fmt.Errorf("parsing presets file %s: %w", path, err)
+	}
+
+	return presets, nil
+}
+
+// Save writes the presets file, creating the config directory if needed
+func (p *Presets) Save() error {
+	path, err := PresetsPath()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return fmt.Errorf("creating config directory: %w", err)
+	}
+
+	data, err := yaml.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("encoding presets: %w", err)
+	}
+
+	if err := os.WriteFile(path, data, 0o644); err != nil {
+		return fmt.Errorf("writing presets file %s: %w", path, err)
+	}
+
+	return nil
+}
+
+// Get returns the flag values of a preset
+func (p *Presets) Get(name string) (map[string]string, bool) {
+	flags, ok := p.Presets[name]
+	return flags, ok
+}
+
+// Set stores a preset, replacing any preset with the same name
+func (p *Presets) Set(name string, flags map[string]string) {
+	if p.Presets == nil {
+		p.Presets = make(map[string]map[string]string)
+	}
+	p.Presets[name] = flags
+}
+
+// Delete removes a preset, reporting whether it existed
+func (p *Presets) Delete(name string) bool {
+	if _, ok := p.Presets[name]; !ok {
+		return false
+	}
+	delete(p.Presets, name)
+	return true
+}
+
+// Names returns the preset names in sorted order
+func (p *Presets) Names() []string {
+	names := make([]string, 0, len(p.Presets))
+	for name := range p.Presets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -0,0 +1,133 @@
+// Package logging records jobs and the FFmpeg processes they run to a log file (--log-file),
+// so conversions that ran unattended can be diagnosed later. Records are JSON lines written
+// with log/slog at or above the --log-level. Without a log file nothing is recorded, except
+// that debug records are printed to stderr.
+package logging
+
+import (
+	"fmt"
+	"log/slog"
+	"os"
+	"strings"
+	"sync"
+)
+
+// tailSize is how much of the end of FFmpeg's stderr is kept for the log
+const tailSize = 4096
+
+// Levels are the accepted --log-level names, from most to least output
+var Levels = []string{"debug", "info", "warn", "error"}
+
+var (
+	logger  = slog.New(slog.DiscardHandler)
+	level   = new(slog.LevelVar)
+	enabled bool
+)
+
+// ParseLevel converts a --log-level name to a slog level
+func ParseLevel(name string) (slog.Level, error) {
+	switch strings.ToLower(name) {
+	case "debug":
+		return slog.LevelDebug, nil
+	case "info":
+		return slog.LevelInfo, nil
+	case "warn":
+		return slog.LevelWarn, nil
+	case "error":
+		return slog.LevelError, nil
+	}
+	return 0, fmt.Errorf("invalid log level: %s (valid: %s)", name, strings.Join(Levels, ", "))
+}
+
+// SetLevel sets the lowest level recorded. Without a log file, debug records go to stderr.
+func SetLevel(l slog.Level) {
+	level.Set(l)
+	if !enabled && l <= slog.LevelDebug {
+		logger = slog.New(slog.NewTextHandler(os.Stderr, &slog.HandlerOptions{Level: level}))
+	}
+}
+
+// Debug reports whether debug records are recorded
+func Debug() bool {
+	return level.Level() <= slog.LevelDebug
+}
+
+// Open appends log records to the file at path, creating it if needed
+func Open(path string) error {
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("opening log file: %w", err)
+	}
+
+	logger = slog.New(slog.NewJSONHandler(file, &slog.HandlerOptions{Level: level}))
+	enabled = true
+	return nil
+}
+
+// Enabled reports whether records are written anywhere: to a log file, or to stderr at debug level
+func Enabled() bool {
+	return enabled || Debug()
+}
+
+// Logger returns the logger records are written with
+func Logger() *slog.Logger {
+	return logger
+}
+
+// Tail keeps the end of the output written to it
+type Tail struct {
+	mu   sync.Mutex
+	data []byte
+	cut  bool // Earlier output was dropped
+}
+
+// Write appends to the tail, dropping the oldest output beyond tailSize bytes
+func (t *Tail) Write(p []byte) (int, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	t.data = append(t.data, p...)
+	if len(t.data) > tailSize {
+		t.data = append(t.data[:0], t.data[len(t.data)-tailSize:]...)
+		t.cut = true
+	}
+	return len(p), nil
+}
+
+// String returns the kept output as lines, starting at the first complete line. The
+// carriage returns FFmpeg ends its stats line with are treated as line breaks.
+func (t *Tail) String() string {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	text := strings.ReplaceAll(string(t.data), "\r", "\n")
+	if t.cut {
+		if i := strings.IndexByte(text, '\n'); i >= 0 {
+			text = text[i+1:]
+		}
+	}
+
+	// Only the last of the repeated stats lines is kept
+	lines := strings.Split(text, "\n")
+	kept := lines[:0]
+	lastStats := -1
+	for _, line := range lines {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		if isStatsLine(line) {
+			if lastStats >= 0 {
+				kept = append(kept[:lastStats], kept[lastStats+1:]...)
+			}
+			lastStats = len(kept)
+		}
+		kept = append(kept, line)
+	}
+	return strings.Join(kept, "\n")
+}
+
+// isStatsLine reports whether a line is FFmpeg's periodic stats line
+func isStatsLine(line string) bool {
+	return strings.HasPrefix(line, "frame=") || strings.HasPrefix(line, "size=")
+}
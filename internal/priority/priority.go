@@ -0,0 +1,6 @@
+// Package priority lowers the scheduling priority of the transcoder process. FFmpeg and
+// FFprobe are started as child processes and inherit the lowered priority.
+package priority
+
+// niceness is the Unix nice value used for background work (0 is normal, 19 the lowest)
+const niceness = 10
@@ -0,0 +1,85 @@
+package priority
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"syscall"
+)
+
+// I/O priority constants from linux/ioprio.h
+const (
+	ioprioWhoProcess = 1
+	ioprioClassIdle  = 3
+	ioprioClassShift = 13
+)
+
+// Lower gives the process a nice value of 10 and idle I/O priority. Linux keeps both per
+// thread, so every existing thread is changed; threads started later inherit the values
+// from the thread that creates them.
+func Lower() error {
+	done := make(map[int]bool)
+	for {
+		tids, err := threadIDs()
+		if err != nil {
+			return err
+		}
+
+		changed := false
+		for _, tid := range tids {
+			if done[tid] {
+				continue
+			}
+			if err := lowerThread(tid); err != nil {
+				return err
+			}
+			done[tid] = true
+			changed = true
+		}
+
+		// Repeat until a pass finds no thread started while the others were changed
+		if !changed {
+			return nil
+		}
+	}
+}
+
+// threadIDs lists the threads of the current process
+func threadIDs() ([]int, error) {
+	entries, err := os.ReadDir("/proc/self/task")
+	if err != nil {
+		return nil, fmt.Errorf("listing threads: %w", err)
+	}
+
+	tids := make([]int, 0, len(entries))
+	for _, entry := range entries {
+		if tid, err := strconv.Atoi(entry.Name()); err == nil {
+			tids = append(tids, tid)
+		}
+	}
+	return tids, nil
+}
+
+// lowerThread lowers the CPU and I/O priority of one thread
+func lowerThread(tid int) error {
+	// The raw value is 20 - nice; a thread that is already nicer is left alone
+	current, err := syscall.Getpriority(syscall.PRIO_PROCESS, tid)
+	if err == syscall.ESRCH {
+		return nil // The thread has exited
+	}
+	if err != nil {
+		return fmt.Errorf("reading CPU priority: %w", err)
+	}
+	if 20-current < niceness {
+		if err := syscall.Setpriority(syscall.PRIO_PROCESS, tid, niceness); err != nil && err != syscall.ESRCH {
+			return fmt.Errorf("lowering CPU priority: %w", err)
+		}
+	}
+
+	_, _, errno := syscall.Syscall(syscall.SYS_IOPRIO_SET, ioprioWhoProcess, uintptr(tid),
+		ioprioClassIdle<<ioprioClassShift)
+	if errno != 0 && errno != syscall.ESRCH {
+		return fmt.Errorf("lowering I/O priority: %w", errno)
+	}
+	return nil
+}
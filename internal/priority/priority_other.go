@@ -0,0 +1,10 @@
+//go:build !unix && !windows
+
+package priority
+
+import "errors"
+
+// Lower is not supported on this platform
+func Lower() error {
+	return errors.New("lowering the process priority is not supported on this platform")
+}
@@ -0,0 +1,23 @@
+//go:build unix && !linux
+
+package priority
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// Lower gives the process a nice value of 10, unless it is already running nicer
+func Lower() error {
+	current, err := syscall.Getpriority(syscall.PRIO_PROCESS, 0)
+	if err != nil {
+		return fmt.Errorf("reading CPU priority: %w", err)
+	}
+	if current >= niceness {
+		return nil
+	}
+	if err := syscall.Setpriority(syscall.PRIO_PROCESS, 0, niceness); err != nil {
+		return fmt.Errorf("lowering CPU priority: %w", err)
+	}
+	return nil
+}
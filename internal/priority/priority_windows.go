@@ -0,0 +1,23 @@
+package priority
+
+import (
+	"fmt"
+	"syscall"
+)
+
+// belowNormalPriorityClass is BELOW_NORMAL_PRIORITY_CLASS from the Windows API
+const belowNormalPriorityClass = 0x00004000
+
+var procSetPriorityClass = syscall.NewLazyDLL("kernel32.dll").NewProc("SetPriorityClass")
+
+// Lower moves the process to the below normal priority class, which child processes inherit
+func Lower() error {
+	process, err := syscall.GetCurrentProcess()
+	if err != nil {
+		return fmt.Errorf("lowering priority: %w", err)
+	}
+	if ok, _, err := procSetPriorityClass.Call(uintptr(process), belowNormalPriorityClass); ok == 0 {
+		return fmt.Errorf("lowering priority: %w", err)
+	}
+	return nil
+}
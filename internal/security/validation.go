@@ -6,13 +6,33 @@ import (
 	"regexp"
 	"strconv"
 	"strings"
+	"unicode/utf8"
 )
 
+// ValidationError reports a user input the security policy rejects
+type ValidationError struct {
+	Err error
+}
+
+func (e *ValidationError) Error() string {
+	return e.Err.Error()
+}
+
+func (e *ValidationError) Unwrap() error {
+	return e.Err
+}
+
+// invalidf formats a ValidationError
+func invalidf(format string, args ...any) error {
+	return &ValidationError{Err: fmt.Errorf(format, args...)}
+}
+
 // SecurityPolicy defines validation rules for user inputs
 type SecurityPolicy struct {
 	AllowedVideoCodecs map[string]bool
 	AllowedAudioCodecs map[string]bool
 	AllowedFormats     map[string]bool
+	AllowedExtraArgs   map[string]int // FFmpeg options accepted as extra arguments without an explicit opt-in, with the number of values each takes
 	MaxPathLength      int
 	MaxParameterLength int
 }
@@ -25,6 +45,10 @@ func NewDefaultSecurityPolicy() *SecurityPolicy {
 			"libx265":    true,
 			"libvpx-vp9": true,
 			"libvpx":     true,
+			"mpeg2video": true,
+			"libtheora":  true,
+			"ffv1":       true,
+			"utvideo":    true,
 			"copy":       true,
 		},
 		AllowedAudioCodecs: map[string]bool{
@@ -34,6 +58,12 @@ func NewDefaultSecurityPolicy() *SecurityPolicy {
 			"libvorbis":  true,
 			"flac":       true,
 			"pcm_s16le":  true,
+			"pcm_s24le":  true,
+			"pcm_s32le":  true,
+			"pcm_f32le":  true,
+			"alac":       true,
+			"ac3":        true,
+			"eac3":       true,
 			"copy":       true,
 		},
 		AllowedFormats: map[string]bool{
@@ -42,12 +72,55 @@ func NewDefaultSecurityPolicy() *SecurityPolicy {
 			"mkv":  true,
 			"webm": true,
 			"mov":  true,
+			"ts":   true,
+			"flv":  true,
+			"3gp":  true,
+			"ogv":  true,
+			"mxf":  true,
 			"mp3":  true,
 			"wav":  true,
 			"aac":  true,
 			"flac": true,
 			"ogg":  true,
+			"opus": true,
+			"adts": true,
 			"m4a":  true,
+			"ac3":  true,
+			"eac3": true,
+			"dts":  true,
+		},
+		AllowedExtraArgs: map[string]int{
+			"-crf":             1,
+			"-qp":              1,
+			"-tune":            1,
+			"-profile":         1,
+			"-level":           1,
+			"-g":               1,
+			"-keyint_min":      1,
+			"-sc_threshold":    1,
+			"-bf":              1,
+			"-refs":            1,
+			"-maxrate":         1,
+			"-minrate":         1,
+			"-bufsize":         1,
+			"-pix_fmt":         1,
+			"-x264-params":     1,
+			"-x265-params":     1,
+			"-row-mt":          1,
+			"-tile-columns":    1,
+			"-deadline":        1,
+			"-cpu-used":        1,
+			"-aq-mode":         1,
+			"-slices":          1,
+			"-slicecrc":        1,
+			"-color_primaries": 1,
+			"-color_trc":       1,
+			"-colorspace":      1,
+			"-color_range":     1,
+			"-ac":              1,
+			"-ar":              1,
+			"-metadata":        1,
+			"-shortest":        0,
 		},
 		MaxPathLength:      255,
 		MaxParameterLength: 50,
@@ -57,12 +130,12 @@ func NewDefaultSecurityPolicy() *SecurityPolicy {
 // ValidateCodec validates video and audio codec parameters
 func (p *SecurityPolicy) ValidateCodec(codec, codecType string) error {
 	if len(codec) > p.MaxParameterLength {
-		return fmt.Errorf("codec parameter too long (max %d characters)", p.MaxParameterLength)
+		return invalidf("codec parameter too long (max %d characters)", p.MaxParameterLength)
 	}
 
 	// Check for dangerous characters that could enable command injection
 	if containsDangerousChars(codec) {
-		return fmt.Errorf("codec contains invalid characters: %s", codec)
+		return invalidf("codec contains invalid characters: %s", codec)
 	}
 
 	var allowedCodecs map[string]bool
@@ -72,11 +145,11 @@ func (p *SecurityPolicy) ValidateCodec(codec, codecType string) error {
 	case "audio":
 		allowedCodecs = p.AllowedAudioCodecs
 	default:
-		return fmt.Errorf("unknown codec type: %s", codecType)
+		return invalidf("unknown codec type: %s", codecType)
 	}
 
 	if !allowedCodecs[codec] {
-		return fmt.Errorf("codec not allowed: %s", codec)
+		return invalidf("codec not allowed: %s", codec)
 	}
 
 	return nil
@@ -89,18 +162,18 @@ func (p *SecurityPolicy) ValidateBitrate(bitrate string) error {
 	}
 
 	if len(bitrate) > p.MaxParameterLength {
-		return fmt.Errorf("bitrate parameter too long (max %d characters)", p.MaxParameterLength)
+		return invalidf("bitrate parameter too long (max %d characters)", p.MaxParameterLength)
 	}
 
 	// Check for dangerous characters
 	if containsDangerousChars(bitrate) {
-		return fmt.Errorf("bitrate contains invalid characters: %s", bitrate)
+		return invalidf("bitrate contains invalid characters: %s", bitrate)
 	}
 
 	// Validate bitrate format (e.g., "2M", "1500k", "192k")
 	bitrateRegex := regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[kKmM]?$`)
 	if !bitrateRegex.MatchString(bitrate) {
-		return fmt.Errorf("invalid bitrate format: %s (use format like 2M, 1500k, 192k)", bitrate)
+		return invalidf("invalid bitrate format: %s (use format like 2M, 1500k, 192k)", bitrate)
 	}
 
 	return nil
@@ -113,18 +186,18 @@ func (p *SecurityPolicy) ValidateResolution(resolution string) error {
 	}
 
 	if len(resolution) > p.MaxParameterLength {
-		return fmt.Errorf("resolution parameter too long (max %d characters)", p.MaxParameterLength)
+		return invalidf("resolution parameter too long (max %d characters)", p.MaxParameterLength)
 	}
 
 	// Check for dangerous characters
 	if containsDangerousChars(resolution) {
-		return fmt.Errorf("resolution contains invalid characters: %s", resolution)
+		return invalidf("resolution contains invalid characters: %s", resolution)
 	}
 
 	// Validate resolution format (e.g., "1920x1080", "1280x720")
 	resolutionRegex := regexp.MustCompile(`^[0-9]+x[0-9]+$`)
 	if !resolutionRegex.MatchString(resolution) {
-		return fmt.Errorf("invalid resolution format: %s (use format like 1920x1080)", resolution)
+		return invalidf("invalid resolution format: %s (use format like 1920x1080)", resolution)
 	}
 
 	// Parse and validate reasonable resolution limits
@@ -133,11 +206,11 @@ func (p *SecurityPolicy) ValidateResolution(resolution string) error {
 	height, _ := strconv.Atoi(parts[1])
 
 	if width > 7680 || height > 4320 { // 8K max
-		return fmt.Errorf("resolution too large: %s (max 7680x4320)", resolution)
+		return invalidf("resolution too large: %s (max 7680x4320)", resolution)
 	}
 
 	if width < 1 || height < 1 {
-		return fmt.Errorf("invalid resolution: %s (minimum 1x1)", resolution)
+		return invalidf("invalid resolution: %s (minimum 1x1)", resolution)
 	}
 
 	return nil
@@ -150,37 +223,279 @@ func (p *SecurityPolicy) ValidateFramerate(framerate string) error {
 	}
 
 	if len(framerate) > p.MaxParameterLength {
-		return fmt.Errorf("framerate parameter too long (max %d characters)", p.MaxParameterLength)
+		return invalidf("framerate parameter too long (max %d characters)", p.MaxParameterLength)
 	}
 
 	// Check for dangerous characters
 	if containsDangerousChars(framerate) {
-		return fmt.Errorf("framerate contains invalid characters: %s", framerate)
+		return invalidf("framerate contains invalid characters: %s", framerate)
 	}
 
 	// Validate framerate format (e.g., "30", "24", "60", "23.976")
 	framerateRegex := regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
 	if !framerateRegex.MatchString(framerate) {
-		return fmt.Errorf("invalid framerate format: %s (use format like 30, 24, 60)", framerate)
+		return invalidf("invalid framerate format: %s (use format like 30, 24, 60)", framerate)
 	}
 
 	// Parse and validate reasonable framerate limits
 	fps, err := strconv.ParseFloat(framerate, 64)
 	if err != nil {
-		return fmt.Errorf("invalid framerate: %s", framerate)
+		return invalidf("invalid framerate: %s", framerate)
 	}
 
 	if fps > 120 || fps <= 0 {
-		return fmt.Errorf("framerate out of range: %s (must be between 0 and 120)", framerate)
+		return invalidf("framerate out of range: %s (must be between 0 and 120)", framerate)
+	}
+
+	return nil
+}
+
+// ValidateVolume validates volume adjustment parameters
+func (p *SecurityPolicy) ValidateVolume(volume string) error {
+	if volume == "" {
+		return nil // Empty volume is allowed
+	}
+
+	if len(volume) > p.MaxParameterLength {
+		return invalidf("volume parameter too long (max %d characters)", p.MaxParameterLength)
+	}
+
+	// Check for dangerous characters
+	if containsDangerousChars(volume) {
+		return invalidf("volume contains invalid characters: %s", volume)
+	}
+
+	// Validate decibel format (e.g., "+3dB", "-6dB")
+	decibelRegex := regexp.MustCompile(`^[+-]?[0-9]+(\.[0-9]+)?[dD][bB]$`)
+	if decibelRegex.MatchString(volume) {
+		db, err := strconv.ParseFloat(volume[:len(volume)-2], 64)
+		if err != nil {
+			return invalidf("invalid volume: %s", volume)
+		}
+		if db < -60 || db > 60 {
+			return invalidf("volume out of range: %s (must be between -60dB and +60dB)", volume)
+		}
+		return nil
+	}
+
+	// Validate multiplier format (e.g., "1.5", "0.5")
+	multiplierRegex := regexp.MustCompile(`^[0-9]+(\.[0-9]+)?$`)
+	if !multiplierRegex.MatchString(volume) {
+		return invalidf("invalid volume format: %s (use a multiplier like 1.5 or decibels like +3dB)", volume)
+	}
+
+	multiplier, err := strconv.ParseFloat(volume, 64)
+	if err != nil {
+		return invalidf("invalid volume: %s", volume)
+	}
+
+	if multiplier <= 0 || multiplier > 10 {
+		return invalidf("volume out of range: %s (multiplier must be between 0 and 10)", volume)
+	}
+
+	return nil
+}
+
+// ValidateSpeedFactor validates playback speed-up factors (e.g., "60x", "120")
+func (p *SecurityPolicy) ValidateSpeedFactor(speed string) error {
+	if speed == "" {
+		return nil // Empty speed is allowed
+	}
+
+	if len(speed) > p.MaxParameterLength {
+		return invalidf("speed parameter too long (max %d characters)", p.MaxParameterLength)
+	}
+
+	// Check for dangerous characters
+	if containsDangerousChars(speed) {
+		return invalidf("speed contains invalid characters: %s", speed)
+	}
+
+	// Validate speed format (e.g., "60x", "120", "2.5x")
+	speedRegex := regexp.MustCompile(`^[0-9]+(\.[0-9]+)?[xX]?$`)
+	if !speedRegex.MatchString(speed) {
+		return invalidf("invalid speed format: %s (use format like 60x, 120x)", speed)
+	}
+
+	factor, err := strconv.ParseFloat(strings.TrimRight(speed, "xX"), 64)
+	if err != nil {
+		return invalidf("invalid speed: %s", speed)
+	}
+
+	if factor <= 1 || factor > 10000 {
+		return invalidf("speed out of range: %s (must be greater than 1x and at most 10000x)", speed)
+	}
+
+	return nil
+}
+
+// ValidateStreamNumber validates 1-based stream selection parameters
+func (p *SecurityPolicy) ValidateStreamNumber(stream string) error {
+	if stream == "" {
+		return nil // Empty stream selection is allowed
+	}
+
+	if len(stream) > p.MaxParameterLength {
+		return invalidf("stream parameter too long (max %d characters)", p.MaxParameterLength)
+	}
+
+	// Check for dangerous characters
+	if containsDangerousChars(stream) {
+		return invalidf("stream contains invalid characters: %s", stream)
+	}
+
+	number, err := strconv.Atoi(stream)
+	if err != nil {
+		return invalidf("invalid stream number: %s (use a number like 1, 2, 3)", stream)
+	}
+
+	if number < 1 || number > 99 {
+		return invalidf("stream number out of range: %s (must be between 1 and 99)", stream)
 	}
 
 	return nil
 }
 
+// ValidateThreads validates the FFmpeg thread count (0 lets FFmpeg decide)
+func (p *SecurityPolicy) ValidateThreads(threads int) error {
+	if threads < 0 || threads > 256 {
+		return invalidf("thread count out of range: %d (must be between 0 and 256)", threads)
+	}
+	return nil
+}
+
+// ValidateLanguageCode validates ISO 639 language code parameters
+func (p *SecurityPolicy) ValidateLanguageCode(language string) error {
+	if language == "" {
+		return nil // Empty language is allowed
+	}
+
+	// Check for dangerous characters
+	if containsDangerousChars(language) {
+		return invalidf("language contains invalid characters: %s", language)
+	}
+
+	// Validate language format (e.g., "eng", "jpn", "de")
+	languageRegex := regexp.MustCompile(`^[a-zA-Z]{2,3}$`)
+	if !languageRegex.MatchString(language) {
+		return invalidf("invalid language code: %s (use an ISO 639 code like eng, jpn, de)", language)
+	}
+
+	return nil
+}
+
+// SanitizeOverlayText validates text drawn onto the video and escapes it for the drawtext
+// filter. Only letters, digits, spaces and common punctuation are accepted: quotes,
+// backslashes, % (drawtext expansion) and filter graph separators are rejected.
+func (p *SecurityPolicy) SanitizeOverlayText(text string) (string, error) {
+	if strings.TrimSpace(text) == "" {
+		return "", invalidf("overlay text is empty")
+	}
+
+	if utf8.RuneCountInString(text) > 100 {
+		return "", invalidf("overlay text too long (max 100 characters)")
+	}
+
+	overlayTextRegex := regexp.MustCompile(`^[\p{L}\p{M}\p{N} .,:!?@#+\-_/©®™]+$`)
+	if !overlayTextRegex.MatchString(text) {
+		return "", invalidf("overlay text contains invalid characters: %s (use letters, digits, spaces and . , : ! ? @ # + - _ / © ® ™)", text)
+	}
+
+	// Colons separate drawtext options; the caller quotes the text, which protects commas
+	return strings.ReplaceAll(text, ":", `\:`), nil
+}
+
+// ValidateExtraArgs validates extra FFmpeg arguments against the allowlist. Options may carry
+// a stream specifier (e.g., -profile:v) and are followed by exactly as many values as they
+// take; anything else, such as a stray output path, is rejected. Values must not contain shell
+// metacharacters.
+func (p *SecurityPolicy) ValidateExtraArgs(args []string) error {
+	for _, arg := range args {
+		if len(arg) > p.MaxPathLength {
+			return invalidf("ffmpeg argument too long (max %d characters)", p.MaxPathLength)
+		}
+
+		if containsDangerousChars(arg) {
+			return invalidf("ffmpeg argument contains invalid characters: %s", arg)
+		}
+	}
+
+	for i := 0; i < len(args); i++ {
+		arg := args[i]
+		if !strings.HasPrefix(arg, "-") {
+			return invalidf("unexpected ffmpeg argument: %s (only options and their values are allowed)", arg)
+		}
+
+		option, _, _ := strings.Cut(arg, ":")
+		values, ok := p.AllowedExtraArgs[option]
+		if !ok {
+			return invalidf("ffmpeg option not allowed: %s", arg)
+		}
+
+		for range values {
+			i++
+			if i == len(args) {
+				return invalidf("ffmpeg option %s needs a value", arg)
+			}
+			if err := validateExtraArgValue(option, args[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// encoderFileParams are x264 and x265 parameters that read or write files
+var encoderFileParams = map[string]bool{
+	"stats":         true,
+	"dump-yuv":      true,
+	"qpfile":        true,
+	"cqmfile":       true,
+	"csv":           true,
+	"recon":         true,
+	"analysis-save": true,
+	"analysis-load": true,
+	"scaling-list":  true,
+	"lambda-file":   true,
+	"zonefile":      true,
+	"dhdr10-info":   true,
+}
+
+// validateExtraArgValue validates the value of an allowlisted extra FFmpeg option
+func validateExtraArgValue(option, value string) error {
+	// Negative numbers are values, not options
+	if value == "" || (strings.HasPrefix(value, "-") && !isNumber(value)) {
+		return invalidf("ffmpeg option %s needs a value, got %q", option, value)
+	}
+
+	switch option {
+	case "-metadata":
+		if !strings.Contains(value, "=") {
+			return invalidf("invalid -metadata value: %s (use key=value)", value)
+		}
+	case "-x264-params", "-x265-params":
+		for _, param := range strings.Split(value, ":") {
+			key, _, _ := strings.Cut(param, "=")
+			if encoderFileParams[strings.ReplaceAll(key, "_", "-")] {
+				return invalidf("%s parameter not allowed: %s (it reads or writes files)", option, key)
+			}
+		}
+	}
+
+	return nil
+}
+
+// isNumber reports whether s parses as a number
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(s, 64)
+	return err == nil
+}
+
 // ValidateFilePath validates file paths to prevent directory traversal
 func (p *SecurityPolicy) ValidateFilePath(path string) error {
 	if len(path) > p.MaxPathLength {
-		return fmt.Errorf("file path too long (max %d characters)", p.MaxPathLength)
+		return invalidf("file path too long (max %d characters)", p.MaxPathLength)
 	}
 
 	// Clean the path and check for directory traversal attempts
@@ -188,12 +503,12 @@ func (p *SecurityPolicy) ValidateFilePath(path string) error {
 
 	// Check for directory traversal patterns
 	if strings.Contains(cleanPath, "..") {
-		return fmt.Errorf("directory traversal detected in path: %s", path)
+		return invalidf("directory traversal detected in path: %s", path)
 	}
 
 	// Check for dangerous characters in path
 	if containsPathDangerousChars(path) {
-		return fmt.Errorf("path contains invalid characters: %s", path)
+		return invalidf("path contains invalid characters: %s", path)
 	}
 
 	return nil
@@ -206,8 +521,13 @@ func (p *SecurityPolicy) ValidateFileFormat(path string) error {
 		ext = ext[1:] // Remove the dot
 	}
 
-	if !p.AllowedFormats[ext] {
-		return fmt.Errorf("file format not allowed: %s", ext)
+	return p.ValidateFormat(ext)
+}
+
+// ValidateFormat validates a format name, such as the container of piped output
+func (p *SecurityPolicy) ValidateFormat(format string) error {
+	if !p.AllowedFormats[strings.ToLower(format)] {
+		return invalidf("file format not allowed: %s", format)
 	}
 
 	return nil
@@ -0,0 +1,40 @@
+package security
+
+import "testing"
+
+func TestValidateExtraArgs(t *testing.T) {
+	tests := []struct {
+		name    string
+		args    []string
+		wantErr bool
+	}{
+		{name: "options with values", args: []string{"-crf", "20", "-tune", "film"}},
+		{name: "stream specifier", args: []string{"-profile:v", "high", "-level", "4.1"}},
+		{name: "negative value", args: []string{"-sc_threshold", "-1"}},
+		{name: "flag", args: []string{"-shortest", "-crf", "23"}},
+		{name: "metadata", args: []string{"-metadata", "title=My Movie"}},
+		{name: "encoder params", args: []string{"-x264-params", "keyint=60:min-keyint=30"}},
+		{name: "none", args: nil},
+
+		{name: "output after flag", args: []string{"-shortest", "/any/path.mp4"}, wantErr: true},
+		{name: "output after value", args: []string{"-crf", "20", "second.mkv"}, wantErr: true},
+		{name: "positional only", args: []string{"out.mp4"}, wantErr: true},
+		{name: "missing value", args: []string{"-crf"}, wantErr: true},
+		{name: "option as value", args: []string{"-tune", "-shortest"}, wantErr: true},
+		{name: "option not allowed", args: []string{"-i", "other.mp4"}, wantErr: true},
+		{name: "metadata without key", args: []string{"-metadata", "My Movie"}, wantErr: true},
+		{name: "x264 stats file", args: []string{"-x264-params", "pass=1:stats=/tmp/x.log"}, wantErr: true},
+		{name: "x265 csv file", args: []string{"-x265-params", "csv=/tmp/x.csv"}, wantErr: true},
+		{name: "shell metacharacters", args: []string{"-metadata", "title=$(id)"}, wantErr: true},
+	}
+
+	policy := NewDefaultSecurityPolicy()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.ValidateExtraArgs(tt.args)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateExtraArgs(%q) error = %v, wantErr %v", tt.args, err, tt.wantErr)
+			}
+		})
+	}
+}
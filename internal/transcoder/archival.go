@@ -0,0 +1,71 @@
+package transcoder
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// Archival output: mathematically lossless video and audio in Matroska, as used for
+// digitization and preservation
+const (
+	ArchivalFormat     = "mkv"
+	ArchivalVideoCodec = "ffv1"
+	ArchivalAudioCodec = "flac"
+)
+
+// losslessVideoEncoders lists the lossless video encoders and the options they are always
+// given. FFV1 is written as version 3 with every frame a keyframe, split into slices that each
+// carry a CRC so damage can be found and stays confined to a slice.
+var losslessVideoEncoders = map[string][]string{
+	"ffv1":    {"-level", "3", "-g", "1", "-slices", "16", "-slicecrc", "1"},
+	"utvideo": {},
+}
+
+// losslessAudioEncoders are the lossless audio encoders; bitrates do not apply to them
+var losslessAudioEncoders = []string{"flac", "alac", "pcm_s16le", "pcm_s24le", "pcm_s32le", "pcm_f32le"}
+
+// IsLosslessVideoEncoder reports whether an encoder keeps the video unchanged; bitrates do not
+// apply to it
+func IsLosslessVideoEncoder(encoder string) bool {
+	_, ok := losslessVideoEncoders[encoder]
+	return ok
+}
+
+// validateArchival checks the options combined with --archival
+func validateArchival(customParams CustomParameters, outputFormat string) error {
+	if outputFormat != ArchivalFormat {
+		return fmt.Errorf("--archival writes MKV; use a .mkv output")
+	}
+	if customParams.Target != "" {
+		return fmt.Errorf("--archival cannot be combined with --target")
+	}
+	if customParams.VideoCodec != "" && !IsLosslessVideoEncoder(customParams.VideoCodec) {
+		return fmt.Errorf("--archival requires a lossless video codec (ffv1, utvideo), not %s", customParams.VideoCodec)
+	}
+	if customParams.AudioCodec != "" && !slices.Contains(losslessAudioEncoders, customParams.AudioCodec) {
+		return fmt.Errorf("--archival requires a lossless audio codec (%s), not %s",
+			strings.Join(losslessAudioEncoders, ", "), customParams.AudioCodec)
+	}
+	if customParams.VideoBitrate != "" || customParams.AudioBitrate != "" {
+		return fmt.Errorf("--archival is lossless and cannot be combined with bitrates")
+	}
+	return nil
+}
+
+// applyArchivalPreset selects FFV1 and FLAC where no codec was given
+func applyArchivalPreset(customParams CustomParameters, verbose bool) CustomParameters {
+	if customParams.VideoCodec == "" {
+		customParams.VideoCodec = ArchivalVideoCodec
+	}
+	if customParams.AudioCodec == "" && !customParams.NoAudio {
+		customParams.AudioCodec = ArchivalAudioCodec
+	}
+
+	if verbose {
+		color.Cyan("🗄️  Archival: lossless %s video and %s audio in MKV", customParams.VideoCodec, customParams.AudioCodec)
+	}
+	return customParams
+}
@@ -0,0 +1,169 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// AttachmentParams holds parameters for extracting the attachments of a media file
+type AttachmentParams struct {
+	InputFile string // Input file path
+	OutputDir string // Directory the attachments are written to
+	FontsOnly bool   // Extract only fonts, as needed by ASS subtitles
+	Overwrite bool   // Replace files that already exist in OutputDir
+	Verbose   bool   // Verbose output
+}
+
+// ExtractedAttachment is an attachment and the file it was written to
+type ExtractedAttachment struct {
+	analyzer.Attachment
+	OutputFile string
+}
+
+// fontExtensions are the extensions of font attachments, for files without a font MIME type
+var fontExtensions = map[string]bool{".ttf": true, ".otf": true, ".ttc": true, ".woff": true, ".woff2": true}
+
+// mimeExtensions name attachments that have no file name of their own
+var mimeExtensions = map[string]string{
+	"image/jpeg": ".jpg", "image/png": ".png", "image/webp": ".webp", "image/gif": ".gif",
+	"font/ttf": ".ttf", "font/otf": ".otf", "application/x-truetype-font": ".ttf",
+	"application/vnd.ms-opentype": ".otf", "application/x-font-ttf": ".ttf",
+	"text/plain": ".txt",
+}
+
+// IsFontAttachment reports whether an attachment is a font, by its MIME type or extension
+func IsFontAttachment(attachment analyzer.Attachment) bool {
+	mimeType := strings.ToLower(attachment.MimeType)
+	if strings.Contains(mimeType, "font") || strings.Contains(mimeType, "opentype") {
+		return true
+	}
+	return fontExtensions[strings.ToLower(filepath.Ext(attachment.Filename))]
+}
+
+// ListAttachments returns the files attached to a media file
+func ListAttachments(ctx context.Context, inputFile string) ([]analyzer.Attachment, error) {
+	if analyzer.IsStdinPath(inputFile) {
+		return nil, fmt.Errorf("attachments cannot be read from stdin input")
+	}
+	if err := securityPolicy.ValidateFilePath(inputFile); err != nil {
+		return nil, fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if err := validateInputFile(inputFile); err != nil {
+		return nil, err
+	}
+
+	info, err := analyzer.AnalyzeMedia(ctx, inputFile)
+	if err != nil {
+		return nil, fmt.Errorf("failed to analyze input: %w", err)
+	}
+	return info.Attachments, nil
+}
+
+// ExtractAttachments writes the attached files of the input (fonts, cover images) into the
+// output directory under their own names, in a single FFmpeg run. Names that are missing,
+// unsafe or taken by an earlier attachment are replaced.
+func ExtractAttachments(ctx context.Context, params AttachmentParams) ([]ExtractedAttachment, error) {
+	if err := securityPolicy.ValidateFilePath(params.OutputDir); err != nil {
+		return nil, fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	attachments, err := ListAttachments(ctx, params.InputFile)
+	if err != nil {
+		return nil, err
+	}
+	if params.FontsOnly {
+		fonts := attachments[:0]
+		for _, attachment := range attachments {
+			if IsFontAttachment(attachment) {
+				fonts = append(fonts, attachment)
+			}
+		}
+		if len(fonts) == 0 {
+			return nil, fmt.Errorf("input has no font attachments: %s", params.InputFile)
+		}
+		attachments = fonts
+	}
+	if len(attachments) == 0 {
+		return nil, fmt.Errorf("input has no attachments: %s", params.InputFile)
+	}
+
+	// Plan every output up front so nothing is written if a name is unusable
+	extracted := make([]ExtractedAttachment, 0, len(attachments))
+	used := make(map[string]bool)
+	for _, attachment := range attachments {
+		outputFile := filepath.Join(params.OutputDir, attachmentFileName(attachment, used))
+		if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+			return nil, fmt.Errorf("security validation failed for attachment %s: %w", attachment.Filename, err)
+		}
+		if _, err := os.Stat(outputFile); err == nil && !params.Overwrite {
+			return nil, fmt.Errorf("output file already exists: %s (use --force to overwrite)", outputFile)
+		}
+		extracted = append(extracted, ExtractedAttachment{Attachment: attachment, OutputFile: outputFile})
+	}
+
+	if err := os.MkdirAll(params.OutputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	cmd := buildAttachmentsCommand(ctx, params.InputFile, extracted)
+	if params.Verbose {
+		color.Green("✅ %d attachment(s) to extract", len(extracted))
+		fmt.Printf("   Command: %s\n", strings.Join(cmd.Args, " "))
+		fmt.Println()
+	}
+
+	run := startFFmpegRun(cmd)
+	output, err := cmd.CombinedOutput()
+	if err := run.finishWithOutput(output, err); err != nil {
+		return nil, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+
+	for i := range extracted {
+		stat, err := os.Stat(extracted[i].OutputFile)
+		if err != nil {
+			return nil, fmt.Errorf("attachment %d was not written: %s", extracted[i].Index, extracted[i].OutputFile)
+		}
+		extracted[i].Size = stat.Size()
+	}
+	return extracted, nil
+}
+
+// attachmentFileName is the name an attachment is written under: its own name without any
+// directories or characters the path checks refuse, or one made from its stream index and
+// MIME type. A name already in use gets a number added.
+func attachmentFileName(attachment analyzer.Attachment, used map[string]bool) string {
+	name := strings.TrimSpace(cueFileNameReplacer.Replace(filepath.Base(strings.ReplaceAll(attachment.Filename, `\`, "/"))))
+	for strings.Contains(name, "..") {
+		name = strings.ReplaceAll(name, "..", ".")
+	}
+	name = strings.Trim(name, " .")
+	if name == "" || name == "-" {
+		name = fmt.Sprintf("attachment%d%s", attachment.Index, mimeExtensions[strings.ToLower(attachment.MimeType)])
+	}
+
+	extension := filepath.Ext(name)
+	base := strings.TrimSuffix(name, extension)
+	for n := 2; used[strings.ToLower(name)]; n++ {
+		name = fmt.Sprintf("%s-%d%s", base, n, extension)
+	}
+	used[strings.ToLower(name)] = true
+	return name
+}
+
+// buildAttachmentsCommand dumps each attachment to its output file. FFmpeg needs an output to
+// open the input, so nothing is decoded into a null output.
+func buildAttachmentsCommand(ctx context.Context, inputFile string, attachments []ExtractedAttachment) *exec.Cmd {
+	args := []string{"-hide_banner", "-y"}
+	for _, attachment := range attachments {
+		args = append(args, fmt.Sprintf("-dump_attachment:%d", attachment.Index), attachment.OutputFile)
+	}
+	args = append(args, "-i", inputFile, "-t", "0", "-f", "null", "-")
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
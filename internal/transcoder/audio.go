@@ -0,0 +1,190 @@
+package transcoder
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// AudioFormats are the audio-only output formats; the codec is chosen from the format
+var AudioFormats = []string{"mp3", "m4a", "aac", "adts", "flac", "wav", "ogg", "opus", "ac3", "eac3", "dts"}
+
+// SampleFormats are the sample formats audio can be written in: 16, 24 and 32-bit integers
+// and 32-bit floating point
+var SampleFormats = []string{"s16", "s24", "s32", "flt"}
+
+// wavSampleCodecs are the WAV encoders of each sample format
+var wavSampleCodecs = map[string]string{
+	"s16": "pcm_s16le",
+	"s24": "pcm_s24le",
+	"s32": "pcm_s32le",
+	"flt": "pcm_f32le",
+}
+
+// CoverFormats are the audio formats that can embed cover art
+var CoverFormats = []string{"mp3", "m4a", "flac"}
+
+// IsAudioFormat reports whether format (an extension without the dot) is an audio-only format
+func IsAudioFormat(format string) bool {
+	return slices.Contains(AudioFormats, strings.ToLower(format))
+}
+
+// canCopyAudio reports whether the audio stream at position already has the codec the output
+// would be encoded to, and nothing asks for it to be changed
+func canCopyAudio(params AudioExtractionParams, encoder string, mediaInfo *analyzer.MediaInfo, position int) bool {
+	if params.Codec != "" || params.Bitrate != "" || params.SampleRate != "" || params.Channels != "" ||
+		params.SampleFormat != "" || params.ChannelMap != "" ||
+		params.Volume != "" || params.Start > 0 || params.End > 0 {
+		return false
+	}
+	if position >= len(mediaInfo.AudioStreams) {
+		return false
+	}
+	codec, ok := encoderCodecs[encoder]
+	return ok && mediaInfo.AudioStreams[position].Codec == codec
+}
+
+// sampleFormatArgs returns the options that make an encoder write samples in the given format.
+// PCM encoders are chosen by their format instead. FLAC and ALAC store 16 or 24-bit samples;
+// FFmpeg hands 24-bit samples to them in 32-bit ones. Lossy encoders have no sample format of
+// their own.
+func sampleFormatArgs(codec, sampleFormat string) ([]string, error) {
+	if sampleFormat == "" || codec == wavSampleCodecs[sampleFormat] {
+		return nil, nil
+	}
+
+	switch {
+	case codec == "flac" || codec == "alac":
+		planar := ""
+		if codec == "alac" {
+			planar = "p"
+		}
+		switch sampleFormat {
+		case "s16":
+			return []string{"-sample_fmt", "s16" + planar}, nil
+		case "s24":
+			return []string{"-sample_fmt", "s32" + planar, "-bits_per_raw_sample", "24"}, nil
+		}
+		return nil, fmt.Errorf("%s stores 16 or 24-bit samples (s16, s24), not %s", codec, sampleFormat)
+	case strings.HasPrefix(codec, "pcm_"):
+		return nil, fmt.Errorf("sample format %s does not match codec %s (leave out the codec)", sampleFormat, codec)
+	default:
+		return nil, fmt.Errorf("sample format only applies to lossless outputs (wav, flac, m4a with alac), not %s", codec)
+	}
+}
+
+// validateDTSPassthrough checks a .dts output: DTS cannot be encoded, so the output only takes
+// DTS audio as it is
+func validateDTSPassthrough(params AudioExtractionParams, codec string, mediaInfo *analyzer.MediaInfo, position int) error {
+	if codec != "copy" {
+		return fmt.Errorf("DTS cannot be encoded; .dts outputs copy DTS audio (use .ac3 or .eac3 to encode)")
+	}
+	if params.Volume != "" || params.SampleRate != "" || params.Channels != "" || params.SampleFormat != "" || params.ChannelMap != "" {
+		return fmt.Errorf("DTS audio is copied as it is; volume, sample rate, channels and sample format cannot be changed")
+	}
+	if position < len(mediaInfo.AudioStreams) && mediaInfo.AudioStreams[position].Codec != "dts" {
+		return fmt.Errorf("audio stream %d is %s, not DTS (use .ac3 or .eac3 to encode it)",
+			position+1, mediaInfo.AudioStreams[position].Codec)
+	}
+	return nil
+}
+
+// audioBitrate returns the bitrate an audio encoder is given for a quality preset. AC-3 and
+// E-AC-3 use the rates of TV and disc soundtracks, which are usually 5.1; lossless encoders
+// take none.
+func audioBitrate(codec, quality string) string {
+	if slices.Contains(losslessAudioEncoders, codec) {
+		return ""
+	}
+	if codec != "ac3" && codec != "eac3" {
+		return getQualityBitrate(quality)
+	}
+	switch strings.ToLower(quality) {
+	case "low":
+		return "192k"
+	case "high":
+		return "640k"
+	default:
+		return "448k"
+	}
+}
+
+// audioMetadataArgs keeps the tags of the input in an audio output and sets tags over them.
+// Ogg files carry their tags on the audio stream rather than the container, so they are read
+// from the stream of Ogg inputs and written to the stream of Ogg outputs. MP3 tags are written
+// as ID3v2.3, which more players and tag editors read than FFmpeg's default 2.4. With strip
+// nothing is kept and only tags are written.
+func audioMetadataArgs(format string, mediaInfo *analyzer.MediaInfo, position int, tags map[string]string, strip bool) []string {
+	source := "0"
+	if strip {
+		source = "-1"
+	} else if mediaInfo != nil && strings.Contains(mediaInfo.Format, "ogg") {
+		source = fmt.Sprintf("0:s:a:%d", position)
+	}
+
+	// -map_metadata -1 drops the stream tags too, so a stripped Ogg output needs no stream mapping
+	mapOption, tagOption := "-map_metadata", "-metadata"
+	if format == "ogg" || format == "opus" {
+		tagOption = "-metadata:s:a:0"
+		if !strip {
+			mapOption = "-map_metadata:s:a:0"
+		}
+	}
+	args := []string{mapOption, source}
+	if format == "mp3" {
+		args = append(args, "-id3v2_version", "3")
+	}
+
+	// Sorted so the command is the same on every run
+	keys := make([]string, 0, len(tags))
+	for key := range tags {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		args = append(args, tagOption, key+"="+tags[key])
+	}
+	return args
+}
+
+// validateCoverArt checks the cover image and that the output format can embed it
+func validateCoverArt(params AudioExtractionParams) error {
+	if params.Cover == "" {
+		return nil
+	}
+	if IsStdoutPath(params.OutputFile) {
+		return fmt.Errorf("cover art cannot be embedded in output written to stdout")
+	}
+	format := resolveOutputFormat(params.OutputFile, params.Container)
+	if !slices.Contains(CoverFormats, format) {
+		return fmt.Errorf("cover art can only be embedded in %s outputs, not %s", strings.Join(CoverFormats, ", "), format)
+	}
+
+	if err := securityPolicy.ValidateFilePath(params.Cover); err != nil {
+		return fmt.Errorf("security validation failed for cover path: %w", err)
+	}
+	switch strings.ToLower(filepath.Ext(params.Cover)) {
+	case ".jpg", ".jpeg", ".png":
+	default:
+		return fmt.Errorf("unsupported cover image: %s (use a JPEG or PNG)", params.Cover)
+	}
+	if _, err := os.Stat(params.Cover); err != nil {
+		return fmt.Errorf("cover %w: %s", analyzer.ErrFileNotFound, params.Cover)
+	}
+	return nil
+}
+
+// coverArtArgs embeds the second input as an attached picture, which players show as the album
+// art rather than play as video. The image is copied as it is; MP3 also labels it as the front
+// cover, which some players look for.
+func coverArtArgs(format string) []string {
+	args := []string{"-map", "1:v:0", "-c:v", "copy", "-disposition:v:0", "attached_pic"}
+	if format == "mp3" {
+		args = append(args, "-metadata:s:v:0", "title=Album cover", "-metadata:s:v:0", "comment=Cover (front)")
+	}
+	return args
+}
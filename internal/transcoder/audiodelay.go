@@ -0,0 +1,45 @@
+package transcoder
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// maxAudioDelay bounds the audio delay; lip-sync errors are fractions of a second, so a
+// larger value is almost always a mistyped unit
+const maxAudioDelay = time.Minute
+
+// validateAudioDelay checks the options combined with an audio delay. The delayed audio is
+// read from a second, time-shifted opening of the input, so the input must be a file.
+func validateAudioDelay(inputPath string, customParams CustomParameters) error {
+	switch {
+	case customParams.AudioDelay.Abs() > maxAudioDelay:
+		return fmt.Errorf("invalid audio delay: %s (must be between -%s and %s)", customParams.AudioDelay, maxAudioDelay, maxAudioDelay)
+	case analyzer.IsStdinPath(inputPath):
+		return fmt.Errorf("--audio-delay cannot be used with stdin input, which cannot be read twice")
+	}
+	return nil
+}
+
+// WithDelayedAudioInput opens the input a second time, shifted by the audio delay, as the
+// last input; the audio is mapped from it and the video from the first input. A positive
+// delay plays the audio later, a negative one earlier.
+func (b *FFmpegCommandBuilder) WithDelayedAudioInput(input string, customParams CustomParameters) *FFmpegCommandBuilder {
+	if b.hasError || customParams.AudioDelay == 0 {
+		return b
+	}
+
+	// The second opening needs the same timestamp repair and preview range as the first
+	b.WithInputOptions(customParams)
+	b.args = append(b.args, "-itsoffset", strconv.FormatFloat(customParams.AudioDelay.Seconds(), 'f', 3, 64))
+	return b.WithInput(input)
+}
+
+// delayedAudioInputIndex is the input index of the delayed audio: after the input and the
+// added audio tracks
+func delayedAudioInputIndex(customParams CustomParameters) int {
+	return len(customParams.AddAudio) + 1
+}
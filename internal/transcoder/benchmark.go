@@ -0,0 +1,197 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// benchmarkFormat is the container of the benchmark encodes, which holds every video codec
+const benchmarkFormat = "mkv"
+
+// BenchmarkParams holds parameters for comparing encoders on a segment of an input
+type BenchmarkParams struct {
+	InputFile string        // Input file path
+	Codecs    []string      // Video encoders to compare (e.g., "libx264")
+	Presets   []string      // Quality presets to encode each codec with (low, medium, high)
+	Start     time.Duration // Start of the segment; negative centers it in the input
+	Length    time.Duration // Length of the segment
+	VMAF      bool          // Score every encode against the segment with VMAF
+	Verbose   bool          // Verbose output
+}
+
+// BenchmarkResult is the outcome of encoding the segment with one codec and preset
+type BenchmarkResult struct {
+	Codec   string        // Video encoder
+	Preset  string        // Quality preset
+	Elapsed time.Duration // Wall-clock time of the encode
+	Speed   float64       // Encoding speed relative to real time
+	Size    int64         // Size of the encode in bytes
+	Bitrate int64         // Overall bitrate of the encode in bits per second
+	VMAF    float64       // Mean VMAF score; 0 unless VMAF was requested
+}
+
+// BenchmarkReport holds the segment that was encoded and the result of every encode
+type BenchmarkReport struct {
+	Start   time.Duration     // Start of the segment in the input
+	Length  time.Duration     // Length of the segment
+	Results []BenchmarkResult // Results in the order of Codecs, then Presets
+}
+
+// BenchmarkEncoders encodes the same segment of the input with every combination of codec and
+// preset and measures the speed and size of each encode, and optionally its VMAF score. Audio
+// is left out so only the video encoder is timed.
+func BenchmarkEncoders(ctx context.Context, params BenchmarkParams) (*BenchmarkReport, error) {
+	if err := validateBenchmarkParams(ctx, params); err != nil {
+		return nil, err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return nil, fmt.Errorf("input has no video stream")
+	}
+	report := &BenchmarkReport{}
+	report.Start, report.Length, err = planBenchmarkSegment(inputInfo.Duration, params.Start, params.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	encodeDir, err := os.MkdirTemp("", "transcoder-benchmark-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating benchmark directory: %w", err)
+	}
+	defer os.RemoveAll(encodeDir)
+
+	total := len(params.Codecs) * len(params.Presets)
+	for _, codec := range params.Codecs {
+		for _, preset := range params.Presets {
+			if params.Verbose {
+				color.Cyan("⏱️  %s, %s preset (%d/%d)", codec, preset, len(report.Results)+1, total)
+			}
+			result, err := benchmarkEncode(ctx, params, encodeDir, codec, preset, report.Start, report.Length)
+			if err != nil {
+				return nil, stoppedBy(ctx, fmt.Errorf("%s with the %s preset: %w", codec, preset, err))
+			}
+			report.Results = append(report.Results, *result)
+		}
+	}
+	return report, nil
+}
+
+// validateBenchmarkParams validates the input, encoders, presets and segment
+func validateBenchmarkParams(ctx context.Context, params BenchmarkParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+	if analyzer.IsStdinPath(params.InputFile) {
+		return fmt.Errorf("benchmark cannot read stdin input")
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if len(params.Codecs) == 0 || len(params.Presets) == 0 {
+		return fmt.Errorf("at least one codec and one preset are needed")
+	}
+	encoders, encodersErr := AvailableEncoders(ctx)
+	for _, codec := range params.Codecs {
+		if codec == "copy" {
+			return fmt.Errorf("copy does not encode and cannot be benchmarked")
+		}
+		if err := securityPolicy.ValidateCodec(codec, "video"); err != nil {
+			return fmt.Errorf("security validation failed for video codec: %w", err)
+		}
+		if encodersErr == nil && !encoders[codec] {
+			return fmt.Errorf("encoder %s is not available in this FFmpeg build (see ffmpeg -encoders)", codec)
+		}
+	}
+	for _, preset := range params.Presets {
+		if !slices.Contains([]string{"low", "medium", "high"}, preset) {
+			return fmt.Errorf("invalid preset '%s'. Valid options: low, medium, high", preset)
+		}
+	}
+
+	if params.Length < time.Second {
+		return fmt.Errorf("invalid segment length: %s (must be at least 1s)", params.Length)
+	}
+	if params.VMAF {
+		if err := checkFilterAvailable(ctx, "libvmaf"); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// planBenchmarkSegment fits the segment into the input, centering it when no start was given
+func planBenchmarkSegment(duration, start, length time.Duration) (time.Duration, time.Duration, error) {
+	if duration <= 0 {
+		return 0, 0, fmt.Errorf("could not determine the input duration")
+	}
+	length = min(length, duration)
+	if start < 0 {
+		return ((duration - length) / 2).Truncate(time.Millisecond), length, nil
+	}
+	if start >= duration {
+		return 0, 0, fmt.Errorf("segment start %s is past the end of the input (%s)", start, duration.Round(time.Second))
+	}
+	return start, min(length, duration-start), nil
+}
+
+// benchmarkEncode encodes the segment with one codec and preset, as convert --preview would
+func benchmarkEncode(ctx context.Context, params BenchmarkParams, encodeDir, codec, preset string,
+	start, length time.Duration) (*BenchmarkResult, error) {
+
+	outputPath := filepath.Join(encodeDir, fmt.Sprintf("%s-%s.%s", codec, preset, benchmarkFormat))
+	customParams := CustomParameters{
+		VideoCodec: codec,
+		NoAudio:    true,
+		Preview:    length,
+		PreviewAt:  start,
+	}
+
+	started := time.Now()
+	err := ConvertVideoWithCustomParams(ctx, params.InputFile, outputPath, preset, true, true, customParams, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	elapsed := time.Since(started)
+
+	stat, err := os.Stat(outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("reading encode: %w", err)
+	}
+	result := &BenchmarkResult{
+		Codec:   codec,
+		Preset:  preset,
+		Elapsed: elapsed,
+		Speed:   length.Seconds() / elapsed.Seconds(),
+		Size:    stat.Size(),
+		Bitrate: int64(float64(stat.Size()*8) / length.Seconds()),
+	}
+
+	if params.VMAF {
+		scores, err := ScoreQuality(ctx, QualityParams{
+			ReferenceFile:     params.InputFile,
+			EncodedFile:       outputPath,
+			Metrics:           []string{"vmaf"},
+			Model:             "vmaf",
+			Verbose:           params.Verbose,
+			ReferenceStart:    start,
+			ReferenceDuration: length,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("scoring: %w", err)
+		}
+		result.VMAF = scores[0].Mean
+	}
+	return result, nil
+}
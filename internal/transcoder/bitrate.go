@@ -0,0 +1,123 @@
+package transcoder
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ContainerOverhead is the share of a file reserved for container and muxing overhead
+const ContainerOverhead = 0.02
+
+// clockDurationRegex matches clock style durations (e.g., "1:23:00", "45:10", "90.5")
+var clockDurationRegex = regexp.MustCompile(`^(?:(\d+):)?(?:(\d+):)?(\d+(?:\.\d+)?)$`)
+
+// ParseDuration parses a duration given as Go duration ("1h23m", "90s") or clock time ("01:23:00", "45:10")
+func ParseDuration(value string) (time.Duration, error) {
+	value = strings.TrimSpace(value)
+	if duration, err := time.ParseDuration(value); err == nil {
+		if duration <= 0 {
+			return 0, fmt.Errorf("duration must be positive: %s", value)
+		}
+		return duration, nil
+	}
+
+	matches := clockDurationRegex.FindStringSubmatch(value)
+	if matches == nil {
+		return 0, fmt.Errorf("invalid duration: %s (use a format like 1h23m, 90s or 01:23:00)", value)
+	}
+
+	// With a single colon the fields are minutes:seconds, with two hours:minutes:seconds
+	hours, minutes := matches[1], matches[2]
+	if minutes == "" {
+		hours, minutes = "", hours
+	}
+
+	var seconds float64
+	for _, part := range []struct {
+		value string
+		unit  float64
+	}{{hours, 3600}, {minutes, 60}} {
+		if part.value == "" {
+			continue
+		}
+		number, _ := strconv.Atoi(part.value)
+		seconds += float64(number) * part.unit
+	}
+	fraction, _ := strconv.ParseFloat(matches[3], 64)
+	seconds += fraction
+
+	duration := time.Duration(seconds * float64(time.Second))
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive: %s", value)
+	}
+	return duration, nil
+}
+
+// sizeRegex matches file sizes (e.g., "2GB", "700M", "1.5 GiB", "4096")
+var sizeRegex = regexp.MustCompile(`^(\d+(?:\.\d+)?)\s*([kKmMgGtT]?)(?:i?[bB])?$`)
+
+// ParseSize parses a file size in bytes; units are 1024-based like the sizes shown by info
+func ParseSize(value string) (int64, error) {
+	matches := sizeRegex.FindStringSubmatch(strings.TrimSpace(value))
+	if matches == nil {
+		return 0, fmt.Errorf("invalid size: %s (use a format like 2GB, 700MB)", value)
+	}
+
+	number, err := strconv.ParseFloat(matches[1], 64)
+	if err != nil || number <= 0 {
+		return 0, fmt.Errorf("size must be positive: %s", value)
+	}
+
+	exponent := strings.Index("KMGT", strings.ToUpper(matches[2])) + 1
+	return int64(number * math.Pow(1024, float64(exponent))), nil
+}
+
+// ParseBitrate converts an ffmpeg style bitrate ("192k", "2M", "128000") to bits per second
+func ParseBitrate(bitrate string) (int64, error) {
+	if err := securityPolicy.ValidateBitrate(bitrate); err != nil {
+		return 0, err
+	}
+	if bitrate == "" {
+		return 0, nil
+	}
+
+	multiplier := 1.0
+	switch bitrate[len(bitrate)-1] {
+	case 'k', 'K':
+		multiplier = 1000
+		bitrate = bitrate[:len(bitrate)-1]
+	case 'm', 'M':
+		multiplier = 1000 * 1000
+		bitrate = bitrate[:len(bitrate)-1]
+	}
+
+	number, err := strconv.ParseFloat(bitrate, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bitrate: %s", bitrate)
+	}
+	return int64(number * multiplier), nil
+}
+
+// CalculateVideoBitrate returns the video bitrate (bits per second) that makes a file of the given
+// duration hit the target size, after the audio bitrate and container overhead are accounted for
+func CalculateVideoBitrate(duration time.Duration, targetSize, audioBitrate int64) (int64, error) {
+	if duration <= 0 {
+		return 0, fmt.Errorf("duration must be positive")
+	}
+	if targetSize <= 0 {
+		return 0, fmt.Errorf("target size must be positive")
+	}
+
+	totalBitrate := float64(targetSize) * 8 * (1 - ContainerOverhead) / duration.Seconds()
+	videoBitrate := int64(totalBitrate) - audioBitrate
+	if videoBitrate <= 0 {
+		return 0, fmt.Errorf("target size is too small: the audio alone needs about %d kbps of the %d kbps available",
+			audioBitrate/1000, int64(totalBitrate)/1000)
+	}
+
+	return videoBitrate, nil
+}
@@ -0,0 +1,133 @@
+package transcoder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// BlurRegion is a rectangle of the picture blurred out, such as a face or a license plate,
+// for the whole video or only between Start and End
+type BlurRegion struct {
+	X, Y          int // Top left corner in pixels, as the video is displayed
+	Width, Height int
+	Start, End    time.Duration // Both zero blurs the region throughout
+}
+
+// ParseBlurRegion parses "x,y,w,h" or "x,y,w,h,start,end"; times are seconds (12.5) or
+// [h:]mm:ss[.fff] (1:02:03.5)
+func ParseBlurRegion(spec string) (BlurRegion, error) {
+	fields := strings.Split(spec, ",")
+	if len(fields) != 4 && len(fields) != 6 {
+		return BlurRegion{}, fmt.Errorf("invalid blur region %s (use x,y,w,h or x,y,w,h,start,end)", spec)
+	}
+
+	var values [4]int
+	for i, field := range fields[:4] {
+		value, err := strconv.Atoi(strings.TrimSpace(field))
+		if err != nil || value < 0 {
+			return BlurRegion{}, fmt.Errorf("invalid blur region %s: %q is not a pixel position or size", spec, field)
+		}
+		values[i] = value
+	}
+	region := BlurRegion{X: values[0], Y: values[1], Width: values[2], Height: values[3]}
+	if region.Width < 8 || region.Height < 8 {
+		return BlurRegion{}, fmt.Errorf("invalid blur region %s: width and height must be at least 8 pixels", spec)
+	}
+
+	if len(fields) == 6 {
+		var err error
+		if region.Start, err = parseBlurTime(strings.TrimSpace(fields[4])); err != nil {
+			return BlurRegion{}, fmt.Errorf("invalid blur region %s: %w", spec, err)
+		}
+		if region.End, err = parseBlurTime(strings.TrimSpace(fields[5])); err != nil {
+			return BlurRegion{}, fmt.Errorf("invalid blur region %s: %w", spec, err)
+		}
+		if region.End <= region.Start {
+			return BlurRegion{}, fmt.Errorf("invalid blur region %s: the end must be after the start", spec)
+		}
+	}
+	return region, nil
+}
+
+// parseBlurTime reads a time as seconds or [h:]mm:ss[.fff]
+func parseBlurTime(value string) (time.Duration, error) {
+	if !strings.Contains(value, ":") {
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil || seconds < 0 {
+			return 0, fmt.Errorf("invalid time: %s", value)
+		}
+		return time.Duration(seconds * float64(time.Second)), nil
+	}
+	return parseChapterTime(value)
+}
+
+// timed reports whether the region is only blurred for part of the video
+func (r BlurRegion) timed() bool {
+	return r.End > 0
+}
+
+// validateBlurRegions checks the options combined with blur regions
+func validateBlurRegions(customParams CustomParameters) error {
+	if customParams.VideoCodec == "copy" {
+		return fmt.Errorf("--blur-region requires video re-encoding and cannot be used with video codec 'copy'")
+	}
+	// Every segment starts at zero, so time ranges would restart with each one
+	for _, region := range customParams.BlurRegions {
+		if region.timed() && customParams.Resumable {
+			return fmt.Errorf("--blur-region with a time range cannot be combined with --resumable")
+		}
+	}
+	return nil
+}
+
+// buildBlurFilter blurs each region in turn: the frame is split, the region is cropped out of
+// one copy, box blurred and laid back over the other, only within its time range if it has one.
+// Regions must lie inside the frame as it is displayed (rotated frames are turned upright first).
+func buildBlurFilter(inputInfo *analyzer.MediaInfo, customParams CustomParameters) (string, error) {
+	if len(inputInfo.VideoStreams) == 0 {
+		return "", fmt.Errorf("--blur-region requires a video stream")
+	}
+	stream := inputInfo.VideoStreams[0]
+	width, height := stream.Width, stream.Height
+	if stream.Rotation == 90 || stream.Rotation == 270 {
+		width, height = height, width
+	}
+
+	// A preview starts later in the input, while its timestamps start at zero
+	var offset time.Duration
+	if customParams.Preview > 0 {
+		offset = customParams.PreviewAt
+	}
+
+	chains := make([]string, 0, len(customParams.BlurRegions))
+	for i, region := range customParams.BlurRegions {
+		if width > 0 && height > 0 && (region.X+region.Width > width || region.Y+region.Height > height) {
+			return "", fmt.Errorf("blur region %d,%d,%d,%d does not fit in the %dx%d frame",
+				region.X, region.Y, region.Width, region.Height, width, height)
+		}
+
+		// The radius stays within what boxblur allows for subsampled chroma planes
+		radius := max(1, min(region.Width, region.Height)/8)
+		overlay := fmt.Sprintf("overlay=%d:%d", region.X, region.Y)
+		if region.timed() {
+			overlay += fmt.Sprintf(":enable='between(t,%.3f,%.3f)'",
+				(region.Start - offset).Seconds(), (region.End - offset).Seconds())
+		}
+		chains = append(chains, fmt.Sprintf("split[blur%[1]dbase][blur%[1]dsrc];[blur%[1]dsrc]crop=%[2]d:%[3]d:%[4]d:%[5]d,boxblur=%[6]d:3[blur%[1]d];[blur%[1]dbase][blur%[1]d]%[7]s",
+			i, region.Width, region.Height, region.X, region.Y, radius, overlay))
+	}
+	return strings.Join(chains, ","), nil
+}
+
+// String describes the region for verbose output
+func (r BlurRegion) String() string {
+	description := fmt.Sprintf("%dx%d at %d,%d", r.Width, r.Height, r.X, r.Y)
+	if r.timed() {
+		description += fmt.Sprintf(" from %s to %s", formatStreamPosition(r.Start.Seconds()), formatStreamPosition(r.End.Seconds()))
+	}
+	return description
+}
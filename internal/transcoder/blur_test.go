@@ -0,0 +1,63 @@
+package transcoder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseBlurRegion(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    BlurRegion
+		wantErr bool
+	}{
+		{
+			name: "whole video",
+			spec: "100,50,200,120",
+			want: BlurRegion{X: 100, Y: 50, Width: 200, Height: 120},
+		},
+		{
+			name: "spaces",
+			spec: "0, 0, 64, 64",
+			want: BlurRegion{Width: 64, Height: 64},
+		},
+		{
+			name: "seconds",
+			spec: "10,10,80,40,12.5,20",
+			want: BlurRegion{X: 10, Y: 10, Width: 80, Height: 40, Start: 12500 * time.Millisecond, End: 20 * time.Second},
+		},
+		{
+			name: "clock times",
+			spec: "10,10,80,40,1:02:03.5,1:05:00",
+			want: BlurRegion{X: 10, Y: 10, Width: 80, Height: 40,
+				Start: time.Hour + 2*time.Minute + 3500*time.Millisecond, End: time.Hour + 5*time.Minute},
+		},
+		{name: "too few fields", spec: "10,10,80", wantErr: true},
+		{name: "five fields", spec: "10,10,80,40,5", wantErr: true},
+		{name: "negative position", spec: "-10,10,80,40", wantErr: true},
+		{name: "not a number", spec: "a,10,80,40", wantErr: true},
+		{name: "too small", spec: "10,10,4,40", wantErr: true},
+		{name: "end before start", spec: "10,10,80,40,20,12", wantErr: true},
+		{name: "end equals start", spec: "10,10,80,40,12,12", wantErr: true},
+		{name: "invalid time", spec: "10,10,80,40,soon,12", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseBlurRegion(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseBlurRegion(%q) = %+v, want an error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseBlurRegion(%q) error = %v", tt.spec, err)
+			}
+			if got != tt.want {
+				t.Errorf("ParseBlurRegion(%q) = %+v, want %+v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,96 @@
+package transcoder
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// channelNames are the FFmpeg channel names a channel map can take channels from
+var channelNames = []string{
+	"FL", "FR", "FC", "LFE", "BL", "BR", "FLC", "FRC", "BC", "SL", "SR",
+	"TC", "TFL", "TFC", "TFR", "TBL", "TBC", "TBR", "DL", "DR", "WL", "WR", "LFE2",
+}
+
+// channelIndexRegex matches a source channel given by position (c0 is the first channel)
+var channelIndexRegex = regexp.MustCompile(`^c([0-9]|[1-5][0-9]|6[0-3])$`)
+
+// maxMappedChannels is the most output channels a channel map can have (7.1)
+const maxMappedChannels = 8
+
+// ParseChannelMap reads a channel map: the source channel of each output channel in order,
+// separated by "-". "FL-FR" keeps the front pair of a 5.1 source, "FR-FL" swaps left and
+// right and "FL-FL" puts the left channel on both sides. Channels are named as FFmpeg names
+// them (FL, FR, FC, LFE, BL, BR, SL, SR, ...) or given by position (c0, c1, ...).
+func ParseChannelMap(value string) ([]string, error) {
+	if strings.TrimSpace(value) == "" {
+		return nil, fmt.Errorf("channel map is empty")
+	}
+
+	var channels []string
+	for _, field := range strings.Split(value, "-") {
+		channel := strings.TrimSpace(field)
+		switch {
+		case slices.Contains(channelNames, strings.ToUpper(channel)):
+			channel = strings.ToUpper(channel)
+		case channelIndexRegex.MatchString(strings.ToLower(channel)):
+			channel = strings.ToLower(channel)
+		default:
+			return nil, fmt.Errorf("invalid channel %q in channel map %s (use names such as FL, FR, FC, LFE or positions such as c0, c1)", field, value)
+		}
+		channels = append(channels, channel)
+	}
+	if len(channels) > maxMappedChannels {
+		return nil, fmt.Errorf("channel map %s has %d channels (at most %d)", value, len(channels), maxMappedChannels)
+	}
+	return channels, nil
+}
+
+// checkChannelMapSource checks that channels given by position exist in the audio stream.
+// Named channels depend on the channel layout, which FFmpeg checks itself.
+func checkChannelMapSource(value string, mediaInfo *analyzer.MediaInfo, position int) error {
+	if position >= len(mediaInfo.AudioStreams) || mediaInfo.AudioStreams[position].Channels == 0 {
+		return nil
+	}
+	available := mediaInfo.AudioStreams[position].Channels
+
+	channels, err := ParseChannelMap(value)
+	if err != nil {
+		return err
+	}
+	for _, channel := range channels {
+		if match := channelIndexRegex.FindStringSubmatch(channel); match != nil {
+			if index, _ := strconv.Atoi(match[1]); index >= available {
+				return fmt.Errorf("channel map uses %s, but audio stream %d has %d channel(s) (c0 to c%d)",
+					channel, position+1, available, available-1)
+			}
+		}
+	}
+	return nil
+}
+
+// channelMapFilter builds the pan filter that fills each output channel from its source
+// channel. Common channel counts get their usual layout so players place them correctly.
+func channelMapFilter(channels []string) string {
+	layout := fmt.Sprintf("%dc", len(channels))
+	switch len(channels) {
+	case 1:
+		layout = "mono"
+	case 2:
+		layout = "stereo"
+	case 6:
+		layout = "5.1"
+	case 8:
+		layout = "7.1"
+	}
+
+	parts := []string{layout}
+	for i, channel := range channels {
+		parts = append(parts, fmt.Sprintf("c%d=%s", i, channel))
+	}
+	return "pan=" + strings.Join(parts, "|")
+}
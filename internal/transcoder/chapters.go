@@ -0,0 +1,365 @@
+package transcoder
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// ChapterFormats are the containers chapter marks can be written to
+var ChapterFormats = []string{"mkv", "mp4", "mov", "webm"}
+
+// Chapter is a named part of a media file
+type Chapter struct {
+	Start time.Duration
+	End   time.Duration // Zero until the chapter is placed in a file; then the start of the next
+	Title string
+}
+
+// ChapterParams holds parameters for replacing the chapters of a media file
+type ChapterParams struct {
+	InputFile    string // Input file path
+	ChaptersFile string // FFmetadata or "timestamp title" list of chapters
+	OutputFile   string // Output file path; empty replaces the input
+	Verbose      bool   // Verbose output
+}
+
+// chapterLineRegex matches a line of a simple chapter list: a timestamp ([h:]mm:ss[.fff]),
+// optionally followed by a dash, and the title
+var chapterLineRegex = regexp.MustCompile(`^((?:\d+:)?\d{1,2}:\d{2}(?:\.\d+)?)(?:\s+[-–]\s*|\s+|$)(.*)$`)
+
+// ffmetadataEscaper escapes the characters FFmetadata files give a meaning to
+var ffmetadataEscaper = strings.NewReplacer(`\`, `\\`, "=", `\=`, ";", `\;`, "#", `\#`, "\n", "\\\n")
+
+// ParseChapterFile reads chapters from an FFmetadata file (starting with ";FFMETADATA1") or
+// from a list with one "timestamp title" line per chapter, such as YouTube descriptions use
+func ParseChapterFile(path string) ([]Chapter, error) {
+	if err := securityPolicy.ValidateFilePath(path); err != nil {
+		return nil, fmt.Errorf("security validation failed for chapters path: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading chapters: %w", err)
+	}
+	content := strings.TrimPrefix(string(data), "\ufeff")
+
+	var chapters []Chapter
+	if strings.HasPrefix(content, ";FFMETADATA1") {
+		chapters, err = parseFFMetadataChapters(content)
+	} else {
+		chapters, err = parseChapterList(content)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid chapters file %s: %w", path, err)
+	}
+	if len(chapters) == 0 {
+		return nil, fmt.Errorf("invalid chapters file %s: no chapters found", path)
+	}
+	for i := 1; i < len(chapters); i++ {
+		if chapters[i].Start <= chapters[i-1].Start {
+			return nil, fmt.Errorf("invalid chapters file %s: chapter %d (%s) does not start after chapter %d",
+				path, i+1, formatStreamPosition(chapters[i].Start.Seconds()), i)
+		}
+	}
+	return chapters, nil
+}
+
+// parseChapterList reads "timestamp title" lines; blank lines and lines starting with # are skipped
+func parseChapterList(content string) ([]Chapter, error) {
+	var chapters []Chapter
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		match := chapterLineRegex.FindStringSubmatch(line)
+		if match == nil {
+			return nil, fmt.Errorf("line %d: expected a timestamp and a title (e.g., \"00:05:30 Second act\"): %s", lineNumber, line)
+		}
+		start, err := parseChapterTime(match[1])
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+		}
+		title := strings.TrimSpace(match[2])
+		if title == "" {
+			title = fmt.Sprintf("Chapter %d", len(chapters)+1)
+		}
+		chapters = append(chapters, Chapter{Start: start, Title: title})
+	}
+	return chapters, scanner.Err()
+}
+
+// parseChapterTime converts [h:]mm:ss[.fff] to a duration
+func parseChapterTime(value string) (time.Duration, error) {
+	parts := strings.Split(value, ":")
+	seconds, err := strconv.ParseFloat(parts[len(parts)-1], 64)
+	if err != nil || seconds >= 60 {
+		return 0, fmt.Errorf("invalid timestamp: %s", value)
+	}
+	total := time.Duration(seconds * float64(time.Second))
+
+	units := []time.Duration{time.Minute, time.Hour}
+	for i, part := range parts[:len(parts)-1] {
+		n, _ := strconv.Atoi(part)
+		unit := units[len(parts)-2-i]
+		if unit == time.Minute && len(parts) == 3 && n > 59 {
+			return 0, fmt.Errorf("invalid timestamp: %s", value)
+		}
+		total += time.Duration(n) * unit
+	}
+	return total, nil
+}
+
+// parseFFMetadataChapters reads the [CHAPTER] sections of an FFmetadata file. Global and
+// stream sections are ignored; the metadata of the media file is kept as it is.
+func parseFFMetadataChapters(content string) ([]Chapter, error) {
+	var chapters []Chapter
+	var chapter *Chapter
+	var start, end int64
+	timebase := [2]int64{1, 1000000000} // FFmpeg's default for chapters without a TIMEBASE
+
+	finish := func() error {
+		if chapter == nil {
+			return nil
+		}
+		if start < 0 {
+			return fmt.Errorf("chapter %d has no START", len(chapters)+1)
+		}
+		if end != 0 && end <= start {
+			return fmt.Errorf("chapter %d: END must come after START", len(chapters)+1)
+		}
+		chapter.Start = ffmetadataTime(start, timebase)
+		if end != 0 {
+			chapter.End = ffmetadataTime(end, timebase)
+		}
+		if chapter.Title == "" {
+			chapter.Title = fmt.Sprintf("Chapter %d", len(chapters)+1)
+		}
+		chapters = append(chapters, *chapter)
+		chapter = nil
+		return nil
+	}
+
+	lines := strings.Split(strings.ReplaceAll(content, "\r\n", "\n"), "\n")
+	for lineNumber := 1; lineNumber <= len(lines); lineNumber++ {
+		line := lines[lineNumber-1]
+		// A trailing backslash continues the value on the next line
+		for strings.HasSuffix(line, `\`) && !strings.HasSuffix(line, `\\`) && lineNumber < len(lines) {
+			line = line[:len(line)-1] + "\n" + lines[lineNumber]
+			lineNumber++
+		}
+		if line == "" || strings.HasPrefix(line, ";") || strings.HasPrefix(line, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(line, "[") {
+			if err := finish(); err != nil {
+				return nil, err
+			}
+			if strings.TrimSpace(line) == "[CHAPTER]" {
+				chapter = &Chapter{}
+				start, end = -1, 0
+				timebase = [2]int64{1, 1000000000}
+			}
+			continue
+		}
+		if chapter == nil {
+			continue
+		}
+
+		key, value, ok := cutFFMetadata(line)
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected key=value: %s", lineNumber, line)
+		}
+		var err error
+		switch strings.ToUpper(key) {
+		case "TIMEBASE":
+			num, den, found := strings.Cut(value, "/")
+			timebase[0], err = strconv.ParseInt(num, 10, 64)
+			if err == nil && found {
+				timebase[1], err = strconv.ParseInt(den, 10, 64)
+			}
+			if err != nil || !found || timebase[0] <= 0 || timebase[1] <= 0 {
+				return nil, fmt.Errorf("line %d: invalid TIMEBASE: %s", lineNumber, value)
+			}
+		case "START":
+			if start, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid START: %s", lineNumber, value)
+			}
+		case "END":
+			if end, err = strconv.ParseInt(value, 10, 64); err != nil {
+				return nil, fmt.Errorf("line %d: invalid END: %s", lineNumber, value)
+			}
+		case "TITLE":
+			chapter.Title = value
+		}
+	}
+	if err := finish(); err != nil {
+		return nil, err
+	}
+	return chapters, nil
+}
+
+// cutFFMetadata splits an FFmetadata line at its first unescaped "=" and unescapes both parts
+func cutFFMetadata(line string) (string, string, bool) {
+	var key strings.Builder
+	var value strings.Builder
+	current := &key
+	found := false
+	for i := 0; i < len(line); i++ {
+		switch {
+		case line[i] == '\\' && i+1 < len(line):
+			i++
+			current.WriteByte(line[i])
+		case line[i] == '=' && !found:
+			found = true
+			current = &value
+		default:
+			current.WriteByte(line[i])
+		}
+	}
+	return key.String(), value.String(), found
+}
+
+// ffmetadataTime converts a position in timebase units to a duration
+func ffmetadataTime(value int64, timebase [2]int64) time.Duration {
+	return time.Duration(float64(value) * float64(timebase[0]) / float64(timebase[1]) * float64(time.Second))
+}
+
+// SetChapters replaces the chapters of a media file without re-encoding. Every stream and the
+// metadata are copied; chapters without an end run until the next one, and the last until the
+// end of the input. Returns the chapters written.
+func SetChapters(ctx context.Context, params ChapterParams) ([]Chapter, error) {
+	if analyzer.IsStdinPath(params.InputFile) {
+		return nil, fmt.Errorf("chapters cannot be set on stdin input")
+	}
+	if err := validateInputFile(params.InputFile); err != nil {
+		return nil, err
+	}
+
+	outputFile := params.OutputFile
+	if outputFile == "" {
+		// Written next to the input, so the final rename stays on one file system
+		extension := filepath.Ext(params.InputFile)
+		outputFile = strings.TrimSuffix(params.InputFile, extension) + ".chapters-tmp" + extension
+	}
+	outputFormat := getFormatFromPath(outputFile)
+	if !slices.Contains(ChapterFormats, outputFormat) {
+		return nil, fmt.Errorf("chapters cannot be written to %s files (use %s)", outputFormat, strings.Join(ChapterFormats, ", "))
+	}
+	if err := validateConversionPaths(params.InputFile, outputFile); err != nil {
+		return nil, err
+	}
+
+	chapters, err := ParseChapterFile(params.ChaptersFile)
+	if err != nil {
+		return nil, err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	if err := checkRemuxCompatibility(inputInfo, outputFormat); err != nil {
+		return nil, err
+	}
+	if err := placeChapters(chapters, inputInfo.Duration); err != nil {
+		return nil, err
+	}
+
+	metadataFile, err := writeChapterMetadata(chapters)
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(metadataFile)
+
+	cmd := buildChaptersCommand(ctx, params.InputFile, metadataFile, outputFile, outputFormat)
+	if params.Verbose {
+		color.Green("✅ %d chapter(s) read from %s", len(chapters), params.ChaptersFile)
+		reportDroppedAttachments(inputInfo, outputFormat)
+		fmt.Printf("   Command: %s\n", strings.Join(cmd.Args, " "))
+		fmt.Println()
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		if params.OutputFile == "" {
+			os.Remove(outputFile)
+		}
+		return nil, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+
+	if params.OutputFile == "" {
+		if err := os.Rename(outputFile, params.InputFile); err != nil {
+			os.Remove(outputFile)
+			return nil, fmt.Errorf("replacing %s: %w", params.InputFile, err)
+		}
+	}
+	return chapters, nil
+}
+
+// placeChapters checks the chapters against the length of the input and fills in their ends
+func placeChapters(chapters []Chapter, duration time.Duration) error {
+	last := chapters[len(chapters)-1]
+	if duration > 0 && last.Start >= duration {
+		return fmt.Errorf("chapter %d starts at %s, after the end of the input (%s)", len(chapters),
+			formatStreamPosition(last.Start.Seconds()), formatStreamPosition(duration.Seconds()))
+	}
+
+	for i := range chapters {
+		next := duration
+		if i+1 < len(chapters) {
+			next = chapters[i+1].Start
+		}
+		if chapters[i].End == 0 || chapters[i].End > next {
+			chapters[i].End = next
+		}
+		if chapters[i].End < chapters[i].Start {
+			chapters[i].End = chapters[i].Start
+		}
+	}
+	return nil
+}
+
+// writeChapterMetadata writes the chapters to a temporary FFmetadata file and returns its path
+func writeChapterMetadata(chapters []Chapter) (string, error) {
+	file, err := os.CreateTemp("", "transcoder-chapters-*.txt")
+	if err != nil {
+		return "", fmt.Errorf("creating chapters file: %w", err)
+	}
+	defer file.Close()
+
+	var b strings.Builder
+	b.WriteString(";FFMETADATA1\n")
+	for _, chapter := range chapters {
+		fmt.Fprintf(&b, "\n[CHAPTER]\nTIMEBASE=1/1000\nSTART=%d\nEND=%d\ntitle=%s\n",
+			chapter.Start.Milliseconds(), chapter.End.Milliseconds(), ffmetadataEscaper.Replace(chapter.Title))
+	}
+	if _, err := file.WriteString(b.String()); err != nil {
+		os.Remove(file.Name())
+		return "", fmt.Errorf("writing chapters file: %w", err)
+	}
+	return file.Name(), nil
+}
+
+// buildChaptersCommand copies every stream and the metadata of the input, taking the chapters
+// from the FFmetadata file alone
+func buildChaptersCommand(ctx context.Context, inputFile, metadataFile, outputFile, outputFormat string) *exec.Cmd {
+	args := []string{"-i", inputFile, "-f", "ffmetadata", "-i", metadataFile}
+	args = append(args, copyAllStreamsMapArgs(outputFormat)...)
+	args = append(args, "-map_metadata", "0", "-map_chapters", "1", "-c", "copy", "-y", outputFile)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
@@ -0,0 +1,208 @@
+package transcoder
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/config"
+)
+
+// defaultCompatibilityData is the built-in stream copy compatibility dataset
+//
+//go:embed data/compatibility.json
+var defaultCompatibilityData []byte
+
+// AnyCodec as a codec entry lets a container take every codec, as MKV does
+const AnyCodec = "*"
+
+// CodecEntry describes a codec that can be stream copied into a container
+type CodecEntry struct {
+	Codec    string   `json:"codec"`              // ffprobe codec name (e.g., "h264", "eac3"), or AnyCodec
+	Profiles []string `json:"profiles,omitempty"` // Allowed profiles; empty means any profile
+}
+
+// ContainerCompatibility describes which codecs a container accepts without re-encoding
+type ContainerCompatibility struct {
+	DefaultVideoCodec string       `json:"default_video_codec"`  // Encoder used when video must be re-encoded
+	DefaultAudioCodec string       `json:"default_audio_codec"`  // Encoder used when audio must be re-encoded
+	VideoCodecs       []CodecEntry `json:"video"`                // Video codecs that can be stream copied
+	AudioCodecs       []CodecEntry `json:"audio"`                // Audio codecs that can be stream copied
+	SubtitleCodecs    []CodecEntry `json:"subtitle"`             // Subtitle codecs the container can carry
+	VideoArgs         []string     `json:"video_args,omitempty"` // Options the default video encoder needs in this container
+	AudioArgs         []string     `json:"audio_args,omitempty"` // Options the default audio encoder needs in this container
+}
+
+var (
+	compatibilityOnce  sync.Once
+	compatibilityTable map[string]ContainerCompatibility
+	compatibilityErr   error
+)
+
+// loadCompatibilityTable parses the embedded dataset and applies the user override file
+func loadCompatibilityTable() (map[string]ContainerCompatibility, error) {
+	table := make(map[string]ContainerCompatibility)
+	if err := json.Unmarshal(defaultCompatibilityData, &table); err != nil {
+		return nil, fmt.Errorf("parsing built-in compatibility data: %w", err)
+	}
+
+	overridePath := CompatibilityOverridePath()
+	if overridePath == "" {
+		return table, nil
+	}
+
+	data, err := os.ReadFile(overridePath)
+	if os.IsNotExist(err) {
+		return table, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading compatibility override %s: %w", overridePath, err)
+	}
+
+	// Containers present in the override replace the built-in entries
+	overrides := make(map[string]ContainerCompatibility)
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("parsing compatibility override %s: %w", overridePath, err)
+	}
+	for format, compat := range overrides {
+		table[strings.ToLower(format)] = compat
+	}
+
+	return table, nil
+}
+
+// CompatibilityOverridePath returns the location of the user compatibility override file
+func CompatibilityOverridePath() string {
+	configDir, err := config.Dir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "compatibility.json")
+}
+
+// getCompatibilityTable returns the loaded compatibility table, loading it on first use
+func getCompatibilityTable() (map[string]ContainerCompatibility, error) {
+	compatibilityOnce.Do(func() {
+		compatibilityTable, compatibilityErr = loadCompatibilityTable()
+	})
+	return compatibilityTable, compatibilityErr
+}
+
+// GetContainerCompatibility returns the stream copy compatibility table for a container format
+func GetContainerCompatibility(format string) (ContainerCompatibility, bool, error) {
+	table, err := getCompatibilityTable()
+	if err != nil {
+		return ContainerCompatibility{}, false, err
+	}
+	compat, ok := table[strings.ToLower(format)]
+	return compat, ok, nil
+}
+
+// getDefaultCodecs returns the best default codecs for each format
+func getDefaultCodecs(format string) (string, string) {
+	if compat, ok, err := GetContainerCompatibility(format); err == nil && ok {
+		return compat.DefaultVideoCodec, compat.DefaultAudioCodec
+	}
+	return "libx264", "aac" // Safe defaults
+}
+
+// containerEncoderArgs returns the options a container needs for its default encoders, such as
+// baseline H.264 for 3GP or 48 kHz audio for MXF. Other encoders were chosen explicitly and
+// are left alone.
+func containerEncoderArgs(format, videoCodec, audioCodec string, noAudio bool) ([]string, error) {
+	compat, ok, err := GetContainerCompatibility(format)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	var args []string
+	if videoCodec == compat.DefaultVideoCodec {
+		args = append(args, compat.VideoArgs...)
+	}
+	if !noAudio && audioCodec == compat.DefaultAudioCodec {
+		args = append(args, compat.AudioArgs...)
+	}
+	// The options may come from the user override file
+	if err := securityPolicy.ValidateExtraArgs(args); err != nil {
+		return nil, fmt.Errorf("invalid options for %s in compatibility data: %w", format, err)
+	}
+	return args, nil
+}
+
+// canUseStreamCopy checks if we can copy streams without re-encoding.
+// The selected audio stream (or the first one) is checked unless audio is removed.
+// Added audio tracks are checked separately and re-encoded on their own when needed.
+func canUseStreamCopy(inputInfo *analyzer.MediaInfo, outputFormat string, customParams CustomParameters) bool {
+	if len(inputInfo.VideoStreams) == 0 {
+		return false
+	}
+	if !customParams.NoAudio && len(inputInfo.AudioStreams) == 0 && len(customParams.AddAudio) == 0 {
+		return false
+	}
+
+	compat, ok, err := GetContainerCompatibility(outputFormat)
+	if err != nil || !ok {
+		return false
+	}
+
+	// Check codec compatibility with output format
+	videoStream := inputInfo.VideoStreams[0]
+	if !isCompatibleCodec(videoStream.Codec, videoStream.Profile, compat.VideoCodecs) {
+		return false
+	}
+	if customParams.NoAudio || len(inputInfo.AudioStreams) == 0 {
+		return true
+	}
+
+	audioPosition := 0
+	if number, err := strconv.Atoi(customParams.AudioStream); err == nil && number >= 1 && number <= len(inputInfo.AudioStreams) {
+		audioPosition = number - 1
+	}
+	audioStream := inputInfo.AudioStreams[audioPosition]
+	return isCompatibleCodec(audioStream.Codec, audioStream.Profile, compat.AudioCodecs)
+}
+
+// isCompatibleCodec checks if a codec (and profile, when restricted) is in the list of compatible codecs
+func isCompatibleCodec(codec, profile string, compatibleCodecs []CodecEntry) bool {
+	for _, entry := range compatibleCodecs {
+		if !entry.matches(codec) {
+			continue
+		}
+		if len(entry.Profiles) == 0 || entry.Codec == AnyCodec {
+			return true
+		}
+		for _, allowed := range entry.Profiles {
+			if strings.EqualFold(profile, allowed) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// matches reports whether the entry is the codec, or any codec
+func (e CodecEntry) matches(codec string) bool {
+	return e.Codec == AnyCodec || strings.EqualFold(e.Codec, codec)
+}
+
+// FormatCodecEntries renders codec entries as a readable list (e.g., "h264, vp9 (Profile 0)")
+func FormatCodecEntries(entries []CodecEntry) string {
+	names := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		if entry.Codec == AnyCodec {
+			return "any codec"
+		}
+		if len(entry.Profiles) > 0 {
+			names = append(names, fmt.Sprintf("%s (%s)", entry.Codec, strings.Join(entry.Profiles, ", ")))
+		} else {
+			names = append(names, entry.Codec)
+		}
+	}
+	return strings.Join(names, ", ")
+}
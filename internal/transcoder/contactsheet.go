@@ -0,0 +1,161 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// contactSheetSpacing is the gap in pixels between the thumbnails of a contact sheet and
+// around its border
+const contactSheetSpacing = 4
+
+// ContactSheetParams holds parameters for tiling frames of a video into one overview image
+type ContactSheetParams struct {
+	InputFile  string // Input video file path
+	OutputFile string // Output image path; its extension selects the format (jpg, png or webp)
+	Columns    int    // Thumbnails per row
+	Rows       int    // Rows of thumbnails
+	ThumbWidth int    // Thumbnail width in pixels; the height keeps the aspect ratio
+	Timestamps bool   // Print the time of each frame on its thumbnail
+	Verbose    bool   // Verbose output
+}
+
+// CreateContactSheet tiles evenly spaced frames of the input into a single image, each frame
+// taken from the middle of an equal share of the video. It returns the times of the frames.
+func CreateContactSheet(ctx context.Context, params ContactSheetParams) ([]time.Duration, error) {
+	if err := validateContactSheetParams(ctx, params); err != nil {
+		return nil, err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return nil, fmt.Errorf("input has no video stream")
+	}
+	if inputInfo.Duration <= 0 {
+		return nil, fmt.Errorf("could not determine the duration of the input")
+	}
+
+	video := inputInfo.VideoStreams[0]
+	if video.Width <= 0 || video.Height <= 0 {
+		return nil, fmt.Errorf("could not determine the resolution of the input")
+	}
+	thumbHeight := storyboardThumbHeight(params.ThumbWidth, displayWidth(video), displayHeight(video))
+
+	times := planContactSheetTimes(inputInfo.Duration, params.Columns*params.Rows)
+	cmd := buildContactSheetCommand(ctx, params, times, thumbHeight)
+	if params.Verbose {
+		color.Cyan("🗂️  %dx%d frames of %dx%d, one every %s", params.Columns, params.Rows,
+			params.ThumbWidth, thumbHeight, formatDuration(inputInfo.Duration/time.Duration(len(times))))
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	// The output is a single image, so there is no timeline to measure progress against
+	sheetInfo := *inputInfo
+	sheetInfo.Duration = 0
+	if err := executeFFmpeg(ctx, cmd, &sheetInfo, params.Verbose); err != nil {
+		return nil, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+	return times, nil
+}
+
+// validateContactSheetParams validates paths, the grid and the output format
+func validateContactSheetParams(ctx context.Context, params ContactSheetParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+	if analyzer.IsStdinPath(params.InputFile) {
+		return fmt.Errorf("a contact sheet cannot be made from stdin input")
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if err := securityPolicy.ValidateFilePath(params.OutputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	format := getFormatFromPath(params.OutputFile)
+	if !slices.Contains(FrameFormats, format) {
+		return fmt.Errorf("unsupported image format: %s (use %s)", format, strings.Join(FrameFormats, ", "))
+	}
+	if format == "webp" {
+		if encoders, err := AvailableEncoders(ctx); err == nil && !encoders["libwebp"] {
+			return fmt.Errorf("encoder libwebp is not available in this FFmpeg build (see ffmpeg -encoders)")
+		}
+	}
+
+	if params.Columns < 1 || params.Columns > 20 || params.Rows < 1 || params.Rows > 20 {
+		return fmt.Errorf("invalid grid %dx%d (columns and rows must be between 1 and 20)", params.Columns, params.Rows)
+	}
+	if params.ThumbWidth < 16 || params.ThumbWidth > 1920 || params.ThumbWidth%2 != 0 {
+		return fmt.Errorf("invalid thumbnail width %d (must be an even number between 16 and 1920)", params.ThumbWidth)
+	}
+	if params.Timestamps {
+		return checkFilterAvailable(ctx, "drawtext")
+	}
+	return nil
+}
+
+// planContactSheetTimes centers each frame in an equal share of the input, so the first and
+// last frames are not black fades
+func planContactSheetTimes(duration time.Duration, count int) []time.Duration {
+	share := duration / time.Duration(count)
+	times := make([]time.Duration, count)
+	for i := range times {
+		times[i] = (share*time.Duration(i) + share/2).Truncate(time.Millisecond)
+	}
+	return times
+}
+
+// buildContactSheetCommand seeks to every frame as its own input, takes one frame from each,
+// labels and scales it, and tiles them all into one image
+func buildContactSheetCommand(ctx context.Context, params ContactSheetParams, times []time.Duration, thumbHeight int) *exec.Cmd {
+	graph := NewFilterGraph()
+	args := make([]string, 0, 4*len(times)+12)
+	pads := make([]string, len(times))
+	for i, at := range times {
+		args = append(args, "-ss", fmt.Sprintf("%.3f", at.Seconds()), "-i", params.InputFile)
+
+		filter := fmt.Sprintf("trim=end_frame=1,setpts=PTS-STARTPTS,scale=%d:%d,setsar=1", params.ThumbWidth, thumbHeight)
+		if params.Timestamps {
+			filter += "," + contactSheetLabel(at, params.ThumbWidth)
+		}
+		pads[i] = fmt.Sprintf("t%d", i)
+		graph.Add([]string{fmt.Sprintf("%d:v:0", i)}, filter, []string{pads[i]})
+	}
+	graph.Add(pads, fmt.Sprintf("concat=n=%d:v=1:a=0,tile=%dx%d:padding=%d:margin=%d",
+		len(times), params.Columns, params.Rows, contactSheetSpacing, contactSheetSpacing), []string{"sheet"})
+
+	args = append(args,
+		"-filter_complex", graph.String(),
+		"-map", "[sheet]",
+		"-frames:v", "1",
+		"-update", "1") // One image, not a numbered sequence
+	switch getFormatFromPath(params.OutputFile) {
+	case "jpg":
+		args = append(args, "-q:v", "2")
+	case "webp":
+		args = append(args, "-c:v", "libwebp", "-quality", "90")
+	}
+
+	args = append(args, "-y", params.OutputFile)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
+
+// contactSheetLabel prints the time of a frame in its bottom right corner, on a dark box so it
+// stays readable on bright frames, sized to take about a third of the thumbnail width. Colons
+// are escaped for the drawtext option parser.
+func contactSheetLabel(at time.Duration, thumbWidth int) string {
+	text := strings.ReplaceAll(formatStreamPosition(at.Seconds()), ":", `\:`)
+	return fmt.Sprintf("drawtext=text='%s':fontsize=%d:fontcolor=white:box=1:boxcolor=black@0.6:boxborderw=3:x=w-tw-6:y=h-th-6",
+		text, max(10, thumbWidth/14))
+}
@@ -0,0 +1,308 @@
+package transcoder
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// CueSheet is a single audio file described as an album of tracks by a CUE file
+type CueSheet struct {
+	File      string // Audio file the sheet describes, as named in the sheet
+	Title     string // Album title
+	Performer string // Album artist
+	Date      string // Release date or year (REM DATE)
+	Genre     string // Genre (REM GENRE)
+	Tracks    []CueTrack
+}
+
+// CueTrack is one track of a CUE sheet
+type CueTrack struct {
+	Number    int
+	Title     string
+	Performer string        // Track artist; empty when it is the album artist
+	Start     time.Duration // Start of the track in the file (INDEX 01)
+}
+
+// cueTimeRegex matches CUE positions: minutes, seconds and frames of 1/75 second
+var cueTimeRegex = regexp.MustCompile(`^(\d+):(\d{2}):(\d{2})$`)
+
+// cueFileNameReplacer replaces characters that file systems or the path checks refuse in
+// track titles
+var cueFileNameReplacer = strings.NewReplacer(
+	"/", "-", "\\", "-", "|", "-", ":", " -", ";", ",", "&", "and",
+	"*", "", "?", "", "<", "", ">", "", "\"", "", "'", "", "`", "", "$", "",
+	"\t", " ", "\r", "", "\n", "",
+)
+
+// ParseCueSheet reads a CUE file. Sheets that are not UTF-8 are read as Latin-1, the encoding
+// older rippers write.
+func ParseCueSheet(path string) (*CueSheet, error) {
+	if err := securityPolicy.ValidateFilePath(path); err != nil {
+		return nil, fmt.Errorf("security validation failed for cue sheet path: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading cue sheet: %w", err)
+	}
+	data = bytes.TrimPrefix(data, []byte("\xef\xbb\xbf"))
+	if !utf8.Valid(data) {
+		runes := make([]rune, len(data))
+		for i, b := range data {
+			runes[i] = rune(b)
+		}
+		data = []byte(string(runes))
+	}
+
+	sheet, err := parseCueSheet(string(data))
+	if err != nil {
+		return nil, fmt.Errorf("invalid cue sheet %s: %w", path, err)
+	}
+	return sheet, nil
+}
+
+// parseCueSheet reads the album and track entries of a CUE sheet. Only single-file sheets are
+// supported, since they describe a rip that needs splitting.
+func parseCueSheet(content string) (*CueSheet, error) {
+	sheet := &CueSheet{}
+	var track *CueTrack
+	files := 0
+
+	scanner := bufio.NewScanner(strings.NewReader(content))
+	for lineNumber := 1; scanner.Scan(); lineNumber++ {
+		command, rest, _ := strings.Cut(strings.TrimSpace(scanner.Text()), " ")
+		rest = strings.TrimSpace(rest)
+
+		switch strings.ToUpper(command) {
+		case "FILE":
+			if files++; files > 1 {
+				return nil, fmt.Errorf("sheet describes several files; only single-file rips can be split")
+			}
+			// FILE "name.flac" WAVE
+			if i := strings.LastIndex(rest, " "); i > 0 {
+				rest = rest[:i]
+			}
+			sheet.File = cueValue(rest)
+		case "TITLE":
+			if track != nil {
+				track.Title = cueValue(rest)
+			} else {
+				sheet.Title = cueValue(rest)
+			}
+		case "PERFORMER":
+			if track != nil {
+				track.Performer = cueValue(rest)
+			} else {
+				sheet.Performer = cueValue(rest)
+			}
+		case "REM":
+			key, value, _ := strings.Cut(rest, " ")
+			switch strings.ToUpper(key) {
+			case "DATE":
+				sheet.Date = cueValue(value)
+			case "GENRE":
+				sheet.Genre = cueValue(value)
+			}
+		case "TRACK":
+			// TRACK 01 AUDIO
+			fields := strings.Fields(rest)
+			if len(fields) == 0 {
+				return nil, fmt.Errorf("line %d: TRACK without a number", lineNumber)
+			}
+			number, err := strconv.Atoi(fields[0])
+			if err != nil || number < 1 {
+				return nil, fmt.Errorf("line %d: invalid track number: %s", lineNumber, rest)
+			}
+			sheet.Tracks = append(sheet.Tracks, CueTrack{Number: number, Start: -1})
+			track = &sheet.Tracks[len(sheet.Tracks)-1]
+		case "INDEX":
+			// INDEX 01 03:25:40; index 00 marks the pregap, which stays with the track before
+			fields := strings.Fields(rest)
+			if track == nil || len(fields) != 2 || fields[0] != "01" {
+				continue
+			}
+			start, err := parseCueTime(fields[1])
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", lineNumber, err)
+			}
+			track.Start = start
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if len(sheet.Tracks) == 0 {
+		return nil, fmt.Errorf("no tracks found")
+	}
+	for i, track := range sheet.Tracks {
+		if track.Start < 0 {
+			return nil, fmt.Errorf("track %d has no INDEX 01", track.Number)
+		}
+		if i > 0 && track.Start <= sheet.Tracks[i-1].Start {
+			return nil, fmt.Errorf("track %d does not start after track %d", track.Number, sheet.Tracks[i-1].Number)
+		}
+	}
+	return sheet, nil
+}
+
+// cueValue removes the quotes around a CUE value
+func cueValue(value string) string {
+	value = strings.TrimSpace(value)
+	if len(value) >= 2 && strings.HasPrefix(value, `"`) && strings.HasSuffix(value, `"`) {
+		value = value[1 : len(value)-1]
+	}
+	return strings.TrimSpace(value)
+}
+
+// parseCueTime converts a CUE position (mm:ss:ff, with 75 frames per second) to a duration
+func parseCueTime(value string) (time.Duration, error) {
+	match := cueTimeRegex.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("invalid cue time: %s (expected mm:ss:ff)", value)
+	}
+	minutes, _ := strconv.Atoi(match[1])
+	seconds, _ := strconv.Atoi(match[2])
+	frames, _ := strconv.Atoi(match[3])
+	if seconds > 59 || frames > 74 {
+		return 0, fmt.Errorf("invalid cue time: %s", value)
+	}
+	return time.Duration(minutes)*time.Minute + time.Duration(seconds)*time.Second +
+		time.Duration(frames)*time.Second/75, nil
+}
+
+// CueTrackFileName names the output of a track "NN - Title.format", leaving out characters
+// that cannot appear in file names
+func CueTrackFileName(track CueTrack, total int, format string) string {
+	width := max(2, len(strconv.Itoa(total)))
+	name := fmt.Sprintf("%0*d", width, track.Number)
+
+	title := strings.Join(strings.Fields(cueFileNameReplacer.Replace(track.Title)), " ")
+	for strings.Contains(title, "..") {
+		title = strings.ReplaceAll(title, "..", ".")
+	}
+	if title = strings.Trim(title, " ."); title != "" {
+		name += " - " + title
+	}
+	return name + "." + format
+}
+
+// SplitByCueSheet extracts every track of a CUE sheet from the input into its own file in
+// outputDir, in the given audio format, tagged with its title, number and the album details.
+// The extraction settings apply to every track. Returns the files written, also on failure.
+func SplitByCueSheet(ctx context.Context, params AudioExtractionParams, sheet *CueSheet, outputDir, format string, overwrite bool) ([]string, error) {
+	if !IsAudioFormat(format) {
+		return nil, fmt.Errorf("unsupported track format: %s (use %s)", format, strings.Join(AudioFormats, ", "))
+	}
+	if err := securityPolicy.ValidateFilePath(outputDir); err != nil {
+		return nil, fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	// Plan every output up front so nothing is written if a name is unusable
+	params.OutputFile = filepath.Join(outputDir, CueTrackFileName(sheet.Tracks[0], len(sheet.Tracks), format))
+	if err := validateAudioExtractionParams(params); err != nil {
+		return nil, err
+	}
+	if analyzer.IsStdinPath(params.InputFile) {
+		return nil, fmt.Errorf("splitting by cue sheet cannot read stdin input")
+	}
+
+	mediaInfo, err := analyzeInputForAudioExtraction(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	last := sheet.Tracks[len(sheet.Tracks)-1]
+	if mediaInfo.Duration > 0 && last.Start >= mediaInfo.Duration {
+		return nil, fmt.Errorf("track %d starts at %s, after the end of the input (%s)",
+			last.Number, formatStreamPosition(last.Start.Seconds()), formatStreamPosition(mediaInfo.Duration.Seconds()))
+	}
+
+	trackParams := make([]AudioExtractionParams, 0, len(sheet.Tracks))
+	for i, cueTrack := range sheet.Tracks {
+		track := params
+		track.OutputFile = filepath.Join(outputDir, CueTrackFileName(cueTrack, len(sheet.Tracks), format))
+		track.Start = cueTrack.Start
+		track.End = 0
+		if i+1 < len(sheet.Tracks) {
+			track.End = sheet.Tracks[i+1].Start
+		}
+		track.Tags = cueTrackTags(sheet, cueTrack)
+
+		if err := validateAudioExtractionPaths(track); err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(track.OutputFile); err == nil && !overwrite {
+			return nil, fmt.Errorf("output file already exists: %s (use --force to overwrite)", track.OutputFile)
+		}
+		trackParams = append(trackParams, track)
+	}
+
+	if err := os.MkdirAll(outputDir, 0o755); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	outputs := make([]string, 0, len(trackParams))
+	for i, track := range trackParams {
+		if params.Verbose {
+			color.Cyan("💿 Track %d of %d: %s → %s", sheet.Tracks[i].Number, len(trackParams),
+				sheet.Tracks[i].Title, track.OutputFile)
+		}
+
+		codec, command, err := prepareAudioExtractionCommand(ctx, track, mediaInfo)
+		if err != nil {
+			return outputs, err
+		}
+		if params.Verbose {
+			displayAudioExtractionInfo(track, codec, command)
+		}
+
+		// Progress is measured against the length of the track
+		trackInfo := *mediaInfo
+		if track.End > 0 {
+			trackInfo.Duration = track.End - track.Start
+		} else if trackInfo.Duration > track.Start {
+			trackInfo.Duration -= track.Start
+		}
+		if err := executeAudioExtraction(ctx, track, command, &trackInfo); err != nil {
+			if ctx.Err() != nil {
+				return outputs, ctx.Err()
+			}
+			return outputs, fmt.Errorf("track %d: %w", sheet.Tracks[i].Number, err)
+		}
+		outputs = append(outputs, track.OutputFile)
+	}
+
+	return outputs, nil
+}
+
+// cueTrackTags are the tags of one track: its own title, artist and number, and the album's
+func cueTrackTags(sheet *CueSheet, track CueTrack) map[string]string {
+	tags := map[string]string{
+		"track": fmt.Sprintf("%d/%d", track.Number, len(sheet.Tracks)),
+	}
+	set := func(key, value string) {
+		if value != "" {
+			tags[key] = value
+		}
+	}
+	set("title", track.Title)
+	set("album", sheet.Title)
+	set("album_artist", sheet.Performer)
+	set("artist", sheet.Performer)
+	set("artist", track.Performer)
+	set("date", sheet.Date)
+	set("genre", sheet.Genre)
+	return tags
+}
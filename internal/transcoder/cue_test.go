@@ -0,0 +1,105 @@
+package transcoder
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestParseCueSheet(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		want    *CueSheet
+		wantErr bool
+	}{
+		{
+			name: "album",
+			content: `REM GENRE Jazz
+REM DATE 1959
+PERFORMER "Miles Davis"
+TITLE "Kind of Blue"
+FILE "Kind of Blue.flac" WAVE
+  TRACK 01 AUDIO
+    TITLE "So What"
+    INDEX 01 00:00:00
+  TRACK 02 AUDIO
+    TITLE "Freddie Freeloader"
+    INDEX 00 09:20:10
+    INDEX 01 09:22:30
+`,
+			want: &CueSheet{
+				File:      "Kind of Blue.flac",
+				Title:     "Kind of Blue",
+				Performer: "Miles Davis",
+				Date:      "1959",
+				Genre:     "Jazz",
+				Tracks: []CueTrack{
+					{Number: 1, Title: "So What", Start: 0},
+					{Number: 2, Title: "Freddie Freeloader", Start: 9*time.Minute + 22*time.Second + 400*time.Millisecond},
+				},
+			},
+		},
+		{
+			name: "track performers on a compilation",
+			content: "TITLE \"Mix\"\r\nFILE \"mix.wav\" WAVE\r\nTRACK 1 AUDIO\r\nPERFORMER \"A\"\r\nINDEX 01 00:00:00\r\n" +
+				"TRACK 2 AUDIO\r\nPERFORMER \"B\"\r\nINDEX 01 03:00:74\r\n",
+			want: &CueSheet{
+				File:  "mix.wav",
+				Title: "Mix",
+				Tracks: []CueTrack{
+					{Number: 1, Performer: "A", Start: 0},
+					{Number: 2, Performer: "B", Start: 3*time.Minute + 74*time.Second/75},
+				},
+			},
+		},
+		{
+			name:    "several files",
+			content: "FILE \"a.flac\" WAVE\nTRACK 01 AUDIO\nINDEX 01 00:00:00\nFILE \"b.flac\" WAVE\nTRACK 02 AUDIO\nINDEX 01 00:00:00\n",
+			wantErr: true,
+		},
+		{
+			name:    "no tracks",
+			content: "FILE \"a.flac\" WAVE\n",
+			wantErr: true,
+		},
+		{
+			name:    "track without INDEX 01",
+			content: "FILE \"a.flac\" WAVE\nTRACK 01 AUDIO\nINDEX 00 00:00:00\n",
+			wantErr: true,
+		},
+		{
+			name:    "tracks out of order",
+			content: "FILE \"a.flac\" WAVE\nTRACK 01 AUDIO\nINDEX 01 05:00:00\nTRACK 02 AUDIO\nINDEX 01 04:00:00\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid frame count",
+			content: "FILE \"a.flac\" WAVE\nTRACK 01 AUDIO\nINDEX 01 00:00:75\n",
+			wantErr: true,
+		},
+		{
+			name:    "invalid track number",
+			content: "FILE \"a.flac\" WAVE\nTRACK one AUDIO\nINDEX 01 00:00:00\n",
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseCueSheet(tt.content)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseCueSheet() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseCueSheet() error = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("parseCueSheet() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
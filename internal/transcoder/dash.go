@@ -0,0 +1,141 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// DashManifestName is the file name of the MPD manifest written into the output directory
+const DashManifestName = "manifest.mpd"
+
+// DashParams holds parameters for packaging a media file as MPEG-DASH
+type DashParams struct {
+	InputFile       string // Input file path
+	OutputDir       string // Directory receiving the manifest and segments
+	SegmentDuration int    // Target segment duration in seconds
+	Conform         bool   // Always encode to H.264/AAC, the DASH-IF interoperability baseline
+	Preset          string // Quality preset used when encoding
+	Verbose         bool   // Verbose output
+}
+
+// PackageDash writes an MPD manifest and fMP4 segments for the input and returns the manifest path.
+// Streams whose codecs DASH players support are copied; the rest are encoded to H.264/AAC.
+func PackageDash(ctx context.Context, params DashParams) (string, error) {
+	if err := validateDashParams(params); err != nil {
+		return "", err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return "", err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return "", fmt.Errorf("input has no video stream")
+	}
+
+	if err := os.MkdirAll(params.OutputDir, 0755); err != nil {
+		return "", fmt.Errorf("creating output directory: %w", err)
+	}
+
+	videoCodec, audioCodec := selectDashCodecs(inputInfo, params.Conform)
+	manifestPath := filepath.Join(params.OutputDir, DashManifestName)
+	cmd := buildDashCommand(ctx, params, videoCodec, audioCodec, manifestPath)
+
+	if params.Verbose {
+		if videoCodec == "copy" && audioCodec == "copy" {
+			color.Green("⚡ Using stream copy (no re-encoding needed)")
+		} else {
+			color.Yellow("🔄 Encoding to DASH-compatible codecs (video: %s, audio: %s)", videoCodec, audioCodec)
+		}
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		return "", stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+	return manifestPath, nil
+}
+
+// validateDashParams validates DASH paths and options
+func validateDashParams(params DashParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+
+	if err := securityPolicy.ValidateFilePath(params.OutputDir); err != nil {
+		return fmt.Errorf("security validation failed for output directory: %w", err)
+	}
+
+	if params.SegmentDuration < 1 || params.SegmentDuration > 60 {
+		return fmt.Errorf("invalid segment duration %d (must be between 1 and 60 seconds)", params.SegmentDuration)
+	}
+
+	return nil
+}
+
+// selectDashCodecs copies streams with codecs that fragmented MP4 players support, unless conformance
+// encoding is requested; everything else is encoded to H.264 and AAC
+func selectDashCodecs(inputInfo *analyzer.MediaInfo, conform bool) (string, string) {
+	videoCodec, audioCodec := "libx264", "aac"
+	if conform {
+		return videoCodec, audioCodec
+	}
+
+	if containsFold(fragmentedVideoCodecs, inputInfo.VideoStreams[0].Codec) {
+		videoCodec = "copy"
+	}
+	if len(inputInfo.AudioStreams) > 0 && containsFold(fragmentedAudioCodecs, inputInfo.AudioStreams[0].Codec) {
+		audioCodec = "copy"
+	}
+	return videoCodec, audioCodec
+}
+
+// playableH264Args keep encoded H.264 at 8-bit 4:2:0 High profile, which browsers and devices
+// decode; 10-bit or 4:2:2 sources would otherwise give High 10 or High 4:2:2 streams
+var playableH264Args = []string{"-pix_fmt", "yuv420p", "-profile:v", "high"}
+
+// buildDashCommand builds the FFmpeg command for the dash muxer.
+// Encoded video gets a keyframe at every segment boundary so segments have equal length.
+func buildDashCommand(ctx context.Context, params DashParams, videoCodec, audioCodec, manifestPath string) *exec.Cmd {
+	segmentDuration := strconv.Itoa(params.SegmentDuration)
+
+	args := []string{
+		"-i", params.InputFile,
+		"-map", "0:v:0", "-map", "0:a:0?",
+		"-c:v", videoCodec,
+	}
+	if videoCodec != "copy" {
+		args = append(args, playableH264Args...)
+		args = append(args,
+			"-b:v", getPresetVideoBitrate(params.Preset),
+			"-force_key_frames", "expr:gte(t,n_forced*"+segmentDuration+")")
+	}
+	args = append(args, "-c:a", audioCodec)
+	if audioCodec != "copy" {
+		args = append(args, "-b:a", getPresetAudioBitrate(params.Preset))
+	}
+
+	args = append(args, "-f", "dash")
+	if ffmpegSupports(ctx, segDurationVersion) {
+		args = append(args, "-seg_duration", segmentDuration)
+	} else {
+		// Older muxers take the segment length in microseconds
+		args = append(args, "-min_seg_duration", strconv.Itoa(params.SegmentDuration*1000000))
+	}
+	args = append(args,
+		"-use_template", "1",
+		"-use_timeline", "1",
+		"-init_seg_name", "init-$RepresentationID$.m4s",
+		"-media_seg_name", "chunk-$RepresentationID$-$Number%05d$.m4s",
+		"-y", manifestPath)
+
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
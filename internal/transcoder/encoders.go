@@ -0,0 +1,115 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/fatih/color"
+)
+
+// encoderFallbacks lists allowed encoders to try, in order, when an encoder is missing from the
+// local FFmpeg build (e.g., builds without libvpx-vp9 or libopus)
+var encoderFallbacks = map[string][]string{
+	"libvpx-vp9": {"libvpx"},
+	"libx265":    {"libx264"},
+	"libx264":    {"libx265"},
+	"libtheora":  {"libvpx"},
+	"libopus":    {"libvorbis", "aac"},
+	"libvorbis":  {"libopus", "aac"},
+	"libmp3lame": {"aac"},
+}
+
+// audioFormatCodecs lists the codecs audio-only formats can hold; they have no compatibility data
+var audioFormatCodecs = map[string][]string{
+	"mp3":  {"mp3"},
+	"aac":  {"aac"},
+	"adts": {"aac"},
+	"m4a":  {"aac", "alac"},
+	"ogg":  {"vorbis", "opus", "flac"},
+	"opus": {"opus"},
+	"wav":  {"pcm_s16le", "pcm_s24le", "pcm_s32le", "pcm_f32le"},
+	"flac": {"flac"},
+	"ac3":  {"ac3"},
+	"eac3": {"eac3"},
+	"dts":  {"dts"},
+}
+
+// encoderLists caches the encoders of every ffmpeg executable used
+var encoderLists buildQueries[map[string]bool]
+
+// AvailableEncoders lists the encoders compiled into the local ffmpeg build
+func AvailableEncoders(ctx context.Context) (map[string]bool, error) {
+	return encoderLists.get(ctx, func(ffmpeg string) (map[string]bool, error) {
+		out, err := exec.CommandContext(ctx, ffmpeg, "-hide_banner", "-encoders").Output()
+		if err != nil {
+			return nil, fmt.Errorf("listing ffmpeg encoders: %w", err)
+		}
+		return parseEncoderList(string(out)), nil
+	})
+}
+
+// parseEncoderList extracts encoder names from `ffmpeg -encoders` output
+func parseEncoderList(output string) map[string]bool {
+	encoders := make(map[string]bool)
+	inList := false
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		// The list starts after the " ------" separator line
+		if len(fields) == 1 && strings.HasPrefix(fields[0], "---") {
+			inList = true
+			continue
+		}
+		if inList && len(fields) >= 2 {
+			encoders[fields[1]] = true
+		}
+	}
+	return encoders
+}
+
+// resolveEncoder checks that an encoder exists in the local FFmpeg build and otherwise picks the
+// first available fallback the output container accepts. When the encoder list cannot be read,
+// the encoder is kept and FFmpeg reports any problem itself.
+func resolveEncoder(ctx context.Context, encoder, outputFormat string, verbose bool) (string, error) {
+	if encoder == "" || encoder == "copy" {
+		return encoder, nil
+	}
+
+	encoders, err := AvailableEncoders(ctx)
+	if err != nil || encoders[encoder] {
+		return encoder, nil
+	}
+
+	for _, fallback := range encoderFallbacks[encoder] {
+		if encoders[fallback] && containerAcceptsEncoder(outputFormat, fallback) {
+			if verbose {
+				color.Yellow("⚠️  Encoder %s is not available in this FFmpeg build; using %s instead", encoder, fallback)
+			}
+			return fallback, nil
+		}
+	}
+
+	return "", fmt.Errorf("encoder %s is not available in this FFmpeg build (see ffmpeg -encoders)", encoder)
+}
+
+// containerAcceptsEncoder reports whether the codec an encoder produces can be stored in the
+// container; containers without compatibility data accept anything
+func containerAcceptsEncoder(format, encoder string) bool {
+	codec := encoderCodecs[encoder]
+	if codecs, ok := audioFormatCodecs[format]; ok {
+		return containsFold(codecs, codec)
+	}
+
+	compat, ok, err := GetContainerCompatibility(format)
+	if err != nil || !ok {
+		return true
+	}
+
+	for _, entry := range append(compat.VideoCodecs, compat.AudioCodecs...) {
+		if entry.matches(codec) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,63 @@
+package transcoder
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// HLS AES-128 key files written into the output directory
+const (
+	HLSKeyName     = "enc.key"     // 16-byte AES-128 key
+	hlsKeyInfoName = "enc.keyinfo" // Key info file read by FFmpeg's -hls_key_info_file
+)
+
+// defaultHLSKeyURI points from a rendition playlist (e.g., 720p/index.m3u8) to the key in the output directory
+const defaultHLSKeyURI = "../" + HLSKeyName
+
+// validateHLSKeyURI rejects key URIs that would break the playlist or the key info file
+func validateHLSKeyURI(uri string) error {
+	if uri == "" {
+		return nil
+	}
+	if len(uri) > securityPolicy.MaxPathLength {
+		return fmt.Errorf("key URI too long (max %d characters)", securityPolicy.MaxPathLength)
+	}
+	if strings.ContainsAny(uri, " \t\r\n\"") {
+		return fmt.Errorf("key URI must not contain whitespace or quotes: %s", uri)
+	}
+	return nil
+}
+
+// writeHLSKeyInfo generates a random AES-128 key and IV and writes the key and key info files.
+// The key info file holds the URI written into playlists, the local key path and the IV.
+func writeHLSKeyInfo(outputDir, keyURI string) (string, error) {
+	if keyURI == "" {
+		keyURI = defaultHLSKeyURI
+	}
+
+	key := make([]byte, 16)
+	iv := make([]byte, 16)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("generating encryption key: %w", err)
+	}
+	if _, err := rand.Read(iv); err != nil {
+		return "", fmt.Errorf("generating encryption IV: %w", err)
+	}
+
+	keyPath := filepath.Join(outputDir, HLSKeyName)
+	if err := os.WriteFile(keyPath, key, 0600); err != nil {
+		return "", fmt.Errorf("writing encryption key: %w", err)
+	}
+
+	keyInfoPath := filepath.Join(outputDir, hlsKeyInfoName)
+	keyInfo := fmt.Sprintf("%s\n%s\n%s\n", keyURI, keyPath, hex.EncodeToString(iv))
+	if err := os.WriteFile(keyInfoPath, []byte(keyInfo), 0600); err != nil {
+		return "", fmt.Errorf("writing key info file: %w", err)
+	}
+
+	return keyInfoPath, nil
+}
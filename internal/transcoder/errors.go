@@ -0,0 +1,123 @@
+package transcoder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FFmpegErrorKind is the cause of an FFmpeg failure, as read from its stderr
+type FFmpegErrorKind int
+
+const (
+	ErrorUnclassified           FFmpegErrorKind = iota // No known cause was recognized
+	ErrorUnknownEncoder                                // The encoder is not part of the FFmpeg build
+	ErrorNoSpace                                       // The disk the output is written to is full
+	ErrorPermissionDenied                              // A file could not be read or written
+	ErrorCorruptInput                                  // The input is damaged, truncated or not media
+	ErrorUnsupportedPixelFormat                        // The encoder cannot take the pixel format
+)
+
+// String returns the name of the kind used in logs (e.g., "no_space")
+func (k FFmpegErrorKind) String() string {
+	switch k {
+	case ErrorUnknownEncoder:
+		return "unknown_encoder"
+	case ErrorNoSpace:
+		return "no_space"
+	case ErrorPermissionDenied:
+		return "permission_denied"
+	case ErrorCorruptInput:
+		return "corrupt_input"
+	case ErrorUnsupportedPixelFormat:
+		return "unsupported_pixel_format"
+	}
+	return "unclassified"
+}
+
+// FFmpegError is a failed FFmpeg run with the cause read from its stderr
+type FFmpegError struct {
+	Kind   FFmpegErrorKind // Cause of the failure
+	Detail string          // FFmpeg's message about the failure
+	Hint   string          // What to do about it (empty when unclassified)
+	Err    error           // Error the process failed with
+}
+
+func (e *FFmpegError) Error() string {
+	msg := fmt.Sprintf("%v: %s", e.Err, e.Detail)
+	if e.Hint != "" {
+		msg += "\n💡 " + e.Hint
+	}
+	return msg
+}
+
+func (e *FFmpegError) Unwrap() error {
+	return e.Err
+}
+
+// ffmpegErrorPatterns are the stderr messages of each kind (matched case-insensitively), with
+// the hint shown for them. The first kind with a matching line, checked from the last line up,
+// is reported.
+var ffmpegErrorPatterns = []struct {
+	kind     FFmpegErrorKind
+	patterns []string
+	hint     string
+}{
+	{
+		kind:     ErrorNoSpace,
+		patterns: []string{"no space left on device", "disk quota exceeded"},
+		hint:     "The disk is full. Free up space or write the output to another drive.",
+	},
+	{
+		kind:     ErrorPermissionDenied,
+		patterns: []string{"permission denied", "operation not permitted", "read-only file system"},
+		hint:     "Check that the input is readable and the output directory is writable.",
+	},
+	{
+		kind:     ErrorUnknownEncoder,
+		patterns: []string{"unknown encoder", "encoder not found", "encoding requested, but no encoder"},
+		hint: "This FFmpeg build lacks the encoder. Pick another codec (see transcoder recommend or " +
+			"ffmpeg -encoders) or install a full FFmpeg build.",
+	},
+	{
+		kind: ErrorUnsupportedPixelFormat,
+		patterns: []string{"is invalid or not supported", "incompatible pixel format", "unsupported pixel format",
+			"impossible to convert between the formats"},
+		hint: "The encoder cannot take the input's pixel format. Convert it with " +
+			"--ffmpeg-args \"-pix_fmt yuv420p\" or choose another codec.",
+	},
+	{
+		kind: ErrorCorruptInput,
+		patterns: []string{"invalid data found when processing input", "moov atom not found",
+			"file ended prematurely", "truncated"},
+		hint: "The input is damaged or incomplete. Try transcoder repair, or wait until the file " +
+			"has finished copying or recording.",
+	},
+}
+
+// classifyFFmpegError explains a failed FFmpeg run from the end of its stderr. Without
+// stderr the error is returned unchanged.
+func classifyFFmpegError(err error, stderr string) error {
+	lines := strings.Split(stderr, "\n")
+
+	for _, entry := range ffmpegErrorPatterns {
+		for i := len(lines) - 1; i >= 0; i-- {
+			lower := strings.ToLower(lines[i])
+			for _, pattern := range entry.patterns {
+				if strings.Contains(lower, pattern) {
+					return &FFmpegError{Kind: entry.kind, Detail: lines[i], Hint: entry.hint, Err: err}
+				}
+			}
+		}
+	}
+
+	// Report the last line that says more than that the run failed
+	for i := len(lines) - 1; i >= 0; i-- {
+		line := lines[i]
+		if line == "" || line == "Conversion failed!" || strings.HasPrefix(line, "frame=") ||
+			strings.HasPrefix(line, "size=") {
+			continue
+		}
+		return &FFmpegError{Kind: ErrorUnclassified, Detail: line, Err: err}
+	}
+	return err
+}
@@ -0,0 +1,57 @@
+package transcoder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// ParseFFmpegArgs splits a string of extra FFmpeg arguments on whitespace. Single or double
+// quotes group words into one argument (e.g., -metadata "title=My Movie"); nothing is expanded,
+// since the arguments are passed to FFmpeg directly rather than through a shell.
+func ParseFFmpegArgs(input string) ([]string, error) {
+	var args []string
+	var current strings.Builder
+	inArg := false
+	var quote rune
+
+	for _, r := range input {
+		switch {
+		case quote != 0 && r == quote:
+			quote = 0
+		case quote != 0:
+			current.WriteRune(r)
+		case r == '"' || r == '\'':
+			quote = r
+			inArg = true
+		case r == ' ' || r == '\t' || r == '\n':
+			if inArg {
+				args = append(args, current.String())
+				current.Reset()
+				inArg = false
+			}
+		default:
+			current.WriteRune(r)
+			inArg = true
+		}
+	}
+
+	if quote != 0 {
+		return nil, fmt.Errorf("unterminated %c quote in ffmpeg arguments", quote)
+	}
+	if inArg {
+		args = append(args, current.String())
+	}
+	return args, nil
+}
+
+// validateExtraArgs checks extra FFmpeg arguments against the allowlist unless they were
+// explicitly marked unsafe
+func validateExtraArgs(customParams CustomParameters) error {
+	if len(customParams.ExtraArgs) == 0 || customParams.UnsafeExtraArgs {
+		return nil
+	}
+	if err := securityPolicy.ValidateExtraArgs(customParams.ExtraArgs); err != nil {
+		return fmt.Errorf("security validation failed for ffmpeg arguments: %w (use --unsafe to pass them unchecked)", err)
+	}
+	return nil
+}
@@ -0,0 +1,201 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/logging"
+)
+
+// hardwareEncoderSuffixes mark FFmpeg encoders that run on a GPU or media engine
+var hardwareEncoderSuffixes = []string{"_nvenc", "_qsv", "_vaapi", "_videotoolbox", "_amf", "_v4l2m2m", "_mf", "_omx"}
+
+// softwareEncoders replace hardware encoders of the same codec family
+var softwareEncoders = map[string]string{
+	"h264": "libx264",
+	"hevc": "libx265",
+	"vp9":  "libvpx-vp9",
+	"vp8":  "libvpx",
+}
+
+// fallbackEncode holds the settings of one attempt at a failed encode
+type fallbackEncode struct {
+	videoCodec   string
+	audioCodec   string
+	params       CustomParameters
+	outputFormat string
+	inputInfo    *analyzer.MediaInfo
+}
+
+// fallbackStrategy describes one change that makes a failed encode more likely to succeed
+type fallbackStrategy struct {
+	Name        string // Short strategy name
+	Description string // Human-readable explanation
+
+	// apply changes the attempt and reports whether anything changed
+	apply func(ctx context.Context, encode *fallbackEncode) bool
+}
+
+// fallbackStrategies are applied one after another, each on top of the ones before it
+var fallbackStrategies = []fallbackStrategy{
+	{
+		Name:        "software-encoder",
+		Description: "re-encode the video with a software encoder",
+		apply:       useSoftwareEncoder,
+	},
+	{
+		Name:        "yuv420p",
+		Description: "convert the video to the yuv420p pixel format",
+		apply:       useYUV420P,
+	},
+	{
+		Name:        "genpts",
+		Description: "regenerate missing timestamps and shift negative ones to zero",
+		apply: func(_ context.Context, encode *fallbackEncode) bool {
+			if encode.params.FixTimestamps {
+				return false
+			}
+			encode.params.FixTimestamps = true
+			return true
+		},
+	},
+}
+
+// validateRetryFallback rejects inputs and outputs an encode cannot be repeated with
+func validateRetryFallback(inputPath, outputPath string, customParams CustomParameters) error {
+	switch {
+	case analyzer.IsStdinPath(inputPath):
+		return fmt.Errorf("--retry-fallback cannot be used with stdin input")
+	case IsStdoutPath(outputPath):
+		return fmt.Errorf("--retry-fallback cannot be used with stdout output")
+	case customParams.Resumable:
+		return fmt.Errorf("--retry-fallback cannot be combined with --resumable")
+	}
+	return nil
+}
+
+// retryWithFallback repeats a failed encode with the fallback strategies applied one by one,
+// until an attempt succeeds. The strategy that succeeded is shown and logged.
+func retryWithFallback(ctx context.Context, inputPath, outputPath string, encode fallbackEncode, preset string, encodeErr error, verbose bool) error {
+	tried := []string{}
+	for i, strategy := range fallbackStrategies {
+		if ctx.Err() != nil {
+			break
+		}
+		if !strategy.apply(ctx, &encode) {
+			continue
+		}
+		tried = append(tried, strategy.Name)
+
+		if verbose {
+			color.Yellow("⚠️  Encode failed: %v", encodeErr)
+			color.Cyan("🔁 Fallback %d/%d: %s", i+1, len(fallbackStrategies), strategy.Description)
+		}
+		logging.Logger().Warn("encode failed, retrying with fallback",
+			"fallback", strategy.Name, "error", encodeErr.Error())
+
+		cmd := buildFFmpegCommandWithCustomParams(ctx, inputPath, outputPath, encode.videoCodec, encode.audioCodec, preset, encode.params, verbose)
+		if cmd == nil {
+			return fmt.Errorf("failed to build secure FFmpeg command")
+		}
+		if verbose {
+			fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+		}
+
+		if encodeErr = executeFFmpeg(ctx, cmd, encode.inputInfo, verbose); encodeErr == nil {
+			if verbose {
+				color.Green("✅ Fallback %s succeeded", strategy.Name)
+			}
+			logging.Logger().Info("fallback succeeded", "fallback", strategy.Name)
+			return nil
+		}
+	}
+
+	if len(tried) == 0 {
+		return encodeErr
+	}
+	return fmt.Errorf("fallbacks (%s) failed too: %w", strings.Join(tried, ", "), encodeErr)
+}
+
+// useSoftwareEncoder drops hardware encoders given with --ffmpeg-args and re-encodes stream
+// copied video with the container's default encoder
+func useSoftwareEncoder(ctx context.Context, encode *fallbackEncode) bool {
+	changed := false
+
+	args := make([]string, 0, len(encode.params.ExtraArgs))
+	for i := 0; i < len(encode.params.ExtraArgs); i++ {
+		arg := encode.params.ExtraArgs[i]
+		if isVideoCodecOption(arg) && i+1 < len(encode.params.ExtraArgs) && isHardwareEncoder(encode.params.ExtraArgs[i+1]) {
+			if encode.videoCodec == "copy" {
+				encode.videoCodec = softwareEncoderFor(encode.params.ExtraArgs[i+1], encode.outputFormat)
+			}
+			i++
+			changed = true
+			continue
+		}
+		args = append(args, arg)
+	}
+	encode.params.ExtraArgs = args
+
+	if encode.videoCodec == "copy" && len(encode.inputInfo.VideoStreams) > 0 {
+		encode.videoCodec, _ = getDefaultCodecs(encode.outputFormat)
+		changed = true
+	}
+	if changed {
+		// The replacement may itself be missing from the FFmpeg build
+		if codec, err := resolveEncoder(ctx, encode.videoCodec, encode.outputFormat, false); err == nil {
+			encode.videoCodec = codec
+		}
+	}
+	return changed
+}
+
+// useYUV420P makes re-encoded video 8-bit 4:2:0, which every encoder and player accepts
+func useYUV420P(_ context.Context, encode *fallbackEncode) bool {
+	if encode.videoCodec == "" || encode.videoCodec == "copy" || len(encode.inputInfo.VideoStreams) == 0 {
+		return false
+	}
+
+	args := make([]string, 0, len(encode.params.ExtraArgs)+2)
+	for i := 0; i < len(encode.params.ExtraArgs); i++ {
+		if encode.params.ExtraArgs[i] == "-pix_fmt" && i+1 < len(encode.params.ExtraArgs) {
+			if encode.params.ExtraArgs[i+1] == "yuv420p" {
+				return false
+			}
+			i++
+			continue
+		}
+		args = append(args, encode.params.ExtraArgs[i])
+	}
+	encode.params.ExtraArgs = append(args, "-pix_fmt", "yuv420p")
+	return true
+}
+
+// isVideoCodecOption reports whether an FFmpeg option selects the video encoder
+func isVideoCodecOption(arg string) bool {
+	return arg == "-c:v" || arg == "-codec:v" || arg == "-vcodec"
+}
+
+// isHardwareEncoder reports whether an encoder runs on a GPU or media engine (e.g., h264_nvenc)
+func isHardwareEncoder(encoder string) bool {
+	for _, suffix := range hardwareEncoderSuffixes {
+		if strings.HasSuffix(encoder, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// softwareEncoderFor returns the software encoder for a hardware encoder's codec family,
+// or the container's default video encoder
+func softwareEncoderFor(hardwareEncoder, outputFormat string) string {
+	family, _, _ := strings.Cut(hardwareEncoder, "_")
+	if encoder, ok := softwareEncoders[family]; ok {
+		return encoder
+	}
+	videoCodec, _ := getDefaultCodecs(outputFormat)
+	return videoCodec
+}
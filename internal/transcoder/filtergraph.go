@@ -0,0 +1,36 @@
+package transcoder
+
+import (
+	"fmt"
+	"strings"
+)
+
+// FilterGraph builds an FFmpeg -filter_complex graph from labeled filter chains
+type FilterGraph struct {
+	chains []string
+}
+
+// NewFilterGraph creates an empty filter graph
+func NewFilterGraph() *FilterGraph {
+	return &FilterGraph{}
+}
+
+// Add appends a chain that reads the input pads, applies the filter and writes the output pads,
+// e.g. Add([]string{"0:v"}, "split=2", []string{"v0", "v1"}) becomes "[0:v]split=2[v0][v1]"
+func (g *FilterGraph) Add(inputs []string, filter string, outputs []string) *FilterGraph {
+	var chain strings.Builder
+	for _, input := range inputs {
+		fmt.Fprintf(&chain, "[%s]", input)
+	}
+	chain.WriteString(filter)
+	for _, output := range outputs {
+		fmt.Fprintf(&chain, "[%s]", output)
+	}
+	g.chains = append(g.chains, chain.String())
+	return g
+}
+
+// String renders the graph for -filter_complex
+func (g *FilterGraph) String() string {
+	return strings.Join(g.chains, ";")
+}
@@ -0,0 +1,96 @@
+package transcoder
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// encoderCodecs maps the encoders convert accepts to the codec they produce
+var encoderCodecs = map[string]string{
+	"libx264":    "h264",
+	"libx265":    "hevc",
+	"libvpx-vp9": "vp9",
+	"libvpx":     "vp8",
+	"mpeg2video": "mpeg2video",
+	"libtheora":  "theora",
+	"ffv1":       "ffv1",
+	"utvideo":    "utvideo",
+	"aac":        "aac",
+	"libopus":    "opus",
+	"libmp3lame": "mp3",
+	"libvorbis":  "vorbis",
+	"flac":       "flac",
+	"pcm_s16le":  "pcm_s16le",
+	"pcm_s24le":  "pcm_s24le",
+	"pcm_s32le":  "pcm_s32le",
+	"pcm_f32le":  "pcm_f32le",
+	"alac":       "alac",
+	"ac3":        "ac3",
+	"eac3":       "eac3",
+}
+
+// Codecs that fragmented MP4 players (Media Source Extensions, CMAF) can play
+var (
+	fragmentedVideoCodecs = []string{"h264", "hevc", "av1", "vp9"}
+	fragmentedAudioCodecs = []string{"aac", "opus", "flac", "ac3", "eac3"}
+)
+
+// validateFragmentedOutput checks that fragmented output goes to MP4 with codecs fMP4 players support.
+// Stream copied streams are checked by their source codec.
+func validateFragmentedOutput(inputInfo *analyzer.MediaInfo, outputFormat, videoCodec, audioCodec string, customParams CustomParameters) error {
+	if outputFormat != "mp4" {
+		return fmt.Errorf("fragmented output requires an .mp4 output file, not .%s", outputFormat)
+	}
+
+	if len(inputInfo.VideoStreams) > 0 {
+		codec := outputCodec(videoCodec, inputInfo.VideoStreams[0].Codec)
+		if !containsFold(fragmentedVideoCodecs, codec) {
+			return fmt.Errorf("video codec %s is not supported in fragmented MP4 (use one of: %s)",
+				codec, strings.Join(fragmentedVideoCodecs, ", "))
+		}
+	}
+
+	if !customParams.NoAudio && len(inputInfo.AudioStreams) > 0 {
+		position := 0
+		if number, err := strconv.Atoi(customParams.AudioStream); err == nil && number >= 1 && number <= len(inputInfo.AudioStreams) {
+			position = number - 1
+		}
+		codec := outputCodec(audioCodec, inputInfo.AudioStreams[position].Codec)
+		if !containsFold(fragmentedAudioCodecs, codec) {
+			return fmt.Errorf("audio codec %s is not supported in fragmented MP4 (use one of: %s)",
+				codec, strings.Join(fragmentedAudioCodecs, ", "))
+		}
+	}
+
+	for _, track := range customParams.AddAudio {
+		if codec := encoderCodecs[track.codec]; codec != "" && !containsFold(fragmentedAudioCodecs, codec) {
+			return fmt.Errorf("added audio track %s would use %s, which is not supported in fragmented MP4", track.Path, codec)
+		}
+	}
+
+	return nil
+}
+
+// outputCodec returns the codec a stream ends up with: the source codec when copied, else the encoder's codec
+func outputCodec(encoder, sourceCodec string) string {
+	if encoder == "copy" {
+		return sourceCodec
+	}
+	if codec, ok := encoderCodecs[encoder]; ok {
+		return codec
+	}
+	return encoder
+}
+
+// containsFold reports whether values contains value, ignoring case
+func containsFold(values []string, value string) bool {
+	for _, candidate := range values {
+		if strings.EqualFold(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
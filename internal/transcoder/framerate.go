@@ -0,0 +1,47 @@
+package transcoder
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// standardFrameRates are the rates constant frame rate output snaps to, as accepted by --framerate
+var standardFrameRates = []string{"23.976", "24", "25", "29.97", "30", "48", "50", "59.94", "60", "120"}
+
+// detectConstantFrameRate picks a constant output rate for the first video stream.
+// The average rate is used because the nominal rate of VFR footage is often a
+// timebase artifact (e.g., 1000 fps), then snapped to the nearest standard rate.
+func detectConstantFrameRate(inputInfo *analyzer.MediaInfo) (string, error) {
+	if len(inputInfo.VideoStreams) == 0 {
+		return "", fmt.Errorf("constant frame rate output requires a video stream")
+	}
+
+	stream := inputInfo.VideoStreams[0]
+	fps := analyzer.ParseFrameRate(stream.AvgFrameRate)
+	if fps <= 0 {
+		fps = analyzer.ParseFrameRate(stream.FrameRate)
+	}
+	if fps <= 0 {
+		return "", fmt.Errorf("could not detect the source frame rate (use --framerate)")
+	}
+
+	return snapFrameRate(fps), nil
+}
+
+// snapFrameRate returns the standard rate within 5% of fps, or fps rounded to a whole number
+func snapFrameRate(fps float64) string {
+	best, bestDiff := "", math.MaxFloat64
+	for _, rate := range standardFrameRates {
+		value, _ := strconv.ParseFloat(rate, 64)
+		if diff := math.Abs(value-fps) / value; diff < bestDiff {
+			best, bestDiff = rate, diff
+		}
+	}
+	if bestDiff <= 0.05 {
+		return best
+	}
+	return strconv.Itoa(max(1, int(math.Round(fps))))
+}
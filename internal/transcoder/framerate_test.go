@@ -0,0 +1,28 @@
+package transcoder
+
+import "testing"
+
+func TestSnapFrameRate(t *testing.T) {
+	tests := []struct {
+		fps  float64
+		want string
+	}{
+		{fps: 30, want: "30"},
+		{fps: 29.97, want: "29.97"},
+		{fps: 29.5, want: "29.97"}, // Phone VFR averages just below the nominal rate
+		{fps: 23.98, want: "23.976"},
+		{fps: 24.3, want: "24"},
+		{fps: 25.4, want: "25"},
+		{fps: 58.2, want: "59.94"},
+		{fps: 118, want: "120"},
+		{fps: 15, want: "15"}, // No standard rate within 5%
+		{fps: 36.6, want: "37"},
+		{fps: 0.2, want: "1"},
+	}
+
+	for _, tt := range tests {
+		if got := snapFrameRate(tt.fps); got != tt.want {
+			t.Errorf("snapFrameRate(%g) = %q, want %q", tt.fps, got, tt.want)
+		}
+	}
+}
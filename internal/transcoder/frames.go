@@ -0,0 +1,171 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// DefaultFrameTemplate names extracted frames after the input and their number
+const DefaultFrameTemplate = "{name}_{n}"
+
+// FrameFormats lists the image formats frames can be extracted to
+var FrameFormats = []string{"jpg", "png", "webp"}
+
+// FramesParams holds parameters for extracting frames of a video as images
+type FramesParams struct {
+	InputFile string        // Input video file path
+	OutputDir string        // Directory receiving the images
+	FPS       float64       // Frames per second to extract; used unless Every or All is set
+	Every     time.Duration // Extract one frame per interval
+	All       bool          // Extract every frame
+	Format    string        // Image format: jpg, png or webp
+	Template  string        // File name without extension; {name} is the input name, {n} the frame number
+	Verbose   bool          // Verbose output
+}
+
+// ExtractFrames writes frames of the first video stream into the output directory as images,
+// at a fixed rate, one per interval or all of them. It returns the number of images written.
+func ExtractFrames(ctx context.Context, params FramesParams) (int, error) {
+	if err := validateFramesParams(ctx, params); err != nil {
+		return 0, err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return 0, err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return 0, fmt.Errorf("input has no video stream")
+	}
+
+	if err := os.MkdirAll(params.OutputDir, 0755); err != nil {
+		return 0, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	pattern := FramePattern(params.InputFile, params.OutputDir, params.Template, params.Format)
+	cmd := buildFramesCommand(ctx, params, pattern)
+	if params.Verbose {
+		color.Cyan("🖼️  Extracting %s as %s", describeFrameRate(params), strings.ToUpper(params.Format))
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		return 0, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+
+	count := 0
+	for {
+		if _, err := os.Stat(fmt.Sprintf(pattern, count+1)); err != nil {
+			break
+		}
+		count++
+	}
+	return count, nil
+}
+
+// validateFramesParams validates paths, the extraction rate, the format and the template
+func validateFramesParams(ctx context.Context, params FramesParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+	if err := securityPolicy.ValidateFilePath(params.OutputDir); err != nil {
+		return fmt.Errorf("security validation failed for output directory: %w", err)
+	}
+
+	switch {
+	case params.All:
+	case params.Every > 0:
+		if params.Every < time.Millisecond {
+			return fmt.Errorf("invalid interval %s (must be at least 1ms)", params.Every)
+		}
+	case params.FPS <= 0 || params.FPS > 1000:
+		return fmt.Errorf("invalid frame rate %g (must be above 0 and at most 1000)", params.FPS)
+	}
+
+	if !slices.Contains(FrameFormats, params.Format) {
+		return fmt.Errorf("invalid image format '%s'. Valid options: %s", params.Format, strings.Join(FrameFormats, ", "))
+	}
+	if params.Format == "webp" {
+		if encoders, err := AvailableEncoders(ctx); err == nil && !encoders["libwebp"] {
+			return fmt.Errorf("encoder libwebp is not available in this FFmpeg build (see ffmpeg -encoders)")
+		}
+	}
+
+	return validateFrameTemplate(params.Template)
+}
+
+// validateFrameTemplate requires exactly one frame number and a plain file name
+func validateFrameTemplate(template string) error {
+	if strings.Count(template, "{n}") != 1 {
+		return fmt.Errorf("invalid name template '%s' (must contain {n} once)", template)
+	}
+	if strings.ContainsAny(template, `/\`) || strings.Contains(template, "..") {
+		return fmt.Errorf("invalid name template '%s' (must be a file name, not a path)", template)
+	}
+	return nil
+}
+
+// FramePattern turns a name template into the numbered image2 pattern FFmpeg writes to
+// (e.g., "{name}_{n}" for movie.mp4 becomes "dir/movie_%05d.jpg")
+func FramePattern(inputFile, outputDir, template, format string) string {
+	name := strings.TrimSuffix(filepath.Base(inputFile), filepath.Ext(inputFile))
+	// A literal % in a name would be read as a pattern by FFmpeg
+	escaper := strings.NewReplacer("%", "%%")
+	parts := strings.SplitN(template, "{n}", 2)
+	for i, part := range parts {
+		parts[i] = escaper.Replace(strings.ReplaceAll(part, "{name}", name))
+	}
+	return filepath.Join(outputDir, strings.Join(parts, "%05d")+"."+format)
+}
+
+// describeFrameRate describes which frames are extracted, for verbose output
+func describeFrameRate(params FramesParams) string {
+	switch {
+	case params.All:
+		return "every frame"
+	case params.Every > 0:
+		return fmt.Sprintf("one frame every %s", params.Every)
+	default:
+		return fmt.Sprintf("%g frames per second", params.FPS)
+	}
+}
+
+// buildFramesCommand samples the frames with the fps filter, or passes every frame through
+// unchanged, and encodes each as an image
+func buildFramesCommand(ctx context.Context, params FramesParams, pattern string) *exec.Cmd {
+	args := []string{"-i", params.InputFile, "-map", "0:v:0"}
+
+	switch {
+	case params.All:
+		// Without this, FFmpeg duplicates or drops frames to a constant rate
+		if ffmpegSupports(ctx, fpsModeVersion) {
+			args = append(args, "-fps_mode", "passthrough")
+		} else {
+			args = append(args, "-vsync", "passthrough")
+		}
+	case params.Every > 0:
+		args = append(args, "-vf", "fps=1/"+strconv.FormatFloat(params.Every.Seconds(), 'f', -1, 64))
+	default:
+		args = append(args, "-vf", "fps="+strconv.FormatFloat(params.FPS, 'f', -1, 64))
+	}
+
+	switch params.Format {
+	case "jpg":
+		args = append(args, "-q:v", "2")
+	case "webp":
+		args = append(args, "-c:v", "libwebp", "-quality", "90")
+	}
+
+	args = append(args, "-an", "-y", pattern)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
@@ -0,0 +1,176 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// AnimationFormats lists the animated image formats a clip can be written to
+var AnimationFormats = []string{"gif", "webp", "avif"}
+
+// animationEncoders lists the encoders for each animated format, in order of preference
+var animationEncoders = map[string][]string{
+	"webp": {"libwebp_anim", "libwebp"},
+	"avif": {"libsvtav1", "libaom-av1"},
+}
+
+// AnimationParams holds parameters for turning part of a video into an animated image
+type AnimationParams struct {
+	InputFile  string        // Input video file path
+	OutputFile string        // Output path; its extension selects the format (gif, webp or avif)
+	Start      time.Duration // Start of the clip in the input
+	Duration   time.Duration // Length of the clip
+	FPS        int           // Frame rate of the animation
+	Width      int           // Width in pixels; the height keeps the aspect ratio
+	Verbose    bool          // Verbose output
+}
+
+// CreateAnimation encodes a clip of the input as a looping animated image. GIFs get a palette
+// computed from the clip; WebP and AVIF are encoded as video, which makes them a fraction of
+// the size of a GIF for web previews.
+func CreateAnimation(ctx context.Context, params AnimationParams) error {
+	format, err := validateAnimationParams(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return fmt.Errorf("input has no video stream")
+	}
+	if inputInfo.Duration > 0 && params.Start >= inputInfo.Duration {
+		return fmt.Errorf("clip start %s is past the end of the input (%s)", params.Start, formatDuration(inputInfo.Duration))
+	}
+
+	encoder, err := selectAnimationEncoder(ctx, format)
+	if err != nil {
+		return err
+	}
+
+	cmd := buildAnimationCommand(ctx, params, format, encoder)
+	if params.Verbose {
+		color.Cyan("🎞️  %s at %d fps, %dpx wide", strings.ToUpper(format), params.FPS, params.Width)
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	clipInfo := *inputInfo
+	clipInfo.Duration = params.Duration
+	if inputInfo.Duration > 0 {
+		clipInfo.Duration = min(params.Duration, inputInfo.Duration-params.Start)
+	}
+	if err := executeFFmpeg(ctx, cmd, &clipInfo, params.Verbose); err != nil {
+		return stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+	return nil
+}
+
+// validateAnimationParams validates paths and clip settings, and returns the output format
+func validateAnimationParams(ctx context.Context, params AnimationParams) (string, error) {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return "", err
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return "", fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if err := securityPolicy.ValidateFilePath(params.OutputFile); err != nil {
+		return "", fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	format := getFormatFromPath(params.OutputFile)
+	if !slices.Contains(AnimationFormats, format) {
+		return "", fmt.Errorf("unsupported animation format: %s (use %s)", format, strings.Join(AnimationFormats, ", "))
+	}
+	if format == "avif" && !ffmpegSupports(ctx, avifMuxerVersion) {
+		return "", fmt.Errorf("animated AVIF needs FFmpeg %d.%d or newer", avifMuxerVersion[0], avifMuxerVersion[1])
+	}
+
+	if params.Start < 0 {
+		return "", fmt.Errorf("invalid start: %s", params.Start)
+	}
+	if params.Duration <= 0 || params.Duration > time.Minute {
+		return "", fmt.Errorf("invalid duration %s (must be above 0 and at most 1m)", params.Duration)
+	}
+	if params.FPS < 1 || params.FPS > 60 {
+		return "", fmt.Errorf("invalid frame rate %d (must be between 1 and 60)", params.FPS)
+	}
+	if params.Width < 16 || params.Width > 1920 || params.Width%2 != 0 {
+		return "", fmt.Errorf("invalid width %d (must be an even number between 16 and 1920)", params.Width)
+	}
+	return format, nil
+}
+
+// selectAnimationEncoder picks the first encoder of the format the local FFmpeg build has.
+// GIF always uses FFmpeg's own encoder.
+func selectAnimationEncoder(ctx context.Context, format string) (string, error) {
+	candidates := animationEncoders[format]
+	if len(candidates) == 0 {
+		return "", nil
+	}
+
+	encoders, err := AvailableEncoders(ctx)
+	if err != nil {
+		return candidates[0], nil
+	}
+	for _, candidate := range candidates {
+		if encoders[candidate] {
+			return candidate, nil
+		}
+	}
+	return "", fmt.Errorf("no %s encoder in this FFmpeg build (needs %s; see ffmpeg -encoders)",
+		strings.ToUpper(format), strings.Join(candidates, " or "))
+}
+
+// buildAnimationCommand cuts the clip, lowers its frame rate and size, and encodes it for
+// the format
+func buildAnimationCommand(ctx context.Context, params AnimationParams, format, encoder string) *exec.Cmd {
+	args := []string{
+		"-ss", strconv.FormatFloat(params.Start.Seconds(), 'f', 3, 64),
+		"-t", strconv.FormatFloat(params.Duration.Seconds(), 'f', 3, 64),
+		"-i", params.InputFile,
+	}
+	resize := fmt.Sprintf("fps=%d,scale=%d:-2:flags=lanczos", params.FPS, params.Width)
+
+	switch format {
+	case "gif":
+		// One palette for the whole clip, built from what changes between frames, keeps
+		// colors faithful within GIF's 256
+		graph := NewFilterGraph().
+			Add([]string{"0:v:0"}, resize+",split", []string{"frames", "source"}).
+			Add([]string{"source"}, "palettegen=stats_mode=diff", []string{"palette"}).
+			Add([]string{"frames", "palette"}, "paletteuse=dither=bayer:bayer_scale=5:diff_mode=rectangle", []string{"animation"})
+		args = append(args,
+			"-filter_complex", graph.String(),
+			"-map", "[animation]",
+			"-loop", "0")
+	case "webp":
+		args = append(args,
+			"-map", "0:v:0",
+			"-vf", resize,
+			"-c:v", encoder,
+			"-quality", "75",
+			"-compression_level", "6",
+			"-loop", "0")
+	case "avif":
+		args = append(args, "-map", "0:v:0", "-vf", resize+",format=yuv420p", "-c:v", encoder)
+		if encoder == "libsvtav1" {
+			args = append(args, "-crf", "40", "-preset", "8")
+		} else {
+			args = append(args, "-crf", "35", "-b:v", "0", "-cpu-used", "6")
+		}
+		args = append(args, "-f", "avif")
+	}
+
+	args = append(args, "-an", "-y", params.OutputFile)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
@@ -0,0 +1,241 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// LadderMasterPlaylistName is the file name of the HLS master playlist written into the output directory
+const LadderMasterPlaylistName = "master.m3u8"
+
+// renditionRegex matches one rendition of a ladder (e.g., "720p:3M")
+var renditionRegex = regexp.MustCompile(`^(\d+)p:(\S+)$`)
+
+// Rendition is one quality level of an adaptive bitrate ladder
+type Rendition struct {
+	Name         string // Rendition name, also used as its directory (e.g., "720p")
+	Height       int    // Output height in pixels; the width keeps the aspect ratio
+	VideoBitrate string // Video bitrate (e.g., "3M")
+}
+
+// LadderParams holds parameters for encoding an adaptive bitrate ladder
+type LadderParams struct {
+	InputFile       string      // Input file path
+	OutputDir       string      // Directory receiving the master playlist and one directory per rendition
+	Renditions      []Rendition // Quality levels to encode
+	SegmentDuration int         // Target HLS segment duration in seconds
+	AudioBitrate    string      // Audio bitrate for every rendition (e.g., "128k")
+	Encrypt         bool        // Encrypt segments with AES-128
+	KeyURI          string      // With Encrypt, URI players fetch the key from (defaults to the key in the output directory)
+	Verbose         bool        // Verbose output
+}
+
+// ParseRenditions parses a ladder such as "1080p:5M,720p:3M,480p:1M"
+func ParseRenditions(spec string) ([]Rendition, error) {
+	renditions := make([]Rendition, 0)
+	seen := make(map[string]bool)
+
+	for _, entry := range strings.Split(spec, ",") {
+		entry = strings.TrimSpace(entry)
+		matches := renditionRegex.FindStringSubmatch(entry)
+		if matches == nil {
+			return nil, fmt.Errorf("invalid rendition: %s (use a format like 720p:3M)", entry)
+		}
+
+		height, err := strconv.Atoi(matches[1])
+		if err != nil || height < 144 || height > 4320 || height%2 != 0 {
+			return nil, fmt.Errorf("invalid rendition height: %s (must be an even number between 144 and 4320)", matches[1])
+		}
+		if err := securityPolicy.ValidateBitrate(matches[2]); err != nil {
+			return nil, fmt.Errorf("invalid bitrate for rendition %s: %w", entry, err)
+		}
+
+		name := matches[1] + "p"
+		if seen[name] {
+			return nil, fmt.Errorf("duplicate rendition: %s", name)
+		}
+		seen[name] = true
+
+		renditions = append(renditions, Rendition{Name: name, Height: height, VideoBitrate: matches[2]})
+	}
+
+	if len(renditions) == 0 {
+		return nil, fmt.Errorf("no renditions given")
+	}
+	return renditions, nil
+}
+
+// EncodeLadder encodes every rendition in a single FFmpeg run and writes an HLS master playlist.
+// The source is decoded once and split into one scaled copy per rendition.
+func EncodeLadder(ctx context.Context, params LadderParams) (string, error) {
+	if err := validateLadderParams(params); err != nil {
+		return "", err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return "", err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return "", fmt.Errorf("input has no video stream")
+	}
+
+	renditions := selectRenditions(params.Renditions, inputInfo.VideoStreams[0].Height, params.Verbose)
+	if len(renditions) == 0 {
+		return "", fmt.Errorf("every rendition is taller than the %dp source", inputInfo.VideoStreams[0].Height)
+	}
+
+	if err := os.MkdirAll(params.OutputDir, 0755); err != nil {
+		return "", fmt.Errorf("creating output directory: %w", err)
+	}
+
+	keyInfoPath := ""
+	if params.Encrypt {
+		if keyInfoPath, err = writeHLSKeyInfo(params.OutputDir, params.KeyURI); err != nil {
+			return "", err
+		}
+		if params.Verbose {
+			color.Cyan("🔐 Encrypting segments with AES-128 (key: %s)", filepath.Join(params.OutputDir, HLSKeyName))
+		}
+	}
+
+	warnUnsupportedFeature(ctx, "Multi-variant HLS output", varStreamMapVersion, params.Verbose)
+
+	cmd := buildLadderCommand(ctx, params, renditions, len(inputInfo.AudioStreams) > 0, keyInfoPath)
+	if params.Verbose {
+		color.Cyan("🪜 Encoding %d rendition(s) in one pass", len(renditions))
+		for _, rendition := range renditions {
+			fmt.Printf("   %-6s %s\n", rendition.Name, rendition.VideoBitrate)
+		}
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		return "", stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+	return filepath.Join(params.OutputDir, LadderMasterPlaylistName), nil
+}
+
+// validateLadderParams validates ladder paths and options
+func validateLadderParams(params LadderParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+
+	if err := securityPolicy.ValidateFilePath(params.OutputDir); err != nil {
+		return fmt.Errorf("security validation failed for output directory: %w", err)
+	}
+
+	if len(params.Renditions) == 0 {
+		return fmt.Errorf("no renditions given")
+	}
+
+	if params.SegmentDuration < 1 || params.SegmentDuration > 60 {
+		return fmt.Errorf("invalid segment duration %d (must be between 1 and 60 seconds)", params.SegmentDuration)
+	}
+
+	if err := securityPolicy.ValidateBitrate(params.AudioBitrate); err != nil {
+		return fmt.Errorf("invalid audio bitrate: %w", err)
+	}
+
+	if params.KeyURI != "" && !params.Encrypt {
+		return fmt.Errorf("a key URI requires encryption")
+	}
+	if err := validateHLSKeyURI(params.KeyURI); err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// selectRenditions drops renditions taller than the source, since upscaling only wastes bitrate
+func selectRenditions(renditions []Rendition, sourceHeight int, verbose bool) []Rendition {
+	if sourceHeight <= 0 {
+		return renditions
+	}
+
+	selected := make([]Rendition, 0, len(renditions))
+	for _, rendition := range renditions {
+		if rendition.Height > sourceHeight {
+			if verbose {
+				color.Yellow("⚠️  Skipping %s: the source is only %dp", rendition.Name, sourceHeight)
+			}
+			continue
+		}
+		selected = append(selected, rendition)
+	}
+	return selected
+}
+
+// buildLadderFilterGraph splits the first video stream and scales one copy per rendition
+func buildLadderFilterGraph(renditions []Rendition) *FilterGraph {
+	graph := NewFilterGraph()
+
+	split := make([]string, len(renditions))
+	for i := range renditions {
+		split[i] = fmt.Sprintf("split%d", i)
+	}
+	graph.Add([]string{"0:v:0"}, fmt.Sprintf("split=%d", len(renditions)), split)
+
+	for i, rendition := range renditions {
+		graph.Add([]string{split[i]}, fmt.Sprintf("scale=-2:%d", rendition.Height), []string{fmt.Sprintf("v%d", i)})
+	}
+	return graph
+}
+
+// buildLadderCommand builds the FFmpeg command that encodes all renditions into HLS variant streams.
+// Keyframes are forced at segment boundaries so players can switch renditions between segments.
+// Segments are encrypted when a key info file is given.
+func buildLadderCommand(ctx context.Context, params LadderParams, renditions []Rendition, hasAudio bool, keyInfoPath string) *exec.Cmd {
+	segmentDuration := strconv.Itoa(params.SegmentDuration)
+
+	args := []string{
+		"-i", params.InputFile,
+		"-filter_complex", buildLadderFilterGraph(renditions).String(),
+	}
+
+	streamMap := make([]string, len(renditions))
+	for i, rendition := range renditions {
+		args = append(args,
+			"-map", fmt.Sprintf("[v%d]", i),
+			fmt.Sprintf("-c:v:%d", i), "libx264",
+			fmt.Sprintf("-b:v:%d", i), rendition.VideoBitrate)
+		streamMap[i] = fmt.Sprintf("v:%d,name:%s", i, rendition.Name)
+
+		// Every variant carries its own copy of the audio
+		if hasAudio {
+			args = append(args,
+				"-map", "0:a:0",
+				fmt.Sprintf("-c:a:%d", i), "aac",
+				fmt.Sprintf("-b:a:%d", i), params.AudioBitrate)
+			streamMap[i] = fmt.Sprintf("v:%d,a:%d,name:%s", i, i, rendition.Name)
+		}
+	}
+
+	// Every rendition plays on the devices HLS targets, also from 10-bit or 4:2:2 sources
+	args = append(args, playableH264Args...)
+	args = append(args,
+		"-force_key_frames", "expr:gte(t,n_forced*"+segmentDuration+")",
+		"-f", "hls",
+		"-hls_time", segmentDuration,
+		"-hls_playlist_type", "vod")
+	if keyInfoPath != "" {
+		args = append(args, "-hls_key_info_file", keyInfoPath)
+	}
+	args = append(args,
+		"-hls_segment_filename", filepath.Join(params.OutputDir, "%v", "segment_%03d.ts"),
+		"-master_pl_name", LadderMasterPlaylistName,
+		"-var_stream_map", strings.Join(streamMap, " "),
+		"-y", filepath.Join(params.OutputDir, "%v", "index.m3u8"))
+
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
@@ -0,0 +1,59 @@
+package transcoder
+
+import (
+	"slices"
+	"testing"
+)
+
+func TestParseRenditions(t *testing.T) {
+	tests := []struct {
+		name    string
+		spec    string
+		want    []Rendition
+		wantErr bool
+	}{
+		{
+			name: "default ladder",
+			spec: "1080p:5M,720p:3M,480p:1M",
+			want: []Rendition{
+				{Name: "1080p", Height: 1080, VideoBitrate: "5M"},
+				{Name: "720p", Height: 720, VideoBitrate: "3M"},
+				{Name: "480p", Height: 480, VideoBitrate: "1M"},
+			},
+		},
+		{
+			name: "spaces around entries",
+			spec: " 720p:2500k , 360p:800k ",
+			want: []Rendition{
+				{Name: "720p", Height: 720, VideoBitrate: "2500k"},
+				{Name: "360p", Height: 360, VideoBitrate: "800k"},
+			},
+		},
+		{name: "missing bitrate", spec: "720p", wantErr: true},
+		{name: "missing p", spec: "720:3M", wantErr: true},
+		{name: "odd height", spec: "721p:3M", wantErr: true},
+		{name: "too short", spec: "120p:500k", wantErr: true},
+		{name: "too tall", spec: "8640p:50M", wantErr: true},
+		{name: "invalid bitrate", spec: "720p:fast", wantErr: true},
+		{name: "duplicate", spec: "720p:3M,720p:2M", wantErr: true},
+		{name: "empty", spec: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRenditions(tt.spec)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("ParseRenditions(%q) = %v, want an error", tt.spec, got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRenditions(%q) error = %v", tt.spec, err)
+			}
+			if !slices.Equal(got, tt.want) {
+				t.Errorf("ParseRenditions(%q) = %v, want %v", tt.spec, got, tt.want)
+			}
+		})
+	}
+}
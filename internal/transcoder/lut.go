@@ -0,0 +1,47 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// lutExtensions are the 3D LUT formats the lut3d filter reads
+var lutExtensions = map[string]bool{
+	".cube": true, // Adobe/Resolve, the format cameras and grading tools export
+	".3dl":  true, // Autodesk/Lustre
+	".dat":  true, // DaVinci
+	".m3d":  true, // Pandora
+	".csp":  true, // Rising Sun Research cineSpace
+}
+
+// validateLUT checks the LUT file like other input files
+func validateLUT(ctx context.Context, customParams CustomParameters) error {
+	if customParams.VideoCodec == "copy" {
+		return fmt.Errorf("--lut requires video re-encoding and cannot be used with video codec 'copy'")
+	}
+	if err := securityPolicy.ValidateFilePath(customParams.LUT); err != nil {
+		return fmt.Errorf("security validation failed for LUT path: %w", err)
+	}
+	if !lutExtensions[strings.ToLower(filepath.Ext(customParams.LUT))] {
+		return fmt.Errorf("unsupported LUT file %s (use a .cube, .3dl, .dat, .m3d or .csp 3D LUT)", customParams.LUT)
+	}
+	info, err := os.Stat(customParams.LUT)
+	if err != nil {
+		return fmt.Errorf("LUT %w: %s", analyzer.ErrFileNotFound, customParams.LUT)
+	}
+	if info.IsDir() {
+		return fmt.Errorf("LUT is a directory, not a file: %s", customParams.LUT)
+	}
+	return checkFilterAvailable(ctx, "lut3d")
+}
+
+// buildLUTFilter applies the LUT with tetrahedral interpolation, the most accurate between
+// the points of the LUT grid
+func buildLUTFilter(customParams CustomParameters) string {
+	return fmt.Sprintf("lut3d=file=%s:interp=tetrahedral", quoteFilterPath(customParams.LUT))
+}
@@ -0,0 +1,80 @@
+package transcoder
+
+import (
+	"strings"
+	"testing"
+)
+
+// getToken reads one token the way FFmpeg's av_get_token does: up to an unquoted, unescaped
+// character of term, dropping quotes and escapes, and returns the token and the rest
+func getToken(s, term string) (string, string) {
+	s = strings.TrimLeft(s, " \n\t\r")
+	var token strings.Builder
+	end := 0
+	for len(s) > 0 && !strings.ContainsRune(term, rune(s[0])) {
+		c := s[0]
+		s = s[1:]
+		switch {
+		case c == '\\' && len(s) > 0:
+			token.WriteByte(s[0])
+			s = s[1:]
+			end = token.Len()
+		case c == '\'':
+			for len(s) > 0 && s[0] != '\'' {
+				token.WriteByte(s[0])
+				s = s[1:]
+			}
+			if len(s) > 0 {
+				s = s[1:]
+			}
+			end = token.Len()
+		default:
+			token.WriteByte(c)
+		}
+	}
+	value := token.String()
+	for len(value) > end && strings.ContainsRune(" \n\t\r", rune(value[len(value)-1])) {
+		value = value[:len(value)-1]
+	}
+	return value, s
+}
+
+func TestBuildLUTFilterPaths(t *testing.T) {
+	tests := []struct {
+		name string
+		path string
+	}{
+		{"plain", "/home/user/luts/film.cube"},
+		{"spaces", "/home/user/my luts/film look.cube"},
+		{"colon", "/media/grade:v2.cube"},
+		{"comma", "/media/warm,soft.cube"},
+		{"quote", "/home/user/director's cut.cube"},
+		{"brackets and semicolon", "/media/[final];look.cube"},
+		{"windows", `C:\Users\me\LUTs\film.cube`},
+		{"everything", `D:\it's a, grade: [v2];\look.cube`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter := buildLUTFilter(CustomParameters{LUT: tt.path})
+
+			// The filter graph parser reads the filter name and its options as a whole...
+			options, rest := getToken(strings.TrimPrefix(filter, "lut3d="), "[],;")
+			if rest != "" {
+				t.Fatalf("filter %q ends the filter early, leaving %q", filter, rest)
+			}
+
+			// ...and the filter splits the options into values
+			if !strings.HasPrefix(options, "file=") {
+				t.Fatalf("options %q do not start with file=", options)
+			}
+			file, rest := getToken(strings.TrimPrefix(options, "file="), ":")
+			if file != tt.path {
+				t.Errorf("lut3d reads file %q, want %q", file, tt.path)
+			}
+			if rest != ":interp=tetrahedral" {
+				t.Errorf("options after the file are %q, want %q", rest, ":interp=tetrahedral")
+			}
+		})
+	}
+}
@@ -0,0 +1,394 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+const (
+	mergePartNames = "part_%02d"
+	mergeListName  = "parts.txt"
+
+	// Normalized audio is resampled to 48 kHz, which every output audio codec (including Opus) accepts
+	mergeSampleRate = 48000
+)
+
+// MergeParams holds parameters for joining several videos into one
+type MergeParams struct {
+	InputFiles []string // Input file paths, in playback order
+	OutputFile string   // Output file path; its extension selects the container
+	Preset     string   // Quality preset for re-encoding (low, medium, high)
+	Reencode   bool     // Re-encode even when the inputs could be joined as they are
+
+	// Transition blends each clip into the next (see MergeTransitions); empty for hard cuts
+	Transition         string
+	TransitionDuration time.Duration
+
+	Verbose bool // Verbose output
+}
+
+// MergeReport describes how the inputs were joined
+type MergeReport struct {
+	Reencoded   bool          // Inputs were normalized to a common format before joining
+	Differences []string      // Why the inputs could not be joined as they are
+	Duration    time.Duration // Total duration of the output
+}
+
+// mergeTarget is the common format inputs are normalized to when they differ
+type mergeTarget struct {
+	width, height int
+	frameRate     string
+	channels      int // 0 when no input has audio
+	videoCodec    string
+	audioCodec    string
+}
+
+// MergeVideos joins the inputs one after the other. Inputs with the same codecs, resolution,
+// frame rate and audio layout are joined with the concat demuxer without re-encoding; otherwise
+// each input is first re-encoded to the format of the first one, and the results are joined.
+// With a transition the inputs are always re-encoded, in one pass. The first video and audio
+// stream of each input are kept.
+func MergeVideos(ctx context.Context, params MergeParams) (*MergeReport, error) {
+	outputFormat, err := validateMergeParams(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	infos := make([]*analyzer.MediaInfo, len(params.InputFiles))
+	report := &MergeReport{}
+	for i, input := range params.InputFiles {
+		info, err := analyzeInputMedia(ctx, input, params.Verbose)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", input, err)
+		}
+		if len(info.VideoStreams) == 0 {
+			return nil, fmt.Errorf("%s: input has no video stream", input)
+		}
+		infos[i] = info
+		report.Duration += info.Duration
+	}
+
+	if params.Transition != "" {
+		return mergeWithTransitions(ctx, params, infos, outputFormat, report)
+	}
+
+	report.Differences = compareMergeInputs(params.InputFiles, infos, outputFormat)
+	report.Reencoded = params.Reencode || len(report.Differences) > 0
+
+	workDir, err := os.MkdirTemp("", "transcoder-merge-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating merge directory: %w", err)
+	}
+	defer os.RemoveAll(workDir)
+
+	parts := params.InputFiles
+	if report.Reencoded {
+		if params.Verbose {
+			color.Yellow("⚙️  Inputs differ, normalizing them before joining:")
+			for _, difference := range report.Differences {
+				fmt.Printf("   %s\n", difference)
+			}
+			fmt.Println()
+		}
+		if parts, err = normalizeMergeInputs(ctx, params, infos, workDir, outputFormat); err != nil {
+			return nil, err
+		}
+	} else if params.Verbose {
+		color.Green("✨ Inputs match, joining without re-encoding")
+	}
+
+	listPath := filepath.Join(workDir, mergeListName)
+	if err := writeConcatFiles(listPath, parts); err != nil {
+		return nil, err
+	}
+
+	webOptimized := outputFormat == "mp4" || outputFormat == "mov"
+	cmd := buildMergeJoinCommand(ctx, listPath, params.OutputFile, webOptimized)
+	if params.Verbose {
+		color.Blue("🔗 Joining %d inputs", len(parts))
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	joinedInfo := *infos[0]
+	joinedInfo.Duration = report.Duration
+	if err := executeFFmpeg(ctx, cmd, &joinedInfo, params.Verbose); err != nil {
+		return nil, stoppedBy(ctx, fmt.Errorf("joining inputs failed: %w", err))
+	}
+	return report, nil
+}
+
+// validateMergeParams validates the inputs, output and preset, and returns the output format
+func validateMergeParams(ctx context.Context, params MergeParams) (string, error) {
+	if len(params.InputFiles) < 2 {
+		return "", fmt.Errorf("at least two inputs are needed to merge")
+	}
+
+	outputFormat := getFormatFromPath(params.OutputFile)
+	if !SupportedFormats[outputFormat] {
+		return "", fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	for _, input := range params.InputFiles {
+		if err := validateInputFile(input); err != nil {
+			return "", err
+		}
+		if analyzer.IsStdinPath(input) {
+			return "", fmt.Errorf("merge cannot read stdin input")
+		}
+		if err := validateConversionPaths(input, params.OutputFile); err != nil {
+			return "", err
+		}
+		if filepath.Clean(input) == filepath.Clean(params.OutputFile) {
+			return "", fmt.Errorf("output file %s is also an input", params.OutputFile)
+		}
+	}
+
+	if !slices.Contains([]string{"low", "medium", "high"}, params.Preset) {
+		return "", fmt.Errorf("invalid preset '%s'. Valid options: low, medium, high", params.Preset)
+	}
+	if params.Transition != "" {
+		if err := validateMergeTransition(ctx, params); err != nil {
+			return "", err
+		}
+	}
+	return outputFormat, nil
+}
+
+// compareMergeInputs lists every way the inputs differ from the first one, and the codecs of
+// the first input the output container cannot hold. The concat demuxer can only join inputs
+// without any difference.
+func compareMergeInputs(inputs []string, infos []*analyzer.MediaInfo, outputFormat string) []string {
+	var differences []string
+	first := infos[0]
+	firstVideo := first.VideoStreams[0]
+
+	if compat, ok, err := GetContainerCompatibility(outputFormat); err == nil && ok {
+		if !isCompatibleCodec(firstVideo.Codec, firstVideo.Profile, compat.VideoCodecs) {
+			differences = append(differences, fmt.Sprintf("%s: video codec %s cannot be stored in %s",
+				inputs[0], describeCodec(firstVideo.Codec, firstVideo.Profile), outputFormat))
+		}
+		if len(first.AudioStreams) > 0 {
+			audio := first.AudioStreams[0]
+			if !isCompatibleCodec(audio.Codec, audio.Profile, compat.AudioCodecs) {
+				differences = append(differences, fmt.Sprintf("%s: audio codec %s cannot be stored in %s",
+					inputs[0], describeCodec(audio.Codec, audio.Profile), outputFormat))
+			}
+		}
+	}
+
+	for i, info := range infos[1:] {
+		input := inputs[i+1]
+		video := info.VideoStreams[0]
+		if video.Codec != firstVideo.Codec || video.Profile != firstVideo.Profile {
+			differences = append(differences, fmt.Sprintf("%s: video codec %s, not %s", input,
+				describeCodec(video.Codec, video.Profile), describeCodec(firstVideo.Codec, firstVideo.Profile)))
+		}
+		if video.Width != firstVideo.Width || video.Height != firstVideo.Height || video.Rotation != firstVideo.Rotation {
+			differences = append(differences, fmt.Sprintf("%s: resolution %dx%d, not %dx%d", input,
+				displayWidth(video), displayHeight(video), displayWidth(firstVideo), displayHeight(firstVideo)))
+		}
+		if video.FrameRate != firstVideo.FrameRate {
+			differences = append(differences, fmt.Sprintf("%s: frame rate %s, not %s", input, video.FrameRate, firstVideo.FrameRate))
+		}
+		if video.PixelFormat != firstVideo.PixelFormat {
+			differences = append(differences, fmt.Sprintf("%s: pixel format %s, not %s", input, video.PixelFormat, firstVideo.PixelFormat))
+		}
+
+		switch {
+		case len(info.AudioStreams) == 0 && len(first.AudioStreams) > 0:
+			differences = append(differences, fmt.Sprintf("%s: no audio", input))
+		case len(info.AudioStreams) > 0 && len(first.AudioStreams) == 0:
+			differences = append(differences, fmt.Sprintf("%s: has audio, %s has none", input, inputs[0]))
+		case len(info.AudioStreams) > 0:
+			audio, firstAudio := info.AudioStreams[0], first.AudioStreams[0]
+			if audio.Codec != firstAudio.Codec {
+				differences = append(differences, fmt.Sprintf("%s: audio codec %s, not %s", input, audio.Codec, firstAudio.Codec))
+			}
+			if audio.SampleRate != firstAudio.SampleRate || audio.Channels != firstAudio.Channels {
+				differences = append(differences, fmt.Sprintf("%s: audio %d Hz %d channels, not %d Hz %d channels", input,
+					audio.SampleRate, audio.Channels, firstAudio.SampleRate, firstAudio.Channels))
+			}
+		}
+	}
+	return differences
+}
+
+// displayWidth is the width of a video stream as shown, after its rotation
+func displayWidth(video analyzer.VideoStream) int {
+	if video.Rotation == 90 || video.Rotation == 270 {
+		return video.Height
+	}
+	return video.Width
+}
+
+// displayHeight is the height of a video stream as shown, after its rotation
+func displayHeight(video analyzer.VideoStream) int {
+	if video.Rotation == 90 || video.Rotation == 270 {
+		return video.Width
+	}
+	return video.Height
+}
+
+// planMergeTarget takes the picture size and frame rate of the first input, and the channel
+// count of the first input with audio
+func planMergeTarget(infos []*analyzer.MediaInfo, outputFormat string) mergeTarget {
+	first := infos[0].VideoStreams[0]
+	target := mergeTarget{
+		// Rounded down to even sizes, which 4:2:0 encoders need
+		width:     displayWidth(first) &^ 1,
+		height:    displayHeight(first) &^ 1,
+		frameRate: first.FrameRate,
+	}
+	if analyzer.ParseFrameRate(target.frameRate) <= 0 {
+		target.frameRate = "30"
+	}
+
+	for _, info := range infos {
+		if len(info.AudioStreams) > 0 {
+			target.channels = max(info.AudioStreams[0].Channels, 1)
+			break
+		}
+	}
+
+	videoCodec, audioCodec := getDefaultCodecs(outputFormat)
+	target.videoCodec = applyVideoPreset(videoCodec, "")
+	target.audioCodec = applyAudioPreset(audioCodec, "")
+	return target
+}
+
+// normalizeMergeInputs re-encodes every input to the common format in workDir and returns
+// the encoded files
+func normalizeMergeInputs(ctx context.Context, params MergeParams, infos []*analyzer.MediaInfo,
+	workDir, outputFormat string) ([]string, error) {
+
+	target := planMergeTarget(infos, outputFormat)
+	if params.Verbose {
+		color.Cyan("🎯 Common format: %dx%d, %s fps, %s/%s", target.width, target.height,
+			target.frameRate, target.videoCodec, target.audioCodec)
+	}
+
+	parts := make([]string, len(params.InputFiles))
+	for i, input := range params.InputFiles {
+		parts[i] = filepath.Join(workDir, fmt.Sprintf(mergePartNames, i+1)+"."+outputFormat)
+		cmd := buildMergeNormalizeCommand(ctx, input, parts[i], infos[i], target, params.Preset)
+		if params.Verbose {
+			color.Cyan("🎬 Normalizing %s (%d/%d)", input, i+1, len(params.InputFiles))
+			fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+		}
+		if err := executeFFmpeg(ctx, cmd, infos[i], params.Verbose); err != nil {
+			return nil, stoppedBy(ctx, fmt.Errorf("normalizing %s failed: %w", input, err))
+		}
+	}
+	return parts, nil
+}
+
+// buildMergeNormalizeCommand re-encodes one input to the common format. The picture is scaled
+// to fit and padded, so inputs of another aspect ratio are letterboxed rather than stretched,
+// and inputs without audio get silence so every part has the same streams.
+func buildMergeNormalizeCommand(ctx context.Context, input, output string, info *analyzer.MediaInfo,
+	target mergeTarget, preset string) *exec.Cmd {
+
+	args := []string{"-i", input}
+	addSilence := target.channels > 0 && len(info.AudioStreams) == 0
+	if addSilence {
+		args = append(args, "-f", "lavfi", "-i",
+			fmt.Sprintf("anullsrc=r=%d:cl=%s", mergeSampleRate, channelLayoutName(target.channels)))
+	}
+
+	args = append(args,
+		"-map", "0:v:0",
+		"-vf", target.videoFilter(),
+		"-c:v", target.videoCodec,
+		"-b:v", getPresetVideoBitrate(preset))
+
+	switch {
+	case target.channels == 0:
+		args = append(args, "-an")
+	case addSilence:
+		args = append(args, "-map", "1:a:0", "-shortest")
+	default:
+		args = append(args, "-map", "0:a:0")
+	}
+	if target.channels > 0 {
+		args = append(args,
+			"-c:a", target.audioCodec,
+			"-b:a", getPresetAudioBitrate(preset),
+			"-ar", strconv.Itoa(mergeSampleRate),
+			"-ac", strconv.Itoa(target.channels))
+	}
+
+	args = append(args, "-y", output)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
+
+// videoFilter scales and pads a picture to the target size and converts it to the target
+// frame rate and pixel format
+func (t mergeTarget) videoFilter() string {
+	return fmt.Sprintf("scale=%[1]d:%[2]d:force_original_aspect_ratio=decrease,"+
+		"pad=%[1]d:%[2]d:(ow-iw)/2:(oh-ih)/2,setsar=1,fps=%[3]s,format=yuv420p",
+		t.width, t.height, t.frameRate)
+}
+
+// channelLayoutName names the usual FFmpeg channel layout for a channel count
+func channelLayoutName(channels int) string {
+	switch channels {
+	case 1:
+		return "mono"
+	case 2:
+		return "stereo"
+	case 6:
+		return "5.1"
+	case 8:
+		return "7.1"
+	default:
+		return fmt.Sprintf("%dc", channels)
+	}
+}
+
+// concatListEscaper quotes a path inside a single-quoted concat demuxer entry
+var concatListEscaper = strings.NewReplacer(`'`, `'\''`)
+
+// writeConcatFiles writes a concat demuxer list of files given by any path
+func writeConcatFiles(listPath string, files []string) error {
+	var list strings.Builder
+	for _, file := range files {
+		absolute, err := filepath.Abs(file)
+		if err != nil {
+			return fmt.Errorf("resolving %s: %w", file, err)
+		}
+		fmt.Fprintf(&list, "file '%s'\n", concatListEscaper.Replace(absolute))
+	}
+	if err := os.WriteFile(listPath, []byte(list.String()), 0644); err != nil {
+		return fmt.Errorf("writing input list: %w", err)
+	}
+	return nil
+}
+
+// buildMergeJoinCommand joins the parts listed in listPath without re-encoding. Absolute
+// paths in the list need -safe 0.
+func buildMergeJoinCommand(ctx context.Context, listPath, outputPath string, webOptimized bool) *exec.Cmd {
+	args := []string{
+		"-f", "concat",
+		"-safe", "0",
+		"-i", listPath,
+		"-map", "0:v:0",
+		"-map", "0:a:0?",
+		"-c", "copy",
+	}
+	if webOptimized {
+		args = append(args, "-movflags", "+faststart")
+	}
+
+	args = append(args, "-y", outputPath)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
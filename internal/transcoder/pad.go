@@ -0,0 +1,135 @@
+package transcoder
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// DefaultPadColor fills the bars added by --pad-to
+const DefaultPadColor = "black"
+
+// aspectRatioRegex matches an aspect ratio such as 16:9, 4:3 or 2.39:1
+var aspectRatioRegex = regexp.MustCompile(`^([0-9]+(?:\.[0-9]+)?):([0-9]+(?:\.[0-9]+)?)$`)
+
+// validatePadTo checks the --pad-to frame and the options combined with it
+func validatePadTo(customParams CustomParameters) error {
+	if customParams.VideoCodec == "copy" {
+		return fmt.Errorf("--pad-to requires video re-encoding and cannot be used with video codec 'copy'")
+	}
+	if customParams.Target != "" {
+		return fmt.Errorf("--pad-to cannot be combined with --target, which sets the frame itself")
+	}
+	if customParams.Resolution != "" {
+		return fmt.Errorf("--pad-to cannot be combined with --resolution; give the size to --pad-to instead (e.g., --pad-to 1920x1080)")
+	}
+	if _, err := parseFilterColor(cmp.Or(customParams.PadColor, DefaultPadColor)); err != nil {
+		return err
+	}
+	if strings.Contains(customParams.PadTo, "x") {
+		if err := securityPolicy.ValidateResolution(customParams.PadTo); err != nil {
+			return fmt.Errorf("invalid --pad-to: %w", err)
+		}
+		return nil
+	}
+	_, err := parseAspectRatio(customParams.PadTo)
+	return err
+}
+
+// parseAspectRatio reads an aspect ratio such as 16:9 as width divided by height
+func parseAspectRatio(value string) (float64, error) {
+	match := aspectRatioRegex.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("invalid --pad-to %s (use an aspect ratio such as 16:9 or a resolution such as 1920x1080)", value)
+	}
+	width, _ := strconv.ParseFloat(match[1], 64)
+	height, _ := strconv.ParseFloat(match[2], 64)
+	if width == 0 || height == 0 || width/height < 0.1 || width/height > 10 {
+		return 0, fmt.Errorf("invalid --pad-to %s: the aspect ratio must be between 1:10 and 10:1", value)
+	}
+	return width / height, nil
+}
+
+// squarePixels resizes anamorphic video, whose pixels are not square, to its displayed shape,
+// so fitting and padding work on what viewers see rather than on the stored frame
+const squarePixels = "scale=trunc(iw*sar/2)*2:ih,setsar=1"
+
+// buildPadFilter fits the video into the --pad-to frame without distorting it. A resolution
+// scales the video to fit inside it, up or down, and pads the rest; an aspect ratio keeps the
+// video at its size and pads it out to the nearest frame of that shape. The bars are split
+// evenly on both sides, so the picture stays centered. Anamorphic video is first resized to
+// square pixels.
+func buildPadFilter(ctx context.Context, inputInfo *analyzer.MediaInfo, customParams CustomParameters) (string, error) {
+	color, err := parseFilterColor(cmp.Or(customParams.PadColor, DefaultPadColor))
+	if err != nil {
+		return "", err
+	}
+
+	if strings.Contains(customParams.PadTo, "x") {
+		width, height, err := parseResolution(customParams.PadTo)
+		if err != nil {
+			return "", err
+		}
+		return fmt.Sprintf("%s,scale=%d:%d:force_original_aspect_ratio=decrease%s,pad=%d:%d:(ow-iw)/2:(oh-ih)/2:color=%s,setsar=1",
+			squarePixels, width, height, evenScaleOption(ffmpegSupports(ctx, forceDivisibleByVersion)), width, height, color), nil
+	}
+
+	ratio, err := parseAspectRatio(customParams.PadTo)
+	if err != nil {
+		return "", err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return "", fmt.Errorf("--pad-to requires a video stream")
+	}
+	stream := inputInfo.VideoStreams[0]
+	if stream.Width <= 0 || stream.Height <= 0 {
+		return "", fmt.Errorf("cannot pad to %s: the frame size of the input is unknown", customParams.PadTo)
+	}
+
+	// Phones store portrait video as landscape frames with a rotation, which turns the pixels too
+	sourceWidth, sourceHeight := stream.Width, stream.Height
+	sar := sampleAspectRatio(stream.SampleAspectRatio)
+	if stream.Rotation == 90 || stream.Rotation == 270 {
+		sourceWidth, sourceHeight = sourceHeight, sourceWidth
+		sar = 1 / sar
+	}
+
+	// Anamorphic pixels are resized to square ones of the same height, as squarePixels does
+	prefix := ""
+	if sar != 1 {
+		sourceWidth = int(float64(sourceWidth)*sar/2) * 2
+		prefix = squarePixels + ","
+	}
+
+	// Widen a narrower source and heighten a wider one, rounding up to even sizes for chroma
+	// subsampling
+	width, height := sourceWidth, sourceHeight
+	if float64(sourceWidth)/float64(sourceHeight) < ratio {
+		width = int(math.Ceil(float64(sourceHeight) * ratio))
+	} else {
+		height = int(math.Ceil(float64(sourceWidth) / ratio))
+	}
+	width, height = width+width%2, height+height%2
+	return fmt.Sprintf("%spad=%d:%d:(ow-iw)/2:(oh-ih)/2:color=%s", prefix, width, height, color), nil
+}
+
+// sampleAspectRatio reads a sample aspect ratio such as 32:27 as width divided by height, or 1
+// when it is missing or unknown (0:1)
+func sampleAspectRatio(value string) float64 {
+	width, height, ok := strings.Cut(value, ":")
+	if !ok {
+		return 1
+	}
+	w, errW := strconv.ParseFloat(width, 64)
+	h, errH := strconv.ParseFloat(height, 64)
+	if errW != nil || errH != nil || w <= 0 || h <= 0 {
+		return 1
+	}
+	return w / h
+}
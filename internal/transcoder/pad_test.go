@@ -0,0 +1,132 @@
+package transcoder
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+func TestBuildPadFilter(t *testing.T) {
+	video := func(width, height, rotation int, sar string) *analyzer.MediaInfo {
+		return &analyzer.MediaInfo{VideoStreams: []analyzer.VideoStream{
+			{Width: width, Height: height, Rotation: rotation, SampleAspectRatio: sar},
+		}}
+	}
+
+	tests := []struct {
+		name      string
+		info      *analyzer.MediaInfo
+		padTo     string
+		color     string
+		want      string
+		wantError bool
+	}{
+		{
+			name:  "narrower source widened",
+			info:  video(1440, 1080, 0, "1:1"),
+			padTo: "16:9",
+			want:  "pad=1920:1080:(ow-iw)/2:(oh-ih)/2:color=black",
+		},
+		{
+			name:  "wider source heightened",
+			info:  video(1920, 1080, 0, "1:1"),
+			padTo: "4:3",
+			want:  "pad=1920:1440:(ow-iw)/2:(oh-ih)/2:color=black",
+		},
+		{
+			name:  "odd size rounded up to even",
+			info:  video(1920, 800, 0, ""),
+			padTo: "2.39:1",
+			want:  "pad=1920:804:(ow-iw)/2:(oh-ih)/2:color=black",
+		},
+		{
+			name:  "unknown sample aspect ratio treated as square",
+			info:  video(1440, 1080, 0, "0:1"),
+			padTo: "16:9",
+			want:  "pad=1920:1080:(ow-iw)/2:(oh-ih)/2:color=black",
+		},
+		{
+			name:  "portrait phone video",
+			info:  video(1920, 1080, 90, "1:1"),
+			padTo: "16:9",
+			want:  "pad=3414:1920:(ow-iw)/2:(oh-ih)/2:color=black",
+		},
+		{
+			name:  "anamorphic DVD",
+			info:  video(720, 480, 0, "32:27"),
+			padTo: "16:9",
+			want:  squarePixels + ",pad=854:480:(ow-iw)/2:(oh-ih)/2:color=black",
+		},
+		{
+			name:  "anamorphic DVD already 16:9",
+			info:  video(720, 576, 0, "64:45"),
+			padTo: "16:9",
+			want:  squarePixels + ",pad=1024:576:(ow-iw)/2:(oh-ih)/2:color=black",
+		},
+		{
+			name:  "rotated anamorphic",
+			info:  video(720, 480, 90, "32:27"),
+			padTo: "16:9",
+			want:  squarePixels + ",pad=1280:720:(ow-iw)/2:(oh-ih)/2:color=black",
+		},
+		{
+			name:  "color",
+			info:  video(1440, 1080, 0, "1:1"),
+			padTo: "16:9",
+			color: "#FF8800",
+			want:  "pad=1920:1080:(ow-iw)/2:(oh-ih)/2:color=0xff8800",
+		},
+		{
+			name:      "no video stream",
+			info:      &analyzer.MediaInfo{},
+			padTo:     "16:9",
+			wantError: true,
+		},
+		{
+			name:      "unknown frame size",
+			info:      video(0, 0, 0, ""),
+			padTo:     "16:9",
+			wantError: true,
+		},
+		{
+			name:      "invalid aspect ratio",
+			info:      video(1920, 1080, 0, "1:1"),
+			padTo:     "wide",
+			wantError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := buildPadFilter(t.Context(), tt.info, CustomParameters{PadTo: tt.padTo, PadColor: tt.color})
+			if tt.wantError {
+				if err == nil {
+					t.Fatalf("buildPadFilter(ctx) = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("buildPadFilter(ctx) error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("buildPadFilter(ctx) = %q, want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestBuildPadFilterResolution(t *testing.T) {
+	got, err := buildPadFilter(t.Context(), &analyzer.MediaInfo{}, CustomParameters{PadTo: "1280x720"})
+	if err != nil {
+		t.Fatalf("buildPadFilter(ctx) error = %v", err)
+	}
+
+	// Square pixels first, so fitting inside the frame keeps the displayed shape
+	if !strings.HasPrefix(got, squarePixels+",scale=1280:720:force_original_aspect_ratio=decrease") {
+		t.Errorf("buildPadFilter(ctx) = %q, want it to start with square pixels and the fit", got)
+	}
+	if !strings.HasSuffix(got, ",pad=1280:720:(ow-iw)/2:(oh-ih)/2:color=black,setsar=1") {
+		t.Errorf("buildPadFilter(ctx) = %q, want it to end with the pad", got)
+	}
+}
@@ -0,0 +1,89 @@
+package transcoder
+
+import (
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+)
+
+// StdoutPath is the output path that writes the result to standard output
+const StdoutPath = "-"
+
+// pipeMuxers maps output formats to the FFmpeg muxer used when writing to a pipe,
+// where FFmpeg cannot guess the format from a file extension
+var pipeMuxers = map[string]string{
+	"mp4":  "mp4",
+	"mov":  "mov",
+	"mkv":  "matroska",
+	"webm": "webm",
+	"avi":  "avi",
+	"ts":   "mpegts",
+	"flv":  "flv",
+	"ogv":  "ogg",
+	"mp3":  "mp3",
+	"wav":  "wav",
+	"aac":  "adts",
+	"adts": "adts",
+	"flac": "flac",
+	"ogg":  "ogg",
+	"opus": "opus",
+	"m4a":  "ipod",
+	"ac3":  "ac3",
+	"eac3": "eac3",
+	"dts":  "dts",
+}
+
+// IsStdoutPath reports whether an output path refers to standard output
+func IsStdoutPath(path string) bool {
+	return path == StdoutPath
+}
+
+// resolveOutputFormat returns the format of an output path; output to stdout uses the given container
+func resolveOutputFormat(path, container string) string {
+	if IsStdoutPath(path) {
+		return strings.ToLower(container)
+	}
+	return getFormatFromPath(path)
+}
+
+// validatePipeContainer checks the container given for output written to stdout
+func validatePipeContainer(container string) error {
+	if container == "" {
+		return fmt.Errorf("writing to stdout requires a container format")
+	}
+	if _, ok := pipeMuxers[strings.ToLower(container)]; !ok {
+		return fmt.Errorf("unsupported container for stdout: %s", container)
+	}
+	if err := securityPolicy.ValidateFormat(container); err != nil {
+		return fmt.Errorf("security validation failed for container: %w", err)
+	}
+	return nil
+}
+
+// pipeOutputArgs returns the output arguments that write the given format to stdout.
+// MP4 and MOV normally seek back to write their index, so they are written fragmented.
+func pipeOutputArgs(format string, fragmented bool) []string {
+	format = strings.ToLower(format)
+	args := []string{"-f", pipeMuxers[format]}
+	if !fragmented && (format == "mp4" || format == "mov" || format == "m4a") {
+		args = append(args, "-movflags", "frag_keyframe+empty_moov")
+	}
+	return append(args, "pipe:1")
+}
+
+// runFFmpegToStdout runs FFmpeg with its output on stdout.
+// Progress is not shown; FFmpeg only writes errors, to stderr.
+func runFFmpegToStdout(cmd *exec.Cmd) error {
+	args := make([]string, 0, len(cmd.Args)+4)
+	args = append(args, cmd.Args[0], "-nostats", "-loglevel", "error")
+	cmd.Args = append(args, cmd.Args[1:]...)
+
+	run := startFFmpegRun(cmd)
+	cmd.Stdout = os.Stdout
+	cmd.Stderr = run.captureStderr(os.Stderr)
+	if err := run.finish(cmd.Run()); err != nil {
+		return fmt.Errorf("ffmpeg execution failed: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,305 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"image"
+	"image/jpeg"
+	"image/png"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+const (
+	// posterWindow is the stretch of video after each candidate point the thumbnail filter
+	// picks its most representative frame from
+	posterWindow = 2 * time.Second
+
+	// posterBlankDeviation is the spread of brightness (0-255) below which a frame is taken to
+	// be blank: black, white or one flat color
+	posterBlankDeviation = 12.0
+
+	posterCandidateNames = "candidate_%02d.png"
+)
+
+// PosterFormats lists the image formats a poster frame can be written as
+var PosterFormats = []string{"jpg", "png"}
+
+// PosterParams holds parameters for picking a poster frame of a video
+type PosterParams struct {
+	InputFile  string // Input video file path
+	OutputFile string // Output image path; its extension selects the format (jpg or png)
+	Candidates int    // Number of evenly spaced points to take a candidate frame from
+	Width      int    // Width in pixels; 0 keeps the size of the video
+	Verbose    bool   // Verbose output
+}
+
+// PosterResult describes the frame chosen as the poster
+type PosterResult struct {
+	Time       time.Duration // Start of the window the frame was taken from
+	Brightness float64       // Mean brightness, 0-255
+	Sharpness  float64       // Variance of the Laplacian; higher is sharper
+	Candidates int           // Number of candidates compared
+}
+
+// posterCandidate is a frame picked by the thumbnail filter, with its scores
+type posterCandidate struct {
+	at         time.Duration
+	brightness float64
+	deviation  float64
+	sharpness  float64
+}
+
+// CreatePoster picks a representative frame of the input and writes it as an image. FFmpeg's
+// thumbnail filter picks the most typical frame around each of several evenly spaced points,
+// skipping flashes and transitions; of those, blank frames (black, white, flat color) are
+// dropped and the sharpest remaining one is kept.
+func CreatePoster(ctx context.Context, params PosterParams) (*PosterResult, error) {
+	format, err := validatePosterParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return nil, fmt.Errorf("input has no video stream")
+	}
+
+	times := planPosterTimes(inputInfo.Duration, params.Candidates)
+	window := min(posterWindow, max(inputInfo.Duration, time.Second))
+
+	candidateDir, err := os.MkdirTemp("", "transcoder-poster-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating candidate directory: %w", err)
+	}
+	defer os.RemoveAll(candidateDir)
+
+	frameRate := analyzer.ParseFrameRate(inputInfo.VideoStreams[0].FrameRate)
+	cmd := buildPosterCandidatesCommand(ctx, params, times, window, frameRate, candidateDir)
+	if params.Verbose {
+		color.Cyan("🖼️  Comparing %d candidate frames", len(times))
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	candidatesInfo := *inputInfo
+	candidatesInfo.Duration = 0
+	if err := executeFFmpeg(ctx, cmd, &candidatesInfo, params.Verbose); err != nil {
+		return nil, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+
+	var best *posterCandidate
+	var bestImage image.Image
+	for i, at := range times {
+		path := filepath.Join(candidateDir, fmt.Sprintf(posterCandidateNames, i+1))
+		img, err := readPNG(path)
+		if err != nil {
+			// Candidates past the last decodable frame of a damaged input are missing
+			continue
+		}
+
+		candidate := scorePosterCandidate(img)
+		candidate.at = at
+		if params.Verbose {
+			fmt.Printf("   %s  brightness %5.1f  contrast %5.1f  sharpness %8.1f\n",
+				formatStreamPosition(at.Seconds()), candidate.brightness, candidate.deviation, candidate.sharpness)
+		}
+		if best == nil || betterPoster(candidate, best) {
+			best, bestImage = candidate, img
+		}
+	}
+	if best == nil {
+		return nil, fmt.Errorf("no frame could be extracted from the input")
+	}
+
+	if err := writePosterImage(params.OutputFile, format, bestImage); err != nil {
+		return nil, err
+	}
+	return &PosterResult{
+		Time:       best.at,
+		Brightness: best.brightness,
+		Sharpness:  best.sharpness,
+		Candidates: len(times),
+	}, nil
+}
+
+// validatePosterParams validates paths and settings, and returns the output format
+func validatePosterParams(params PosterParams) (string, error) {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return "", err
+	}
+	if analyzer.IsStdinPath(params.InputFile) {
+		return "", fmt.Errorf("a poster cannot be picked from stdin input")
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return "", fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if err := securityPolicy.ValidateFilePath(params.OutputFile); err != nil {
+		return "", fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	format := getFormatFromPath(params.OutputFile)
+	if format == "jpeg" {
+		format = "jpg"
+	}
+	if format != "jpg" && format != "png" {
+		return "", fmt.Errorf("unsupported image format: %s (use %s)", format, strings.Join(PosterFormats, ", "))
+	}
+
+	if params.Candidates < 1 || params.Candidates > 50 {
+		return "", fmt.Errorf("invalid number of candidates: %d (must be between 1 and 50)", params.Candidates)
+	}
+	if params.Width != 0 && (params.Width < 16 || params.Width > 7680 || params.Width%2 != 0) {
+		return "", fmt.Errorf("invalid width %d (must be an even number between 16 and 7680)", params.Width)
+	}
+	return format, nil
+}
+
+// planPosterTimes spreads the candidate points over the input without its credits, as samples
+// are, with fewer points when the input is too short for all of them
+func planPosterTimes(duration time.Duration, candidates int) []time.Duration {
+	count := min(candidates, int(duration/posterWindow))
+	if count < 1 {
+		return []time.Duration{0}
+	}
+	times, err := planSampleOffsets(duration, count, posterWindow)
+	if err != nil {
+		return []time.Duration{0}
+	}
+	return times
+}
+
+// buildPosterCandidatesCommand seeks to every candidate point as its own input and writes the
+// thumbnail filter's pick of the window after it as a PNG
+func buildPosterCandidatesCommand(ctx context.Context, params PosterParams, times []time.Duration, window time.Duration,
+	frameRate float64, candidateDir string) *exec.Cmd {
+
+	// The thumbnail filter compares batches of frames; one batch covers the window
+	batch := 50
+	if frameRate > 0 {
+		batch = min(max(int(math.Round(frameRate*window.Seconds())), 2), 300)
+	}
+	filter := fmt.Sprintf("thumbnail=%d", batch)
+	if params.Width > 0 {
+		filter += fmt.Sprintf(",scale=%d:-2", params.Width)
+	}
+
+	args := make([]string, 0, 12*len(times))
+	for _, at := range times {
+		args = append(args,
+			"-ss", strconv.FormatFloat(at.Seconds(), 'f', 3, 64),
+			"-t", strconv.FormatFloat(window.Seconds(), 'f', 3, 64),
+			"-i", params.InputFile)
+	}
+
+	graph := NewFilterGraph()
+	for i := range times {
+		graph.Add([]string{fmt.Sprintf("%d:v:0", i)}, filter, []string{fmt.Sprintf("c%d", i)})
+	}
+	args = append(args, "-filter_complex", graph.String())
+	for i := range times {
+		args = append(args,
+			"-map", fmt.Sprintf("[c%d]", i),
+			"-frames:v", "1",
+			"-update", "1",
+			"-y", filepath.Join(candidateDir, fmt.Sprintf(posterCandidateNames, i+1)))
+	}
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
+
+// readPNG decodes a PNG file
+func readPNG(path string) (image.Image, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return png.Decode(file)
+}
+
+// scorePosterCandidate measures the brightness of a frame, its spread (a blank frame has
+// almost none) and its sharpness as the variance of the Laplacian of the luma: edges in
+// focus give strong responses, blur weak ones
+func scorePosterCandidate(img image.Image) *posterCandidate {
+	bounds := img.Bounds()
+	width, height := bounds.Dx(), bounds.Dy()
+	luma := make([]float64, width*height)
+	var sum, sumSquares float64
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			r, g, b, _ := img.At(bounds.Min.X+x, bounds.Min.Y+y).RGBA()
+			value := (0.299*float64(r) + 0.587*float64(g) + 0.114*float64(b)) / 257
+			luma[y*width+x] = value
+			sum += value
+			sumSquares += value * value
+		}
+	}
+
+	count := float64(len(luma))
+	mean := sum / count
+	candidate := &posterCandidate{
+		brightness: mean,
+		deviation:  math.Sqrt(max(sumSquares/count-mean*mean, 0)),
+	}
+
+	var lapSum, lapSquares, lapCount float64
+	for y := 1; y < height-1; y++ {
+		for x := 1; x < width-1; x++ {
+			i := y*width + x
+			laplacian := luma[i-width] + luma[i+width] + luma[i-1] + luma[i+1] - 4*luma[i]
+			lapSum += laplacian
+			lapSquares += laplacian * laplacian
+			lapCount++
+		}
+	}
+	if lapCount > 0 {
+		lapMean := lapSum / lapCount
+		candidate.sharpness = lapSquares/lapCount - lapMean*lapMean
+	}
+	return candidate
+}
+
+// betterPoster prefers frames with content over blank ones, then sharper frames
+func betterPoster(candidate, best *posterCandidate) bool {
+	candidateBlank := candidate.deviation < posterBlankDeviation
+	bestBlank := best.deviation < posterBlankDeviation
+	if candidateBlank != bestBlank {
+		return !candidateBlank
+	}
+	if candidateBlank {
+		// Among blank frames only, the one with the most going on
+		return candidate.deviation > best.deviation
+	}
+	return candidate.sharpness > best.sharpness
+}
+
+// writePosterImage encodes the chosen frame in the output format
+func writePosterImage(path, format string, img image.Image) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating poster: %w", err)
+	}
+
+	if format == "png" {
+		err = png.Encode(file, img)
+	} else {
+		err = jpeg.Encode(file, img, &jpeg.Options{Quality: 92})
+	}
+	if closeErr := file.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return fmt.Errorf("writing poster: %w", err)
+	}
+	return nil
+}
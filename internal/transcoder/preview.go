@@ -0,0 +1,82 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// PreviewEstimate extrapolates the size and encoding time of a full conversion from a preview
+type PreviewEstimate struct {
+	PreviewDuration time.Duration // Duration of the preview
+	PreviewSize     int64         // Size of the preview in bytes
+	InputDuration   time.Duration // Duration of the whole input
+	EstimatedSize   int64         // Expected size of the full output in bytes
+	EstimatedTime   time.Duration // Expected wall-clock time of the full encode
+}
+
+// validatePreview checks the preview range options against the rest of the conversion
+func validatePreview(inputPath, outputPath string, customParams CustomParameters) error {
+	switch {
+	case customParams.Preview < 0:
+		return fmt.Errorf("--preview must be a positive duration")
+	case customParams.PreviewAt < 0:
+		return fmt.Errorf("--preview-at must not be negative")
+	case customParams.Preview == 0:
+		return fmt.Errorf("--preview-at requires --preview")
+	case analyzer.IsStdinPath(inputPath):
+		return fmt.Errorf("--preview cannot be used with stdin input")
+	case IsStdoutPath(outputPath):
+		return fmt.Errorf("--preview cannot be used with stdout output, whose size cannot be measured")
+	case customParams.Resumable:
+		return fmt.Errorf("--preview cannot be combined with --resumable")
+	case len(customParams.AddAudio) > 0:
+		return fmt.Errorf("--preview cannot be combined with added audio tracks")
+	}
+	return nil
+}
+
+// applyPreviewRange fits the preview into the input and makes progress cover only the preview
+func applyPreviewRange(inputInfo *analyzer.MediaInfo, customParams CustomParameters, verbose bool) (CustomParameters, error) {
+	if inputInfo.Duration > 0 {
+		if customParams.PreviewAt >= inputInfo.Duration {
+			return customParams, fmt.Errorf("--preview-at %s is past the end of the input (%s)",
+				customParams.PreviewAt, inputInfo.Duration.Round(time.Second))
+		}
+		customParams.Preview = min(customParams.Preview, inputInfo.Duration-customParams.PreviewAt)
+		inputInfo.Duration = customParams.Preview
+	}
+
+	if verbose {
+		color.Cyan("🎞️  Preview: encoding %s starting at %s", customParams.Preview, customParams.PreviewAt)
+	}
+	return customParams, nil
+}
+
+// EstimateFromPreview reads a finished preview back and extrapolates the size and encoding time
+// of converting the whole input with the same settings
+func EstimateFromPreview(ctx context.Context, inputPath, previewPath string, elapsed time.Duration) (*PreviewEstimate, error) {
+	inputInfo, err := analyzer.AnalyzeMedia(ctx, inputPath)
+	if err != nil {
+		return nil, fmt.Errorf("analyzing input: %w", err)
+	}
+	previewInfo, err := analyzer.AnalyzeMedia(ctx, previewPath)
+	if err != nil {
+		return nil, fmt.Errorf("analyzing preview: %w", err)
+	}
+	if previewInfo.Duration <= 0 || inputInfo.Duration <= 0 {
+		return nil, fmt.Errorf("preview or input has no duration to extrapolate from")
+	}
+
+	scale := inputInfo.Duration.Seconds() / previewInfo.Duration.Seconds()
+	return &PreviewEstimate{
+		PreviewDuration: previewInfo.Duration,
+		PreviewSize:     previewInfo.Size,
+		InputDuration:   inputInfo.Duration,
+		EstimatedSize:   int64(float64(previewInfo.Size) * scale),
+		EstimatedTime:   time.Duration(float64(elapsed) * scale),
+	}, nil
+}
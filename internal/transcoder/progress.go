@@ -0,0 +1,153 @@
+package transcoder
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"math"
+	"time"
+)
+
+// Progress is one progress update of a running FFmpeg process
+type Progress struct {
+	Percent      float64       // Completion percentage; 0 when Duration is unknown
+	ETA          time.Duration // Estimated time remaining; 0 when unknown
+	Speed        float64       // Encoding speed relative to real time
+	FPS          float64       // Frames encoded per second
+	Frames       int64         // Frames written so far
+	BytesWritten int64         // Output size so far
+	Bitrate      string        // Current output bitrate (e.g., "1523.4kbits/s"); empty when unknown
+	Position     time.Duration // Output position
+	Duration     time.Duration // Length of the output being written; 0 when unknown
+	Done         bool          // The process has exited; repeats the last update
+}
+
+// ProgressFunc receives progress updates. It is called from the goroutine reading FFmpeg's
+// output, so it should return quickly.
+type ProgressFunc func(Progress)
+
+// progressKey is the context key of the progress reporting of a job
+type progressKey struct{}
+
+// progressSettings is where the FFmpeg runs of a job report their progress
+type progressSettings struct {
+	onProgress ProgressFunc
+	events     io.Writer
+}
+
+// WithProgress returns a context whose FFmpeg runs report their progress to onProgress (the
+// progress bar of the CLI), which is not called when FFmpeg's own output is shown instead.
+// When events is not nil, it receives newline-delimited JSON progress events in place of the
+// progress bar and FFmpeg's own output (--progress-format json). Either may be nil.
+func WithProgress(ctx context.Context, onProgress ProgressFunc, events io.Writer) context.Context {
+	return context.WithValue(ctx, progressKey{}, progressSettings{onProgress: onProgress, events: events})
+}
+
+// progressEvents returns the writer for JSON progress events set with WithProgress, or nil
+func progressEvents(ctx context.Context) io.Writer {
+	settings, _ := ctx.Value(progressKey{}).(progressSettings)
+	return settings.events
+}
+
+// newProgress computes percentage and ETA for FFmpeg's position in an output of the given duration
+func newProgress(position time.Duration, speed float64, duration time.Duration) Progress {
+	progress := Progress{Position: position, Speed: speed, Duration: duration}
+	if duration > 0 {
+		progress.Percent = calculateProgressPercent(position.Seconds(), duration.Seconds())
+		if speed > 0 && position < duration {
+			progress.ETA = time.Duration(float64(duration-position) / speed)
+		}
+	}
+	return progress
+}
+
+// progressFromReport converts a block of FFmpeg's -progress output to a progress update
+func progressFromReport(report progressReport, duration time.Duration) Progress {
+	progress := newProgress(report.outTime, report.speed, duration)
+	progress.FPS = report.fps
+	progress.Frames = report.frame
+	progress.BytesWritten = report.totalSize
+	progress.Bitrate = report.bitrate
+	return progress
+}
+
+// reportProgress passes a progress update to the progress function set with WithProgress and,
+// except for the final repeat, to the JSON progress events
+func reportProgress(ctx context.Context, progress Progress) {
+	settings, _ := ctx.Value(progressKey{}).(progressSettings)
+	if settings.events != nil && !progress.Done {
+		emitProgressEvent(settings.events, progress)
+	}
+	if settings.onProgress != nil {
+		settings.onProgress(progress)
+	}
+}
+
+// progressEvent is a JSON event reporting the progress of a running FFmpeg process
+type progressEvent struct {
+	Event        string   `json:"event"`         // Always "progress"
+	Percent      *float64 `json:"percent"`       // Completion percentage; null when the duration is unknown
+	ETASeconds   *float64 `json:"eta_seconds"`   // Estimated seconds remaining; null when unknown
+	Speed        float64  `json:"speed"`         // Encoding speed relative to real time
+	FPS          float64  `json:"fps"`           // Frames encoded per second
+	Frame        int64    `json:"frame"`         // Frames written so far
+	BytesWritten int64    `json:"bytes_written"` // Output size so far
+	Bitrate      string   `json:"bitrate"`       // Current output bitrate (e.g., "1523.4kbits/s")
+	Position     float64  `json:"position"`      // Output position in seconds
+}
+
+// statusEvent is a JSON event marking the start or end of an FFmpeg process
+type statusEvent struct {
+	Event    string   `json:"event"`              // "start", "end" or "error"
+	Duration *float64 `json:"duration,omitempty"` // Input duration in seconds (start, when known)
+	Error    string   `json:"error,omitempty"`    // Failure message (error)
+}
+
+// writeProgressEvent emits a JSON event; a failing writer does not stop the conversion
+func writeProgressEvent(events io.Writer, event any) {
+	data, err := json.Marshal(event)
+	if err != nil {
+		return
+	}
+	events.Write(append(data, '\n'))
+}
+
+// emitStartEvent reports that FFmpeg is starting on an input of the given duration
+func emitStartEvent(events io.Writer, totalSeconds float64) {
+	event := statusEvent{Event: "start"}
+	if totalSeconds > 0 {
+		event.Duration = &totalSeconds
+	}
+	writeProgressEvent(events, event)
+}
+
+// emitEndEvent reports that FFmpeg finished, or the error it failed with
+func emitEndEvent(events io.Writer, err error) {
+	if err != nil {
+		writeProgressEvent(events, statusEvent{Event: "error", Error: err.Error()})
+		return
+	}
+	writeProgressEvent(events, statusEvent{Event: "end"})
+}
+
+// emitProgressEvent reports a progress update; percent and ETA are left out without a duration
+func emitProgressEvent(events io.Writer, progress Progress) {
+	event := progressEvent{
+		Event:        "progress",
+		Speed:        progress.Speed,
+		FPS:          progress.FPS,
+		Frame:        progress.Frames,
+		BytesWritten: progress.BytesWritten,
+		Bitrate:      progress.Bitrate,
+		Position:     progress.Position.Seconds(),
+	}
+	if progress.Duration > 0 {
+		percent := math.Round(progress.Percent*10) / 10
+		event.Percent = &percent
+		if progress.Speed > 0 {
+			eta := math.Round(progress.ETA.Seconds()*10) / 10
+			event.ETASeconds = &eta
+		}
+	}
+	writeProgressEvent(events, event)
+}
@@ -0,0 +1,32 @@
+package transcoder
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+)
+
+func TestReportProgressPerContext(t *testing.T) {
+	var first, second []Progress
+	var events bytes.Buffer
+	firstCtx := WithProgress(t.Context(), func(p Progress) { first = append(first, p) }, nil)
+	secondCtx := WithProgress(t.Context(), func(p Progress) { second = append(second, p) }, &events)
+
+	reportProgress(firstCtx, Progress{Frames: 1})
+	reportProgress(secondCtx, Progress{Frames: 2})
+	reportProgress(secondCtx, Progress{Frames: 2, Done: true})
+	reportProgress(t.Context(), Progress{Frames: 3})
+
+	if len(first) != 1 || first[0].Frames != 1 {
+		t.Errorf("first context got %+v, want only frame 1", first)
+	}
+	if len(second) != 2 || second[0].Frames != 2 || !second[1].Done {
+		t.Errorf("second context got %+v, want frame 2 and its final repeat", second)
+	}
+	if got := strings.Count(events.String(), "\n"); got != 1 {
+		t.Errorf("second context wrote %d events, want 1 (no event for the final repeat):\n%s", got, events.String())
+	}
+	if !strings.Contains(events.String(), `"frame":2`) {
+		t.Errorf("event %q does not report frame 2", events.String())
+	}
+}
@@ -0,0 +1,496 @@
+package transcoder
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// vmafModelVersion is the first release whose libvmaf filter selects built-in models by name
+var vmafModelVersion = [2]int{5, 1}
+
+// vmafModels maps the --model names to the models built into libvmaf
+var vmafModels = map[string]string{
+	"vmaf":     "vmaf_v0.6.1",    // 1080p content watched on a TV at three screen heights
+	"vmaf-4k":  "vmaf_4k_v0.6.1", // 4K content watched on a TV at 1.5 screen heights
+	"vmaf-neg": "vmaf_v0.6.1neg", // No enhancement gain: sharpening does not raise the score
+}
+
+// VMAFModelNames returns the names accepted as a VMAF model, sorted
+func VMAFModelNames() []string {
+	names := make([]string, 0, len(vmafModels))
+	for name := range vmafModels {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// QualityParams holds parameters for scoring an encode against its source
+type QualityParams struct {
+	ReferenceFile string   // Source the encode was made from
+	EncodedFile   string   // Encode being scored
+	Metrics       []string // Metrics to compute (see QualityMetricNames); VMAF when empty
+	Model         string   // VMAF model name (see VMAFModelNames)
+	Threads       int      // Threads libvmaf may use (0 lets it decide)
+	CSVFile       string   // File receiving the per-frame scores (optional)
+	Verbose       bool     // Verbose output
+
+	// Part of the reference the encode was made from, e.g. by a preview; the whole
+	// reference is compared when ReferenceDuration is 0
+	ReferenceStart    time.Duration
+	ReferenceDuration time.Duration
+}
+
+// QualityScore summarizes the per-frame scores of a quality metric
+type QualityScore struct {
+	Metric       string    `json:"metric"`          // Metric name (e.g., "vmaf")
+	Model        string    `json:"model,omitempty"` // VMAF model (e.g., "vmaf_v0.6.1")
+	Unit         string    `json:"unit,omitempty"`  // Unit of the scores (e.g., "dB")
+	Frames       int       `json:"frames"`          // Frames compared
+	Mean         float64   `json:"mean"`            // Arithmetic mean
+	HarmonicMean float64   `json:"harmonic_mean"`   // Harmonic mean, which weighs bad frames more
+	Min          float64   `json:"min"`             // Worst frame
+	P1           float64   `json:"p1"`              // 1st percentile: 99% of frames score higher
+	P5           float64   `json:"p5"`              // 5th percentile
+	Median       float64   `json:"median"`          // 50th percentile
+	Max          float64   `json:"max"`             // Best frame
+	PerFrame     []float64 `json:"-"`               // Score of every frame, in order
+}
+
+// maxPSNR replaces the infinite PSNR of frames identical to the reference
+const maxPSNR = 100.0
+
+// qualityMetric describes how a metric is computed by FFmpeg and read back
+type qualityMetric struct {
+	name   string // Name given to --metric
+	filter string // FFmpeg filter computing it
+	unit   string // Unit of the scores
+
+	// options returns the filter options writing per-frame scores to logPath
+	options func(ctx context.Context, params QualityParams, model, logPath string) string
+
+	// read returns the per-frame scores from the file written by the filter
+	read func(path string) ([]float64, error)
+}
+
+// qualityMetrics are the metrics the quality command can compute, in display order
+var qualityMetrics = []qualityMetric{
+	{
+		name:   "vmaf",
+		filter: "libvmaf",
+		options: func(ctx context.Context, params QualityParams, model, logPath string) string {
+			options := "log_fmt=json:log_path=" + escapeFilterValue(logPath)
+			if ffmpegSupports(ctx, vmafModelVersion) {
+				// Older releases only take a model file path and fall back to vmaf_v0.6.1
+				options += ":model=version=" + model
+			}
+			if params.Threads > 0 {
+				options += ":n_threads=" + strconv.Itoa(params.Threads)
+			}
+			return options
+		},
+		read: readVMAFLog,
+	},
+	{
+		name:   "psnr",
+		filter: "psnr",
+		unit:   "dB",
+		options: func(_ context.Context, _ QualityParams, _, logPath string) string {
+			return "stats_file=" + escapeFilterValue(logPath)
+		},
+		read: func(path string) ([]float64, error) {
+			return readStatsFile(path, "psnr_avg")
+		},
+	},
+	{
+		name:   "ssim",
+		filter: "ssim",
+		options: func(_ context.Context, _ QualityParams, _, logPath string) string {
+			return "stats_file=" + escapeFilterValue(logPath)
+		},
+		read: func(path string) ([]float64, error) {
+			return readStatsFile(path, "All")
+		},
+	},
+}
+
+// QualityMetricNames returns the names accepted as a quality metric
+func QualityMetricNames() []string {
+	names := make([]string, 0, len(qualityMetrics))
+	for _, metric := range qualityMetrics {
+		names = append(names, metric.name)
+	}
+	return names
+}
+
+// vmafLog is the part of libvmaf's JSON log that holds the per-frame scores
+type vmafLog struct {
+	Frames []struct {
+		Metrics map[string]float64 `json:"metrics"`
+	} `json:"frames"`
+}
+
+// ScoreQuality compares every frame of the encode with the reference, computing all metrics
+// in one FFmpeg run. The encode is scaled to the reference's resolution and both are brought to
+// the reference's frame rate and 8-bit 4:2:0 first, as the metrics need frames of equal size and
+// format, paired one to one. Scores are returned in the order of QualityMetricNames.
+func ScoreQuality(ctx context.Context, params QualityParams) ([]*QualityScore, error) {
+	if len(params.Metrics) == 0 {
+		params.Metrics = []string{"vmaf"}
+	}
+	metrics, model, err := validateQualityParams(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	reference, err := analyzeInputMedia(ctx, params.ReferenceFile, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	encoded, err := analyzeInputMedia(ctx, params.EncodedFile, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	if len(reference.VideoStreams) == 0 || len(encoded.VideoStreams) == 0 {
+		return nil, fmt.Errorf("both files need a video stream to be compared")
+	}
+	video := reference.VideoStreams[0]
+	if video.Width <= 0 || video.Height <= 0 {
+		return nil, fmt.Errorf("could not determine the resolution of the reference")
+	}
+
+	logPaths := make([]string, len(metrics))
+	for i, metric := range metrics {
+		if err := checkFilterAvailable(ctx, metric.filter); err != nil {
+			return nil, err
+		}
+
+		logFile, err := os.CreateTemp("", "transcoder-"+metric.name+"-*.log")
+		if err != nil {
+			return nil, fmt.Errorf("creating %s log file: %w", metric.name, err)
+		}
+		logFile.Close()
+		defer os.Remove(logFile.Name())
+		logPaths[i] = logFile.Name()
+	}
+
+	cmd := buildQualityCommand(ctx, params, metrics, model, video, logPaths)
+	if params.Verbose {
+		color.Cyan("📏 Scoring %s against %s (%s)", params.EncodedFile, params.ReferenceFile,
+			strings.Join(params.Metrics, ", "))
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	if err := executeFFmpeg(ctx, cmd, encoded, params.Verbose); err != nil {
+		return nil, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+
+	scores := make([]*QualityScore, 0, len(metrics))
+	for i, metric := range metrics {
+		perFrame, err := metric.read(logPaths[i])
+		if err != nil {
+			return nil, err
+		}
+		score := summarizeScores(perFrame)
+		score.Metric = metric.name
+		score.Unit = metric.unit
+		if metric.name == "vmaf" {
+			score.Model = model
+		}
+		scores = append(scores, score)
+	}
+
+	if params.CSVFile != "" {
+		if err := writeQualityCSV(params.CSVFile, scores); err != nil {
+			return nil, err
+		}
+	}
+	return scores, nil
+}
+
+// validateQualityParams validates the inputs, CSV path, metrics and model, and returns the
+// metrics in display order and the libvmaf model
+func validateQualityParams(ctx context.Context, params QualityParams) ([]qualityMetric, string, error) {
+	for _, input := range []string{params.ReferenceFile, params.EncodedFile} {
+		if err := validateInputFile(input); err != nil {
+			return nil, "", err
+		}
+		if err := securityPolicy.ValidateFilePath(input); err != nil {
+			return nil, "", fmt.Errorf("security validation failed for input path: %w", err)
+		}
+	}
+	if params.CSVFile != "" {
+		if err := securityPolicy.ValidateFilePath(params.CSVFile); err != nil {
+			return nil, "", fmt.Errorf("security validation failed for CSV path: %w", err)
+		}
+	}
+
+	for _, name := range params.Metrics {
+		if !slices.Contains(QualityMetricNames(), name) {
+			return nil, "", fmt.Errorf("unknown quality metric '%s'. Valid options: %s",
+				name, strings.Join(QualityMetricNames(), ", "))
+		}
+	}
+	metrics := make([]qualityMetric, 0, len(params.Metrics))
+	for _, metric := range qualityMetrics {
+		if slices.Contains(params.Metrics, metric.name) {
+			metrics = append(metrics, metric)
+		}
+	}
+
+	model, ok := vmafModels[params.Model]
+	if !ok {
+		return nil, "", fmt.Errorf("unknown VMAF model '%s'. Valid options: %s",
+			params.Model, strings.Join(VMAFModelNames(), ", "))
+	}
+	if params.Model != "vmaf" && slices.Contains(params.Metrics, "vmaf") && !ffmpegSupports(ctx, vmafModelVersion) {
+		return nil, "", fmt.Errorf("the %s model needs FFmpeg %d.%d or newer", params.Model,
+			vmafModelVersion[0], vmafModelVersion[1])
+	}
+
+	if params.Threads < 0 || params.Threads > 64 {
+		return nil, "", fmt.Errorf("invalid thread count %d (must be between 0 and 64)", params.Threads)
+	}
+	return metrics, model, nil
+}
+
+// buildQualityCommand compares the encode with the reference, splitting both when several
+// metrics are computed. The encode comes first in every pair, as libvmaf expects the
+// distorted video first; each metric writes its per-frame scores to its log path.
+func buildQualityCommand(ctx context.Context, params QualityParams, metrics []qualityMetric, model string,
+	video analyzer.VideoStream, logPaths []string) *exec.Cmd {
+
+	// Both inputs start at zero and are resampled to the reference's frame rate, so frames are
+	// paired by time even when the encode dropped or duplicated some or changed the rate
+	normalize := "setpts=PTS-STARTPTS"
+	rate := video.FrameRate
+	if video.IsVariableFrameRate() {
+		rate = video.AvgFrameRate
+	}
+	if analyzer.ParseFrameRate(rate) > 0 {
+		normalize += ",fps=" + rate
+	}
+
+	// The metrics compare planes of the same pixel format, so 10-bit or 4:4:4 encodes are
+	// compared with 8-bit 4:2:0 references on equal terms
+	distorted := fmt.Sprintf("%s,scale=%d:%d:flags=bicubic,format=yuv420p", normalize, video.Width, video.Height)
+	reference := normalize + ",format=yuv420p"
+	distortedPads, referencePads := []string{"distorted"}, []string{"reference"}
+	if len(metrics) > 1 {
+		distortedPads, referencePads = nil, nil
+		for i := range metrics {
+			distortedPads = append(distortedPads, fmt.Sprintf("distorted%d", i))
+			referencePads = append(referencePads, fmt.Sprintf("reference%d", i))
+		}
+		distorted += fmt.Sprintf(",split=%d", len(metrics))
+		reference += fmt.Sprintf(",split=%d", len(metrics))
+	}
+
+	graph := NewFilterGraph().
+		Add([]string{"0:v"}, distorted, distortedPads).
+		Add([]string{"1:v"}, reference, referencePads)
+	for i, metric := range metrics {
+		graph.Add([]string{distortedPads[i], referencePads[i]},
+			metric.filter+"="+metric.options(ctx, params, model, logPaths[i]), nil)
+	}
+
+	args := []string{"-i", params.EncodedFile}
+	if params.ReferenceDuration > 0 {
+		args = append(args,
+			"-ss", strconv.FormatFloat(params.ReferenceStart.Seconds(), 'f', 3, 64),
+			"-t", strconv.FormatFloat(params.ReferenceDuration.Seconds(), 'f', 3, 64))
+	}
+	args = append(args,
+		"-i", params.ReferenceFile,
+		"-filter_complex", graph.String(),
+		"-f", "null", "-")
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
+
+// filterValueEscaper escapes a value inside a filter option; FFmpeg accepts forward slashes on Windows
+var filterValueEscaper = strings.NewReplacer(`\`, "/", ":", `\:`, "'", `\'`)
+
+// escapeFilterValue escapes a file path used as a filter option value
+func escapeFilterValue(value string) string {
+	return filterValueEscaper.Replace(value)
+}
+
+// filterOptionEscaper escapes the characters that end or quote a value inside a filter's options
+var filterOptionEscaper = strings.NewReplacer(`\`, `\\`, "'", `\'`, ":", `\:`)
+
+// quoteFilterPath escapes a user-supplied file path for a filter option inside -vf or
+// -filter_complex. FFmpeg unescapes the value twice, once for the filter graph and once for the
+// filter's options, so the path is escaped for the options and then quoted for the graph, where
+// only the quote itself needs care. Any character, including the backslashes and drive colon of
+// a Windows path, reaches the filter unchanged.
+func quoteFilterPath(path string) string {
+	return "'" + strings.ReplaceAll(filterOptionEscaper.Replace(path), "'", `'\''`) + "'"
+}
+
+// readVMAFLog reads the per-frame VMAF scores from libvmaf's JSON log
+func readVMAFLog(path string) ([]float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading VMAF log: %w", err)
+	}
+
+	var log vmafLog
+	if err := json.Unmarshal(data, &log); err != nil {
+		return nil, fmt.Errorf("parsing VMAF log: %w", err)
+	}
+
+	scores := make([]float64, 0, len(log.Frames))
+	for _, frame := range log.Frames {
+		if score, ok := frame.Metrics["vmaf"]; ok {
+			scores = append(scores, score)
+		}
+	}
+	if len(scores) == 0 {
+		return nil, fmt.Errorf("the VMAF log has no frame scores")
+	}
+	return scores, nil
+}
+
+// readStatsFile reads one value per frame from the stats file of the psnr or ssim filter,
+// whose lines look like "n:1 mse_avg:0.52 ... psnr_avg:50.97 ..." and "n:1 Y:0.99 ... All:0.98 (19.5)"
+func readStatsFile(path, key string) ([]float64, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading %s stats: %w", key, err)
+	}
+
+	var scores []float64
+	for _, line := range strings.Split(string(data), "\n") {
+		for _, field := range strings.Fields(line) {
+			name, value, ok := strings.Cut(field, ":")
+			if !ok || name != key {
+				continue
+			}
+			score, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				return nil, fmt.Errorf("parsing %s stats: invalid value %q", key, value)
+			}
+			scores = append(scores, min(score, maxPSNR))
+		}
+	}
+	if len(scores) == 0 {
+		return nil, fmt.Errorf("the %s stats have no frame scores", key)
+	}
+	return scores, nil
+}
+
+// writeQualityCSV writes the per-frame scores as CSV with one column per metric, for plotting
+func writeQualityCSV(path string, scores []*QualityScore) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return fmt.Errorf("creating CSV file: %w", err)
+	}
+	defer file.Close()
+
+	writer := csv.NewWriter(file)
+	header := []string{"frame"}
+	frames := 0
+	for _, score := range scores {
+		header = append(header, score.Metric)
+		frames = max(frames, len(score.PerFrame))
+	}
+	writer.Write(header)
+
+	for frame := 0; frame < frames; frame++ {
+		record := []string{strconv.Itoa(frame)}
+		for _, score := range scores {
+			value := ""
+			if frame < len(score.PerFrame) {
+				value = strconv.FormatFloat(score.PerFrame[frame], 'f', 4, 64)
+			}
+			record = append(record, value)
+		}
+		writer.Write(record)
+	}
+
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("writing CSV file: %w", err)
+	}
+	return file.Close()
+}
+
+// summarizeScores computes the mean and percentiles of per-frame scores
+func summarizeScores(scores []float64) *QualityScore {
+	sorted := slices.Clone(scores)
+	slices.Sort(sorted)
+
+	sum, inverseSum := 0.0, 0.0
+	for _, score := range sorted {
+		sum += score
+		// As libvmaf does, shift by one so frames scoring zero do not dominate
+		inverseSum += 1 / (score + 1)
+	}
+	count := float64(len(sorted))
+
+	return &QualityScore{
+		PerFrame:     scores,
+		Frames:       len(sorted),
+		Mean:         sum / count,
+		HarmonicMean: count/inverseSum - 1,
+		Min:          sorted[0],
+		P1:           percentile(sorted, 1),
+		P5:           percentile(sorted, 5),
+		Median:       percentile(sorted, 50),
+		Max:          sorted[len(sorted)-1],
+	}
+}
+
+// percentile returns the p-th percentile of sorted values, interpolating between neighbours
+func percentile(sorted []float64, p float64) float64 {
+	rank := p / 100 * float64(len(sorted)-1)
+	lower := int(math.Floor(rank))
+	upper := min(lower+1, len(sorted)-1)
+	return sorted[lower] + (sorted[upper]-sorted[lower])*(rank-float64(lower))
+}
+
+// filterLists caches the filters of every ffmpeg executable used
+var filterLists buildQueries[map[string]bool]
+
+// checkFilterAvailable reports a filter missing from the local FFmpeg build. When the filter
+// list cannot be read, FFmpeg reports any problem itself.
+func checkFilterAvailable(ctx context.Context, filter string) error {
+	filters, err := filterLists.get(ctx, func(ffmpeg string) (map[string]bool, error) {
+		out, err := exec.CommandContext(ctx, ffmpeg, "-hide_banner", "-filters").Output()
+		if err != nil {
+			return nil, err
+		}
+		return parseFilterList(string(out)), nil
+	})
+	if err != nil || filters[filter] {
+		return nil
+	}
+	return fmt.Errorf("this FFmpeg build has no %s filter (see ffmpeg -filters); "+
+		"install a full FFmpeg build", filter)
+}
+
+// parseFilterList extracts filter names from `ffmpeg -filters` output, whose entries look
+// like " ... libvmaf  VV->V  Calculate the VMAF between two video streams."
+func parseFilterList(output string) map[string]bool {
+	filters := make(map[string]bool)
+	for _, line := range strings.Split(output, "\n") {
+		fields := strings.Fields(line)
+		if len(fields) >= 3 && strings.Contains(fields[2], "->") {
+			filters[fields[1]] = true
+		}
+	}
+	return filters
+}
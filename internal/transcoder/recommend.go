@@ -0,0 +1,300 @@
+package transcoder
+
+import (
+	_ "embed"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	"github.com/rishad1234/term-video-transcoder/internal/config"
+)
+
+// defaultRecommendationData is the built-in recommendation rule set
+//
+//go:embed data/recommendations.json
+var defaultRecommendationData []byte
+
+// Stream copy modes for a recommendation goal
+const (
+	StreamCopyAny      = "any"      // Copy whenever the container accepts the source codecs
+	StreamCopyMatching = "matching" // Copy only when the source codecs are among the goal's codecs
+)
+
+// EncoderChoice is one candidate encoder for a goal, in order of preference
+type EncoderChoice struct {
+	Encoder string `json:"encoder"`           // ffmpeg encoder name (e.g., "libx265")
+	Codec   string `json:"codec"`             // ffprobe codec name the encoder produces (e.g., "hevc")
+	Bitrate string `json:"bitrate,omitempty"` // Recommended bitrate; empty means use the preset
+	Reason  string `json:"reason"`            // Why this encoder suits the goal
+}
+
+// GoalRule describes how to reach a recommendation goal
+type GoalRule struct {
+	Summary          string          `json:"summary"`                      // One-line description of the goal
+	Containers       []string        `json:"containers"`                   // Output containers in order of preference
+	StreamCopy       string          `json:"stream_copy,omitempty"`        // Stream copy mode ("any", "matching" or empty)
+	StreamCopyReason string          `json:"stream_copy_reason,omitempty"` // Why stream copy suits the goal
+	Preset           string          `json:"preset"`                       // Quality preset for re-encoding
+	PresetReason     string          `json:"preset_reason"`                // Why this preset suits the goal
+	Video            []EncoderChoice `json:"video"`                        // Video encoder candidates
+	Audio            []EncoderChoice `json:"audio"`                        // Audio encoder candidates
+}
+
+// RecommendedOption is a single command line option and the reason it was chosen
+type RecommendedOption struct {
+	Flag   string // Option as passed to convert (e.g., "--video-codec libx265")
+	Reason string // Explanation of the choice
+}
+
+// Recommendation is a complete convert command for a goal
+type Recommendation struct {
+	Goal       string              // Goal name (e.g., "smallest")
+	Summary    string              // Goal description
+	Output     string              // Suggested output path
+	StreamCopy bool                // Whether the command stream copies instead of re-encoding
+	CopyReason string              // Why stream copy was chosen
+	Options    []RecommendedOption // Options in command order (empty for stream copy)
+}
+
+// Command returns the full convert command line for the recommendation
+func (r Recommendation) Command(input string) string {
+	parts := []string{"transcoder", "convert", input, r.Output}
+	for _, option := range r.Options {
+		parts = append(parts, option.Flag)
+	}
+	return strings.Join(parts, " ")
+}
+
+var (
+	recommendationOnce  sync.Once
+	recommendationRules map[string]GoalRule
+	recommendationErr   error
+)
+
+// loadRecommendationRules parses the embedded rule set and applies the user override file
+func loadRecommendationRules() (map[string]GoalRule, error) {
+	rules := make(map[string]GoalRule)
+	if err := json.Unmarshal(defaultRecommendationData, &rules); err != nil {
+		return nil, fmt.Errorf("parsing built-in recommendation rules: %w", err)
+	}
+
+	overridePath := RecommendationOverridePath()
+	if overridePath == "" {
+		return rules, nil
+	}
+
+	data, err := os.ReadFile(overridePath)
+	if os.IsNotExist(err) {
+		return rules, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading recommendation override %s: %w", overridePath, err)
+	}
+
+	// Goals present in the override replace the built-in rules
+	overrides := make(map[string]GoalRule)
+	if err := json.Unmarshal(data, &overrides); err != nil {
+		return nil, fmt.Errorf("parsing recommendation override %s: %w", overridePath, err)
+	}
+	for goal, rule := range overrides {
+		rules[strings.ToLower(goal)] = rule
+	}
+
+	return rules, nil
+}
+
+// RecommendationOverridePath returns the location of the user recommendation override file
+func RecommendationOverridePath() string {
+	configDir, err := config.Dir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(configDir, "recommendations.json")
+}
+
+// getRecommendationRules returns the loaded rule set, loading it on first use
+func getRecommendationRules() (map[string]GoalRule, error) {
+	recommendationOnce.Do(func() {
+		recommendationRules, recommendationErr = loadRecommendationRules()
+	})
+	return recommendationRules, recommendationErr
+}
+
+// RecommendationGoals returns the available goal names in sorted order
+func RecommendationGoals() ([]string, error) {
+	rules, err := getRecommendationRules()
+	if err != nil {
+		return nil, err
+	}
+
+	goals := make([]string, 0, len(rules))
+	for goal := range rules {
+		goals = append(goals, goal)
+	}
+	sort.Strings(goals)
+	return goals, nil
+}
+
+// Recommend builds a convert command for the goal from the source media and available encoders.
+// An empty output derives the output path from the input (e.g., "movie.smallest.mkv").
+func Recommend(inputInfo *analyzer.MediaInfo, goal, output string, encoders map[string]bool) (Recommendation, error) {
+	rules, err := getRecommendationRules()
+	if err != nil {
+		return Recommendation{}, fmt.Errorf("loading recommendation rules: %w", err)
+	}
+
+	goal = strings.ToLower(goal)
+	rule, ok := rules[goal]
+	if !ok {
+		goals, _ := RecommendationGoals()
+		return Recommendation{}, fmt.Errorf("unknown goal: %s (available: %s)", goal, strings.Join(goals, ", "))
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return Recommendation{}, fmt.Errorf("input has no video stream (use extract for audio-only files)")
+	}
+
+	// An explicit output fixes the container
+	if output != "" {
+		format := getFormatFromPath(output)
+		if !SupportedFormats[format] {
+			return Recommendation{}, fmt.Errorf("unsupported output format: %s", format)
+		}
+		rule.Containers = []string{format}
+	}
+	if len(rule.Containers) == 0 {
+		return Recommendation{}, fmt.Errorf("goal %s has no output containers", goal)
+	}
+
+	rec := Recommendation{Goal: goal, Summary: rule.Summary}
+
+	// Prefer stream copy when the goal allows it and a container accepts the source as is
+	if container, ok := findStreamCopyContainer(inputInfo, rule); ok {
+		rec.Output = recommendationOutputPath(inputInfo.Filename, output, goal, container)
+		rec.StreamCopy = true
+		rec.CopyReason = rule.StreamCopyReason
+		return rec, nil
+	}
+
+	container := rule.Containers[0]
+	compat, ok, err := GetContainerCompatibility(container)
+	if err != nil || !ok {
+		return Recommendation{}, fmt.Errorf("no compatibility data for container %s", container)
+	}
+	rec.Output = recommendationOutputPath(inputInfo.Filename, output, goal, container)
+
+	video, ok := chooseEncoder(rule.Video, encoders, compat.VideoCodecs)
+	if !ok {
+		return Recommendation{}, fmt.Errorf("none of the video encoders for goal %s are available in ffmpeg", goal)
+	}
+	rec.Options = append(rec.Options, RecommendedOption{Flag: "--video-codec " + video.Encoder, Reason: video.Reason})
+	if video.Bitrate != "" {
+		rec.Options = append(rec.Options, RecommendedOption{
+			Flag:   "--video-bitrate " + video.Bitrate,
+			Reason: fmt.Sprintf("Target bitrate for %s", video.Encoder),
+		})
+	}
+
+	if len(inputInfo.AudioStreams) > 0 {
+		audio, ok := chooseEncoder(rule.Audio, encoders, compat.AudioCodecs)
+		if !ok {
+			return Recommendation{}, fmt.Errorf("none of the audio encoders for goal %s are available in ffmpeg", goal)
+		}
+		rec.Options = append(rec.Options, RecommendedOption{Flag: "--audio-codec " + audio.Encoder, Reason: audio.Reason})
+		if audio.Bitrate != "" {
+			rec.Options = append(rec.Options, RecommendedOption{
+				Flag:   "--audio-bitrate " + audio.Bitrate,
+				Reason: fmt.Sprintf("Enough for %s to sound clean without wasting space", audio.Encoder),
+			})
+		}
+	}
+
+	if rule.Preset != "" {
+		rec.Options = append(rec.Options, RecommendedOption{Flag: "--preset " + rule.Preset, Reason: rule.PresetReason})
+	}
+
+	return rec, nil
+}
+
+// findStreamCopyContainer returns the first goal container that accepts the source streams unchanged
+func findStreamCopyContainer(inputInfo *analyzer.MediaInfo, rule GoalRule) (string, bool) {
+	switch rule.StreamCopy {
+	case StreamCopyAny:
+	case StreamCopyMatching:
+		if !sourceMatchesChoices(inputInfo, rule) {
+			return "", false
+		}
+	default:
+		return "", false
+	}
+
+	for _, container := range rule.Containers {
+		if canUseStreamCopy(inputInfo, container, CustomParameters{}) {
+			return container, true
+		}
+	}
+	return "", false
+}
+
+// sourceMatchesChoices reports whether the source codecs are among the goal's encoder candidates
+func sourceMatchesChoices(inputInfo *analyzer.MediaInfo, rule GoalRule) bool {
+	if !containsCodec(rule.Video, inputInfo.VideoStreams[0].Codec) {
+		return false
+	}
+	if len(inputInfo.AudioStreams) > 0 && !containsCodec(rule.Audio, inputInfo.AudioStreams[0].Codec) {
+		return false
+	}
+	return true
+}
+
+// containsCodec reports whether any encoder choice produces the given codec
+func containsCodec(choices []EncoderChoice, codec string) bool {
+	for _, choice := range choices {
+		if strings.EqualFold(choice.Codec, codec) {
+			return true
+		}
+	}
+	return false
+}
+
+// chooseEncoder picks the first candidate that ffmpeg provides, the transcoder allows,
+// and the container can hold
+func chooseEncoder(choices []EncoderChoice, encoders map[string]bool, containerCodecs []CodecEntry) (EncoderChoice, bool) {
+	for _, choice := range choices {
+		if !encoders[choice.Encoder] {
+			continue
+		}
+		if securityPolicy.ValidateCodec(choice.Encoder, "video") != nil && securityPolicy.ValidateCodec(choice.Encoder, "audio") != nil {
+			continue
+		}
+		if !containerAcceptsCodec(choice.Codec, containerCodecs) {
+			continue
+		}
+		return choice, true
+	}
+	return EncoderChoice{}, false
+}
+
+// containerAcceptsCodec reports whether a container lists the codec at all, regardless of profile
+func containerAcceptsCodec(codec string, containerCodecs []CodecEntry) bool {
+	for _, entry := range containerCodecs {
+		if entry.matches(codec) {
+			return true
+		}
+	}
+	return false
+}
+
+// recommendationOutputPath returns the explicit output or derives one next to the input
+func recommendationOutputPath(input, output, goal, container string) string {
+	if output != "" {
+		return output
+	}
+	ext := filepath.Ext(input)
+	name := strings.TrimSuffix(input, ext)
+	return fmt.Sprintf("%s.%s.%s", name, goal, container)
+}
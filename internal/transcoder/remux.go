@@ -0,0 +1,133 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// RemuxParams holds parameters for changing the container of a media file
+type RemuxParams struct {
+	InputFile  string // Input file path
+	OutputFile string // Output file path; the extension selects the container
+	Verbose    bool   // Verbose output
+}
+
+// RemuxMedia copies every stream of the input into a new container without re-encoding.
+// It fails before running FFmpeg when a stream cannot be carried by the output container.
+func RemuxMedia(ctx context.Context, params RemuxParams) error {
+	outputFormat, err := validateRemuxParams(params)
+	if err != nil {
+		return err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return err
+	}
+
+	if err := checkRemuxCompatibility(inputInfo, outputFormat); err != nil {
+		return err
+	}
+
+	cmd := buildRemuxCommand(ctx, params, outputFormat)
+	if params.Verbose {
+		color.Green("✅ All streams can be copied into %s", strings.ToUpper(outputFormat))
+		reportDroppedAttachments(inputInfo, outputFormat)
+		fmt.Printf("   Command: %s\n", strings.Join(cmd.Args, " "))
+		fmt.Println()
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		return stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+	return nil
+}
+
+// validateRemuxParams validates remux paths for security and returns the output format
+func validateRemuxParams(params RemuxParams) (string, error) {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return "", err
+	}
+
+	outputFormat := getFormatFromPath(params.OutputFile)
+	if !SupportedFormats[outputFormat] {
+		return "", fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	return outputFormat, validateConversionPaths(params.InputFile, params.OutputFile)
+}
+
+// checkRemuxCompatibility verifies that every stream can be stream copied into the output container
+func checkRemuxCompatibility(inputInfo *analyzer.MediaInfo, outputFormat string) error {
+	compat, ok, err := GetContainerCompatibility(outputFormat)
+	if err != nil {
+		return fmt.Errorf("loading codec compatibility data: %w", err)
+	}
+	if !ok {
+		return fmt.Errorf("no codec compatibility data for %s (add it to %s)", outputFormat, CompatibilityOverridePath())
+	}
+
+	var problems []string
+	for _, stream := range inputInfo.VideoStreams {
+		if !isCompatibleCodec(stream.Codec, stream.Profile, compat.VideoCodecs) {
+			problems = append(problems, fmt.Sprintf("stream %d: video codec %s", stream.Index, describeCodec(stream.Codec, stream.Profile)))
+		}
+	}
+	for _, stream := range inputInfo.AudioStreams {
+		if !isCompatibleCodec(stream.Codec, stream.Profile, compat.AudioCodecs) {
+			problems = append(problems, fmt.Sprintf("stream %d: audio codec %s", stream.Index, describeCodec(stream.Codec, stream.Profile)))
+		}
+	}
+	for _, stream := range inputInfo.SubtitleStreams {
+		if !isCompatibleCodec(stream.Codec, "", compat.SubtitleCodecs) {
+			problems = append(problems, fmt.Sprintf("stream %d: subtitle codec %s", stream.Index, stream.Codec))
+		}
+	}
+
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("cannot remux into %s without re-encoding:\n  %s\nuse convert to re-encode, or see 'transcoder compat %s'",
+		outputFormat, strings.Join(problems, "\n  "), outputFormat)
+}
+
+// describeCodec renders a codec name with its profile when one is known
+func describeCodec(codec, profile string) string {
+	if profile == "" {
+		return codec
+	}
+	return fmt.Sprintf("%s (%s)", codec, profile)
+}
+
+// copyAllStreamsMapArgs maps every stream of the first input except those a stream copy cannot
+// carry: data streams (such as QuickTime timecode or GoPro telemetry tracks), and attached files
+// (such as subtitle fonts) outside MKV
+func copyAllStreamsMapArgs(outputFormat string) []string {
+	args := []string{"-map", "0", "-map", "-0:d"}
+	if outputFormat != "mkv" {
+		args = append(args, "-map", "-0:t")
+	}
+	return args
+}
+
+// reportDroppedAttachments tells which attached files the output container cannot keep
+func reportDroppedAttachments(inputInfo *analyzer.MediaInfo, outputFormat string) {
+	if outputFormat == "mkv" || len(inputInfo.Attachments) == 0 {
+		return
+	}
+	color.Yellow("⚠️  %d attached file(s) (e.g., fonts) are left out; %s cannot carry attachments",
+		len(inputInfo.Attachments), strings.ToUpper(outputFormat))
+}
+
+// buildRemuxCommand builds the FFmpeg command that copies all streams into the output container
+func buildRemuxCommand(ctx context.Context, params RemuxParams, outputFormat string) *exec.Cmd {
+	args := []string{"-i", params.InputFile}
+	args = append(args, copyAllStreamsMapArgs(outputFormat)...)
+	args = append(args, "-c", "copy", "-y", params.OutputFile)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
@@ -0,0 +1,155 @@
+package transcoder
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// RepairParams holds parameters for repairing a damaged media file
+type RepairParams struct {
+	InputFile  string // Damaged input file path
+	OutputFile string // Repaired output file path
+	Verbose    bool   // Verbose output
+}
+
+// RepairStrategy describes one recovery attempt for a damaged media file
+type RepairStrategy struct {
+	Name        string   // Short strategy name
+	Description string   // Human-readable explanation
+	InputArgs   []string // Options placed before -i (demuxer/decoder behavior)
+	OutputArgs  []string // Options placed after -i (mapping and codecs)
+	Reencode    bool     // Encode with the output container's default codecs
+}
+
+// repairStrategies are tried in order, from cheapest and most faithful to most aggressive
+var repairStrategies = []RepairStrategy{
+	{
+		Name:        "remux",
+		Description: "remux with regenerated timestamps",
+		InputArgs:   []string{"-fflags", "+genpts"},
+		OutputArgs:  []string{"-map", "0:v?", "-map", "0:a?", "-c", "copy"},
+	},
+	{
+		Name:        "ignore-errors",
+		Description: "remux ignoring decode errors and discarding corrupt packets",
+		InputArgs:   []string{"-err_detect", "ignore_err", "-fflags", "+genpts+discardcorrupt"},
+		OutputArgs:  []string{"-map", "0:v?", "-map", "0:a?", "-c", "copy"},
+	},
+	{
+		Name:        "reencode",
+		Description: "error-resilient decode and full re-encode",
+		InputArgs:   []string{"-err_detect", "ignore_err", "-fflags", "+genpts+discardcorrupt"},
+		OutputArgs:  []string{"-map", "0:v?", "-map", "0:a?"},
+		Reencode:    true,
+	},
+}
+
+// RepairMedia tries each recovery strategy until one produces a readable output.
+// It returns the name of the strategy that succeeded.
+func RepairMedia(ctx context.Context, params RepairParams) (string, error) {
+	if err := validateRepairParams(params); err != nil {
+		return "", err
+	}
+
+	for i, strategy := range repairStrategies {
+		if params.Verbose {
+			color.Cyan("🔧 Attempt %d/%d: %s", i+1, len(repairStrategies), strategy.Description)
+		}
+
+		if err := runRepairStrategy(ctx, params, strategy); err != nil {
+			if ctx.Err() != nil {
+				os.Remove(params.OutputFile)
+				return "", ctx.Err()
+			}
+			if params.Verbose {
+				color.Yellow("   ✗ %s failed: %v", strategy.Name, err)
+			}
+			continue
+		}
+
+		if params.Verbose {
+			color.Green("   ✓ %s succeeded", strategy.Name)
+		}
+		return strategy.Name, nil
+	}
+
+	os.Remove(params.OutputFile)
+	return "", fmt.Errorf("all repair strategies failed for %s", params.InputFile)
+}
+
+// validateRepairParams validates repair paths for security
+func validateRepairParams(params RepairParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+
+	outputFormat := getFormatFromPath(params.OutputFile)
+	if !SupportedFormats[outputFormat] {
+		return fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	return validateConversionPaths(params.InputFile, params.OutputFile)
+}
+
+// buildRepairCommand builds the FFmpeg arguments for a repair strategy
+func buildRepairCommand(ctx context.Context, params RepairParams, strategy RepairStrategy) ([]string, error) {
+	command := []string{analyzer.FFmpeg(ctx)}
+	command = append(command, strategy.InputArgs...)
+	command = append(command, "-i", params.InputFile)
+	command = append(command, strategy.OutputArgs...)
+
+	// Re-encode with codecs the output container holds, as convert does (e.g., VP9/Opus for WebM)
+	if strategy.Reencode {
+		outputFormat := getFormatFromPath(params.OutputFile)
+		videoCodec, audioCodec := getDefaultCodecs(outputFormat)
+		containerArgs, err := containerEncoderArgs(outputFormat, videoCodec, audioCodec, false)
+		if err != nil {
+			return nil, err
+		}
+		command = append(command, "-c:v", videoCodec, "-c:a", audioCodec)
+		command = append(command, containerArgs...)
+	}
+
+	command = append(command, "-y", params.OutputFile)
+	return command, nil
+}
+
+// runRepairStrategy runs one strategy and verifies that the output can be analyzed
+func runRepairStrategy(ctx context.Context, params RepairParams, strategy RepairStrategy) error {
+	command, err := buildRepairCommand(ctx, params, strategy)
+	if err != nil {
+		return err
+	}
+	if params.Verbose {
+		fmt.Printf("   Command: %s\n", strings.Join(command, " "))
+	}
+
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	run := startFFmpegRun(cmd)
+	output, err := cmd.CombinedOutput()
+	if err := run.finishWithOutput(output, err); err != nil {
+		// The hints point at repair itself, so only FFmpeg's message is kept
+		var ffmpegErr *FFmpegError
+		if errors.As(err, &ffmpegErr) {
+			return fmt.Errorf("ffmpeg execution failed: %w: %s", ffmpegErr.Err, ffmpegErr.Detail)
+		}
+		return fmt.Errorf("ffmpeg execution failed: %w", err)
+	}
+
+	info, err := analyzer.AnalyzeMedia(ctx, params.OutputFile)
+	if err != nil {
+		return fmt.Errorf("output is not readable: %w", err)
+	}
+	if len(info.VideoStreams) == 0 && len(info.AudioStreams) == 0 {
+		return fmt.Errorf("output contains no audio or video streams")
+	}
+
+	return nil
+}
@@ -0,0 +1,43 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+)
+
+// Resamplers are the sample rate converters --resampler chooses from: auto picks soxr when the
+// local FFmpeg has it and FFmpeg's own (swr) otherwise
+var Resamplers = []string{"auto", "soxr", "swr"}
+
+// resolveResampler returns the resampler used for a sample rate change, or "" when FFmpeg's
+// default applies. An explicit soxr needs an FFmpeg built with libsoxr. The resampler is
+// assumed to be valid.
+func resolveResampler(ctx context.Context, resampler, sampleRate string) (string, error) {
+	if sampleRate == "" {
+		if resampler == "soxr" || resampler == "swr" {
+			return "", fmt.Errorf("--resampler only applies when the sample rate is changed (--sample-rate)")
+		}
+		return "", nil
+	}
+
+	soxr := ffmpegBuiltWith(ctx, "--enable-libsoxr")
+	switch resampler {
+	case "soxr":
+		if !soxr {
+			return "", fmt.Errorf("this FFmpeg build has no soxr resampler (built without --enable-libsoxr); use --resampler swr")
+		}
+		return "soxr", nil
+	case "swr":
+		return "", nil
+	default:
+		if soxr {
+			return "soxr", nil
+		}
+		return "", nil
+	}
+}
+
+// resampleFilter returns the filter converting the sample rate with the given resampler
+func resampleFilter(resampler, sampleRate string) string {
+	return fmt.Sprintf("aresample=%s:resampler=%s", sampleRate, resampler)
+}
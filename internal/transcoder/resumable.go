@@ -0,0 +1,352 @@
+package transcoder
+
+import (
+	"bufio"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// Resumable conversions encode the video in segments kept next to the output until they are joined
+const (
+	resumableSegmentLength = 2 * time.Minute
+	resumeDirSuffix        = ".resume"
+	resumeStateName        = "state.json"
+	resumeSegmentNames     = "segment_%05d.mkv"
+	resumeConcatListName   = "segments.txt"
+)
+
+// resumeState records which segments of a resumable conversion are finished
+type resumeState struct {
+	Input     string `json:"input"`      // Input file the segments were encoded from
+	InputSize int64  `json:"input_size"` // Input size, to notice a replaced input
+	Settings  string `json:"settings"`   // Encoding settings, to notice changed options
+	Completed []int  `json:"completed"`  // Indexes of the finished segments
+}
+
+// resumableSegment is one time range of the input, encoded on its own
+type resumableSegment struct {
+	start    time.Duration
+	duration time.Duration
+}
+
+// convertResumable encodes the video segment by segment, recording each finished segment in a
+// state file, then joins the segments and encodes the audio in one pass (avoiding gaps at the
+// joins). An interrupted conversion run again with the same options resumes after the last
+// finished segment; the state is removed once the output is complete.
+func convertResumable(ctx context.Context, inputPath, outputPath, videoCodec, audioCodec string,
+	customParams CustomParameters, inputInfo *analyzer.MediaInfo, verbose bool) error {
+
+	if inputInfo.Duration <= 0 {
+		return fmt.Errorf("--resumable needs the input duration, which could not be determined")
+	}
+
+	stateDir := outputPath + resumeDirSuffix
+	if err := os.MkdirAll(stateDir, 0755); err != nil {
+		return fmt.Errorf("creating resume directory: %w", err)
+	}
+
+	state, err := loadResumeState(stateDir, inputPath, resumeSettings(videoCodec, audioCodec, customParams))
+	if err != nil {
+		return err
+	}
+
+	// The segments only encode the video and the join only the audio, so each gets its part of
+	// the options the container needs for its default encoders
+	outputFormat := getFormatFromPath(outputPath)
+	videoArgs, err := containerEncoderArgs(outputFormat, videoCodec, "", true)
+	if err != nil {
+		return err
+	}
+	audioArgs, err := containerEncoderArgs(outputFormat, "copy", audioCodec, customParams.NoAudio)
+	if err != nil {
+		return err
+	}
+
+	segments := planResumableSegments(inputInfo.Duration, resumableSegmentLength)
+	if len(state.Completed) > 0 {
+		color.Cyan("♻️  Resuming: %d of %d segments already encoded", len(state.Completed), len(segments))
+	} else {
+		color.Cyan("🧩 Encoding in %d segments of %s (progress is kept in %s)",
+			len(segments), formatDuration(resumableSegmentLength), stateDir)
+	}
+
+	for i, segment := range segments {
+		if slices.Contains(state.Completed, i) {
+			continue
+		}
+
+		cmd := buildSegmentCommand(ctx, inputPath, filepath.Join(stateDir, fmt.Sprintf(resumeSegmentNames, i+1)),
+			videoCodec, videoArgs, segment, customParams, verbose)
+		if cmd == nil {
+			return fmt.Errorf("failed to build secure FFmpeg command")
+		}
+		if verbose {
+			color.Blue("🚀 Segment %d/%d", i+1, len(segments))
+			fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+		}
+
+		if err := runSegment(ctx, cmd, segment, inputInfo.Duration, verbose); err != nil {
+			if ctx.Err() != nil {
+				return fmt.Errorf("stopped at segment %d/%d (run again to resume): %w", i+1, len(segments), ctx.Err())
+			}
+			return fmt.Errorf("segment %d/%d failed (run again to resume): %w", i+1, len(segments), err)
+		}
+
+		state.Completed = append(state.Completed, i)
+		if err := saveResumeState(stateDir, state); err != nil {
+			return err
+		}
+	}
+
+	if err := writeConcatList(stateDir, resumeConcatListName, resumeSegmentNames, len(segments)); err != nil {
+		return err
+	}
+
+	cmd := buildJoinCommand(ctx, inputPath, outputPath, filepath.Join(stateDir, resumeConcatListName), audioCodec, audioArgs, customParams)
+	if verbose {
+		color.Blue("🔗 Joining %d segments", len(segments))
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+	if err := executeFFmpeg(ctx, cmd, inputInfo, verbose); err != nil {
+		return fmt.Errorf("joining segments failed (run again to retry): %w", err)
+	}
+
+	if err := os.RemoveAll(stateDir); err != nil {
+		color.Yellow("⚠️  Could not remove %s: %v", stateDir, err)
+	}
+
+	if verbose && customParams.WebOptimized {
+		reportFastStart(outputPath)
+	}
+	return nil
+}
+
+// validateResumable rejects options a segmented conversion cannot support
+func validateResumable(inputPath, outputPath string, customParams CustomParameters) error {
+	switch {
+	case analyzer.IsStdinPath(inputPath):
+		return fmt.Errorf("--resumable cannot be used with stdin input")
+	case IsStdoutPath(outputPath):
+		return fmt.Errorf("--resumable cannot be used with stdout output")
+	case len(customParams.AddAudio) > 0:
+		return fmt.Errorf("--resumable cannot be combined with added audio tracks")
+	case len(customParams.ExtraArgs) > 0:
+		return fmt.Errorf("--resumable cannot be combined with extra ffmpeg arguments")
+	case customParams.AudioDelay != 0:
+		return fmt.Errorf("--resumable cannot be combined with --audio-delay")
+	}
+	return nil
+}
+
+// resumeSettings describes the encoding settings, so a resume with different options is refused
+func resumeSettings(videoCodec, audioCodec string, customParams CustomParameters) string {
+	return fmt.Sprintf("%s|%s|%s|%s|%s|%s|%t|%t|%s|%s|%s|%s", videoCodec, customParams.VideoBitrate,
+		customParams.Resolution, customParams.Framerate, audioCodec, customParams.AudioBitrate,
+		customParams.ConstantFrameRate, customParams.FixTimestamps, customParams.textFilter, customParams.blurFilter,
+		customParams.lutFilter, customParams.padFilter)
+}
+
+// loadResumeState reads the state of an earlier run, or starts a new one
+func loadResumeState(stateDir, inputPath, settings string) (*resumeState, error) {
+	stat, err := os.Stat(inputPath)
+	if err != nil {
+		return nil, err
+	}
+	fresh := &resumeState{Input: inputPath, InputSize: stat.Size(), Settings: settings}
+
+	statePath := filepath.Join(stateDir, resumeStateName)
+	data, err := os.ReadFile(statePath)
+	if os.IsNotExist(err) {
+		return fresh, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading resume state: %w", err)
+	}
+
+	var state resumeState
+	if err := json.Unmarshal(data, &state); err != nil {
+		return nil, fmt.Errorf("parsing resume state %s: %w", statePath, err)
+	}
+	if state.Input != fresh.Input || state.InputSize != fresh.InputSize || state.Settings != fresh.Settings {
+		return nil, fmt.Errorf("%s belongs to a different input or different options; delete it to start over", stateDir)
+	}
+	return &state, nil
+}
+
+// saveResumeState records the finished segments
+func saveResumeState(stateDir string, state *resumeState) error {
+	data, err := json.MarshalIndent(state, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.WriteFile(filepath.Join(stateDir, resumeStateName), data, 0644); err != nil {
+		return fmt.Errorf("writing resume state: %w", err)
+	}
+	return nil
+}
+
+// planResumableSegments splits the input duration into consecutive segments
+func planResumableSegments(duration, length time.Duration) []resumableSegment {
+	count := int(math.Ceil(float64(duration) / float64(length)))
+	segments := make([]resumableSegment, 0, count)
+	for start := time.Duration(0); start < duration; start += length {
+		segments = append(segments, resumableSegment{start: start, duration: min(length, duration-start)})
+	}
+	return segments
+}
+
+// buildSegmentCommand encodes the video of one segment into its own file, with the video
+// options of the output container; the audio is left for the join, where it is encoded in one pass
+func buildSegmentCommand(ctx context.Context, inputPath, segmentPath, videoCodec string, videoArgs []string,
+	segment resumableSegment, customParams CustomParameters, verbose bool) *exec.Cmd {
+
+	segmentParams := CustomParameters{
+		VideoBitrate:      customParams.VideoBitrate,
+		Resolution:        customParams.Resolution,
+		Framerate:         customParams.Framerate,
+		ConstantFrameRate: customParams.ConstantFrameRate,
+		FixTimestamps:     customParams.FixTimestamps,
+		Threads:           customParams.Threads,
+		NoAudio:           true,
+
+		lutFilter:  customParams.lutFilter,
+		padFilter:  customParams.padFilter,
+		blurFilter: customParams.blurFilter,
+		textFilter: customParams.textFilter,
+
+		containerArgs: videoArgs,
+	}
+
+	builder := NewFFmpegCommandBuilder(ctx, verbose).
+		WithInputOptions(segmentParams).
+		WithInputRange(segment.start, segment.duration).
+		WithInput(inputPath).
+		WithVideoCodec(videoCodec, segmentParams).
+		WithAudioCodec("", segmentParams).
+		WithCustomParameters(segmentParams)
+	builder.args = append(builder.args, "-map", "0:v:0", "-sn", "-dn")
+
+	return builder.WithOutput(segmentPath).Build()
+}
+
+// runSegment encodes one segment, showing the progress of the whole conversion
+func runSegment(ctx context.Context, cmd *exec.Cmd, segment resumableSegment, total time.Duration, verbose bool) (err error) {
+	run := startFFmpegRun(cmd)
+	defer func() { err = run.finish(err) }()
+
+	if verbose && progressEvents(ctx) == nil {
+		cmd.Stdout = os.Stdout
+		cmd.Stderr = run.captureStderr(os.Stderr)
+		return cmd.Run()
+	}
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return fmt.Errorf("failed to start ffmpeg: %w", err)
+	}
+
+	var last *Progress
+	scanner := bufio.NewScanner(run.captureReader(stderr))
+	scanner.Split(scanStatusLines)
+	for scanner.Scan() {
+		line := scanner.Text()
+		matches := streamTimeRegex.FindStringSubmatch(line)
+		if matches == nil {
+			continue
+		}
+
+		position := segment.start.Seconds() + parseTimeFromMatches(matches)
+		speed := 0.0
+		if m := streamSpeedRegex.FindStringSubmatch(line); m != nil {
+			speed, _ = strconv.ParseFloat(m[1], 64)
+		}
+		progress := newProgress(time.Duration(position*float64(time.Second)), speed, total)
+		last = &progress
+		reportProgress(ctx, progress)
+	}
+
+	err = cmd.Wait()
+	if last != nil {
+		last.Done = true
+		reportProgress(ctx, *last)
+	}
+	return err
+}
+
+// writeConcatList lists the files named by nameFormat (numbered from 1) in order for FFmpeg's
+// concat demuxer. The list is written into dir, next to the files, so the names stay relative.
+func writeConcatList(dir, listName, nameFormat string, count int) error {
+	var list strings.Builder
+	for i := 1; i <= count; i++ {
+		fmt.Fprintf(&list, "file '%s'\n", fmt.Sprintf(nameFormat, i))
+	}
+	if err := os.WriteFile(filepath.Join(dir, listName), []byte(list.String()), 0644); err != nil {
+		return fmt.Errorf("writing segment list: %w", err)
+	}
+	return nil
+}
+
+// buildJoinCommand joins the encoded video segments without re-encoding and adds the audio
+// of the original input, encoded in one pass with the audio options of the output container
+func buildJoinCommand(ctx context.Context, inputPath, outputPath, listPath, audioCodec string, audioArgs []string,
+	customParams CustomParameters) *exec.Cmd {
+	args := []string{
+		"-f", "concat",
+		"-i", listPath,
+		"-i", inputPath,
+		"-map", "0:v:0",
+	}
+
+	if !customParams.NoAudio {
+		audioMap := "1:a:0?"
+		if customParams.AudioStream != "" {
+			number, _ := strconv.Atoi(customParams.AudioStream)
+			audioMap = fmt.Sprintf("1:a:%d", number-1)
+		}
+		args = append(args, "-map", audioMap)
+	}
+
+	args = append(args, "-c:v", "copy")
+	if !customParams.NoAudio {
+		args = append(args, "-c:a", audioCodec)
+		if audioCodec != "copy" && customParams.AudioBitrate != "" {
+			args = append(args, "-b:a", customParams.AudioBitrate)
+		}
+		args = append(args, audioArgs...)
+		if customParams.Volume != "" {
+			args = append(args, "-af", buildVolumeFilter(customParams.Volume))
+		}
+	}
+
+	if customParams.WebOptimized {
+		args = append(args, "-movflags", "+faststart")
+	}
+	if customParams.Fragmented {
+		args = append(args, "-movflags", "frag_keyframe+empty_moov")
+	}
+
+	// The metadata comes from the original input rather than the segment list
+	if customParams.StripMetadata {
+		args = append(args, metadataArgs(true)...)
+	} else {
+		args = append(args, "-map_metadata", "1")
+	}
+
+	args = append(args, "-y", outputPath)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
@@ -0,0 +1,124 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// RotationParams holds parameters for normalizing the rotation of a video
+type RotationParams struct {
+	InputFile    string // Input file path
+	OutputFile   string // Output file path
+	MetadataOnly bool   // Rewrite the rotation flag without re-encoding
+	Rotation     int    // With MetadataOnly, the clockwise rotation to store (0 clears the flag)
+	Verbose      bool   // Verbose output
+}
+
+// FixRotation normalizes the rotation of the first video stream and returns the source rotation.
+// By default the frames are physically rotated and the flag cleared, so every player shows the
+// video upright; with MetadataOnly the flag is rewritten and all streams are copied.
+func FixRotation(ctx context.Context, params RotationParams) (int, error) {
+	outputFormat, err := validateRotationParams(params)
+	if err != nil {
+		return 0, err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return 0, err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return 0, fmt.Errorf("input has no video stream")
+	}
+
+	rotation := inputInfo.VideoStreams[0].Rotation
+	if !params.MetadataOnly && rotation == 0 {
+		return 0, fmt.Errorf("video has no rotation metadata; nothing to apply")
+	}
+
+	var cmd *exec.Cmd
+	if params.MetadataOnly {
+		cmd = buildRotationMetadataCommand(ctx, params)
+	} else {
+		cmd = buildApplyRotationCommand(ctx, params, inputInfo, outputFormat)
+	}
+
+	if params.Verbose {
+		color.Cyan("🔄 Source rotation: %d°", rotation)
+		fmt.Printf("   Command: %s\n", strings.Join(cmd.Args, " "))
+		fmt.Println()
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		return 0, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+	return rotation, nil
+}
+
+// validateRotationParams validates rotation paths and values and returns the output format
+func validateRotationParams(params RotationParams) (string, error) {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return "", err
+	}
+
+	outputFormat := getFormatFromPath(params.OutputFile)
+	if !SupportedFormats[outputFormat] {
+		return "", fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	if params.Rotation%90 != 0 || params.Rotation < 0 || params.Rotation >= 360 {
+		return "", fmt.Errorf("invalid rotation %d (must be 0, 90, 180 or 270)", params.Rotation)
+	}
+	if params.Rotation != 0 && !params.MetadataOnly {
+		return "", fmt.Errorf("a rotation value can only be set together with metadata-only mode")
+	}
+
+	return outputFormat, validateConversionPaths(params.InputFile, params.OutputFile)
+}
+
+// buildApplyRotationCommand re-encodes the video with FFmpeg's automatic rotation, which turns
+// the frames upright and drops the display matrix; audio is copied when the container allows it
+func buildApplyRotationCommand(ctx context.Context, params RotationParams, inputInfo *analyzer.MediaInfo, outputFormat string) *exec.Cmd {
+	videoCodec, audioCodec := getDefaultCodecs(outputFormat)
+	if compat, ok, err := GetContainerCompatibility(outputFormat); err == nil && ok {
+		copyAudio := true
+		for _, audio := range inputInfo.AudioStreams {
+			copyAudio = copyAudio && isCompatibleCodec(audio.Codec, audio.Profile, compat.AudioCodecs)
+		}
+		if copyAudio {
+			audioCodec = "copy"
+		}
+	}
+
+	// Keep roughly the source quality; fall back to the medium preset when the bitrate is unknown
+	videoBitrate := getPresetVideoBitrate("medium")
+	if bitrate := inputInfo.VideoStreams[0].Bitrate; bitrate > 0 {
+		videoBitrate = strconv.FormatInt(bitrate/1000, 10) + "k"
+	}
+
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx),
+		"-i", params.InputFile,
+		"-map", "0:v:0", "-map", "0:a?",
+		"-c:v", videoCodec, "-b:v", videoBitrate,
+		"-c:a", audioCodec,
+		"-metadata:s:v:0", "rotate=0",
+		"-y", params.OutputFile)
+}
+
+// buildRotationMetadataCommand copies all streams and stores a new display rotation.
+// -display_rotation takes counter-clockwise degrees and needs FFmpeg 6.1 or newer.
+func buildRotationMetadataCommand(ctx context.Context, params RotationParams) *exec.Cmd {
+	counterClockwise := (360 - params.Rotation) % 360
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx),
+		"-display_rotation:v:0", strconv.Itoa(counterClockwise),
+		"-i", params.InputFile,
+		"-map", "0",
+		"-c", "copy",
+		"-y", params.OutputFile)
+}
@@ -0,0 +1,102 @@
+package transcoder
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"io"
+	"os/exec"
+	"strings"
+	"time"
+
+	"github.com/rishad1234/term-video-transcoder/internal/logging"
+)
+
+// stoppedBy returns ctx's error in place of err when ctx was cancelled, since FFmpeg was
+// then stopped rather than failing by itself
+func stoppedBy(ctx context.Context, err error) error {
+	if err != nil && ctx.Err() != nil && !errors.Is(err, ctx.Err()) {
+		return ctx.Err()
+	}
+	return err
+}
+
+// ffmpegRun keeps the end of an FFmpeg process's stderr, so a failure can be explained,
+// and records the process in the log file when logging is enabled
+type ffmpegRun struct {
+	command string
+	started time.Time
+	stderr  logging.Tail
+	full    *bytes.Buffer // Complete stderr, kept at debug level
+}
+
+// startFFmpegRun notes an FFmpeg process about to start. Its stderr must be routed
+// through the returned run so failures can be classified and logged.
+func startFFmpegRun(cmd *exec.Cmd) *ffmpegRun {
+	run := &ffmpegRun{command: strings.Join(cmd.Args, " "), started: time.Now()}
+	if logging.Debug() {
+		run.full = &bytes.Buffer{}
+	}
+	logging.Logger().Info("ffmpeg started", "command", run.command)
+	return run
+}
+
+// output is the writer FFmpeg's stderr is recorded with
+func (r *ffmpegRun) output() io.Writer {
+	if r.full == nil {
+		return &r.stderr
+	}
+	return io.MultiWriter(&r.stderr, r.full)
+}
+
+// captureStderr returns a writer that copies FFmpeg's stderr to w (which may be nil) and the run
+func (r *ffmpegRun) captureStderr(w io.Writer) io.Writer {
+	if w == nil {
+		return r.output()
+	}
+	return io.MultiWriter(w, r.output())
+}
+
+// captureReader returns a reader that copies FFmpeg's stderr read from a pipe to the run
+func (r *ffmpegRun) captureReader(stderr io.Reader) io.Reader {
+	return io.TeeReader(stderr, r.output())
+}
+
+// finishWithOutput is finish for a process whose combined output was collected
+func (r *ffmpegRun) finishWithOutput(output []byte, err error) error {
+	r.output().Write(output)
+	return r.finish(err)
+}
+
+// finish records the outcome, timing and end of stderr of the process. It returns err
+// classified from the stderr, so callers report why FFmpeg failed.
+func (r *ffmpegRun) finish(err error) error {
+	tail := r.stderr.String()
+	if r.full != nil {
+		logging.Logger().Debug("ffmpeg output", "command", r.command, "output", r.full.String())
+	}
+
+	attrs := []any{
+		"command", r.command,
+		"seconds", time.Since(r.started).Round(time.Millisecond).Seconds(),
+		"stderr_tail", tail,
+	}
+	if err == nil {
+		logging.Logger().Info("ffmpeg finished", attrs...)
+		return nil
+	}
+
+	err = classifyFFmpegError(err, tail)
+
+	var exitErr *exec.ExitError
+	if errors.As(err, &exitErr) {
+		attrs = append(attrs, "exit_code", exitErr.ExitCode())
+	}
+	var ffmpegErr *FFmpegError
+	if errors.As(err, &ffmpegErr) {
+		attrs = append(attrs, "kind", ffmpegErr.Kind.String())
+	}
+	attrs = append(attrs, "error", err.Error())
+	logging.Logger().Error("ffmpeg failed", attrs...)
+	return err
+}
@@ -0,0 +1,177 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+const (
+	// MaxSamplePoints is the largest number of clips a sample can be made of
+	MaxSamplePoints = 20
+
+	// Opening and end credits compress far better than the film itself, so the share of the
+	// input skipped at each end keeps them out of the sample
+	sampleHeadSkip = 0.05
+	sampleTailSkip = 0.10
+
+	sampleClipNames     = "clip_%02d"
+	sampleListName      = "clips.txt"
+	sampleMinimumLength = time.Second
+)
+
+// SampleParams holds parameters for encoding a review sample from several points of an input
+type SampleParams struct {
+	InputFile  string        // Input file path
+	OutputFile string        // Output file path; its extension selects the container
+	Points     int           // Number of clips, taken at evenly spaced points
+	Length     time.Duration // Length of each clip
+
+	// Encoding settings, as for ConvertVideoWithCustomParams
+	Preset          string
+	PresetExplicit  bool
+	CustomParamsSet bool
+	CustomParams    CustomParameters
+
+	Verbose bool // Verbose output
+}
+
+// CreateSample encodes short clips from evenly spaced points of the input with the given
+// settings and joins them into one file, to review the settings on varied scenes before
+// encoding everything. It returns the start of each clip in the input.
+func CreateSample(ctx context.Context, params SampleParams) ([]time.Duration, error) {
+	outputFormat, err := validateSampleParams(params)
+	if err != nil {
+		return nil, err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	offsets, err := planSampleOffsets(inputInfo.Duration, params.Points, params.Length)
+	if err != nil {
+		return nil, err
+	}
+
+	clipDir, err := os.MkdirTemp("", "transcoder-sample-*")
+	if err != nil {
+		return nil, fmt.Errorf("creating clip directory: %w", err)
+	}
+	defer os.RemoveAll(clipDir)
+
+	clipNames := sampleClipNames + "." + outputFormat
+	for i, offset := range offsets {
+		clipParams := params.CustomParams
+		clipParams.Preview = params.Length
+		clipParams.PreviewAt = offset
+		clipParams.Resumable = false
+		clipParams.WebOptimized = false
+
+		if params.Verbose {
+			color.Cyan("🎬 Clip %d/%d at %s", i+1, len(offsets), formatDuration(offset))
+		}
+		err := ConvertVideoWithCustomParams(ctx, params.InputFile, filepath.Join(clipDir, fmt.Sprintf(clipNames, i+1)),
+			params.Preset, params.PresetExplicit, params.CustomParamsSet, clipParams, params.Verbose)
+		if err != nil {
+			return nil, stoppedBy(ctx, fmt.Errorf("clip %d/%d at %s: %w", i+1, len(offsets), formatDuration(offset), err))
+		}
+	}
+
+	if err := writeConcatList(clipDir, sampleListName, clipNames, len(offsets)); err != nil {
+		return nil, err
+	}
+
+	webOptimized := outputFormat == "mp4" || outputFormat == "mov"
+	cmd := buildSampleJoinCommand(ctx, filepath.Join(clipDir, sampleListName), params.OutputFile, webOptimized)
+	if params.Verbose {
+		color.Blue("🔗 Joining %d clips", len(offsets))
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	joinedInfo := *inputInfo
+	joinedInfo.Duration = time.Duration(len(offsets)) * params.Length
+	if err := executeFFmpeg(ctx, cmd, &joinedInfo, params.Verbose); err != nil {
+		return nil, stoppedBy(ctx, fmt.Errorf("joining clips failed: %w", err))
+	}
+	return offsets, nil
+}
+
+// validateSampleParams validates sample paths and clip settings
+func validateSampleParams(params SampleParams) (string, error) {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return "", err
+	}
+	if analyzer.IsStdinPath(params.InputFile) {
+		return "", fmt.Errorf("a sample cannot be made from stdin input")
+	}
+
+	outputFormat := getFormatFromPath(params.OutputFile)
+	if !SupportedFormats[outputFormat] {
+		return "", fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	if err := validateConversionPaths(params.InputFile, params.OutputFile); err != nil {
+		return "", err
+	}
+
+	if params.Points < 1 || params.Points > MaxSamplePoints {
+		return "", fmt.Errorf("invalid number of points: %d (must be between 1 and %d)", params.Points, MaxSamplePoints)
+	}
+	if params.Length < sampleMinimumLength {
+		return "", fmt.Errorf("invalid clip length: %s (must be at least %s)", params.Length, sampleMinimumLength)
+	}
+	if len(params.CustomParams.AddAudio) > 0 {
+		return "", fmt.Errorf("a sample cannot include added audio tracks")
+	}
+
+	return outputFormat, nil
+}
+
+// planSampleOffsets spreads the clips evenly over the input without its credits: each clip is
+// centered in an equal share of the remaining time. Inputs too short to leave out the credits
+// are sampled over their whole duration.
+func planSampleOffsets(duration time.Duration, points int, length time.Duration) ([]time.Duration, error) {
+	total := time.Duration(points) * length
+	if duration < total {
+		return nil, fmt.Errorf("input (%s) is shorter than %d clips of %s", formatDuration(duration), points, length)
+	}
+
+	start := time.Duration(float64(duration) * sampleHeadSkip)
+	end := duration - time.Duration(float64(duration)*sampleTailSkip)
+	if end-start < total {
+		start, end = 0, duration
+	}
+
+	share := (end - start) / time.Duration(points)
+	offsets := make([]time.Duration, points)
+	for i := range offsets {
+		offset := start + share*time.Duration(i) + (share-length)/2
+		offsets[i] = offset.Truncate(time.Millisecond)
+	}
+	return offsets, nil
+}
+
+// buildSampleJoinCommand joins the encoded clips without re-encoding
+func buildSampleJoinCommand(ctx context.Context, listPath, outputPath string, webOptimized bool) *exec.Cmd {
+	args := []string{
+		"-f", "concat",
+		"-i", listPath,
+		"-map", "0",
+		"-c", "copy",
+	}
+	if webOptimized {
+		args = append(args, "-movflags", "+faststart")
+	}
+
+	args = append(args, "-y", outputPath)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
@@ -0,0 +1,301 @@
+package transcoder
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// DefaultSceneThreshold is the scene change score, in percent, a cut must exceed
+const DefaultSceneThreshold = 10.0
+
+// sceneSplitNames names the files of a split: the input's name, the scene number and its extension
+const sceneSplitNames = "%s.scene%%03d%s"
+
+// SceneParams holds parameters for detecting the scene changes of a video
+type SceneParams struct {
+	InputFile string        // Input video file path
+	Threshold float64       // Scene change score in percent (0-100) a cut must exceed
+	MinLength time.Duration // Cuts closer than this to the previous one are ignored
+	Verbose   bool          // Verbose output
+}
+
+// Scene is a part of a video between two scene changes
+type Scene struct {
+	Start time.Duration // Start in the input
+	End   time.Duration // End in the input
+	Score float64       // Score in percent of the cut starting the scene; 0 for the first scene
+}
+
+// sceneDetector computes scene change scores with an FFmpeg filter and prints them with the
+// metadata filter under key, scaled by scale to percent
+type sceneDetector struct {
+	filter func(threshold float64) string
+	key    string
+	scale  float64
+}
+
+// sceneDetectors are scdet (FFmpeg 4.4 and newer), which passes every frame on so progress is
+// continuous, and the select filter's scene score for older builds
+var sceneDetectors = map[string]sceneDetector{
+	"scdet": {
+		filter: func(threshold float64) string {
+			return "scdet=threshold=" + strconv.FormatFloat(threshold, 'f', -1, 64)
+		},
+		key:   "lavfi.scd.score",
+		scale: 1,
+	},
+	"select": {
+		filter: func(threshold float64) string {
+			return "select=gt(scene\\," + strconv.FormatFloat(threshold/100, 'f', -1, 64) + ")"
+		},
+		key:   "lavfi.scene_score",
+		scale: 100,
+	},
+}
+
+// DetectScenes finds the scene changes of the first video stream and returns the scenes
+// between them, covering the whole input
+func DetectScenes(ctx context.Context, params SceneParams) ([]Scene, error) {
+	if err := validateSceneParams(params); err != nil {
+		return nil, err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return nil, fmt.Errorf("input has no video stream")
+	}
+
+	detector := sceneDetectors["scdet"]
+	if checkFilterAvailable(ctx, "scdet") != nil {
+		detector = sceneDetectors["select"]
+	}
+
+	logFile, err := os.CreateTemp("", "transcoder-scenes-*.log")
+	if err != nil {
+		return nil, fmt.Errorf("creating scene log file: %w", err)
+	}
+	logFile.Close()
+	defer os.Remove(logFile.Name())
+
+	cmd := buildSceneDetectCommand(ctx, params, detector, logFile.Name())
+	if params.Verbose {
+		color.Cyan("🎬 Detecting scene changes above %.3g%%", params.Threshold)
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		return nil, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+
+	cuts, err := readSceneLog(logFile.Name(), detector)
+	if err != nil {
+		return nil, err
+	}
+	return buildScenes(cuts, inputInfo.Duration, params.MinLength), nil
+}
+
+// validateSceneParams validates the input path and detection settings
+func validateSceneParams(params SceneParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+	if analyzer.IsStdinPath(params.InputFile) {
+		return fmt.Errorf("scene detection cannot read stdin input")
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if params.Threshold <= 0 || params.Threshold >= 100 {
+		return fmt.Errorf("invalid scene threshold %.3g (must be between 0 and 100)", params.Threshold)
+	}
+	if params.MinLength < 0 {
+		return fmt.Errorf("invalid minimum scene length: %s", params.MinLength)
+	}
+	return nil
+}
+
+// buildSceneDetectCommand decodes the video and writes the time and score of every scene
+// change to logPath
+func buildSceneDetectCommand(ctx context.Context, params SceneParams, detector sceneDetector, logPath string) *exec.Cmd {
+	filter := detector.filter(params.Threshold) +
+		",metadata=mode=print:key=" + detector.key + ":file=" + escapeFilterValue(logPath)
+
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx),
+		"-i", params.InputFile,
+		"-map", "0:v:0",
+		"-vf", filter,
+		"-an",
+		"-f", "null", "-")
+}
+
+// sceneCut is one scene change: the time of its first frame and its score in percent
+type sceneCut struct {
+	time  time.Duration
+	score float64
+}
+
+// readSceneLog reads the scene changes the metadata filter printed. Each is a frame line
+// ("frame:120 pts:120120 pts_time:5.005") followed by the score ("lavfi.scd.score=23.4").
+func readSceneLog(path string, detector sceneDetector) ([]sceneCut, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading scene log: %w", err)
+	}
+	defer file.Close()
+
+	var cuts []sceneCut
+	var frameTime time.Duration
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if strings.HasPrefix(line, "frame:") {
+			for _, field := range strings.Fields(line) {
+				if value, ok := strings.CutPrefix(field, "pts_time:"); ok {
+					seconds, _ := strconv.ParseFloat(value, 64)
+					frameTime = time.Duration(math.Round(seconds*1000)) * time.Millisecond
+				}
+			}
+			continue
+		}
+
+		if value, ok := strings.CutPrefix(line, detector.key+"="); ok {
+			score, err := strconv.ParseFloat(value, 64)
+			if err != nil {
+				continue
+			}
+			cuts = append(cuts, sceneCut{time: frameTime, score: score * detector.scale})
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading scene log: %w", err)
+	}
+	return cuts, nil
+}
+
+// buildScenes turns the cuts into scenes covering the whole duration, ignoring cuts closer
+// than minLength to the previous one (flashes, fast cuts)
+func buildScenes(cuts []sceneCut, duration, minLength time.Duration) []Scene {
+	scenes := []Scene{{Start: 0}}
+	for _, cut := range cuts {
+		current := &scenes[len(scenes)-1]
+		if cut.time <= current.Start || cut.time-current.Start < minLength {
+			continue
+		}
+		if duration > 0 && duration-cut.time < minLength {
+			continue
+		}
+		current.End = cut.time
+		scenes = append(scenes, Scene{Start: cut.time, Score: cut.score})
+	}
+	scenes[len(scenes)-1].End = duration
+	return scenes
+}
+
+// SceneSplitParams holds parameters for splitting a video at its scene changes
+type SceneSplitParams struct {
+	InputFile string  // Input video file path
+	OutputDir string  // Directory receiving one file per scene
+	Scenes    []Scene // Scenes found by DetectScenes
+	Verbose   bool    // Verbose output
+}
+
+// SplitScenes writes every scene into its own file in the output directory, named after the
+// input (movie.scene001.mp4), with stream copy. As nothing is re-encoded, each file starts at
+// the keyframe at or after its scene change. It returns the files written.
+func SplitScenes(ctx context.Context, params SceneSplitParams) ([]string, error) {
+	if err := validateSceneSplitParams(params); err != nil {
+		return nil, err
+	}
+
+	if err := os.MkdirAll(params.OutputDir, 0755); err != nil {
+		return nil, fmt.Errorf("creating output directory: %w", err)
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return nil, err
+	}
+
+	extension := filepath.Ext(params.InputFile)
+	name := strings.TrimSuffix(filepath.Base(params.InputFile), extension)
+	pattern := filepath.Join(params.OutputDir, fmt.Sprintf(sceneSplitNames, name, extension))
+
+	cmd := buildSceneSplitCommand(ctx, params, pattern)
+	if params.Verbose {
+		color.Cyan("✂️  Splitting into %d scenes", len(params.Scenes))
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		return nil, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+
+	// Scenes shorter than a GOP share a keyframe and end up in one file
+	outputs := make([]string, 0, len(params.Scenes))
+	for i := 1; i <= len(params.Scenes); i++ {
+		output := fmt.Sprintf(pattern, i)
+		if _, err := os.Stat(output); err == nil {
+			outputs = append(outputs, output)
+		}
+	}
+	return outputs, nil
+}
+
+// validateSceneSplitParams validates the paths of a split
+func validateSceneSplitParams(params SceneSplitParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if err := securityPolicy.ValidateFileFormat(params.InputFile); err != nil {
+		return fmt.Errorf("security validation failed for input format: %w", err)
+	}
+	if err := securityPolicy.ValidateFilePath(params.OutputDir); err != nil {
+		return fmt.Errorf("security validation failed for output directory: %w", err)
+	}
+	if len(params.Scenes) == 0 {
+		return fmt.Errorf("no scenes to split")
+	}
+	return nil
+}
+
+// buildSceneSplitCommand cuts the input at the start of every scene but the first with the
+// segment muxer, copying all streams
+func buildSceneSplitCommand(ctx context.Context, params SceneSplitParams, pattern string) *exec.Cmd {
+	times := make([]string, 0, len(params.Scenes)-1)
+	for _, scene := range params.Scenes[1:] {
+		times = append(times, strconv.FormatFloat(scene.Start.Seconds(), 'f', 3, 64))
+	}
+
+	args := []string{
+		"-i", params.InputFile,
+		"-map", "0",
+		"-c", "copy",
+		"-f", "segment",
+		"-segment_start_number", "1",
+		"-reset_timestamps", "1",
+	}
+	if len(times) > 0 {
+		args = append(args, "-segment_times", strings.Join(times, ","))
+	}
+
+	args = append(args, "-y", pattern)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
@@ -0,0 +1,369 @@
+package transcoder
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+const (
+	// DefaultSilenceThreshold is the level in dB below which audio counts as silent
+	DefaultSilenceThreshold = -50.0
+
+	// DefaultSilenceMinLength is the shortest quiet stretch reported as silence
+	DefaultSilenceMinLength = 2 * time.Second
+
+	// silenceEdgeTolerance is how close to the start or end of the input a silence must reach
+	// to count as leading or trailing
+	silenceEdgeTolerance = 100 * time.Millisecond
+
+	// silenceTrimPadding is the silence kept next to the sound when trimming, so the first
+	// and last words are not clipped
+	silenceTrimPadding = 250 * time.Millisecond
+
+	// DefaultMinSegmentLength is the shortest part SplitOnSilence cuts a recording into
+	DefaultMinSegmentLength = 30 * time.Second
+)
+
+// SilenceParams holds parameters for detecting the silent parts of an audio track
+type SilenceParams struct {
+	InputFile string        // Input audio or video file path
+	Threshold float64       // Level in dB (below 0) under which audio counts as silent
+	MinLength time.Duration // Shortest quiet stretch reported as silence
+	Verbose   bool          // Verbose output
+}
+
+// Silence is a silent range of the input
+type Silence struct {
+	Start time.Duration // Start in the input
+	End   time.Duration // End in the input
+}
+
+// SilenceTrim describes the part of the input kept by TrimSilence
+type SilenceTrim struct {
+	Start    time.Duration // Start of the kept audio in the input
+	End      time.Duration // End of the kept audio in the input
+	Duration time.Duration // Duration of the input
+}
+
+// SilenceSegment is a part of the input between silences, written to its own file
+type SilenceSegment struct {
+	Start      time.Duration // Start in the input
+	End        time.Duration // End in the input
+	OutputFile string
+}
+
+// DetectSilence finds the silent ranges of the first audio track with FFmpeg's silencedetect
+// filter
+func DetectSilence(ctx context.Context, params SilenceParams) ([]Silence, error) {
+	silences, _, err := detectSilence(ctx, params)
+	return silences, err
+}
+
+// TrimSilence extracts the audio of the input without its leading and trailing silence,
+// keeping a quarter of a second next to the sound. Silence in the middle is kept. The
+// extraction settings apply as for ExtractAudio; their Start and End are set from the
+// detected silence.
+func TrimSilence(ctx context.Context, params SilenceParams, extraction AudioExtractionParams) (*SilenceTrim, error) {
+	if err := checkSilenceExtraction(ctx, extraction); err != nil {
+		return nil, err
+	}
+
+	silences, duration, err := detectSilence(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("could not determine the duration of the input")
+	}
+
+	trim := planSilenceTrim(silences, duration)
+	if trim.End <= trim.Start {
+		return nil, fmt.Errorf("input is silent throughout (no audio above %gdB)", params.Threshold)
+	}
+	if params.Verbose {
+		color.Cyan("✂️  Keeping %s to %s of %s", formatStreamPosition(trim.Start.Seconds()),
+			formatStreamPosition(trim.End.Seconds()), formatDuration(duration))
+	}
+
+	extraction.Start = trim.Start
+	extraction.End = 0
+	if trim.End < duration {
+		extraction.End = trim.End
+	}
+	if err := ExtractAudio(ctx, extraction); err != nil {
+		return nil, err
+	}
+	return trim, nil
+}
+
+// SplitOnSilence cuts the audio of the input into separate files at its silences, leaving the
+// silence out apart from a quarter of a second next to the sound. Silences closer than
+// minSegment to the start of the current part are kept in it, and a last part shorter than
+// minSegment joins the one before. The parts are named after extraction.OutputFile
+// (talk.mp3 becomes talk.part01.mp3, talk.part02.mp3, ...) and encoded as by ExtractAudio.
+// Returns the parts written, also on failure.
+func SplitOnSilence(ctx context.Context, params SilenceParams, minSegment time.Duration, extraction AudioExtractionParams, overwrite bool) ([]SilenceSegment, error) {
+	if minSegment < time.Second {
+		return nil, fmt.Errorf("invalid minimum segment length %s (must be at least 1s)", minSegment)
+	}
+	if err := checkSilenceExtraction(ctx, extraction); err != nil {
+		return nil, err
+	}
+
+	silences, duration, err := detectSilence(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+	if duration <= 0 {
+		return nil, fmt.Errorf("could not determine the duration of the input")
+	}
+
+	segments := planSilenceSplit(silences, duration, minSegment)
+	if len(segments) == 0 {
+		return nil, fmt.Errorf("input is silent throughout (no audio above %gdB)", params.Threshold)
+	}
+
+	// Plan every output up front so nothing is written if one already exists
+	for i := range segments {
+		segments[i].OutputFile = silenceSegmentPath(extraction.OutputFile, i+1, len(segments))
+		if _, err := os.Stat(segments[i].OutputFile); err == nil && !overwrite {
+			return nil, fmt.Errorf("output file already exists: %s (use --force to overwrite)", segments[i].OutputFile)
+		}
+	}
+
+	written := make([]SilenceSegment, 0, len(segments))
+	for i, segment := range segments {
+		if params.Verbose {
+			color.Cyan("✂️  Part %d of %d: %s to %s → %s", i+1, len(segments), formatStreamPosition(segment.Start.Seconds()),
+				formatStreamPosition(segment.End.Seconds()), segment.OutputFile)
+		}
+
+		part := extraction
+		part.OutputFile = segment.OutputFile
+		part.Start = segment.Start
+		part.End = 0
+		if segment.End < duration {
+			part.End = segment.End
+		}
+		if err := ExtractAudio(ctx, part); err != nil {
+			if ctx.Err() != nil {
+				return written, ctx.Err()
+			}
+			return written, fmt.Errorf("part %d: %w", i+1, err)
+		}
+		written = append(written, segment)
+	}
+	return written, nil
+}
+
+// checkSilenceExtraction validates the extraction settings and fails on a missing encoder
+// before a pass is spent on detection
+func checkSilenceExtraction(ctx context.Context, extraction AudioExtractionParams) error {
+	if err := validateAudioExtractionParams(extraction); err != nil {
+		return err
+	}
+	if IsStdoutPath(extraction.OutputFile) {
+		return fmt.Errorf("trimming or splitting at silences cannot write to stdout")
+	}
+	format := resolveOutputFormat(extraction.OutputFile, extraction.Container)
+	codec, err := selectAudioCodec("."+format, extraction.Codec)
+	if err != nil {
+		return err
+	}
+	_, err = resolveEncoder(ctx, codec, format, false)
+	return err
+}
+
+// detectSilence runs the detection and also returns the duration of the input
+func detectSilence(ctx context.Context, params SilenceParams) ([]Silence, time.Duration, error) {
+	if err := validateSilenceParams(params); err != nil {
+		return nil, 0, err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return nil, 0, err
+	}
+	if len(inputInfo.AudioStreams) == 0 {
+		return nil, 0, fmt.Errorf("input has no audio stream")
+	}
+
+	logFile, err := os.CreateTemp("", "transcoder-silence-*.log")
+	if err != nil {
+		return nil, 0, fmt.Errorf("creating silence log file: %w", err)
+	}
+	logFile.Close()
+	defer os.Remove(logFile.Name())
+
+	cmd := buildSilenceDetectCommand(ctx, params, logFile.Name())
+	if params.Verbose {
+		color.Cyan("🔇 Detecting silence below %gdB lasting %s or more", params.Threshold, params.MinLength)
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		return nil, 0, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+
+	silences, err := readSilenceLog(logFile.Name(), inputInfo.Duration)
+	if err != nil {
+		return nil, 0, err
+	}
+	return silences, inputInfo.Duration, nil
+}
+
+// validateSilenceParams validates the input path and detection settings
+func validateSilenceParams(params SilenceParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+	if analyzer.IsStdinPath(params.InputFile) {
+		return fmt.Errorf("silence detection cannot read stdin input")
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	if params.Threshold >= 0 || params.Threshold < -120 {
+		return fmt.Errorf("invalid silence threshold %gdB (must be between -120 and 0)", params.Threshold)
+	}
+	if params.MinLength < 10*time.Millisecond {
+		return fmt.Errorf("invalid minimum silence length %s (must be at least 10ms)", params.MinLength)
+	}
+	return nil
+}
+
+// buildSilenceDetectCommand decodes the first audio track and writes the start and end of
+// every silence to logPath
+func buildSilenceDetectCommand(ctx context.Context, params SilenceParams, logPath string) *exec.Cmd {
+	filter := fmt.Sprintf("silencedetect=noise=%sdB:d=%s,ametadata=mode=print:file=%s",
+		strconv.FormatFloat(params.Threshold, 'f', -1, 64),
+		strconv.FormatFloat(params.MinLength.Seconds(), 'f', -1, 64),
+		escapeFilterValue(logPath))
+
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx),
+		"-i", params.InputFile,
+		"-map", "0:a:0",
+		"-af", filter,
+		"-vn",
+		"-f", "null", "-")
+}
+
+// readSilenceLog reads the silences the metadata filter printed, as start and end entries
+// ("lavfi.silence_start=12.5", "lavfi.silence_end=15.1"). A silence still running at the end
+// of the input has no end entry and is closed at duration.
+func readSilenceLog(path string, duration time.Duration) ([]Silence, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("reading silence log: %w", err)
+	}
+	defer file.Close()
+
+	parseTime := func(value string) (time.Duration, bool) {
+		seconds, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return 0, false
+		}
+		return time.Duration(math.Round(max(seconds, 0)*1000)) * time.Millisecond, true
+	}
+
+	var silences []Silence
+	open := false
+	scanner := bufio.NewScanner(file)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if value, ok := strings.CutPrefix(line, "lavfi.silence_start="); ok {
+			if start, ok := parseTime(value); ok && !open {
+				silences = append(silences, Silence{Start: start})
+				open = true
+			}
+		} else if value, ok := strings.CutPrefix(line, "lavfi.silence_end="); ok {
+			if end, ok := parseTime(value); ok && open {
+				silences[len(silences)-1].End = end
+				open = false
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("reading silence log: %w", err)
+	}
+
+	if open {
+		silences[len(silences)-1].End = max(duration, silences[len(silences)-1].Start)
+	}
+	return silences, nil
+}
+
+// planSilenceTrim keeps the input from the end of a silence it starts with to the start of
+// a silence it ends with, padded by silenceTrimPadding
+func planSilenceTrim(silences []Silence, duration time.Duration) *SilenceTrim {
+	trim := &SilenceTrim{Start: 0, End: duration, Duration: duration}
+	if len(silences) == 0 {
+		return trim
+	}
+
+	first, last := silences[0], silences[len(silences)-1]
+	if first.Start <= silenceEdgeTolerance && first.End >= duration-silenceEdgeTolerance {
+		// One silence covering everything
+		trim.End = trim.Start
+		return trim
+	}
+	if first.Start <= silenceEdgeTolerance {
+		trim.Start = max(first.End-silenceTrimPadding, 0)
+	}
+	if last.End >= duration-silenceEdgeTolerance {
+		trim.End = min(last.Start+silenceTrimPadding, duration)
+	}
+	return trim
+}
+
+// planSilenceSplit cuts the input at silences that end a part of at least minSegment. Each
+// part keeps silenceTrimPadding of the silences around it; leading and trailing silence is
+// left out.
+func planSilenceSplit(silences []Silence, duration, minSegment time.Duration) []SilenceSegment {
+	trim := planSilenceTrim(silences, duration)
+	if trim.End <= trim.Start {
+		return nil
+	}
+
+	var segments []SilenceSegment
+	start := trim.Start
+	for _, silence := range silences {
+		// Leading and trailing silence is already outside the trimmed range
+		if silence.Start <= trim.Start || silence.End >= trim.End {
+			continue
+		}
+		end := silence.Start + silenceTrimPadding
+		if end-start < minSegment {
+			continue
+		}
+		segments = append(segments, SilenceSegment{Start: start, End: end})
+		start = max(silence.End-silenceTrimPadding, end)
+	}
+
+	// A short remainder joins the part before
+	if len(segments) > 0 && trim.End-start < minSegment {
+		segments[len(segments)-1].End = trim.End
+	} else {
+		segments = append(segments, SilenceSegment{Start: start, End: trim.End})
+	}
+	return segments
+}
+
+// silenceSegmentPath names a part after the output ("talk.mp3" becomes "talk.part01.mp3")
+func silenceSegmentPath(outputFile string, number, total int) string {
+	ext := filepath.Ext(outputFile)
+	width := max(2, len(strconv.Itoa(total)))
+	return fmt.Sprintf("%s.part%0*d%s", strings.TrimSuffix(outputFile, ext), width, number, ext)
+}
@@ -0,0 +1,124 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// SpectrogramParams holds parameters for drawing the spectrogram of an audio track as an image
+type SpectrogramParams struct {
+	InputFile     string // Input audio or video file path
+	OutputFile    string // Output image path; its extension selects the format (png, webp or jpg)
+	Width         int    // Width of the spectrum in pixels (time axis)
+	Height        int    // Height of the spectrum in pixels (frequency axis)
+	SplitChannels bool   // Draw each channel in its own band instead of combined
+	Legend        bool   // Draw time and frequency axes and the color scale around the spectrum
+	Verbose       bool   // Verbose output
+}
+
+// CreateSpectrogram draws the frequency content of the whole first audio track over time as a
+// single image with FFmpeg's showspectrumpic filter. Lossy encoders cut off high frequencies,
+// so a "lossless" file transcoded from MP3 or AAC shows an empty band above a sharp line.
+func CreateSpectrogram(ctx context.Context, params SpectrogramParams) error {
+	if err := validateSpectrogramParams(ctx, params); err != nil {
+		return err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return err
+	}
+	if len(inputInfo.AudioStreams) == 0 {
+		return fmt.Errorf("input has no audio stream")
+	}
+
+	cmd := buildSpectrogramCommand(ctx, params)
+	if params.Verbose {
+		audio := inputInfo.AudioStreams[0]
+		if audio.SampleRate > 0 {
+			color.Cyan("📈 Spectrogram of %dx%d, 0 to %.1f kHz", params.Width, params.Height, float64(audio.SampleRate)/2000)
+		} else {
+			color.Cyan("📈 Spectrogram of %dx%d", params.Width, params.Height)
+		}
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	// The image is drawn once the whole track is read, so there is no timeline to measure
+	// progress against
+	spectrumInfo := *inputInfo
+	spectrumInfo.Duration = 0
+	if err := executeFFmpeg(ctx, cmd, &spectrumInfo, params.Verbose); err != nil {
+		return stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+	return nil
+}
+
+// validateSpectrogramParams validates paths, the image size and the output format
+func validateSpectrogramParams(ctx context.Context, params SpectrogramParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if err := securityPolicy.ValidateFilePath(params.OutputFile); err != nil {
+		return fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	format := getFormatFromPath(params.OutputFile)
+	if !slices.Contains(FrameFormats, format) {
+		return fmt.Errorf("unsupported image format: %s (use %s)", format, strings.Join(FrameFormats, ", "))
+	}
+	if format == "webp" {
+		if encoders, err := AvailableEncoders(ctx); err == nil && !encoders["libwebp"] {
+			return fmt.Errorf("encoder libwebp is not available in this FFmpeg build (see ffmpeg -encoders)")
+		}
+	}
+
+	if params.Width < 64 || params.Width > 7680 {
+		return fmt.Errorf("invalid width %d (must be between 64 and 7680)", params.Width)
+	}
+	if params.Height < 64 || params.Height > 4320 {
+		return fmt.Errorf("invalid height %d (must be between 64 and 4320)", params.Height)
+	}
+	return nil
+}
+
+// buildSpectrogramCommand draws the first audio track into one image, with frequencies on a
+// linear scale so a lossy cutoff shows as a straight line
+func buildSpectrogramCommand(ctx context.Context, params SpectrogramParams) *exec.Cmd {
+	mode := "combined"
+	if params.SplitChannels {
+		mode = "separate"
+	}
+	legend := 0
+	if params.Legend {
+		legend = 1
+	}
+	filter := fmt.Sprintf("showspectrumpic=s=%dx%d:mode=%s:legend=%d:fscale=lin:scale=log",
+		params.Width, params.Height, mode, legend)
+	graph := NewFilterGraph().Add([]string{"0:a:0"}, filter, []string{"spectrogram"})
+
+	args := []string{
+		"-i", params.InputFile,
+		"-filter_complex", graph.String(),
+		"-map", "[spectrogram]",
+		"-frames:v", "1",
+		"-update", "1",
+	}
+	switch getFormatFromPath(params.OutputFile) {
+	case "jpg":
+		args = append(args, "-q:v", "2")
+	case "webp":
+		args = append(args, "-c:v", "libwebp", "-quality", "90")
+	}
+
+	args = append(args, "-y", params.OutputFile)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
@@ -0,0 +1,151 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// Storyboard files written into the output directory
+const (
+	StoryboardVTTName     = "storyboard.vtt"
+	storyboardSpriteNames = "sprite_%03d.jpg"
+)
+
+// StoryboardParams holds parameters for generating seek preview thumbnails
+type StoryboardParams struct {
+	InputFile  string // Input file path
+	OutputDir  string // Directory receiving the sprite sheets and the WebVTT file
+	Interval   int    // Seconds between thumbnails
+	ThumbWidth int    // Thumbnail width in pixels; the height keeps the aspect ratio
+	Columns    int    // Thumbnails per sprite sheet row
+	Rows       int    // Thumbnail rows per sprite sheet
+	Verbose    bool   // Verbose output
+}
+
+// CreateStoryboard extracts a thumbnail every interval, tiles them into sprite sheets and writes a
+// WebVTT file mapping each time range to its thumbnail, as used by web players for seek previews.
+// It returns the path of the WebVTT file.
+func CreateStoryboard(ctx context.Context, params StoryboardParams) (string, error) {
+	if err := validateStoryboardParams(params); err != nil {
+		return "", err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return "", err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return "", fmt.Errorf("input has no video stream")
+	}
+	if inputInfo.Duration <= 0 {
+		return "", fmt.Errorf("could not determine the duration of the input")
+	}
+
+	video := inputInfo.VideoStreams[0]
+	if video.Width <= 0 || video.Height <= 0 {
+		return "", fmt.Errorf("could not determine the resolution of the input")
+	}
+	thumbHeight := storyboardThumbHeight(params.ThumbWidth, video.Width, video.Height)
+
+	if err := os.MkdirAll(params.OutputDir, 0755); err != nil {
+		return "", fmt.Errorf("creating output directory: %w", err)
+	}
+
+	cmd := buildStoryboardCommand(ctx, params, thumbHeight)
+	if params.Verbose {
+		color.Cyan("🖼️  %dx%d thumbnails every %ds, %dx%d per sprite sheet",
+			params.ThumbWidth, thumbHeight, params.Interval, params.Columns, params.Rows)
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, params.Verbose); err != nil {
+		return "", stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+
+	vttPath := filepath.Join(params.OutputDir, StoryboardVTTName)
+	vtt := buildStoryboardVTT(params, thumbHeight, inputInfo.Duration)
+	if err := os.WriteFile(vttPath, []byte(vtt), 0644); err != nil {
+		return "", fmt.Errorf("writing WebVTT file: %w", err)
+	}
+	return vttPath, nil
+}
+
+// validateStoryboardParams validates storyboard paths and layout
+func validateStoryboardParams(params StoryboardParams) error {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return err
+	}
+
+	if err := securityPolicy.ValidateFilePath(params.OutputDir); err != nil {
+		return fmt.Errorf("security validation failed for output directory: %w", err)
+	}
+
+	if params.Interval < 1 || params.Interval > 3600 {
+		return fmt.Errorf("invalid interval %d (must be between 1 and 3600 seconds)", params.Interval)
+	}
+	if params.ThumbWidth < 16 || params.ThumbWidth > 1920 || params.ThumbWidth%2 != 0 {
+		return fmt.Errorf("invalid thumbnail width %d (must be an even number between 16 and 1920)", params.ThumbWidth)
+	}
+	if params.Columns < 1 || params.Columns > 20 || params.Rows < 1 || params.Rows > 20 {
+		return fmt.Errorf("invalid sprite layout %dx%d (columns and rows must be between 1 and 20)", params.Columns, params.Rows)
+	}
+
+	return nil
+}
+
+// storyboardThumbHeight keeps the source aspect ratio, rounded to an even number for the encoder
+func storyboardThumbHeight(thumbWidth, width, height int) int {
+	return max(2, int(math.Round(float64(thumbWidth)*float64(height)/float64(width)/2))*2)
+}
+
+// buildStoryboardCommand samples one frame per interval, scales it and tiles the frames into sprite sheets
+func buildStoryboardCommand(ctx context.Context, params StoryboardParams, thumbHeight int) *exec.Cmd {
+	filter := fmt.Sprintf("fps=1/%d,scale=%d:%d,tile=%dx%d",
+		params.Interval, params.ThumbWidth, thumbHeight, params.Columns, params.Rows)
+
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx),
+		"-i", params.InputFile,
+		"-vf", filter,
+		"-an",
+		"-q:v", "4",
+		"-y", filepath.Join(params.OutputDir, storyboardSpriteNames))
+}
+
+// buildStoryboardVTT maps each interval to its thumbnail using media fragment coordinates
+// (e.g., "sprite_001.jpg#xywh=160,0,160,90")
+func buildStoryboardVTT(params StoryboardParams, thumbHeight int, duration time.Duration) string {
+	interval := time.Duration(params.Interval) * time.Second
+	perSheet := params.Columns * params.Rows
+
+	var vtt strings.Builder
+	vtt.WriteString("WEBVTT\n")
+	for i := 0; time.Duration(i)*interval < duration; i++ {
+		start := time.Duration(i) * interval
+		end := min(start+interval, duration)
+
+		sheet := fmt.Sprintf(storyboardSpriteNames, i/perSheet+1)
+		position := i % perSheet
+		x := (position % params.Columns) * params.ThumbWidth
+		y := (position / params.Columns) * thumbHeight
+
+		fmt.Fprintf(&vtt, "\n%s --> %s\n%s#xywh=%d,%d,%d,%d\n",
+			formatVTTTimestamp(start), formatVTTTimestamp(end), sheet, x, y, params.ThumbWidth, thumbHeight)
+	}
+	return vtt.String()
+}
+
+// formatVTTTimestamp formats a duration as a WebVTT timestamp (HH:MM:SS.mmm)
+func formatVTTTimestamp(d time.Duration) string {
+	milliseconds := d.Milliseconds()
+	return fmt.Sprintf("%02d:%02d:%02d.%03d",
+		milliseconds/3600000, milliseconds/60000%60, milliseconds/1000%60, milliseconds%1000)
+}
@@ -0,0 +1,294 @@
+package transcoder
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// maxStreamURLLength bounds stream URLs, which carry long stream keys
+const maxStreamURLLength = 1024
+
+// streamFormats maps supported URL schemes to the container FFmpeg sends over them
+var streamFormats = map[string]string{
+	"rtmp":  "flv",
+	"rtmps": "flv",
+	"srt":   "mpegts",
+}
+
+// Status values parsed from FFmpeg's stats line
+var (
+	streamTimeRegex    = regexp.MustCompile(`time=(\d{2}):(\d{2}):(\d{2})\.(\d{2})`)
+	streamBitrateRegex = regexp.MustCompile(`bitrate=\s*([0-9.]+)kbits/s`)
+	streamFPSRegex     = regexp.MustCompile(`fps=\s*([0-9.]+)`)
+	streamDropRegex    = regexp.MustCompile(`drop=\s*(\d+)`)
+	streamSpeedRegex   = regexp.MustCompile(`speed=\s*([0-9.]+)x`)
+)
+
+// StreamParams holds parameters for streaming a file to a live ingest server
+type StreamParams struct {
+	InputFile    string // Input file path
+	URL          string // Ingest URL (rtmp://, rtmps:// or srt://)
+	Realtime     bool   // Read the input at its native frame rate (-re)
+	VideoBitrate string // Video bitrate (e.g., "4500k")
+	AudioBitrate string // Audio bitrate (e.g., "160k")
+	Retries      int    // Reconnect attempts after the connection drops
+	Verbose      bool   // Verbose output
+}
+
+// streamStatus is the latest state reported by FFmpeg while streaming
+type streamStatus struct {
+	position float64 // Seconds of the input sent so far
+	bitrate  float64 // Output bitrate in kbit/s
+	fps      float64 // Frames encoded per second
+	dropped  int     // Frames dropped because encoding fell behind
+	speed    float64 // Encoding speed relative to realtime
+}
+
+// StreamMedia sends the input to a live ingest server, reconnecting when the connection drops.
+// After a drop the stream resumes from the position reached before the failure; the retry
+// budget is restored once a connection has stayed up for a minute.
+func StreamMedia(ctx context.Context, params StreamParams) error {
+	format, err := validateStreamParams(params)
+	if err != nil {
+		return err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return fmt.Errorf("input has no video stream")
+	}
+
+	var offset float64
+	failures := 0
+	for {
+		cmd := buildStreamCommand(ctx, params, format, offset, len(inputInfo.AudioStreams) > 0)
+		if params.Verbose {
+			command := strings.Join(cmd.Args, " ")
+			fmt.Printf("Command: %s\n\n", strings.Replace(command, params.URL, MaskStreamURL(params.URL), 1))
+		}
+
+		status, err := runStream(ctx, cmd, offset)
+		offset += status.position
+		if err == nil {
+			return nil
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		if inputInfo.Duration > 0 && offset >= inputInfo.Duration.Seconds() {
+			return nil
+		}
+
+		// FFmpeg includes the URL in its errors; keep the stream key off the screen
+		err = errors.New(strings.ReplaceAll(err.Error(), params.URL, MaskStreamURL(params.URL)))
+
+		if status.position >= 60 {
+			failures = 0
+		}
+		if failures >= params.Retries {
+			return fmt.Errorf("stream failed after %d reconnect attempt(s): %w", failures, err)
+		}
+		failures++
+
+		delay := min(time.Duration(1<<(failures-1))*2*time.Second, 30*time.Second)
+		color.Yellow("⚠️  Connection dropped (%v); reconnecting in %s from %s (attempt %d/%d)",
+			err, delay, formatStreamPosition(offset), failures, params.Retries)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(delay):
+		}
+	}
+}
+
+// validateStreamParams validates the input, the ingest URL and the bitrates and returns the output format
+func validateStreamParams(params StreamParams) (string, error) {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return "", err
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return "", fmt.Errorf("security validation failed for input path: %w", err)
+	}
+
+	format, err := streamFormatForURL(params.URL)
+	if err != nil {
+		return "", err
+	}
+
+	if err := securityPolicy.ValidateBitrate(params.VideoBitrate); err != nil {
+		return "", fmt.Errorf("invalid video bitrate: %w", err)
+	}
+	if err := securityPolicy.ValidateBitrate(params.AudioBitrate); err != nil {
+		return "", fmt.Errorf("invalid audio bitrate: %w", err)
+	}
+	if params.Retries < 0 || params.Retries > 100 {
+		return "", fmt.Errorf("invalid retries %d (must be between 0 and 100)", params.Retries)
+	}
+
+	return format, nil
+}
+
+// streamFormatForURL checks the ingest URL and returns the container used for its protocol
+func streamFormatForURL(url string) (string, error) {
+	if len(url) > maxStreamURLLength {
+		return "", fmt.Errorf("stream URL too long (max %d characters)", maxStreamURLLength)
+	}
+	if strings.ContainsAny(url, " \t\r\n") {
+		return "", fmt.Errorf("stream URL must not contain whitespace")
+	}
+
+	scheme, rest, found := strings.Cut(url, "://")
+	format, supported := streamFormats[strings.ToLower(scheme)]
+	if !found || !supported || rest == "" {
+		return "", fmt.Errorf("unsupported stream URL: %s (use rtmp://, rtmps:// or srt://)", MaskStreamURL(url))
+	}
+	return format, nil
+}
+
+// MaskStreamURL hides the stream key (the last path segment and any query) so it is not shown on screen
+func MaskStreamURL(url string) string {
+	if i := strings.Index(url, "?"); i >= 0 {
+		url = url[:i+1] + "****"
+	} else if i := strings.LastIndex(url, "/"); i > strings.Index(url, "://")+2 {
+		url = url[:i+1] + "****"
+	}
+	return url
+}
+
+// buildStreamCommand builds the FFmpeg command that encodes the input for live ingest.
+// Keyframes every two seconds and a capped bitrate match what streaming platforms expect.
+func buildStreamCommand(ctx context.Context, params StreamParams, format string, offset float64, hasAudio bool) *exec.Cmd {
+	args := []string{}
+	if params.Realtime {
+		args = append(args, "-re")
+	}
+	if offset > 0 {
+		args = append(args, "-ss", strconv.FormatFloat(offset, 'f', 2, 64))
+	}
+
+	args = append(args,
+		"-i", params.InputFile,
+		"-map", "0:v:0",
+		"-c:v", "libx264",
+		"-preset", "veryfast",
+		"-b:v", params.VideoBitrate,
+		"-maxrate", params.VideoBitrate,
+		"-bufsize", params.VideoBitrate,
+		"-pix_fmt", "yuv420p",
+		"-force_key_frames", "expr:gte(t,n_forced*2)")
+
+	if hasAudio {
+		args = append(args,
+			"-map", "0:a:0",
+			"-c:a", "aac",
+			"-b:a", params.AudioBitrate,
+			"-ar", "44100")
+	}
+
+	args = append(args, "-f", format, params.URL)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
+
+// runStream runs one streaming attempt and keeps a live status line updated.
+// It returns the last status so a retry can resume where this attempt stopped.
+func runStream(ctx context.Context, cmd *exec.Cmd, offset float64) (status streamStatus, err error) {
+	events := progressEvents(ctx)
+	run := startFFmpegRun(cmd)
+	defer func() { err = run.finish(err) }()
+
+	stderr, err := cmd.StderrPipe()
+	if err != nil {
+		return streamStatus{}, fmt.Errorf("failed to create stderr pipe: %w", err)
+	}
+	if err := cmd.Start(); err != nil {
+		return streamStatus{}, fmt.Errorf("failed to start ffmpeg: %w", err)
+	}
+
+	scanner := bufio.NewScanner(run.captureReader(stderr))
+	scanner.Split(scanStatusLines)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if !parseStreamStatus(line, &status) {
+			continue
+		}
+		if events != nil {
+			emitProgressEvent(events, Progress{
+				FPS:      status.fps,
+				Bitrate:  fmt.Sprintf("%.1fkbits/s", status.bitrate),
+				Position: time.Duration((offset + status.position) * float64(time.Second)),
+				Speed:    status.speed,
+			})
+			continue
+		}
+		displayStreamStatus(status, offset)
+	}
+	if events == nil {
+		fmt.Println()
+	}
+
+	return status, cmd.Wait()
+}
+
+// scanStatusLines splits FFmpeg output on newlines and on the carriage returns used by the stats line
+func scanStatusLines(data []byte, atEOF bool) (int, []byte, error) {
+	if i := bytes.IndexAny(data, "\r\n"); i >= 0 {
+		return i + 1, data[:i], nil
+	}
+	if atEOF && len(data) > 0 {
+		return len(data), data, nil
+	}
+	return 0, nil, nil
+}
+
+// parseStreamStatus updates the status from an FFmpeg stats line and reports whether it was one
+func parseStreamStatus(line string, status *streamStatus) bool {
+	matches := streamTimeRegex.FindStringSubmatch(line)
+	if matches == nil {
+		return false
+	}
+
+	status.position = parseTimeFromMatches(matches)
+	if m := streamBitrateRegex.FindStringSubmatch(line); m != nil {
+		status.bitrate, _ = strconv.ParseFloat(m[1], 64)
+	}
+	if m := streamFPSRegex.FindStringSubmatch(line); m != nil {
+		status.fps, _ = strconv.ParseFloat(m[1], 64)
+	}
+	if m := streamDropRegex.FindStringSubmatch(line); m != nil {
+		status.dropped, _ = strconv.Atoi(m[1])
+	}
+	if m := streamSpeedRegex.FindStringSubmatch(line); m != nil {
+		status.speed, _ = strconv.ParseFloat(m[1], 64)
+	}
+	return true
+}
+
+// displayStreamStatus renders the live status line; dropped frames turn it yellow
+func displayStreamStatus(status streamStatus, offset float64) {
+	line := fmt.Sprintf("\r🔴 LIVE %s | %.0f kbps | %.0f fps | dropped %d | %.2fx  ",
+		formatStreamPosition(offset+status.position), status.bitrate, status.fps, status.dropped, status.speed)
+	if status.dropped > 0 {
+		line = color.YellowString(line)
+	}
+	fmt.Print(line)
+}
+
+// formatStreamPosition formats a position in seconds as HH:MM:SS
+func formatStreamPosition(seconds float64) string {
+	total := int(seconds)
+	return fmt.Sprintf("%02d:%02d:%02d", total/3600, total/60%60, total%60)
+}
@@ -0,0 +1,23 @@
+package transcoder
+
+import "testing"
+
+func TestMaskStreamURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{"rtmp://a.rtmp.youtube.com/live2/abcd-efgh-ijkl", "rtmp://a.rtmp.youtube.com/live2/****"},
+		{"rtmps://live-api-s.facebook.com:443/rtmp/FB-123?s_bl=1&a=key", "rtmps://live-api-s.facebook.com:443/rtmp/FB-123?****"},
+		{"srt://ingest.example.com:9000?streamid=secret", "srt://ingest.example.com:9000?****"},
+		{"rtmp://localhost/live/", "rtmp://localhost/live/****"},
+		{"rtmp://localhost", "rtmp://localhost"}, // No path, nothing to hide
+		{"not a url", "not a url"},
+	}
+
+	for _, tt := range tests {
+		if got := MaskStreamURL(tt.url); got != tt.want {
+			t.Errorf("MaskStreamURL(%q) = %q, want %q", tt.url, got, tt.want)
+		}
+	}
+}
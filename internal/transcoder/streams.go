@@ -0,0 +1,292 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// resolveAudioStreamSelection finds the audio stream chosen by number (1-based) or language.
+// It returns the 0-based position within the input's audio streams, or -1 when nothing was selected.
+func resolveAudioStreamSelection(inputInfo *analyzer.MediaInfo, stream, language string) (int, error) {
+	if stream != "" && language != "" {
+		return -1, fmt.Errorf("audio stream and audio language cannot be used together")
+	}
+
+	if stream != "" {
+		number, err := strconv.Atoi(stream)
+		if err != nil {
+			return -1, fmt.Errorf("invalid audio stream number: %s", stream)
+		}
+		if number < 1 || number > len(inputInfo.AudioStreams) {
+			return -1, fmt.Errorf("audio stream %d not found (input has %d audio stream(s))",
+				number, len(inputInfo.AudioStreams))
+		}
+		return number - 1, nil
+	}
+
+	if language != "" {
+		for i, audioStream := range inputInfo.AudioStreams {
+			if strings.EqualFold(audioStream.Language, language) {
+				return i, nil
+			}
+		}
+		return -1, fmt.Errorf("no audio stream with language '%s' found (available: %s)",
+			language, describeAudioLanguages(inputInfo.AudioStreams))
+	}
+
+	return -1, nil
+}
+
+// describeAudioLanguages lists the language tags of the audio streams for error messages
+func describeAudioLanguages(streams []analyzer.AudioStream) string {
+	languages := make([]string, 0, len(streams))
+	for i, audioStream := range streams {
+		language := audioStream.Language
+		if language == "" {
+			language = "und"
+		}
+		languages = append(languages, fmt.Sprintf("%d=%s", i+1, language))
+	}
+	if len(languages) == 0 {
+		return "none"
+	}
+	return strings.Join(languages, ", ")
+}
+
+// audioStreamMapArg returns the -map specifier for a 0-based audio stream position
+func audioStreamMapArg(position int) string {
+	return fmt.Sprintf("0:a:%d", position)
+}
+
+// DefaultTrackNameTemplate names per-track outputs (e.g., "movie.track1.eng" + ".mp3")
+const DefaultTrackNameTemplate = "{name}.track{index}.{lang}"
+
+// trackTemplateRegex restricts naming templates to safe filename characters and placeholders
+var trackTemplateRegex = regexp.MustCompile(`^([A-Za-z0-9._-]|\{name\}|\{index\}|\{lang\})+$`)
+
+// validateTrackNameTemplate validates a per-track output naming template
+func validateTrackNameTemplate(template string) error {
+	if !trackTemplateRegex.MatchString(template) {
+		return fmt.Errorf("invalid track name template: %s (allowed: letters, digits, . _ - and {name}, {index}, {lang})", template)
+	}
+
+	if !strings.Contains(template, "{index}") {
+		return fmt.Errorf("track name template must contain {index} so each track gets a unique name: %s", template)
+	}
+
+	return nil
+}
+
+// buildTrackOutputPath expands the naming template for one audio stream
+func buildTrackOutputPath(outputFile, template string, number int, language string) string {
+	ext := filepath.Ext(outputFile)
+	name := strings.TrimSuffix(filepath.Base(outputFile), ext)
+	if language == "" {
+		language = "und"
+	}
+
+	replacer := strings.NewReplacer(
+		"{name}", name,
+		"{index}", strconv.Itoa(number),
+		"{lang}", strings.ToLower(language),
+	)
+	return filepath.Join(filepath.Dir(outputFile), replacer.Replace(template)+ext)
+}
+
+// ExtractAllAudioTracks extracts every audio stream of the input into its own file.
+// Output names are derived from params.OutputFile using the naming template.
+func ExtractAllAudioTracks(ctx context.Context, params AudioExtractionParams, template string, overwrite bool) ([]string, error) {
+	if params.Stream != "" || params.Language != "" {
+		return nil, fmt.Errorf("extracting all tracks cannot be combined with audio stream or language selection")
+	}
+
+	if template == "" {
+		template = DefaultTrackNameTemplate
+	}
+	if err := validateTrackNameTemplate(template); err != nil {
+		return nil, err
+	}
+
+	if err := validateAudioExtractionParams(params); err != nil {
+		return nil, err
+	}
+
+	mediaInfo, err := analyzeInputForAudioExtraction(ctx, params)
+	if err != nil {
+		return nil, err
+	}
+
+	// Plan every output up front so nothing is written if a name is unusable
+	trackParams := make([]AudioExtractionParams, 0, len(mediaInfo.AudioStreams))
+	for i, audioStream := range mediaInfo.AudioStreams {
+		track := params
+		track.Stream = strconv.Itoa(i + 1)
+		track.OutputFile = buildTrackOutputPath(params.OutputFile, template, i+1, audioStream.Language)
+
+		if err := validateAudioExtractionPaths(track); err != nil {
+			return nil, err
+		}
+		if _, err := os.Stat(track.OutputFile); err == nil && !overwrite {
+			return nil, fmt.Errorf("output file already exists: %s (use --force to overwrite)", track.OutputFile)
+		}
+
+		trackParams = append(trackParams, track)
+	}
+
+	outputs := make([]string, 0, len(trackParams))
+	for _, track := range trackParams {
+		if params.Verbose {
+			color.Cyan("🎚️  Track %s of %d → %s", track.Stream, len(trackParams), track.OutputFile)
+		}
+
+		codec, command, err := prepareAudioExtractionCommand(ctx, track, mediaInfo)
+		if err != nil {
+			return outputs, err
+		}
+
+		if params.Verbose {
+			displayAudioExtractionInfo(track, codec, command)
+		}
+
+		if err := executeAudioExtraction(ctx, track, command, mediaInfo); err != nil {
+			if ctx.Err() != nil {
+				return outputs, ctx.Err()
+			}
+			return outputs, fmt.Errorf("track %s: %w", track.Stream, err)
+		}
+		outputs = append(outputs, track.OutputFile)
+	}
+
+	return outputs, nil
+}
+
+// AudioTrack describes an external audio file muxed into the output as an additional stream
+type AudioTrack struct {
+	Path     string // Audio file path (e.g., "commentary.flac")
+	Language string // ISO 639 language tag written on the new stream (optional)
+
+	codec string // Resolved encoder for the stream ("copy" when it can be stream copied)
+}
+
+// ParseAudioTrack parses an added audio track of the form "file.flac" or "file.flac:lang"
+func ParseAudioTrack(spec string) (AudioTrack, error) {
+	track := AudioTrack{Path: spec}
+
+	// Only treat the suffix as a language when it looks like one, so paths containing ':' still work
+	if i := strings.LastIndex(spec, ":"); i > 0 {
+		if language := spec[i+1:]; language != "" && securityPolicy.ValidateLanguageCode(language) == nil {
+			track.Path = spec[:i]
+			track.Language = strings.ToLower(language)
+		}
+	}
+
+	if track.Path == "" {
+		return AudioTrack{}, fmt.Errorf("missing file in added audio track: %s", spec)
+	}
+	if err := securityPolicy.ValidateFilePath(track.Path); err != nil {
+		return AudioTrack{}, fmt.Errorf("security validation failed for added audio track: %w", err)
+	}
+
+	return track, nil
+}
+
+// validateAudioTracks checks that every added audio track is safe to use and exists
+func validateAudioTracks(tracks []AudioTrack) error {
+	for _, track := range tracks {
+		if err := securityPolicy.ValidateFilePath(track.Path); err != nil {
+			return fmt.Errorf("security validation failed for added audio track: %w", err)
+		}
+		if err := securityPolicy.ValidateLanguageCode(track.Language); err != nil {
+			return fmt.Errorf("security validation failed for added audio language: %w", err)
+		}
+		if _, err := os.Stat(track.Path); os.IsNotExist(err) {
+			return fmt.Errorf("added audio track %w: %s", analyzer.ErrFileNotFound, track.Path)
+		}
+	}
+	return nil
+}
+
+// resolveAudioTrackCodecs probes each added audio track and decides whether it can be stream copied.
+// Tracks are only re-encoded when the main audio is copied and the track's codec does not fit the container.
+func resolveAudioTrackCodecs(ctx context.Context, tracks []AudioTrack, outputFormat, audioCodec, preset string, verbose bool) ([]AudioTrack, error) {
+	resolved := make([]AudioTrack, 0, len(tracks))
+	for _, track := range tracks {
+		trackInfo, err := analyzer.AnalyzeMedia(ctx, track.Path)
+		if err != nil {
+			return nil, fmt.Errorf("failed to analyze added audio track %s: %w", track.Path, err)
+		}
+		if len(trackInfo.AudioStreams) == 0 {
+			return nil, fmt.Errorf("added audio track has no audio stream: %s", track.Path)
+		}
+
+		track.codec = audioCodec
+		if audioCodec == "copy" {
+			audioStream := trackInfo.AudioStreams[0]
+			compat, ok, err := GetContainerCompatibility(outputFormat)
+			if err != nil || !ok || !isCompatibleCodec(audioStream.Codec, audioStream.Profile, compat.AudioCodecs) {
+				_, defaultAudio := getDefaultCodecs(outputFormat)
+				track.codec = applyAudioPreset(defaultAudio, preset)
+				if verbose {
+					color.Yellow("🔄 Re-encoding added track %s (%s → %s)", track.Path, audioStream.Codec, track.codec)
+				}
+			}
+		}
+
+		resolved = append(resolved, track)
+	}
+	return resolved, nil
+}
+
+// audioTrackOutputIndex returns the output audio stream index of the i-th added track
+func audioTrackOutputIndex(customParams CustomParameters, i int) int {
+	// The original audio stream (if mapped) comes first
+	if customParams.AudioStream != "" {
+		return i + 1
+	}
+	return i
+}
+
+// textSubtitleCodecs are the text subtitle formats FFmpeg can convert into one another
+var textSubtitleCodecs = map[string]bool{
+	"subrip": true, "ass": true, "ssa": true, "webvtt": true, "mov_text": true, "text": true,
+}
+
+// subtitleStream is a subtitle stream kept when the streams are mapped explicitly
+type subtitleStream struct {
+	position int  // 0-based position within the input's subtitle streams
+	copy     bool // Stream copied; otherwise converted by the container's default subtitle encoder
+}
+
+// selectSubtitleStreams picks the subtitle streams the output container can carry, since
+// explicit -map options turn off FFmpeg's own stream selection. Codecs the container lists are
+// copied; other text subtitles are converted to its text format; bitmap subtitles it cannot
+// hold are left out.
+func selectSubtitleStreams(inputInfo *analyzer.MediaInfo, outputFormat string) []subtitleStream {
+	compat, ok, err := GetContainerCompatibility(outputFormat)
+	if err != nil || !ok {
+		return nil
+	}
+	takesText := false
+	for _, entry := range compat.SubtitleCodecs {
+		takesText = takesText || entry.Codec == AnyCodec || textSubtitleCodecs[entry.Codec]
+	}
+
+	var streams []subtitleStream
+	for i, stream := range inputInfo.SubtitleStreams {
+		switch {
+		case isCompatibleCodec(stream.Codec, "", compat.SubtitleCodecs):
+			streams = append(streams, subtitleStream{position: i, copy: true})
+		case takesText && textSubtitleCodecs[stream.Codec]:
+			streams = append(streams, subtitleStream{position: i})
+		}
+	}
+	return streams
+}
@@ -0,0 +1,232 @@
+package transcoder
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// SubtitleFormats are the subtitle formats whose timing can be changed
+var SubtitleFormats = []string{"srt", "vtt"}
+
+// SubtitleShiftParams holds parameters for retiming a subtitle file
+type SubtitleShiftParams struct {
+	InputFile  string        // SRT or WebVTT file
+	OutputFile string        // Output file path in the same format; empty replaces the input
+	Offset     time.Duration // Added to every timestamp, after scaling; negative moves subtitles earlier
+	Scale      float64       // Every timestamp is multiplied by this (1 keeps the timing)
+}
+
+// SubtitleShiftResult describes a retimed subtitle file
+type SubtitleShiftResult struct {
+	Cues    int           // Cues written
+	Dropped int           // Cues removed because they would end before the start
+	First   time.Duration // Start of the first cue written
+	Last    time.Duration // End of the last cue written
+}
+
+// subtitleTimingRegex matches a cue timing line: start, end and any cue settings after them
+var subtitleTimingRegex = regexp.MustCompile(`^\s*(\S+)\s+-->\s+(\S+)(.*)$`)
+
+// subtitleTimestampRegex matches SRT (00:01:02,500) and WebVTT (00:01:02.500 or 01:02.500)
+// timestamps
+var subtitleTimestampRegex = regexp.MustCompile(`^(?:(\d+):)?(\d{1,2}):(\d{2})[,.](\d{1,3})$`)
+
+// vttInlineTimestampRegex matches the timestamps inside WebVTT cue text (karaoke timing)
+var vttInlineTimestampRegex = regexp.MustCompile(`<((?:\d+:)?\d{2}:\d{2}\.\d{3})>`)
+
+// ShiftSubtitles moves and stretches the timing of an SRT or WebVTT file without touching
+// its text. Timestamps are scaled first, then offset; cues moved before zero start at zero,
+// and cues that would end there are removed.
+func ShiftSubtitles(params SubtitleShiftParams) (*SubtitleShiftResult, error) {
+	format := getFormatFromPath(params.InputFile)
+	if !slices.Contains(SubtitleFormats, format) {
+		return nil, fmt.Errorf("unsupported subtitle format: %s (use %s)", format, strings.Join(SubtitleFormats, ", "))
+	}
+	if params.Scale <= 0 || math.IsInf(params.Scale, 0) || math.IsNaN(params.Scale) {
+		return nil, fmt.Errorf("invalid scale: %g (must be greater than 0)", params.Scale)
+	}
+
+	outputFile := params.OutputFile
+	if outputFile == "" {
+		// Written next to the input, so the final rename stays on one file system
+		outputFile = strings.TrimSuffix(params.InputFile, filepath.Ext(params.InputFile)) + ".shift-tmp." + format
+	}
+	if outputFormat := getFormatFromPath(outputFile); outputFormat != format {
+		return nil, fmt.Errorf("output must be a .%s file like the input, not .%s", format, outputFormat)
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return nil, fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if err := securityPolicy.ValidateFilePath(outputFile); err != nil {
+		return nil, fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	stat, err := os.Stat(params.InputFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading subtitles: %w", err)
+	}
+	data, err := os.ReadFile(params.InputFile)
+	if err != nil {
+		return nil, fmt.Errorf("reading subtitles: %w", err)
+	}
+
+	content, result, err := shiftSubtitleText(string(data), format, params.Offset, params.Scale)
+	if err != nil {
+		return nil, fmt.Errorf("invalid subtitles %s: %w", params.InputFile, err)
+	}
+
+	if err := os.WriteFile(outputFile, []byte(content), stat.Mode().Perm()); err != nil {
+		return nil, fmt.Errorf("writing subtitles: %w", err)
+	}
+	if params.OutputFile == "" {
+		if err := os.Rename(outputFile, params.InputFile); err != nil {
+			os.Remove(outputFile)
+			return nil, fmt.Errorf("replacing %s: %w", params.InputFile, err)
+		}
+	}
+	return result, nil
+}
+
+// shiftSubtitleText retimes the cues of SRT or WebVTT content. Blocks without a timing line
+// (the WebVTT header, NOTE and STYLE blocks) are kept as they are, SRT cues are renumbered
+// when some are dropped, and the line endings of the input are kept.
+func shiftSubtitleText(content, format string, offset time.Duration, scale float64) (string, *SubtitleShiftResult, error) {
+	newline := "\n"
+	if strings.Contains(content, "\r\n") {
+		newline = "\r\n"
+	}
+	bom := strings.HasPrefix(content, "\ufeff")
+	content = strings.TrimPrefix(strings.ReplaceAll(content, "\r\n", "\n"), "\ufeff")
+
+	retime := func(t time.Duration) time.Duration {
+		return time.Duration(math.Round(float64(t)*scale/float64(time.Millisecond)))*time.Millisecond + offset
+	}
+	formatTimestamp := formatVTTTimestamp
+	if format == "srt" {
+		formatTimestamp = formatSRTTimestamp
+	}
+
+	result := &SubtitleShiftResult{}
+	var blocks []string
+	for _, block := range splitSubtitleBlocks(content) {
+		lines := strings.Split(block, "\n")
+		timing := -1
+		for i, line := range lines {
+			if strings.Contains(line, "-->") {
+				timing = i
+				break
+			}
+		}
+		if timing < 0 {
+			blocks = append(blocks, block)
+			continue
+		}
+
+		match := subtitleTimingRegex.FindStringSubmatch(lines[timing])
+		if match == nil {
+			return "", nil, fmt.Errorf("invalid cue timing: %s", lines[timing])
+		}
+		start, err := parseSubtitleTimestamp(match[1])
+		if err != nil {
+			return "", nil, err
+		}
+		end, err := parseSubtitleTimestamp(match[2])
+		if err != nil {
+			return "", nil, err
+		}
+
+		start, end = retime(start), retime(end)
+		if end <= 0 {
+			result.Dropped++
+			continue
+		}
+		start = max(start, 0)
+		lines[timing] = formatTimestamp(start) + " --> " + formatTimestamp(end) + match[3]
+
+		// SRT cues are numbered; keep the numbers consecutive when cues were dropped
+		if format == "srt" && timing > 0 {
+			if _, err := strconv.Atoi(strings.TrimSpace(lines[timing-1])); err == nil {
+				lines[timing-1] = strconv.Itoa(result.Cues + 1)
+			}
+		}
+		if format == "vtt" {
+			for i := timing + 1; i < len(lines); i++ {
+				lines[i] = vttInlineTimestampRegex.ReplaceAllStringFunc(lines[i], func(inline string) string {
+					t, err := parseSubtitleTimestamp(inline[1 : len(inline)-1])
+					if err != nil {
+						return inline
+					}
+					return "<" + formatVTTTimestamp(max(retime(t), 0)) + ">"
+				})
+			}
+		}
+
+		if result.Cues == 0 {
+			result.First = start
+		}
+		result.Last = max(result.Last, end)
+		result.Cues++
+		blocks = append(blocks, strings.Join(lines, "\n"))
+	}
+	if result.Cues == 0 && result.Dropped == 0 {
+		return "", nil, fmt.Errorf("no cues found")
+	}
+
+	output := strings.Join(blocks, "\n\n") + "\n"
+	if bom {
+		output = "\ufeff" + output
+	}
+	return strings.ReplaceAll(output, "\n", newline), result, nil
+}
+
+// splitSubtitleBlocks splits subtitle content at blank lines
+func splitSubtitleBlocks(content string) []string {
+	var blocks []string
+	var block []string
+	for _, line := range strings.Split(content, "\n") {
+		if strings.TrimSpace(line) == "" {
+			if len(block) > 0 {
+				blocks = append(blocks, strings.Join(block, "\n"))
+				block = nil
+			}
+			continue
+		}
+		block = append(block, line)
+	}
+	if len(block) > 0 {
+		blocks = append(blocks, strings.Join(block, "\n"))
+	}
+	return blocks
+}
+
+// parseSubtitleTimestamp converts an SRT or WebVTT timestamp to a duration
+func parseSubtitleTimestamp(value string) (time.Duration, error) {
+	match := subtitleTimestampRegex.FindStringSubmatch(value)
+	if match == nil {
+		return 0, fmt.Errorf("invalid timestamp: %s", value)
+	}
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.Atoi(match[3])
+	if minutes > 59 || seconds > 59 {
+		return 0, fmt.Errorf("invalid timestamp: %s", value)
+	}
+	// A fraction of "5" is half a second, not five milliseconds
+	fraction := match[4] + strings.Repeat("0", 3-len(match[4]))
+	milliseconds, _ := strconv.Atoi(fraction)
+
+	return time.Duration(hours)*time.Hour + time.Duration(minutes)*time.Minute +
+		time.Duration(seconds)*time.Second + time.Duration(milliseconds)*time.Millisecond, nil
+}
+
+// formatSRTTimestamp formats a duration as an SRT timestamp (HH:MM:SS,mmm)
+func formatSRTTimestamp(d time.Duration) string {
+	return strings.Replace(formatVTTTimestamp(d), ".", ",", 1)
+}
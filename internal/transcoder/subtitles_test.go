@@ -0,0 +1,113 @@
+package transcoder
+
+import (
+	"testing"
+	"time"
+)
+
+func TestShiftSubtitleText(t *testing.T) {
+	const srt = "1\n00:00:01,000 --> 00:00:02,500\nHello\n\n2\n00:00:03,000 --> 00:00:04,000\nWorld\n"
+
+	tests := []struct {
+		name    string
+		content string
+		format  string
+		offset  time.Duration
+		scale   float64
+		want    string
+		cues    int
+		dropped int
+		wantErr bool
+	}{
+		{
+			name:    "srt later",
+			content: srt,
+			format:  "srt",
+			offset:  1500 * time.Millisecond,
+			scale:   1,
+			want:    "1\n00:00:02,500 --> 00:00:04,000\nHello\n\n2\n00:00:04,500 --> 00:00:05,500\nWorld\n",
+			cues:    2,
+		},
+		{
+			name:    "srt earlier drops and renumbers",
+			content: srt,
+			format:  "srt",
+			offset:  -2600 * time.Millisecond,
+			scale:   1,
+			want:    "1\n00:00:00,400 --> 00:00:01,400\nWorld\n",
+			cues:    1,
+			dropped: 1,
+		},
+		{
+			name:    "srt clamped at zero",
+			content: srt,
+			format:  "srt",
+			offset:  -2 * time.Second,
+			scale:   1,
+			want:    "1\n00:00:00,000 --> 00:00:00,500\nHello\n\n2\n00:00:01,000 --> 00:00:02,000\nWorld\n",
+			cues:    2,
+		},
+		{
+			name:    "srt scaled from 25 to 23.976 fps",
+			content: "1\n00:01:00,000 --> 00:01:02,000\nHello\n",
+			format:  "srt",
+			scale:   25 / 23.976,
+			want:    "1\n00:01:02,563 --> 00:01:04,648\nHello\n",
+			cues:    1,
+		},
+		{
+			name:    "srt keeps CRLF line endings and BOM",
+			content: "\ufeff1\r\n00:00:01,000 --> 00:00:02,000\r\nHello\r\n",
+			format:  "srt",
+			offset:  time.Second,
+			scale:   1,
+			want:    "\ufeff1\r\n00:00:02,000 --> 00:00:03,000\r\nHello\r\n",
+			cues:    1,
+		},
+		{
+			name:    "vtt keeps header, settings and inline timestamps",
+			content: "WEBVTT\n\nNOTE made by hand\n\n00:01.000 --> 00:02.000 align:start\n<00:01.500>Hello\n",
+			format:  "vtt",
+			offset:  time.Second,
+			scale:   1,
+			want:    "WEBVTT\n\nNOTE made by hand\n\n00:00:02.000 --> 00:00:03.000 align:start\n<00:00:02.500>Hello\n",
+			cues:    1,
+		},
+		{
+			name:    "invalid timestamp",
+			content: "1\n00:00:01 --> 00:00:02,000\nHello\n",
+			format:  "srt",
+			scale:   1,
+			wantErr: true,
+		},
+		{
+			name:    "no cues",
+			content: "WEBVTT\n\nNOTE nothing here\n",
+			format:  "vtt",
+			scale:   1,
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, result, err := shiftSubtitleText(tt.content, tt.format, tt.offset, tt.scale)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("shiftSubtitleText() = %q, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("shiftSubtitleText() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("shiftSubtitleText() = %q, want %q", got, tt.want)
+			}
+			if result.Cues != tt.cues || result.Dropped != tt.dropped {
+				t.Errorf("shiftSubtitleText() wrote %d cues and dropped %d, want %d and %d",
+					result.Cues, result.Dropped, tt.cues, tt.dropped)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// ConversionSummary compares a finished output with its input
+type ConversionSummary struct {
+	InputSize        int64   `json:"input_size"`              // Input size in bytes; 0 when read from stdin
+	OutputSize       int64   `json:"output_size"`             // Output size in bytes
+	CompressionRatio float64 `json:"compression_ratio"`       // Input size divided by output size; 0 when unknown
+	ElapsedSeconds   float64 `json:"elapsed_seconds"`         // Wall-clock time of the conversion
+	Duration         float64 `json:"duration"`                // Output duration in seconds
+	Speed            float64 `json:"speed"`                   // Average encoding speed relative to real time
+	Bitrate          int64   `json:"bitrate"`                 // Overall output bitrate in bits per second
+	VideoCodec       string  `json:"video_codec,omitempty"`   // Codec of the first output video stream
+	VideoBitrate     int64   `json:"video_bitrate,omitempty"` // Bitrate of the first output video stream
+	AudioCodec       string  `json:"audio_codec,omitempty"`   // Codec of the first output audio stream
+	AudioBitrate     int64   `json:"audio_bitrate,omitempty"` // Bitrate of the first output audio stream
+}
+
+// SummarizeConversion reads the finished output back with the analyzer and compares it with the input
+func SummarizeConversion(ctx context.Context, inputPath, outputPath string, elapsed time.Duration) (*ConversionSummary, error) {
+	outputInfo, err := analyzer.AnalyzeMedia(ctx, outputPath)
+	if err != nil {
+		return nil, fmt.Errorf("analyzing output: %w", err)
+	}
+
+	summary := &ConversionSummary{
+		OutputSize:     outputInfo.Size,
+		ElapsedSeconds: math.Round(elapsed.Seconds()*1000) / 1000,
+		Duration:       outputInfo.Duration.Seconds(),
+		Bitrate:        outputInfo.Bitrate,
+	}
+	if !analyzer.IsStdinPath(inputPath) {
+		if stat, err := os.Stat(inputPath); err == nil {
+			summary.InputSize = stat.Size()
+		}
+	}
+	if summary.InputSize > 0 && summary.OutputSize > 0 {
+		summary.CompressionRatio = math.Round(float64(summary.InputSize)/float64(summary.OutputSize)*100) / 100
+	}
+	if elapsed > 0 {
+		summary.Speed = math.Round(outputInfo.Duration.Seconds()/elapsed.Seconds()*100) / 100
+	}
+	if len(outputInfo.VideoStreams) > 0 {
+		summary.VideoCodec = outputInfo.VideoStreams[0].Codec
+		summary.VideoBitrate = outputInfo.VideoStreams[0].Bitrate
+	}
+	if len(outputInfo.AudioStreams) > 0 {
+		summary.AudioCodec = outputInfo.AudioStreams[0].Codec
+		summary.AudioBitrate = outputInfo.AudioStreams[0].Bitrate
+	}
+
+	return summary, nil
+}
+
+// summaryEvent is the JSON event carrying a conversion summary
+type summaryEvent struct {
+	Event string `json:"event"` // Always "summary"
+	*ConversionSummary
+}
+
+// EmitSummaryEvent writes the summary as a JSON event when progress events are enabled
+func EmitSummaryEvent(ctx context.Context, summary *ConversionSummary) {
+	events := progressEvents(ctx)
+	if events == nil {
+		return
+	}
+	writeProgressEvent(events, summaryEvent{Event: "summary", ConversionSummary: summary})
+}
@@ -0,0 +1,260 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// PlatformTarget describes the upload recommendations of a video platform
+type PlatformTarget struct {
+	Name         string        // Target name used with --target (e.g., "youtube")
+	Description  string        // Human-readable summary for help output
+	Width        int           // Frame width in the target's orientation
+	Height       int           // Frame height in the target's orientation
+	Vertical     bool          // 9:16 frame; other shapes are fitted inside it and padded
+	VideoCodec   string        // Video encoder
+	Profile      string        // H.264 profile
+	PixelFormat  string        // Pixel format players on the platform expect
+	VideoBitrate string        // Target video bitrate
+	MaxRate      string        // Peak video bitrate
+	BufSize      string        // Rate control buffer size
+	MaxFramerate float64       // Highest frame rate the platform accepts
+	AudioCodec   string        // Audio encoder
+	AudioBitrate string        // Audio bitrate
+	SampleRate   string        // Audio sample rate in Hz
+	MaxDuration  time.Duration // Longest video the platform accepts (zero if there is no practical limit)
+}
+
+// PlatformTargets are the built-in platform presets, all written as H.264/AAC MP4
+var PlatformTargets = map[string]PlatformTarget{
+	"youtube": {
+		Name:         "youtube",
+		Description:  "YouTube: up to 1080p (vertical video stays vertical), 8 Mbps, 48 kHz AAC",
+		Width:        1920,
+		Height:       1080,
+		VideoCodec:   "libx264",
+		Profile:      "high",
+		PixelFormat:  "yuv420p",
+		VideoBitrate: "8M",
+		MaxRate:      "12M",
+		BufSize:      "24M",
+		MaxFramerate: 60,
+		AudioCodec:   "aac",
+		AudioBitrate: "384k",
+		SampleRate:   "48000",
+	},
+	"instagram-reel": {
+		Name:         "instagram-reel",
+		Description:  "Instagram Reels: 1080x1920 vertical, 30 fps, up to 3 minutes",
+		Width:        1080,
+		Height:       1920,
+		Vertical:     true,
+		VideoCodec:   "libx264",
+		Profile:      "high",
+		PixelFormat:  "yuv420p",
+		VideoBitrate: "5M",
+		MaxRate:      "8M",
+		BufSize:      "16M",
+		MaxFramerate: 30,
+		AudioCodec:   "aac",
+		AudioBitrate: "128k",
+		SampleRate:   "44100",
+		MaxDuration:  3 * time.Minute,
+	},
+	"tiktok": {
+		Name:         "tiktok",
+		Description:  "TikTok: 1080x1920 vertical, up to 60 fps and 10 minutes",
+		Width:        1080,
+		Height:       1920,
+		Vertical:     true,
+		VideoCodec:   "libx264",
+		Profile:      "high",
+		PixelFormat:  "yuv420p",
+		VideoBitrate: "6M",
+		MaxRate:      "10M",
+		BufSize:      "20M",
+		MaxFramerate: 60,
+		AudioCodec:   "aac",
+		AudioBitrate: "128k",
+		SampleRate:   "44100",
+		MaxDuration:  10 * time.Minute,
+	},
+	"twitter": {
+		Name:         "twitter",
+		Description:  "Twitter/X: up to 720p (vertical video stays vertical), 40 fps, up to 2:20",
+		Width:        1280,
+		Height:       720,
+		VideoCodec:   "libx264",
+		Profile:      "high",
+		PixelFormat:  "yuv420p",
+		VideoBitrate: "5M",
+		MaxRate:      "6M",
+		BufSize:      "12M",
+		MaxFramerate: 40,
+		AudioCodec:   "aac",
+		AudioBitrate: "128k",
+		SampleRate:   "44100",
+		MaxDuration:  2*time.Minute + 20*time.Second,
+	},
+}
+
+// PlatformTargetNames returns the names of the built-in platform targets in sorted order
+func PlatformTargetNames() []string {
+	names := make([]string, 0, len(PlatformTargets))
+	for name := range PlatformTargets {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// LookupPlatformTarget returns the platform target with the given name
+func LookupPlatformTarget(name string) (PlatformTarget, error) {
+	target, ok := PlatformTargets[strings.ToLower(name)]
+	if !ok {
+		return PlatformTarget{}, fmt.Errorf("unknown target: %s (valid: %s)", name, strings.Join(PlatformTargetNames(), ", "))
+	}
+	return target, nil
+}
+
+// validateTarget checks a platform target against the output format and other options
+func validateTarget(customParams CustomParameters, outputFormat string) error {
+	if _, err := LookupPlatformTarget(customParams.Target); err != nil {
+		return err
+	}
+	if outputFormat != "mp4" {
+		return fmt.Errorf("--target %s writes MP4; use a .mp4 output", customParams.Target)
+	}
+	if customParams.Resumable {
+		return fmt.Errorf("--resumable cannot be combined with --target")
+	}
+	return nil
+}
+
+// applyPlatformTarget fills in the target's codecs and bitrates where none were given and
+// works out the scaling and output options for the input. --resolution replaces the
+// target's frame size and --framerate its frame rate cap.
+func applyPlatformTarget(ctx context.Context, inputInfo *analyzer.MediaInfo, customParams CustomParameters, verbose bool) (CustomParameters, error) {
+	target, err := LookupPlatformTarget(customParams.Target)
+	if err != nil {
+		return customParams, err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return customParams, fmt.Errorf("--target requires a video stream")
+	}
+	stream := inputInfo.VideoStreams[0]
+
+	if customParams.VideoCodec == "" {
+		customParams.VideoCodec = target.VideoCodec
+	}
+	// Resolved here so the profile options below match the encoder actually used
+	customParams.VideoCodec, err = resolveEncoder(ctx, customParams.VideoCodec, "mp4", verbose)
+	if err != nil {
+		return customParams, err
+	}
+	if customParams.AudioCodec == "" {
+		customParams.AudioCodec = target.AudioCodec
+	}
+	if customParams.VideoBitrate == "" {
+		customParams.VideoBitrate = target.VideoBitrate
+	}
+	if customParams.AudioBitrate == "" {
+		customParams.AudioBitrate = target.AudioBitrate
+	}
+
+	width, height := target.Width, target.Height
+	if customParams.Resolution != "" {
+		width, height, err = parseResolution(customParams.Resolution)
+		if err != nil {
+			return customParams, err
+		}
+		customParams.Resolution = ""
+	}
+
+	// Phones store portrait video as landscape frames with a rotation
+	sourceWidth, sourceHeight := stream.Width, stream.Height
+	if stream.Rotation == 90 || stream.Rotation == 270 {
+		sourceWidth, sourceHeight = sourceHeight, sourceWidth
+	}
+	portrait := sourceHeight > sourceWidth
+	if !target.Vertical && portrait != (height > width) {
+		width, height = height, width
+	}
+	customParams.targetFilter = buildTargetScaleFilter(width, height, target.Vertical, ffmpegSupports(ctx, forceDivisibleByVersion))
+
+	if customParams.Framerate == "" && target.MaxFramerate > 0 {
+		fps := analyzer.ParseFrameRate(stream.AvgFrameRate)
+		if fps <= 0 {
+			fps = analyzer.ParseFrameRate(stream.FrameRate)
+		}
+		if fps > target.MaxFramerate {
+			customParams.Framerate = strconv.FormatFloat(target.MaxFramerate, 'f', -1, 64)
+		}
+	}
+
+	// Profile and rate limits only apply to the target's own encoder
+	args := []string{"-pix_fmt", target.PixelFormat}
+	if customParams.VideoCodec == target.VideoCodec {
+		args = append(args, "-profile:v", target.Profile, "-maxrate", target.MaxRate, "-bufsize", target.BufSize)
+	}
+	if !customParams.NoAudio {
+		args = append(args, "-ar", target.SampleRate)
+	}
+	customParams.targetArgs = args
+
+	if verbose {
+		color.Cyan("🎯 Target: %s", target.Description)
+		if target.MaxDuration > 0 && inputInfo.Duration > target.MaxDuration {
+			color.Yellow("⚠️  Input is %s long; %s accepts up to %s", formatDuration(inputInfo.Duration),
+				target.Name, formatDuration(target.MaxDuration))
+		}
+	}
+
+	return customParams, nil
+}
+
+// buildTargetScaleFilter fits the video into the target frame. Vertical targets pad the
+// rest of the 9:16 frame; other targets keep the source shape and never upscale. Without
+// force_divisible_by (FFmpeg < 4.2) a second scale rounds the size down to even numbers.
+func buildTargetScaleFilter(width, height int, pad, forceDivisibleBy bool) string {
+	even := evenScaleOption(forceDivisibleBy)
+	if pad {
+		return fmt.Sprintf("scale=%d:%d:force_original_aspect_ratio=decrease%s,"+
+			"pad=%d:%d:(ow-iw)/2:(oh-ih)/2,setsar=1", width, height, even, width, height)
+	}
+	return fmt.Sprintf("scale=w='min(%d,iw)':h='min(%d,ih)':force_original_aspect_ratio=decrease%s,setsar=1",
+		width, height, even)
+}
+
+// evenScaleOption keeps the size picked by force_original_aspect_ratio even, as chroma
+// subsampling needs; older FFmpeg rounds it down with a second scale instead
+func evenScaleOption(forceDivisibleBy bool) string {
+	if !forceDivisibleBy {
+		return ",scale=trunc(iw/2)*2:trunc(ih/2)*2"
+	}
+	return ":force_divisible_by=2"
+}
+
+// parseResolution splits a validated WIDTHxHEIGHT resolution
+func parseResolution(resolution string) (int, int, error) {
+	parts := strings.Split(strings.ToLower(resolution), "x")
+	if len(parts) != 2 {
+		return 0, 0, fmt.Errorf("invalid resolution: %s", resolution)
+	}
+	width, err := strconv.Atoi(parts[0])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid resolution: %s", resolution)
+	}
+	height, err := strconv.Atoi(parts[1])
+	if err != nil {
+		return 0, 0, fmt.Errorf("invalid resolution: %s", resolution)
+	}
+	return width, height, nil
+}
@@ -0,0 +1,152 @@
+package transcoder
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+)
+
+// TextPositions are the places a text overlay can be drawn
+var TextPositions = []string{"top-left", "top", "top-right", "center", "bottom-left", "bottom", "bottom-right"}
+
+// Defaults of the text overlay
+const (
+	DefaultTextPosition = "bottom-right"
+	DefaultTextSize     = 24
+	DefaultTextColor    = "white"
+)
+
+// overlayFonts are common sans-serif fonts looked for when no font is given, in order of
+// preference: Linux distributions, then macOS, then Windows
+var overlayFonts = []string{
+	"/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf",
+	"/usr/share/fonts/dejavu/DejaVuSans.ttf",
+	"/usr/share/fonts/TTF/DejaVuSans.ttf",
+	"/usr/share/fonts/truetype/liberation/LiberationSans-Regular.ttf",
+	"/usr/share/fonts/liberation-sans/LiberationSans-Regular.ttf",
+	"/usr/share/fonts/truetype/noto/NotoSans-Regular.ttf",
+	"/usr/share/fonts/noto/NotoSans-Regular.ttf",
+	"/System/Library/Fonts/Supplemental/Arial.ttf",
+	"/Library/Fonts/Arial.ttf",
+	"/System/Library/Fonts/Helvetica.ttc",
+	`C:\Windows\Fonts\arial.ttf`,
+}
+
+// validateTextOverlay checks the text overlay options
+func validateTextOverlay(ctx context.Context, customParams CustomParameters) error {
+	if _, err := securityPolicy.SanitizeOverlayText(customParams.TextOverlay); err != nil {
+		return fmt.Errorf("invalid --text-overlay: %w", err)
+	}
+	if customParams.VideoCodec == "copy" {
+		return fmt.Errorf("--text-overlay requires video re-encoding and cannot be used with video codec 'copy'")
+	}
+	if customParams.TextPosition != "" && !slices.Contains(TextPositions, customParams.TextPosition) {
+		return fmt.Errorf("invalid text position '%s' (use %s)", customParams.TextPosition, strings.Join(TextPositions, ", "))
+	}
+	if customParams.TextSize != 0 && (customParams.TextSize < 8 || customParams.TextSize > 400) {
+		return fmt.Errorf("invalid text size %d (must be between 8 and 400)", customParams.TextSize)
+	}
+	if customParams.TextColor != "" {
+		if _, err := parseFilterColor(customParams.TextColor); err != nil {
+			return err
+		}
+	}
+	if err := validateOverlayFont(customParams.TextFont); err != nil {
+		return err
+	}
+	return checkFilterAvailable(ctx, "drawtext")
+}
+
+// validateOverlayFont checks a font file given for the overlays
+func validateOverlayFont(font string) error {
+	if font == "" {
+		return nil
+	}
+	if err := securityPolicy.ValidateFilePath(font); err != nil {
+		return fmt.Errorf("security validation failed for font path: %w", err)
+	}
+	if !fontExtensions[strings.ToLower(filepath.Ext(font))] {
+		return fmt.Errorf("unsupported font file %s (use a .ttf, .otf or .ttc font)", font)
+	}
+	if _, err := os.Stat(font); err != nil {
+		return fmt.Errorf("font file not found: %s", font)
+	}
+	return nil
+}
+
+// findOverlayFont returns the first common font installed on this system, or "" when there
+// is none
+func findOverlayFont() string {
+	for _, font := range overlayFonts {
+		if info, err := os.Stat(font); err == nil && !info.IsDir() {
+			return font
+		}
+	}
+	return ""
+}
+
+// overlayFontOption returns the drawtext option selecting the font: the given font file, a
+// common installed font, or else a sans-serif font FFmpeg finds itself when built with fontconfig
+func overlayFontOption(ctx context.Context, fontFile string) (string, error) {
+	if fontFile = cmp.Or(fontFile, findOverlayFont()); fontFile != "" {
+		return "fontfile=" + quoteFilterPath(fontFile), nil
+	}
+	if !ffmpegBuiltWith(ctx, "--enable-libfontconfig") {
+		return "", fmt.Errorf("no font found for the overlay; choose one with --text-font")
+	}
+	return "font=Sans", nil
+}
+
+// buildTextOverlayFilter draws the overlay text with a drop shadow, so it stays readable on
+// bright and dark frames, inset from the frame edges by a thirtieth of the frame height
+func buildTextOverlayFilter(ctx context.Context, customParams CustomParameters) (string, error) {
+	text, err := securityPolicy.SanitizeOverlayText(customParams.TextOverlay)
+	if err != nil {
+		return "", fmt.Errorf("invalid --text-overlay: %w", err)
+	}
+
+	position := cmp.Or(customParams.TextPosition, DefaultTextPosition)
+	size := customParams.TextSize
+	if size == 0 {
+		size = DefaultTextSize
+	}
+	color, err := parseFilterColor(cmp.Or(customParams.TextColor, DefaultTextColor))
+	if err != nil {
+		return "", err
+	}
+
+	font, err := overlayFontOption(ctx, customParams.TextFont)
+	if err != nil {
+		return "", err
+	}
+
+	x, y := textOverlayPosition(position)
+	return fmt.Sprintf("drawtext=%s:text='%s':fontsize=%d:fontcolor=%s:shadowcolor=black@0.6:shadowx=2:shadowy=2:x=%s:y=%s",
+		font, text, size, color, x, y), nil
+}
+
+// textOverlayPosition returns the drawtext x and y expressions placing the text
+func textOverlayPosition(position string) (string, string) {
+	const margin = "h/30"
+
+	x := "(w-tw)/2"
+	switch {
+	case strings.HasSuffix(position, "left"):
+		x = margin
+	case strings.HasSuffix(position, "right"):
+		x = "w-tw-" + margin
+	}
+
+	y := "(h-th)/2"
+	switch {
+	case strings.HasPrefix(position, "top"):
+		y = margin
+	case strings.HasPrefix(position, "bottom"):
+		y = "h-th-" + margin
+	}
+	return x, y
+}
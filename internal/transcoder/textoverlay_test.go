@@ -0,0 +1,38 @@
+package transcoder
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBuildTextOverlayFilterFontFile(t *testing.T) {
+	tests := []struct {
+		name string
+		font string
+	}{
+		{"linux", "/usr/share/fonts/truetype/dejavu/DejaVuSans.ttf"},
+		{"windows fallback", `C:\Windows\Fonts\arial.ttf`},
+		{"quote and comma", "/home/user/fonts/Ann's Hand, Bold.otf"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := buildTextOverlayFilter(t.Context(), CustomParameters{TextOverlay: "Hello", TextFont: tt.font})
+			if err != nil {
+				t.Fatalf("buildTextOverlayFilter(ctx) error = %v", err)
+			}
+
+			options, rest := getToken(strings.TrimPrefix(filter, "drawtext="), "[],;")
+			if rest != "" {
+				t.Fatalf("filter %q ends the filter early, leaving %q", filter, rest)
+			}
+			font, rest := getToken(strings.TrimPrefix(options, "fontfile="), ":")
+			if font != tt.font {
+				t.Errorf("drawtext reads fontfile %q, want %q", font, tt.font)
+			}
+			if !strings.HasPrefix(rest, ":text=") {
+				t.Errorf("options after the font are %q, want the text next", rest)
+			}
+		})
+	}
+}
@@ -0,0 +1,94 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// timecodeRegex matches SMPTE timecode; drop-frame timecode has ; or . before the frames
+var timecodeRegex = regexp.MustCompile(`^(\d{2}):(\d{2}):(\d{2})([:;.])(\d{2})$`)
+
+// frameRateRegex matches the frame rates drawtext accepts for counting timecode (e.g., 25, 30000/1001)
+var frameRateRegex = regexp.MustCompile(`^[0-9]+(/[0-9]+)?$`)
+
+// validateBurnTimecode checks the options combined with --burn-timecode
+func validateBurnTimecode(ctx context.Context, customParams CustomParameters) error {
+	if customParams.VideoCodec == "copy" {
+		return fmt.Errorf("--burn-timecode requires video re-encoding and cannot be used with video codec 'copy'")
+	}
+	// Every segment would start counting again from the first timecode
+	if customParams.Resumable {
+		return fmt.Errorf("--burn-timecode cannot be combined with --resumable")
+	}
+	if err := validateOverlayFont(customParams.TextFont); err != nil {
+		return err
+	}
+	return checkFilterAvailable(ctx, "drawtext")
+}
+
+// buildTimecodeFilter burns a running HH:MM:SS:FF timecode into the bottom center of the
+// picture, on a dark box, counting at the frame rate of the input from its own start timecode
+// (00:00:00:00 when it has none)
+func buildTimecodeFilter(ctx context.Context, inputInfo *analyzer.MediaInfo, customParams CustomParameters) (string, error) {
+	if len(inputInfo.VideoStreams) == 0 {
+		return "", fmt.Errorf("--burn-timecode requires a video stream")
+	}
+	stream := inputInfo.VideoStreams[0]
+	rate := stream.FrameRate
+	if stream.IsVariableFrameRate() {
+		rate = stream.AvgFrameRate
+	}
+	fps := analyzer.ParseFrameRate(rate)
+	if fps <= 0 || !frameRateRegex.MatchString(rate) {
+		return "", fmt.Errorf("cannot burn in timecode: the frame rate of the input is unknown")
+	}
+
+	// A preview starts later in the input, and so does its timecode
+	var skipped time.Duration
+	if customParams.Preview > 0 {
+		skipped = customParams.PreviewAt
+	}
+	timecode := startTimecode(inputInfo.Timecode, fps, skipped)
+
+	font, err := overlayFontOption(ctx, customParams.TextFont)
+	if err != nil {
+		return "", err
+	}
+
+	// Sized to the input so the timecode scales with the picture; colons are escaped for the
+	// drawtext option parser
+	size := max(16, stream.Height/20)
+	return fmt.Sprintf("drawtext=%s:timecode='%s':rate=%s:fontsize=%d:fontcolor=white:box=1:boxcolor=black@0.6:boxborderw=%d:x=(w-tw)/2:y=h-th-h/30",
+		font, strings.ReplaceAll(timecode, ":", `\:`), rate, size, max(4, size/4)), nil
+}
+
+// startTimecode is the timecode of the first encoded frame: the source timecode, or midnight,
+// advanced by the frames skipped before it. Frames are counted without dropping frame numbers.
+func startTimecode(source string, fps float64, skipped time.Duration) string {
+	match := timecodeRegex.FindStringSubmatch(source)
+	if match == nil {
+		match = []string{"", "00", "00", "00", ":", "00"}
+	}
+	separator := match[4]
+	if separator == "." {
+		separator = ";"
+	}
+
+	nominal := int(math.Round(fps))
+	hours, _ := strconv.Atoi(match[1])
+	minutes, _ := strconv.Atoi(match[2])
+	seconds, _ := strconv.Atoi(match[3])
+	frames, _ := strconv.Atoi(match[5])
+	total := ((hours*60+minutes)*60+seconds)*nominal + frames + int(math.Round(skipped.Seconds()*fps))
+
+	frames = total % nominal
+	total /= nominal
+	return fmt.Sprintf("%02d:%02d:%02d%s%02d", total/3600%24, total/60%60, total%60, separator, frames)
+}
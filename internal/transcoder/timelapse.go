@@ -0,0 +1,143 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// TimelapseParams holds parameters for building a time-lapse
+type TimelapseParams struct {
+	InputFile  string  // Input video file path
+	OutputFile string  // Output video file path
+	Speed      string  // Speed-up factor (e.g., "60x")
+	FPS        string  // Output frame rate (e.g., "30")
+	MotionBlur float64 // Share of the dropped frames blended into each kept one (0 off, 1 all)
+	Preset     string  // Quality preset (low, medium, high)
+	Verbose    bool    // Verbose output
+}
+
+// CreateTimelapse turns long footage into a time-lapse by keeping every Nth frame
+func CreateTimelapse(ctx context.Context, params TimelapseParams) error {
+	outputFormat, err := validateTimelapseParams(params)
+	if err != nil {
+		return err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return err
+	}
+	if len(inputInfo.VideoStreams) == 0 {
+		return fmt.Errorf("no video streams found in input file: %s", params.InputFile)
+	}
+
+	speed := parseSpeedFactor(params.Speed)
+	outputFPS, _ := strconv.ParseFloat(params.FPS, 64)
+	inputFPS := analyzer.ParseFrameRate(inputInfo.VideoStreams[0].FrameRate)
+	if inputFPS <= 0 {
+		return fmt.Errorf("could not determine input frame rate")
+	}
+
+	filter, err := buildTimelapseFilter(inputFPS, speed, outputFPS, params.MotionBlur)
+	if err != nil {
+		return err
+	}
+
+	videoCodec, _ := getDefaultCodecs(outputFormat)
+	cmd := NewFFmpegCommandBuilder(ctx, params.Verbose).
+		WithInput(params.InputFile).
+		WithVideoFilter(filter).
+		WithVideoCodec(videoCodec, CustomParameters{VideoBitrate: getPresetVideoBitrate(params.Preset)}).
+		WithAudioCodec("", CustomParameters{NoAudio: true}).
+		WithCustomParameters(CustomParameters{Framerate: params.FPS}).
+		WithOutput(params.OutputFile).
+		Build()
+	if cmd == nil {
+		return fmt.Errorf("failed to build secure FFmpeg command")
+	}
+
+	// Progress is measured against the (much shorter) output timeline
+	outputInfo := *inputInfo
+	outputInfo.Duration = time.Duration(float64(inputInfo.Duration) / speed)
+
+	if params.Verbose {
+		color.Cyan("⏩ Time-lapse: %.0fx speed, %.3g fps source → %s fps output", speed, inputFPS, params.FPS)
+		fmt.Printf("   Output duration: %s\n", formatDuration(outputInfo.Duration))
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	return stoppedBy(ctx, executeFFmpeg(ctx, cmd, &outputInfo, params.Verbose))
+}
+
+// validateTimelapseParams validates paths and time-lapse settings for security
+func validateTimelapseParams(params TimelapseParams) (string, error) {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return "", err
+	}
+
+	outputFormat := getFormatFromPath(params.OutputFile)
+	if !SupportedFormats[outputFormat] {
+		return "", fmt.Errorf("unsupported output format: %s", outputFormat)
+	}
+
+	if err := validateConversionPaths(params.InputFile, params.OutputFile); err != nil {
+		return "", err
+	}
+
+	if err := securityPolicy.ValidateSpeedFactor(params.Speed); err != nil {
+		return "", fmt.Errorf("security validation failed for speed: %w", err)
+	}
+
+	if err := securityPolicy.ValidateFramerate(params.FPS); err != nil {
+		return "", fmt.Errorf("security validation failed for framerate: %w", err)
+	}
+
+	if params.MotionBlur < 0 || params.MotionBlur > 1 {
+		return "", fmt.Errorf("motion blur strength must be between 0 and 1")
+	}
+
+	if params.Speed == "" || params.FPS == "" {
+		return "", fmt.Errorf("speed and frame rate are required for a time-lapse")
+	}
+
+	return outputFormat, nil
+}
+
+// parseSpeedFactor converts a validated speed string (e.g., "60x") to a number
+func parseSpeedFactor(speed string) float64 {
+	factor, _ := strconv.ParseFloat(strings.TrimRight(speed, "xX"), 64)
+	return factor
+}
+
+// maxMotionBlurFrames caps the frames averaged for motion blur; tmix holds them all in memory
+const maxMotionBlurFrames = 128
+
+// buildTimelapseFilter builds the frame-dropping filter chain for a time-lapse.
+// Only every Nth source frame is kept, so the encoder never sees the dropped frames.
+// Motion blur averages each kept frame with the frames dropped before it, like a long
+// shutter: at strength 1 over the whole interval, at 0.5 over half of it.
+func buildTimelapseFilter(inputFPS, speed, outputFPS, motionBlur float64) (string, error) {
+	step := int(math.Round(inputFPS * speed / outputFPS))
+	if step < 1 {
+		return "", fmt.Errorf("speed %.3gx is too low for %.3g fps output from a %.3g fps source", speed, outputFPS, inputFPS)
+	}
+
+	filters := make([]string, 0, 3)
+	if motionBlur > 0 {
+		frames := min(max(2, int(math.Round(float64(step)*motionBlur))), maxMotionBlurFrames)
+		filters = append(filters, fmt.Sprintf("tmix=frames=%d", frames))
+	}
+	if step > 1 {
+		filters = append(filters, fmt.Sprintf("framestep=%d", step))
+	}
+	filters = append(filters, fmt.Sprintf("setpts=N/(%s*TB)", strconv.FormatFloat(outputFPS, 'f', -1, 64)))
+
+	return strings.Join(filters, ","), nil
+}
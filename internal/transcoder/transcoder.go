@@ -2,12 +2,13 @@ package transcoder
 
 import (
 	"bufio"
+	"context"
 	"fmt"
 	"io"
 	"os"
 	"os/exec"
 	"path/filepath"
-	"regexp"
+	"slices"
 	"strconv"
 	"strings"
 	"time"
@@ -24,6 +25,11 @@ var SupportedFormats = map[string]bool{
 	"mkv":  true,
 	"webm": true,
 	"mov":  true,
+	"ts":   true,
+	"flv":  true,
+	"3gp":  true,
+	"ogv":  true,
+	"mxf":  true,
 }
 
 // Global security policy for input validation
@@ -37,6 +43,93 @@ type CustomParameters struct {
 	AudioBitrate string // User-specified audio bitrate (e.g., "192k", "128k")
 	Resolution   string // User-specified resolution (e.g., "1920x1080")
 	Framerate    string // User-specified framerate (e.g., "30", "24")
+	Volume       string // User-specified volume adjustment (e.g., "1.5", "+3dB")
+
+	// Force constant frame rate output; Framerate is detected from the input when empty
+	ConstantFrameRate bool
+
+	// Number of threads FFmpeg may use per encode (0 lets FFmpeg decide)
+	Threads int
+
+	// Stream selection (does not require re-encoding)
+	AudioStream   string // 1-based audio stream number to use (e.g., "2")
+	AudioLanguage string // Language of the audio stream to use (e.g., "jpn")
+	NoAudio       bool   // Drop all audio streams (video-only output)
+
+	// Additional audio files muxed alongside the original audio (e.g., commentary tracks)
+	AddAudio []AudioTrack
+
+	// Shift the original audio against the video to fix lip sync; negative plays it earlier (works with stream copy)
+	AudioDelay time.Duration
+
+	// Regenerate missing timestamps and shift negative ones to zero (works with stream copy)
+	FixTimestamps bool
+
+	// Move the MP4/MOV index to the front so playback starts while downloading (works with stream copy)
+	WebOptimized bool
+
+	// Write fragmented MP4 for Media Source Extensions and CMAF workflows (works with stream copy)
+	Fragmented bool
+
+	// Drop the metadata of the input (titles, dates, stream languages) instead of keeping it
+	StripMetadata bool
+
+	// Container format of output written to stdout (e.g., "mkv"), which has no extension to go by
+	Container string
+
+	// Duration of input read from stdin, which cannot be probed in full (used for progress only)
+	InputDuration time.Duration
+
+	// Encode the video in segments so an interrupted conversion resumes where it stopped
+	Resumable bool
+
+	// Repeat a failed encode with safer settings (software encoder, yuv420p, regenerated timestamps)
+	RetryFallback bool
+
+	// Encode only Preview of the input starting at PreviewAt, to check quality and size first
+	Preview   time.Duration
+	PreviewAt time.Duration
+
+	// Extra FFmpeg arguments inserted right before the output, so they override generated options
+	ExtraArgs       []string
+	UnsafeExtraArgs bool // Skip the allowlist check for ExtraArgs
+
+	// Platform preset (e.g., "youtube") providing codecs, bitrates, frame size and pixel format
+	Target       string
+	targetFilter string   // Scaling filter fitting the input into the target frame
+	targetArgs   []string // Pixel format, profile, rate control and sample rate options
+
+	containerArgs []string // Options the output container needs for its default encoders
+
+	// Lossless FFV1 video and FLAC audio in MKV, for digitization and preservation
+	Archival bool
+
+	// Text drawn onto the video, such as a copyright notice; empty fields use the defaults
+	TextOverlay  string
+	TextPosition string // One of TextPositions
+	TextSize     int    // Font size in pixels
+	TextColor    string // Hex color or color name
+	TextFont     string // Font file, also used for the timecode; a common installed font is used when empty
+	textFilter   string // drawtext filter drawing the text
+
+	// Burn a running timecode into the picture, for review copies and dailies
+	BurnTimecode   bool
+	timecodeFilter string // drawtext filter drawing the timecode
+
+	// Frame the video is fitted into with bars, as an aspect ratio (16:9) or a resolution (1920x1080)
+	PadTo     string
+	PadColor  string // Hex color or color name of the bars
+	padFilter string // Filters scaling and padding the video
+
+	// 3D LUT file baked into the picture, converting log footage or applying a creative grade
+	LUT       string
+	lutFilter string // lut3d filter applying the LUT
+
+	// Regions blurred out to anonymize faces or license plates
+	BlurRegions []BlurRegion
+	blurFilter  string // Filter chain blurring the regions
+
+	subtitleStreams []subtitleStream // Subtitles kept when the streams are mapped explicitly
 }
 
 // AudioExtractionParams holds parameters for audio extraction
@@ -48,50 +141,107 @@ type AudioExtractionParams struct {
 	Codec      string // Custom codec (e.g., "libmp3lame", "aac")
 	SampleRate string // Custom sample rate (e.g., "44100", "48000")
 	Channels   string // Number of channels (e.g., "1", "2", "6")
+	Volume     string // Volume adjustment (e.g., "1.5", "+3dB")
+	Stream     string // 1-based audio stream number to extract (e.g., "2")
+	Language   string // Language of the audio stream to extract (e.g., "jpn")
+	Container  string // Output format when writing to stdout (e.g., "mp3")
+	Threads    int    // Number of threads FFmpeg may use (0 lets FFmpeg decide)
 	Verbose    bool   // Verbose output
+
+	// Sample format of WAV, FLAC and ALAC outputs (s16, s24, s32 or flt)
+	SampleFormat string
+
+	// Sample rate converter used with SampleRate: auto (or empty), soxr or swr
+	Resampler string
+
+	// Source channel of each output channel, e.g., "FL-FR" or "FR-FL" (see ParseChannelMap)
+	ChannelMap string
+
+	// Part of the input to extract; a zero End extracts to the end of the input
+	Start, End time.Duration
+
+	// Copy the audio instead of re-encoding it when it already has the codec the output format
+	// would be encoded to and no setting changes it
+	CopyMatching bool
+
+	// Tags written to the output over those kept from the input (e.g., "title", "track")
+	Tags map[string]string
+
+	// Image embedded as the cover art (JPEG or PNG; MP3, M4A and FLAC outputs only)
+	Cover string
+
+	// Drop the tags of the input instead of keeping them; Tags are still written
+	StripMetadata bool
+
+	// Duration of input read from stdin, which cannot be probed in full (used for progress only)
+	InputDuration time.Duration
 }
 
-// ConvertVideoWithCustomParams converts a video file with custom parameters support
-func ConvertVideoWithCustomParams(inputPath, outputPath, preset string, presetExplicit, customParamsSet bool, customParams CustomParameters, verbose bool) error {
+// ConvertVideoWithCustomParams converts a video file with custom parameters support.
+// FFmpeg and ffprobe are stopped when ctx is cancelled, and ctx's error is returned.
+func ConvertVideoWithCustomParams(ctx context.Context, inputPath, outputPath, preset string, presetExplicit, customParamsSet bool, customParams CustomParameters, verbose bool) error {
 	// Step 1: Validate all inputs and parameters
-	outputFormat, err := validateConversionInputs(inputPath, outputPath, customParamsSet, customParams)
+	outputFormat, err := validateConversionInputs(ctx, inputPath, outputPath, customParamsSet, customParams)
 	if err != nil {
 		return err
 	}
 
 	// Step 2: Analyze input media
-	inputInfo, err := analyzeInputMedia(inputPath, verbose)
+	inputInfo, err := analyzeInputMedia(ctx, inputPath, verbose)
 	if err != nil {
-		return err
+		return stoppedBy(ctx, err)
+	}
+	if customParams.InputDuration > 0 {
+		inputInfo.Duration = customParams.InputDuration
 	}
 
 	// Step 3: Select codecs and prepare parameters
 	videoCodec, audioCodec, finalParams, canCopy, err := prepareConversionParameters(
-		inputInfo, outputFormat, preset, presetExplicit, customParamsSet, customParams, verbose)
+		ctx, inputInfo, outputFormat, preset, presetExplicit, customParamsSet, customParams, verbose)
 	if err != nil {
-		return err
+		return stoppedBy(ctx, err)
+	}
+	if finalParams.Preview > 0 {
+		if finalParams, err = applyPreviewRange(inputInfo, finalParams, verbose); err != nil {
+			return err
+		}
 	}
 
 	// Step 4: Build and execute conversion
-	return executeConversion(inputPath, outputPath, videoCodec, audioCodec, preset,
+	err = executeConversion(ctx, inputPath, outputPath, videoCodec, audioCodec, preset,
 		finalParams, inputInfo, canCopy, customParamsSet, verbose)
+	return stoppedBy(ctx, err)
 }
 
 // validateConversionInputs performs comprehensive validation of all conversion inputs
-func validateConversionInputs(inputPath, outputPath string, customParamsSet bool, customParams CustomParameters) (string, error) {
+func validateConversionInputs(ctx context.Context, inputPath, outputPath string, customParamsSet bool, customParams CustomParameters) (string, error) {
 	// Validate input file
 	if err := validateInputFile(inputPath); err != nil {
 		return "", err
 	}
 
-	// Validate output format
-	outputFormat := getFormatFromPath(outputPath)
+	// Validate output format (output written to stdout uses the given container)
+	if IsStdoutPath(outputPath) {
+		if err := validatePipeContainer(customParams.Container); err != nil {
+			return "", err
+		}
+	}
+	outputFormat := resolveOutputFormat(outputPath, customParams.Container)
 	if !SupportedFormats[outputFormat] {
 		return "", fmt.Errorf("unsupported output format: %s", outputFormat)
 	}
 
+	// Make sure the codec compatibility data (including user overrides) is usable
+	if _, err := getCompatibilityTable(); err != nil {
+		return "", fmt.Errorf("loading codec compatibility data: %w", err)
+	}
+
 	// Security validation for file paths
-	if err := validateConversionPaths(inputPath, outputPath); err != nil {
+	if IsStdoutPath(outputPath) {
+		if err := securityPolicy.ValidateFilePath(inputPath); err != nil {
+			return "", fmt.Errorf("security validation failed for input path: %w", err)
+		}
+	} else if err := validateConversionPaths(inputPath, outputPath); err != nil {
 		return "", err
 	}
 
@@ -102,6 +252,90 @@ func validateConversionInputs(inputPath, outputPath string, customParamsSet bool
 		}
 	}
 
+	// The thread count applies with or without custom encoding parameters
+	if err := securityPolicy.ValidateThreads(customParams.Threads); err != nil {
+		return "", fmt.Errorf("security validation failed for threads: %w", err)
+	}
+
+	// Stream selection applies with or without custom encoding parameters
+	if err := validateStreamSelection(customParams.AudioStream, customParams.AudioLanguage); err != nil {
+		return "", err
+	}
+
+	if err := validateAudioTracks(customParams.AddAudio); err != nil {
+		return "", err
+	}
+
+	if err := validateNoAudioCombination(customParams); err != nil {
+		return "", err
+	}
+
+	if customParams.AudioDelay != 0 {
+		if err := validateAudioDelay(inputPath, customParams); err != nil {
+			return "", err
+		}
+	}
+
+	if customParams.Resumable {
+		if err := validateResumable(inputPath, outputPath, customParams); err != nil {
+			return "", err
+		}
+	}
+
+	if customParams.Target != "" {
+		if err := validateTarget(customParams, outputFormat); err != nil {
+			return "", err
+		}
+	}
+
+	if customParams.Archival {
+		if err := validateArchival(customParams, outputFormat); err != nil {
+			return "", err
+		}
+	}
+
+	if customParams.TextOverlay != "" {
+		if err := validateTextOverlay(ctx, customParams); err != nil {
+			return "", err
+		}
+	}
+
+	if customParams.BurnTimecode {
+		if err := validateBurnTimecode(ctx, customParams); err != nil {
+			return "", err
+		}
+	}
+
+	if customParams.PadTo != "" {
+		if err := validatePadTo(customParams); err != nil {
+			return "", err
+		}
+	}
+
+	if customParams.LUT != "" {
+		if err := validateLUT(ctx, customParams); err != nil {
+			return "", err
+		}
+	}
+
+	if len(customParams.BlurRegions) > 0 {
+		if err := validateBlurRegions(customParams); err != nil {
+			return "", err
+		}
+	}
+
+	if customParams.RetryFallback {
+		if err := validateRetryFallback(inputPath, outputPath, customParams); err != nil {
+			return "", err
+		}
+	}
+
+	if customParams.Preview != 0 || customParams.PreviewAt != 0 {
+		if err := validatePreview(inputPath, outputPath, customParams); err != nil {
+			return "", err
+		}
+	}
+
 	return outputFormat, nil
 }
 
@@ -152,16 +386,52 @@ func validateConversionCustomParams(customParams CustomParameters) error {
 		return fmt.Errorf("security validation failed for framerate: %w", err)
 	}
 
+	if err := securityPolicy.ValidateVolume(customParams.Volume); err != nil {
+		return fmt.Errorf("security validation failed for volume: %w", err)
+	}
+
+	if customParams.Volume != "" && customParams.AudioCodec == "copy" {
+		return fmt.Errorf("volume adjustment requires audio re-encoding and cannot be used with audio codec 'copy'")
+	}
+
+	return validateExtraArgs(customParams)
+}
+
+// validateNoAudioCombination rejects audio options when audio is being removed
+func validateNoAudioCombination(customParams CustomParameters) error {
+	if !customParams.NoAudio {
+		return nil
+	}
+
+	if customParams.AudioCodec != "" || customParams.AudioBitrate != "" || customParams.Volume != "" ||
+		customParams.AudioStream != "" || customParams.AudioLanguage != "" || len(customParams.AddAudio) > 0 ||
+		customParams.AudioDelay != 0 {
+		return fmt.Errorf("audio options cannot be used when audio is removed (no audio)")
+	}
+
+	return nil
+}
+
+// validateStreamSelection validates audio stream selection parameters for security
+func validateStreamSelection(stream, language string) error {
+	if err := securityPolicy.ValidateStreamNumber(stream); err != nil {
+		return fmt.Errorf("security validation failed for audio stream: %w", err)
+	}
+
+	if err := securityPolicy.ValidateLanguageCode(language); err != nil {
+		return fmt.Errorf("security validation failed for audio language: %w", err)
+	}
+
 	return nil
 }
 
 // analyzeInputMedia analyzes the input media file
-func analyzeInputMedia(inputPath string, verbose bool) (*analyzer.MediaInfo, error) {
+func analyzeInputMedia(ctx context.Context, inputPath string, verbose bool) (*analyzer.MediaInfo, error) {
 	if verbose {
 		color.Blue("🔍 Analyzing input media...")
 	}
 
-	inputInfo, err := analyzer.AnalyzeMedia(inputPath)
+	inputInfo, err := analyzer.AnalyzeMedia(ctx, inputPath)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze input: %w", err)
 	}
@@ -170,13 +440,86 @@ func analyzeInputMedia(inputPath string, verbose bool) (*analyzer.MediaInfo, err
 }
 
 // prepareConversionParameters selects codecs and prepares final parameters for conversion
-func prepareConversionParameters(inputInfo *analyzer.MediaInfo, outputFormat, preset string,
+func prepareConversionParameters(ctx context.Context, inputInfo *analyzer.MediaInfo, outputFormat, preset string,
 	presetExplicit, customParamsSet bool, customParams CustomParameters, verbose bool) (string, string, CustomParameters, bool, error) {
 
+	// A platform target supplies the settings that were not given explicitly
+	if customParams.Target != "" {
+		var err error
+		customParams, err = applyPlatformTarget(ctx, inputInfo, customParams, verbose)
+		if err != nil {
+			return "", "", CustomParameters{}, false, err
+		}
+	}
+	if customParams.Archival {
+		customParams = applyArchivalPreset(customParams, verbose)
+	}
+	if customParams.TextOverlay != "" {
+		filter, err := buildTextOverlayFilter(ctx, customParams)
+		if err != nil {
+			return "", "", CustomParameters{}, false, err
+		}
+		customParams.textFilter = filter
+	}
+	if customParams.BurnTimecode {
+		filter, err := buildTimecodeFilter(ctx, inputInfo, customParams)
+		if err != nil {
+			return "", "", CustomParameters{}, false, err
+		}
+		customParams.timecodeFilter = filter
+	}
+	if customParams.LUT != "" {
+		customParams.lutFilter = buildLUTFilter(customParams)
+	}
+	if customParams.PadTo != "" {
+		filter, err := buildPadFilter(ctx, inputInfo, customParams)
+		if err != nil {
+			return "", "", CustomParameters{}, false, err
+		}
+		customParams.padFilter = filter
+	}
+	if len(customParams.BlurRegions) > 0 {
+		filter, err := buildBlurFilter(inputInfo, customParams)
+		if err != nil {
+			return "", "", CustomParameters{}, false, err
+		}
+		customParams.blurFilter = filter
+	}
+
+	// Resolve the requested audio stream (by number or language) against the input
+	audioPosition, err := resolveAudioStreamSelection(inputInfo, customParams.AudioStream, customParams.AudioLanguage)
+	if err != nil {
+		return "", "", CustomParameters{}, false, err
+	}
+	if audioPosition >= 0 {
+		customParams.AudioStream = strconv.Itoa(audioPosition + 1)
+	} else if (len(customParams.AddAudio) > 0 || customParams.AudioDelay != 0) && len(inputInfo.AudioStreams) > 0 {
+		// Added tracks and delayed audio need explicit mapping, so keep the first original audio stream explicitly
+		customParams.AudioStream = "1"
+	}
+	if customParams.AudioDelay != 0 && len(inputInfo.AudioStreams) == 0 {
+		return "", "", CustomParameters{}, false, fmt.Errorf("--audio-delay needs an input with audio")
+	}
+	if customParams.AudioStream != "" {
+		customParams.subtitleStreams = selectSubtitleStreams(inputInfo, outputFormat)
+	}
+
 	// Select optimal codecs (considering custom parameters and security)
 	videoCodec, audioCodec, canCopy := selectCodecsWithCustomParamsSecure(
 		inputInfo, outputFormat, preset, presetExplicit, customParamsSet, customParams, verbose)
 
+	// Fall back to another encoder when the selected one is missing from the FFmpeg build
+	videoCodec, err = resolveEncoder(ctx, videoCodec, outputFormat, verbose)
+	if err != nil {
+		return "", "", CustomParameters{}, false, err
+	}
+	if !customParams.NoAudio {
+		audioCodec, err = resolveEncoder(ctx, audioCodec, outputFormat, verbose)
+		if err != nil {
+			return "", "", CustomParameters{}, false, err
+		}
+	}
+
 	// Apply preset-based bitrates if no custom bitrates specified
 	finalParams := customParams
 	if !customParamsSet || customParams.VideoBitrate == "" {
@@ -186,15 +529,53 @@ func prepareConversionParameters(inputInfo *analyzer.MediaInfo, outputFormat, pr
 		finalParams.AudioBitrate = getPresetAudioBitrate(preset)
 	}
 
+	finalParams.containerArgs, err = containerEncoderArgs(outputFormat, videoCodec, audioCodec, customParams.NoAudio)
+	if err != nil {
+		return "", "", CustomParameters{}, false, err
+	}
+
+	// Pick a constant output rate close to the source when none was given
+	if customParams.ConstantFrameRate && customParams.Framerate == "" {
+		finalParams.Framerate, err = detectConstantFrameRate(inputInfo)
+		if err != nil {
+			return "", "", CustomParameters{}, false, err
+		}
+		if verbose {
+			color.Cyan("🎞️  Constant frame rate output at %s fps", finalParams.Framerate)
+		}
+	}
+
+	// Decide per added track whether it can be stream copied into the container
+	if len(customParams.AddAudio) > 0 {
+		finalParams.AddAudio, err = resolveAudioTrackCodecs(ctx, customParams.AddAudio, outputFormat, audioCodec, preset, verbose)
+		if err != nil {
+			return "", "", CustomParameters{}, false, err
+		}
+	}
+
+	if customParams.Fragmented {
+		if err := validateFragmentedOutput(inputInfo, outputFormat, videoCodec, audioCodec, finalParams); err != nil {
+			return "", "", CustomParameters{}, false, err
+		}
+	}
+
 	return videoCodec, audioCodec, finalParams, canCopy, nil
 }
 
 // executeConversion builds the command and executes the conversion
-func executeConversion(inputPath, outputPath, videoCodec, audioCodec, preset string,
+func executeConversion(ctx context.Context, inputPath, outputPath, videoCodec, audioCodec, preset string,
 	customParams CustomParameters, inputInfo *analyzer.MediaInfo, canCopy, customParamsSet, verbose bool) error {
 
+	// Segmenting only pays off when the video is re-encoded; copying is fast enough to restart
+	if customParams.Resumable && videoCodec != "copy" {
+		return convertResumable(ctx, inputPath, outputPath, videoCodec, audioCodec, customParams, inputInfo, verbose)
+	}
+	if customParams.Resumable && verbose {
+		color.Yellow("⚠️  Video is stream copied, so the conversion is not split into resumable segments")
+	}
+
 	// Build FFmpeg command (with security validation)
-	cmd := buildFFmpegCommandWithCustomParams(inputPath, outputPath, videoCodec, audioCodec, preset, customParams, verbose)
+	cmd := buildFFmpegCommandWithCustomParams(ctx, inputPath, outputPath, videoCodec, audioCodec, preset, customParams, verbose)
 	if cmd == nil {
 		return fmt.Errorf("failed to build secure FFmpeg command")
 	}
@@ -204,7 +585,47 @@ func executeConversion(inputPath, outputPath, videoCodec, audioCodec, preset str
 		displayConversionInfo(canCopy, customParamsSet, customParams, cmd)
 	}
 
-	return executeFFmpeg(cmd, inputInfo, verbose)
+	if analyzer.IsStdinPath(inputPath) {
+		cmd.Stdin = analyzer.StdinReader()
+	}
+
+	if IsStdoutPath(outputPath) {
+		return runFFmpegToStdout(cmd)
+	}
+
+	if err := executeFFmpeg(ctx, cmd, inputInfo, verbose); err != nil {
+		if !customParams.RetryFallback || ctx.Err() != nil {
+			return err
+		}
+		encode := fallbackEncode{
+			videoCodec:   videoCodec,
+			audioCodec:   audioCodec,
+			params:       customParams,
+			outputFormat: getFormatFromPath(outputPath),
+			inputInfo:    inputInfo,
+		}
+		if err := retryWithFallback(ctx, inputPath, outputPath, encode, preset, err, verbose); err != nil {
+			return err
+		}
+	}
+
+	if verbose && customParams.WebOptimized {
+		reportFastStart(outputPath)
+	}
+	return nil
+}
+
+// reportFastStart tells whether the moov atom was relocated in front of the media data
+func reportFastStart(outputPath string) {
+	fastStart, err := analyzer.IsFastStart(outputPath)
+	switch {
+	case err != nil:
+		color.Yellow("⚠️  Could not verify web optimization: %v", err)
+	case fastStart:
+		color.Green("🌐 Web optimized: moov atom relocated before the media data")
+	default:
+		color.Yellow("⚠️  Web optimization not applied: moov atom is still after the media data")
+	}
 }
 
 // displayConversionInfo shows conversion information in verbose mode
@@ -214,6 +635,15 @@ func displayConversionInfo(canCopy, customParamsSet bool, customParams CustomPar
 	} else {
 		color.Yellow("🔄 Re-encoding with selected codecs")
 	}
+	if customParams.FixTimestamps {
+		color.Cyan("🕒 Regenerating timestamps and shifting negative ones to zero")
+	}
+	if customParams.AudioDelay != 0 {
+		color.Cyan("🎙️  Audio delayed by %s against the video", customParams.AudioDelay)
+	}
+	if customParams.Fragmented {
+		color.Cyan("🧩 Writing fragmented MP4 (fragment per keyframe)")
+	}
 
 	// Show custom parameters if any are set
 	if customParamsSet {
@@ -265,7 +695,7 @@ func selectCodecsWithCustomParamsSecure(inputInfo *analyzer.MediaInfo, outputFor
 	}
 
 	// Fall back to original logic for automatic selection
-	return selectCodecs(inputInfo, outputFormat, preset, presetExplicit, verbose)
+	return selectCodecs(inputInfo, outputFormat, preset, presetExplicit, customParams, verbose)
 }
 
 // getPresetVideoBitrate returns video bitrate for quality presets
@@ -298,8 +728,11 @@ func getPresetAudioBitrate(preset string) string {
 
 // validateInputFile checks if the input file exists and is readable
 func validateInputFile(inputPath string) error {
+	if analyzer.IsStdinPath(inputPath) {
+		return nil
+	}
 	if _, err := os.Stat(inputPath); os.IsNotExist(err) {
-		return fmt.Errorf("input file does not exist: %s", inputPath)
+		return fmt.Errorf("input %w: %s", analyzer.ErrFileNotFound, inputPath)
 	}
 	return nil
 }
@@ -314,7 +747,7 @@ func getFormatFromPath(path string) string {
 }
 
 // selectCodecs implements automatic codec selection logic
-func selectCodecs(inputInfo *analyzer.MediaInfo, outputFormat, preset string, presetExplicit, verbose bool) (string, string, bool) {
+func selectCodecs(inputInfo *analyzer.MediaInfo, outputFormat, preset string, presetExplicit bool, customParams CustomParameters, verbose bool) (string, string, bool) {
 	// Get default codecs for the output format
 	defaultVideoCodec, defaultAudioCodec := getDefaultCodecs(outputFormat)
 
@@ -322,7 +755,7 @@ func selectCodecs(inputInfo *analyzer.MediaInfo, outputFormat, preset string, pr
 	// Use stream copy only if:
 	// 1. Formats are compatible, AND
 	// 2. User did NOT explicitly set a preset (they want speed optimization)
-	if canUseStreamCopy(inputInfo, outputFormat) && !presetExplicit {
+	if canUseStreamCopy(inputInfo, outputFormat, customParams) && !presetExplicit {
 		if verbose {
 			color.Green("✨ Input codecs are compatible with output format")
 		}
@@ -356,67 +789,59 @@ func displayCustomParameters(params CustomParameters) {
 	if params.AudioBitrate != "" {
 		fmt.Printf("   Audio Bitrate: %s\n", params.AudioBitrate)
 	}
+	if params.Target != "" {
+		fmt.Printf("   Target: %s\n", params.Target)
+	}
+	if params.Archival {
+		fmt.Println("   Archival: lossless")
+	}
+	if params.TextOverlay != "" {
+		fmt.Printf("   Text Overlay: %q (%s, %dpx)\n", params.TextOverlay, params.TextPosition, params.TextSize)
+	}
+	if params.BurnTimecode {
+		fmt.Println("   Timecode: burned in")
+	}
+	if params.PadTo != "" {
+		fmt.Printf("   Pad To: %s (%s bars)\n", params.PadTo, params.PadColor)
+	}
+	if params.LUT != "" {
+		fmt.Printf("   LUT: %s\n", params.LUT)
+	}
+	for _, region := range params.BlurRegions {
+		fmt.Printf("   Blur Region: %s\n", region)
+	}
 	if params.Resolution != "" {
 		fmt.Printf("   Resolution: %s\n", params.Resolution)
 	}
 	if params.Framerate != "" {
 		fmt.Printf("   Frame Rate: %s fps\n", params.Framerate)
 	}
-	fmt.Println()
-}
-
-// getDefaultCodecs returns the best default codecs for each format
-func getDefaultCodecs(format string) (string, string) {
-	switch format {
-	case "mp4", "mov":
-		return "libx264", "aac"
-	case "webm":
-		return "libvpx-vp9", "libopus"
-	case "mkv":
-		return "libx264", "aac"
-	case "avi":
-		return "libx264", "libmp3lame"
-	default:
-		return "libx264", "aac" // Safe defaults
+	if params.ConstantFrameRate {
+		fmt.Println("   Frame Rate Mode: constant")
 	}
-}
-
-// canUseStreamCopy checks if we can copy streams without re-encoding
-func canUseStreamCopy(inputInfo *analyzer.MediaInfo, outputFormat string) bool {
-	if len(inputInfo.VideoStreams) == 0 || len(inputInfo.AudioStreams) == 0 {
-		return false
+	if params.AudioStream != "" {
+		fmt.Printf("   Audio Stream: %s\n", params.AudioStream)
 	}
-
-	videoCodec := inputInfo.VideoStreams[0].Codec
-	audioCodec := inputInfo.AudioStreams[0].Codec
-
-	// Check codec compatibility with output format
-	switch outputFormat {
-	case "mp4", "mov":
-		return isCompatibleCodec(videoCodec, []string{"h264", "hevc"}) &&
-			isCompatibleCodec(audioCodec, []string{"aac", "mp3"})
-	case "webm":
-		return isCompatibleCodec(videoCodec, []string{"vp8", "vp9", "av1"}) &&
-			isCompatibleCodec(audioCodec, []string{"vorbis", "opus"})
-	case "mkv":
-		// MKV is very flexible, most codecs work
-		return true
-	case "avi":
-		return isCompatibleCodec(videoCodec, []string{"h264", "xvid", "divx"}) &&
-			isCompatibleCodec(audioCodec, []string{"mp3", "ac3"})
+	if params.NoAudio {
+		fmt.Println("   Audio: removed")
 	}
-
-	return false
-}
-
-// isCompatibleCodec checks if a codec is in the list of compatible codecs
-func isCompatibleCodec(codec string, compatibleCodecs []string) bool {
-	for _, compatible := range compatibleCodecs {
-		if strings.Contains(strings.ToLower(codec), compatible) {
-			return true
+	for _, track := range params.AddAudio {
+		if track.Language != "" {
+			fmt.Printf("   Added Audio: %s (%s)\n", track.Path, track.Language)
+		} else {
+			fmt.Printf("   Added Audio: %s\n", track.Path)
 		}
 	}
-	return false
+	if params.Volume != "" {
+		fmt.Printf("   Volume: %s\n", params.Volume)
+	}
+	if params.Threads > 0 {
+		fmt.Printf("   Threads: %d\n", params.Threads)
+	}
+	if len(params.ExtraArgs) > 0 {
+		fmt.Printf("   FFmpeg Args: %s\n", strings.Join(params.ExtraArgs, " "))
+	}
+	fmt.Println()
 }
 
 // applyVideoPreset applies quality settings to video codec
@@ -425,7 +850,7 @@ func applyVideoPreset(baseCodec, preset string) string {
 	// For security, we only return the base codec name
 	// Quality presets are now handled through separate validated parameters
 	switch baseCodec {
-	case "libx264", "libx265", "libvpx-vp9", "copy":
+	case "libx264", "libx265", "libvpx-vp9", "mpeg2video", "libtheora", "ffv1", "utvideo", "copy":
 		return baseCodec
 	default:
 		// Default to safe codec if unknown
@@ -439,7 +864,7 @@ func applyAudioPreset(baseCodec, preset string) string {
 	// For security, we only return the base codec name
 	// Quality presets are now handled through separate validated parameters
 	switch baseCodec {
-	case "aac", "libopus", "libmp3lame", "libvorbis", "flac", "copy":
+	case "aac", "libopus", "libmp3lame", "libvorbis", "flac", "alac", "ac3", "eac3", "pcm_s16le", "pcm_s24le", "copy":
 		return baseCodec
 	default:
 		// Default to safe codec if unknown
@@ -451,20 +876,46 @@ func applyAudioPreset(baseCodec, preset string) string {
 // This function now includes security validation to prevent command injection
 // FFmpegCommandBuilder represents a builder for constructing FFmpeg commands
 type FFmpegCommandBuilder struct {
+	ctx      context.Context // Stops the built command when cancelled
 	args     []string
 	verbose  bool
 	hasError bool
 }
 
-// NewFFmpegCommandBuilder creates a new FFmpeg command builder
-func NewFFmpegCommandBuilder(verbose bool) *FFmpegCommandBuilder {
+// NewFFmpegCommandBuilder creates a new FFmpeg command builder; the command is killed when ctx is cancelled
+func NewFFmpegCommandBuilder(ctx context.Context, verbose bool) *FFmpegCommandBuilder {
 	return &FFmpegCommandBuilder{
-		args:     []string{"ffmpeg"},
+		ctx:      ctx,
+		args:     []string{analyzer.FFmpeg(ctx)},
 		verbose:  verbose,
 		hasError: false,
 	}
 }
 
+// WithInputOptions adds demuxer options that must precede the input file
+func (b *FFmpegCommandBuilder) WithInputOptions(customParams CustomParameters) *FFmpegCommandBuilder {
+	if customParams.FixTimestamps {
+		b.args = append(b.args, "-fflags", "+genpts")
+	}
+	if customParams.Preview > 0 {
+		b.WithInputRange(customParams.PreviewAt, customParams.Preview)
+	}
+	return b
+}
+
+// WithInputRange limits the next input to a time range; seeking before the input is fast
+// and frame accurate when re-encoding
+func (b *FFmpegCommandBuilder) WithInputRange(start, duration time.Duration) *FFmpegCommandBuilder {
+	if b.hasError {
+		return b
+	}
+
+	b.args = append(b.args,
+		"-ss", strconv.FormatFloat(start.Seconds(), 'f', 3, 64),
+		"-t", strconv.FormatFloat(duration.Seconds(), 'f', 3, 64))
+	return b
+}
+
 // WithInput adds input file to the command
 func (b *FFmpegCommandBuilder) WithInput(input string) *FFmpegCommandBuilder {
 	if b.hasError {
@@ -483,6 +934,65 @@ func (b *FFmpegCommandBuilder) WithInput(input string) *FFmpegCommandBuilder {
 	return b
 }
 
+// WithAudioTrackInputs adds the added audio track files as extra inputs
+func (b *FFmpegCommandBuilder) WithAudioTrackInputs(tracks []AudioTrack) *FFmpegCommandBuilder {
+	for _, track := range tracks {
+		b.WithInput(track.Path)
+	}
+	return b
+}
+
+// WithStreamMapping adds explicit stream mapping when a specific audio stream was selected
+// or additional audio tracks are muxed in
+func (b *FFmpegCommandBuilder) WithStreamMapping(customParams CustomParameters) *FFmpegCommandBuilder {
+	if b.hasError || (customParams.AudioStream == "" && len(customParams.AddAudio) == 0 && customParams.AudioDelay == 0) {
+		return b
+	}
+
+	b.args = append(b.args, "-map", "0:v:0?")
+
+	if customParams.AudioStream != "" {
+		if err := securityPolicy.ValidateStreamNumber(customParams.AudioStream); err != nil {
+			if b.verbose {
+				color.Red("Security validation failed for audio stream: %v", err)
+			}
+			b.hasError = true
+			return b
+		}
+
+		number, _ := strconv.Atoi(customParams.AudioStream)
+		mapArg := audioStreamMapArg(number - 1)
+		if customParams.AudioDelay != 0 {
+			mapArg = fmt.Sprintf("%d:a:%d", delayedAudioInputIndex(customParams), number-1)
+		}
+		b.args = append(b.args, "-map", mapArg)
+	}
+
+	// Added tracks are inputs 1..n; use the first audio stream of each
+	for i := range customParams.AddAudio {
+		b.args = append(b.args, "-map", fmt.Sprintf("%d:a:0", i+1))
+	}
+
+	// Keep the subtitles FFmpeg's own selection would have carried over
+	for i, subtitle := range customParams.subtitleStreams {
+		b.args = append(b.args, "-map", fmt.Sprintf("0:s:%d", subtitle.position))
+		if subtitle.copy {
+			b.args = append(b.args, fmt.Sprintf("-c:s:%d", i), "copy")
+		}
+	}
+	return b
+}
+
+// WithVideoFilter adds an internally generated video filter chain to the command
+func (b *FFmpegCommandBuilder) WithVideoFilter(filter string) *FFmpegCommandBuilder {
+	if b.hasError || filter == "" {
+		return b
+	}
+
+	b.args = append(b.args, "-vf", filter)
+	return b
+}
+
 // WithVideoCodec adds video codec configuration to the command
 func (b *FFmpegCommandBuilder) WithVideoCodec(videoCodec string, customParams CustomParameters) *FFmpegCommandBuilder {
 	if b.hasError {
@@ -506,7 +1016,9 @@ func (b *FFmpegCommandBuilder) WithAudioCodec(audioCodec string, customParams Cu
 		return b
 	}
 
-	if audioCodec == "copy" {
+	if customParams.NoAudio {
+		b.args = append(b.args, "-an")
+	} else if audioCodec == "copy" {
 		b.args = append(b.args, "-c:a", "copy")
 	} else {
 		if err := b.addAudioCodecWithValidation(audioCodec, customParams); err != nil {
@@ -517,6 +1029,53 @@ func (b *FFmpegCommandBuilder) WithAudioCodec(audioCodec string, customParams Cu
 	return b
 }
 
+// WithAudioTracks adds per-stream codec overrides and language tags for added audio tracks
+func (b *FFmpegCommandBuilder) WithAudioTracks(audioCodec string, customParams CustomParameters) *FFmpegCommandBuilder {
+	if b.hasError {
+		return b
+	}
+
+	for i, track := range customParams.AddAudio {
+		outputIndex := audioTrackOutputIndex(customParams, i)
+
+		// Tracks that cannot be copied alongside copied audio get their own encoder
+		if track.codec != "" && track.codec != audioCodec {
+			if err := securityPolicy.ValidateCodec(track.codec, "audio"); err != nil {
+				if b.verbose {
+					color.Red("Security validation failed for added audio codec: %v", err)
+				}
+				b.hasError = true
+				return b
+			}
+			b.args = append(b.args, fmt.Sprintf("-c:a:%d", outputIndex), track.codec)
+
+			if customParams.AudioBitrate != "" {
+				if err := securityPolicy.ValidateBitrate(customParams.AudioBitrate); err != nil {
+					if b.verbose {
+						color.Red("Security validation failed for audio bitrate: %v", err)
+					}
+					b.hasError = true
+					return b
+				}
+				b.args = append(b.args, fmt.Sprintf("-b:a:%d", outputIndex), customParams.AudioBitrate)
+			}
+		}
+
+		if track.Language != "" {
+			if err := securityPolicy.ValidateLanguageCode(track.Language); err != nil {
+				if b.verbose {
+					color.Red("Security validation failed for added audio language: %v", err)
+				}
+				b.hasError = true
+				return b
+			}
+			b.args = append(b.args, fmt.Sprintf("-metadata:s:a:%d", outputIndex), "language="+track.Language)
+		}
+	}
+
+	return b
+}
+
 // WithCustomParameters adds additional custom parameters to the command
 func (b *FFmpegCommandBuilder) WithCustomParameters(customParams CustomParameters) *FFmpegCommandBuilder {
 	if b.hasError {
@@ -531,6 +1090,34 @@ func (b *FFmpegCommandBuilder) WithCustomParameters(customParams CustomParameter
 		}
 	}
 
+	// Grade the picture with the LUT, blur regions at the input's own coordinates, burn in the
+	// timecode so it scales with the picture, fit the video into a platform target's or
+	// --pad-to frame, then draw the text overlay onto it
+	var videoFilters []string
+	for _, filter := range []string{customParams.lutFilter, customParams.blurFilter, customParams.timecodeFilter,
+		customParams.targetFilter, customParams.padFilter, customParams.textFilter} {
+		if filter != "" {
+			videoFilters = append(videoFilters, filter)
+		}
+	}
+	if len(videoFilters) > 0 {
+		b.args = append(b.args, "-vf", strings.Join(videoFilters, ","))
+	}
+
+	// Cap the CPU usage of the encoders
+	if customParams.Threads > 0 {
+		b.args = append(b.args, "-threads", strconv.Itoa(customParams.Threads))
+	}
+
+	// Duplicate or drop frames so every frame has the same duration
+	if customParams.ConstantFrameRate {
+		if ffmpegSupports(b.ctx, fpsModeVersion) {
+			b.args = append(b.args, "-fps_mode", "cfr")
+		} else {
+			b.args = append(b.args, "-vsync", "cfr")
+		}
+	}
+
 	// Add framerate if specified
 	if customParams.Framerate != "" {
 		if err := b.addFramerateParameter(customParams.Framerate); err != nil {
@@ -539,6 +1126,64 @@ func (b *FFmpegCommandBuilder) WithCustomParameters(customParams CustomParameter
 		}
 	}
 
+	// Container defaults first, so a platform target can override them
+	b.args = append(b.args, customParams.containerArgs...)
+
+	// Pixel format, profile and rate control of a platform target
+	b.args = append(b.args, customParams.targetArgs...)
+
+	// Add volume adjustment if specified
+	if customParams.Volume != "" {
+		if err := b.addVolumeParameter(customParams.Volume); err != nil {
+			b.hasError = true
+			return b
+		}
+	}
+
+	// Shift timestamps so the output starts at zero (avoids "non-monotonous DTS" failures)
+	if customParams.FixTimestamps {
+		b.args = append(b.args, "-avoid_negative_ts", "make_zero")
+	}
+
+	// Rewrite the file after encoding so the moov atom precedes the media data
+	if customParams.WebOptimized {
+		b.args = append(b.args, "-movflags", "+faststart")
+	}
+
+	// Start a fragment at every keyframe behind an empty initial moov atom
+	if customParams.Fragmented {
+		b.args = append(b.args, "-movflags", "frag_keyframe+empty_moov")
+	}
+
+	return b
+}
+
+// WithMetadata keeps the global metadata of the main input, or drops all metadata when asked.
+// Stream metadata such as languages follows the mapped streams unless stripped.
+func (b *FFmpegCommandBuilder) WithMetadata(customParams CustomParameters) *FFmpegCommandBuilder {
+	if b.hasError {
+		return b
+	}
+
+	b.args = append(b.args, metadataArgs(customParams.StripMetadata)...)
+	return b
+}
+
+// metadataArgs maps the metadata of the first input to the output, or none of it
+func metadataArgs(strip bool) []string {
+	if strip {
+		return []string{"-map_metadata", "-1"}
+	}
+	return []string{"-map_metadata", "0"}
+}
+
+// WithExtraArgs adds user-supplied FFmpeg arguments (already validated or explicitly allowed)
+func (b *FFmpegCommandBuilder) WithExtraArgs(args []string) *FFmpegCommandBuilder {
+	if b.hasError {
+		return b
+	}
+
+	b.args = append(b.args, args...)
 	return b
 }
 
@@ -560,13 +1205,23 @@ func (b *FFmpegCommandBuilder) WithOutput(output string) *FFmpegCommandBuilder {
 	return b
 }
 
+// WithPipeOutput writes the output to stdout in the given container format
+func (b *FFmpegCommandBuilder) WithPipeOutput(format string, fragmented bool) *FFmpegCommandBuilder {
+	if b.hasError {
+		return b
+	}
+
+	b.args = append(b.args, pipeOutputArgs(format, fragmented)...)
+	return b
+}
+
 // Build creates the final exec.Cmd or returns nil if there were errors
 func (b *FFmpegCommandBuilder) Build() *exec.Cmd {
 	if b.hasError {
 		return nil
 	}
 
-	return exec.Command(b.args[0], b.args[1:]...)
+	return exec.CommandContext(b.ctx, b.args[0], b.args[1:]...)
 }
 
 // addVideoCodecWithValidation adds video codec with security validation
@@ -582,6 +1237,12 @@ func (b *FFmpegCommandBuilder) addVideoCodecWithValidation(videoCodec string, cu
 	// Only use the validated codec name - no additional parameters
 	b.args = append(b.args, "-c:v", videoCodec)
 
+	// Lossless encoders take their fixed options instead of a bitrate
+	if args, ok := losslessVideoEncoders[videoCodec]; ok {
+		b.args = append(b.args, args...)
+		return nil
+	}
+
 	// Add custom video bitrate if specified and validated
 	if customParams.VideoBitrate != "" {
 		if err := securityPolicy.ValidateBitrate(customParams.VideoBitrate); err != nil {
@@ -609,8 +1270,8 @@ func (b *FFmpegCommandBuilder) addAudioCodecWithValidation(audioCodec string, cu
 	// Only use the validated codec name - no additional parameters
 	b.args = append(b.args, "-c:a", audioCodec)
 
-	// Add custom audio bitrate if specified and validated
-	if customParams.AudioBitrate != "" {
+	// Add custom audio bitrate if specified and validated; lossless encoders have none
+	if customParams.AudioBitrate != "" && !slices.Contains(losslessAudioEncoders, audioCodec) {
 		if err := securityPolicy.ValidateBitrate(customParams.AudioBitrate); err != nil {
 			if b.verbose {
 				color.Red("Security validation failed for audio bitrate: %v", err)
@@ -647,120 +1308,189 @@ func (b *FFmpegCommandBuilder) addFramerateParameter(framerate string) error {
 	return nil
 }
 
+// addVolumeParameter adds the volume audio filter with validation
+func (b *FFmpegCommandBuilder) addVolumeParameter(volume string) error {
+	if err := securityPolicy.ValidateVolume(volume); err != nil {
+		if b.verbose {
+			color.Red("Security validation failed for volume: %v", err)
+		}
+		return err
+	}
+	b.args = append(b.args, "-af", buildVolumeFilter(volume))
+	return nil
+}
+
+// buildVolumeFilter converts a validated volume value into a volume filter expression
+func buildVolumeFilter(volume string) string {
+	// The volume filter accepts "1.5" or "3dB"; normalize the dB suffix and drop a leading plus
+	value := strings.TrimPrefix(volume, "+")
+	if strings.HasSuffix(strings.ToLower(value), "db") {
+		value = value[:len(value)-2] + "dB"
+	}
+	return "volume=" + value
+}
+
 // buildFFmpegCommandWithCustomParams constructs the FFmpeg command with custom parameters
 // This function now uses the builder pattern for improved maintainability
-func buildFFmpegCommandWithCustomParams(input, output, videoCodec, audioCodec, preset string, customParams CustomParameters, verbose bool) *exec.Cmd {
-	builder := NewFFmpegCommandBuilder(verbose)
-
-	return builder.
+func buildFFmpegCommandWithCustomParams(ctx context.Context, input, output, videoCodec, audioCodec, preset string, customParams CustomParameters, verbose bool) *exec.Cmd {
+	builder := NewFFmpegCommandBuilder(ctx, verbose).
+		WithInputOptions(customParams).
 		WithInput(input).
+		WithAudioTrackInputs(customParams.AddAudio).
+		WithDelayedAudioInput(input, customParams).
+		WithStreamMapping(customParams).
 		WithVideoCodec(videoCodec, customParams).
 		WithAudioCodec(audioCodec, customParams).
+		WithAudioTracks(audioCodec, customParams).
 		WithCustomParameters(customParams).
-		WithOutput(output).
-		Build()
+		WithMetadata(customParams).
+		WithExtraArgs(customParams.ExtraArgs)
+
+	if IsStdoutPath(output) {
+		return builder.WithPipeOutput(customParams.Container, customParams.Fragmented).Build()
+	}
+	return builder.WithOutput(output).Build()
 }
 
 // buildFFmpegCommand constructs the FFmpeg command with all parameters (legacy function)
 func buildFFmpegCommand(input, output, videoCodec, audioCodec, preset string, verbose bool) *exec.Cmd {
 	emptyParams := CustomParameters{}
-	return buildFFmpegCommandWithCustomParams(input, output, videoCodec, audioCodec, preset, emptyParams, verbose)
+	return buildFFmpegCommandWithCustomParams(context.Background(), input, output, videoCodec, audioCodec, preset, emptyParams, verbose)
 }
 
 // executeFFmpeg runs the FFmpeg command and handles output
-func executeFFmpeg(cmd *exec.Cmd, inputInfo *analyzer.MediaInfo, verbose bool) error {
-	if verbose {
+func executeFFmpeg(ctx context.Context, cmd *exec.Cmd, inputInfo *analyzer.MediaInfo, verbose bool) error {
+	if verbose && progressEvents(ctx) == nil {
 		color.Blue("🚀 Starting FFmpeg conversion...")
 		// In verbose mode, show FFmpeg output directly
+		run := startFFmpegRun(cmd)
 		cmd.Stdout = os.Stdout
-		cmd.Stderr = os.Stderr
-		return cmd.Run()
+		cmd.Stderr = run.captureStderr(os.Stderr)
+		return run.finish(cmd.Run())
 	}
 
 	// Non-verbose mode: show progress bar
-	return executeFFmpegWithProgress(cmd, inputInfo)
+	return executeFFmpegWithProgress(ctx, cmd, inputInfo)
 }
 
-// executeFFmpegWithProgress runs FFmpeg and displays a progress indicator
-// executeFFmpegWithProgress runs FFmpeg and displays a progress indicator
-func executeFFmpegWithProgress(cmd *exec.Cmd, inputInfo *analyzer.MediaInfo) error {
+// executeFFmpegWithProgress runs FFmpeg and reports its progress to the progress function and
+// JSON progress events set with WithProgress
+func executeFFmpegWithProgress(ctx context.Context, cmd *exec.Cmd, inputInfo *analyzer.MediaInfo) error {
 	// Setup progress tracking
-	progressTracker, err := initializeProgressTracking(cmd, inputInfo)
+	progressTracker, err := initializeProgressTracking(ctx, cmd, inputInfo)
 	if err != nil {
 		return err
 	}
 
+	run := startFFmpegRun(cmd)
+	cmd.Stderr = run.captureStderr(cmd.Stderr)
+
 	// Start FFmpeg process
-	if err := startFFmpegProcess(cmd, progressTracker); err != nil {
-		return err
+	if err := startFFmpegProcess(ctx, cmd, progressTracker); err != nil {
+		return run.finish(err)
 	}
 
 	// Monitor progress and wait for completion
-	return monitorFFmpegProgress(cmd, progressTracker)
+	err = run.finish(monitorFFmpegProgress(ctx, cmd, progressTracker))
+	if events := progressEvents(ctx); events != nil {
+		emitEndEvent(events, err)
+	}
+	return err
 }
 
 // ProgressTracker holds progress tracking state
 type ProgressTracker struct {
-	totalSeconds  float64
-	progressShown bool
-	stderrPipe    io.ReadCloser
-	timeRegex     *regexp.Regexp
-	speedRegex    *regexp.Regexp
+	duration     time.Duration
+	last         *Progress // Latest update, repeated as Done once FFmpeg exits
+	progressPipe io.ReadCloser
+	done         chan struct{} // Closed once all progress reports have been read
 }
 
-// initializeProgressTracking sets up progress tracking for FFmpeg execution
-func initializeProgressTracking(cmd *exec.Cmd, inputInfo *analyzer.MediaInfo) (*ProgressTracker, error) {
-	color.Blue("🚀 Starting FFmpeg conversion...")
+// progressReport is one block of FFmpeg's -progress key=value output
+type progressReport struct {
+	frame     int64         // Frames written so far
+	fps       float64       // Current encoding frame rate
+	bitrate   string        // Current output bitrate (e.g., "1523.4kbits/s"); empty when unknown
+	totalSize int64         // Bytes written so far
+	outTime   time.Duration // Output position
+	speed     float64       // Encoding speed relative to real time
+	finished  bool          // Last report of the run (progress=end)
+}
 
+// initializeProgressTracking sets up progress tracking for FFmpeg execution
+func initializeProgressTracking(ctx context.Context, cmd *exec.Cmd, inputInfo *analyzer.MediaInfo) (*ProgressTracker, error) {
 	totalSeconds := inputInfo.Duration.Seconds()
-	fmt.Printf("⏳ Processing %.1fs video...\n", totalSeconds)
+	if events := progressEvents(ctx); events != nil {
+		emitStartEvent(events, totalSeconds)
+	} else {
+		color.Blue("🚀 Starting FFmpeg conversion...")
+		if totalSeconds > 0 {
+			fmt.Printf("⏳ Processing %.1fs video...\n", totalSeconds)
+		} else {
+			fmt.Println("⏳ Processing video of unknown length...")
+		}
+	}
 
-	// Add progress reporting to stderr using -stats_period
-	newArgs := make([]string, 0, len(cmd.Args)+2)
-	newArgs = append(newArgs, cmd.Args[0])            // ffmpeg
-	newArgs = append(newArgs, "-stats_period", "0.2") // Update stats every 0.2 seconds
-	newArgs = append(newArgs, cmd.Args[1:]...)        // Rest of arguments
+	// Report progress as key=value lines on stdout instead of the human-readable stats
+	// line on stderr, whose format changes between versions
+	progressArgs := []string{"-progress", "pipe:1", "-nostats"}
+	if ffmpegSupports(ctx, statsPeriodVersion) {
+		// Older versions report every 0.5 seconds and reject the option
+		progressArgs = append(progressArgs, "-stats_period", "0.2") // Update progress every 0.2 seconds
+	}
+	newArgs := make([]string, 0, len(cmd.Args)+len(progressArgs))
+	newArgs = append(newArgs, cmd.Args[0])     // ffmpeg
+	newArgs = append(newArgs, progressArgs...) // Progress reporting
+	newArgs = append(newArgs, cmd.Args[1:]...) // Rest of arguments
 	cmd.Args = newArgs
 
-	// Create pipes for stderr (stats)
-	stderrPipe, err := cmd.StderrPipe()
+	// Create pipe for the progress reports
+	progressPipe, err := cmd.StdoutPipe()
 	if err != nil {
-		return nil, fmt.Errorf("failed to create stderr pipe: %w", err)
+		return nil, fmt.Errorf("failed to create stdout pipe: %w", err)
 	}
 
-	// Suppress stdout in non-verbose mode
-	cmd.Stdout = nil
+	// Suppress FFmpeg's log output in non-verbose mode
+	cmd.Stderr = nil
 
 	return &ProgressTracker{
-		totalSeconds:  totalSeconds,
-		progressShown: false,
-		stderrPipe:    stderrPipe,
-		timeRegex:     regexp.MustCompile(`time=(\d{2}):(\d{2}):(\d{2})\.(\d{2})`),
-		speedRegex:    regexp.MustCompile(`speed=\s*([0-9.]+)x`),
+		duration:     inputInfo.Duration,
+		progressPipe: progressPipe,
+		done:         make(chan struct{}),
 	}, nil
 }
 
 // startFFmpegProcess starts the FFmpeg process and begins progress monitoring
-func startFFmpegProcess(cmd *exec.Cmd, tracker *ProgressTracker) error {
+func startFFmpegProcess(ctx context.Context, cmd *exec.Cmd, tracker *ProgressTracker) error {
 	// Start the command
 	if err := cmd.Start(); err != nil {
 		return fmt.Errorf("failed to start ffmpeg: %w", err)
 	}
 
 	// Start progress monitoring goroutine
-	go parseFFmpegProgressOutput(tracker)
+	go func() {
+		defer close(tracker.done)
+		readProgressReports(tracker.progressPipe, func(report progressReport) {
+			progress := progressFromReport(report, tracker.duration)
+			tracker.last = &progress
+			reportProgress(ctx, progress)
+		})
+	}()
 
 	return nil
 }
 
 // monitorFFmpegProgress waits for FFmpeg completion and handles cleanup
-func monitorFFmpegProgress(cmd *exec.Cmd, tracker *ProgressTracker) error {
+func monitorFFmpegProgress(ctx context.Context, cmd *exec.Cmd, tracker *ProgressTracker) error {
+	// Read every report before Wait closes the pipe
+	<-tracker.done
+
 	// Wait for command to complete
 	err := cmd.Wait()
 
-	// Clear the progress line if we showed any
-	if tracker.progressShown {
-		fmt.Printf("\r%s\r", strings.Repeat(" ", 100))
+	if tracker.last != nil {
+		tracker.last.Done = true
+		reportProgress(ctx, *tracker.last)
 	}
 
 	if err != nil {
@@ -770,22 +1500,40 @@ func monitorFFmpegProgress(cmd *exec.Cmd, tracker *ProgressTracker) error {
 	return nil
 }
 
-// parseFFmpegProgressOutput parses FFmpeg stats output for progress information
-func parseFFmpegProgressOutput(tracker *ProgressTracker) {
-	scanner := bufio.NewScanner(tracker.stderrPipe)
+// readProgressReports parses FFmpeg's -progress output and calls report at the end of every block
+func readProgressReports(r io.Reader, report func(progressReport)) {
+	var current progressReport
+	scanner := bufio.NewScanner(r)
 
 	for scanner.Scan() {
-		line := scanner.Text()
-
-		// Parse time progress
-		if matches := tracker.timeRegex.FindStringSubmatch(line); len(matches) > 4 {
-			currentSeconds := parseTimeFromMatches(matches)
-			progressPercent := calculateProgressPercent(currentSeconds, tracker.totalSeconds)
-			speed := parseSpeedFromLine(line, tracker.speedRegex)
-			eta := calculateETA(speed, currentSeconds, tracker.totalSeconds)
-
-			displayProgressBar(progressPercent, speed, eta)
-			tracker.progressShown = true
+		key, value, ok := strings.Cut(strings.TrimSpace(scanner.Text()), "=")
+		if !ok {
+			continue
+		}
+		value = strings.TrimSpace(value)
+
+		switch key {
+		case "frame":
+			current.frame, _ = strconv.ParseInt(value, 10, 64)
+		case "fps":
+			current.fps, _ = strconv.ParseFloat(value, 64)
+		case "bitrate":
+			if value == "N/A" {
+				value = ""
+			}
+			current.bitrate = value
+		case "total_size":
+			current.totalSize, _ = strconv.ParseInt(value, 10, 64)
+		case "out_time_us", "out_time_ms":
+			// Both are in microseconds; out_time_ms is the older, misnamed key
+			if us, err := strconv.ParseInt(value, 10, 64); err == nil && us > 0 {
+				current.outTime = time.Duration(us) * time.Microsecond
+			}
+		case "speed":
+			current.speed, _ = strconv.ParseFloat(strings.TrimSuffix(value, "x"), 64)
+		case "progress":
+			current.finished = value == "end"
+			report(current)
 		}
 	}
 }
@@ -809,34 +1557,6 @@ func calculateProgressPercent(currentSeconds, totalSeconds float64) float64 {
 	return progressPercent
 }
 
-// parseSpeedFromLine extracts speed information from FFmpeg output line
-func parseSpeedFromLine(line string, speedRegex *regexp.Regexp) float64 {
-	speed := 0.0
-	if speedMatches := speedRegex.FindStringSubmatch(line); len(speedMatches) > 1 {
-		speed, _ = strconv.ParseFloat(speedMatches[1], 64)
-	}
-	return speed
-}
-
-// calculateETA calculates estimated time of arrival
-func calculateETA(speed, currentSeconds, totalSeconds float64) string {
-	eta := ""
-	if speed > 0 && currentSeconds < totalSeconds {
-		remainingSeconds := (totalSeconds - currentSeconds) / speed
-		eta = fmt.Sprintf(" (ETA: %s)", formatDuration(time.Duration(remainingSeconds)*time.Second))
-	}
-	return eta
-}
-
-// displayProgressBar renders the progress bar
-func displayProgressBar(progressPercent, speed float64, eta string) {
-	barWidth := 30
-	filled := int((progressPercent / 100) * float64(barWidth))
-	bar := strings.Repeat("█", filled) + strings.Repeat("░", barWidth-filled)
-
-	fmt.Printf("\r📊 [%s] %.1f%% - %.1fx speed%s", bar, progressPercent, speed, eta)
-}
-
 // formatDuration formats a duration into a human-readable string
 func formatDuration(d time.Duration) string {
 	if d < time.Minute {
@@ -849,20 +1569,28 @@ func formatDuration(d time.Duration) string {
 }
 
 // ExtractAudio extracts audio from a video file with specified parameters
-func ExtractAudio(params AudioExtractionParams) error {
+func ExtractAudio(ctx context.Context, params AudioExtractionParams) error {
 	// Step 1: Validate all parameters
 	if err := validateAudioExtractionParams(params); err != nil {
 		return err
 	}
 
 	// Step 2: Analyze input media
-	mediaInfo, err := analyzeInputForAudioExtraction(params)
+	mediaInfo, err := analyzeInputForAudioExtraction(ctx, params)
 	if err != nil {
-		return err
+		return stoppedBy(ctx, err)
+	}
+	if params.InputDuration > 0 {
+		mediaInfo.Duration = params.InputDuration
+	}
+	if params.End > 0 {
+		mediaInfo.Duration = params.End - params.Start
+	} else if params.Start > 0 && mediaInfo.Duration > params.Start {
+		mediaInfo.Duration -= params.Start
 	}
 
 	// Step 3: Select codec and build command
-	codec, command, err := prepareAudioExtractionCommand(params, mediaInfo)
+	codec, command, err := prepareAudioExtractionCommand(ctx, params, mediaInfo)
 	if err != nil {
 		return err
 	}
@@ -873,7 +1601,7 @@ func ExtractAudio(params AudioExtractionParams) error {
 	}
 
 	// Step 5: Execute extraction
-	return executeAudioExtraction(params, command, mediaInfo)
+	return stoppedBy(ctx, executeAudioExtraction(ctx, params, command, mediaInfo))
 }
 
 // validateAudioExtractionParams performs comprehensive validation of audio extraction parameters
@@ -898,6 +1626,11 @@ func validateAudioExtractionPaths(params AudioExtractionParams) error {
 		return fmt.Errorf("security validation failed for input path: %w", err)
 	}
 
+	// Output written to stdout has no path, only a container
+	if IsStdoutPath(params.OutputFile) {
+		return validatePipeContainer(params.Container)
+	}
+
 	if err := securityPolicy.ValidateFilePath(params.OutputFile); err != nil {
 		return fmt.Errorf("security validation failed for output path: %w", err)
 	}
@@ -933,16 +1666,57 @@ func validateAudioExtractionAudioParams(params AudioExtractionParams) error {
 		}
 	}
 
-	return nil
+	if params.ChannelMap != "" {
+		if _, err := ParseChannelMap(params.ChannelMap); err != nil {
+			return err
+		}
+		if params.Channels != "" {
+			return fmt.Errorf("a channel map sets the channels itself and cannot be combined with a channel count")
+		}
+	}
+
+	if params.Resampler != "" && !slices.Contains(Resamplers, params.Resampler) {
+		return fmt.Errorf("invalid resampler: %s (valid: %s)", params.Resampler, strings.Join(Resamplers, ", "))
+	}
+
+	if params.SampleFormat != "" && !slices.Contains(SampleFormats, params.SampleFormat) {
+		return fmt.Errorf("invalid sample format: %s (valid: %s)", params.SampleFormat, strings.Join(SampleFormats, ", "))
+	}
+
+	if err := securityPolicy.ValidateVolume(params.Volume); err != nil {
+		return fmt.Errorf("security validation failed for volume: %w", err)
+	}
+
+	if params.Volume != "" && params.Codec == "copy" {
+		return fmt.Errorf("volume adjustment requires audio re-encoding and cannot be used with codec 'copy'")
+	}
+
+	if params.ChannelMap != "" && params.Codec == "copy" {
+		return fmt.Errorf("channel mapping requires audio re-encoding and cannot be used with codec 'copy'")
+	}
+
+	if err := securityPolicy.ValidateThreads(params.Threads); err != nil {
+		return fmt.Errorf("security validation failed for threads: %w", err)
+	}
+
+	if params.Start < 0 || (params.End > 0 && params.End <= params.Start) {
+		return fmt.Errorf("invalid range: %s to %s", params.Start, params.End)
+	}
+
+	if err := validateCoverArt(params); err != nil {
+		return err
+	}
+
+	return validateStreamSelection(params.Stream, params.Language)
 }
 
 // analyzeInputForAudioExtraction analyzes the input media and validates audio streams
-func analyzeInputForAudioExtraction(params AudioExtractionParams) (*analyzer.MediaInfo, error) {
+func analyzeInputForAudioExtraction(ctx context.Context, params AudioExtractionParams) (*analyzer.MediaInfo, error) {
 	if params.Verbose {
 		color.Cyan("🔍 Analyzing input media...")
 	}
 
-	mediaInfo, err := analyzer.AnalyzeMedia(params.InputFile)
+	mediaInfo, err := analyzer.AnalyzeMedia(ctx, params.InputFile)
 	if err != nil {
 		return nil, fmt.Errorf("failed to analyze input media: %w", err)
 	}
@@ -956,16 +1730,58 @@ func analyzeInputForAudioExtraction(params AudioExtractionParams) (*analyzer.Med
 }
 
 // prepareAudioExtractionCommand selects codec and builds the FFmpeg command
-func prepareAudioExtractionCommand(params AudioExtractionParams, mediaInfo *analyzer.MediaInfo) (string, []string, error) {
+func prepareAudioExtractionCommand(ctx context.Context, params AudioExtractionParams, mediaInfo *analyzer.MediaInfo) (string, []string, error) {
 	// Determine output format and codec
-	outputExt := strings.ToLower(filepath.Ext(params.OutputFile))
+	outputExt := "." + resolveOutputFormat(params.OutputFile, params.Container)
 	codec, err := selectAudioCodec(outputExt, params.Codec)
 	if err != nil {
 		return "", nil, err
 	}
+	// The sample format of WAV output is the PCM encoder itself
+	if outputExt == ".wav" && params.Codec == "" && params.SampleFormat != "" {
+		codec = wavSampleCodecs[params.SampleFormat]
+	}
+	codec, err = resolveEncoder(ctx, codec, strings.TrimPrefix(outputExt, "."), params.Verbose)
+	if err != nil {
+		return "", nil, err
+	}
+
+	// Resolve the requested audio stream (by number or language) against the input
+	audioPosition, err := resolveAudioStreamSelection(mediaInfo, params.Stream, params.Language)
+	if err != nil {
+		return "", nil, err
+	}
+	if audioPosition >= 0 {
+		params.Stream = strconv.Itoa(audioPosition + 1)
+	}
+
+	if params.ChannelMap != "" {
+		if err := checkChannelMapSource(params.ChannelMap, mediaInfo, max(audioPosition, 0)); err != nil {
+			return "", nil, err
+		}
+	}
+
+	if params.CopyMatching && canCopyAudio(params, codec, mediaInfo, max(audioPosition, 0)) {
+		codec = "copy"
+	}
+	if outputExt == ".dts" {
+		if err := validateDTSPassthrough(params, codec, mediaInfo, max(audioPosition, 0)); err != nil {
+			return "", nil, err
+		}
+	}
+	if _, err := sampleFormatArgs(codec, params.SampleFormat); err != nil {
+		return "", nil, err
+	}
+
+	// Copied audio keeps its sample rate, so there is nothing to resample
+	if codec == "copy" {
+		params.Resampler = ""
+	} else if params.Resampler, err = resolveResampler(ctx, params.Resampler, params.SampleRate); err != nil {
+		return "", nil, err
+	}
 
 	// Build FFmpeg command with security validation
-	command := buildAudioExtractionCommandSecure(params, codec, mediaInfo)
+	command := buildAudioExtractionCommandSecure(ctx, params, codec, mediaInfo)
 	if command == nil {
 		return "", nil, fmt.Errorf("failed to build secure audio extraction command")
 	}
@@ -975,7 +1791,7 @@ func prepareAudioExtractionCommand(params AudioExtractionParams, mediaInfo *anal
 
 // displayAudioExtractionInfo shows extraction information in verbose mode
 func displayAudioExtractionInfo(params AudioExtractionParams, codec string, command []string) {
-	outputExt := strings.ToLower(filepath.Ext(params.OutputFile))
+	outputExt := "." + resolveOutputFormat(params.OutputFile, params.Container)
 
 	fmt.Printf("🎵 Extracting audio to %s format\n", strings.TrimPrefix(outputExt, "."))
 	fmt.Printf("🔧 Using codec: %s\n", codec)
@@ -993,22 +1809,29 @@ func displayAudioExtractionInfo(params AudioExtractionParams, codec string, comm
 }
 
 // executeAudioExtraction executes the audio extraction command
-func executeAudioExtraction(params AudioExtractionParams, command []string, mediaInfo *analyzer.MediaInfo) error {
+func executeAudioExtraction(ctx context.Context, params AudioExtractionParams, command []string, mediaInfo *analyzer.MediaInfo) error {
 	if params.Verbose {
 		color.Green("🚀 Starting audio extraction...")
 	}
 
-	cmd := exec.Command(command[0], command[1:]...)
+	cmd := exec.CommandContext(ctx, command[0], command[1:]...)
+	if analyzer.IsStdinPath(params.InputFile) {
+		cmd.Stdin = analyzer.StdinReader()
+	}
+	if IsStdoutPath(params.OutputFile) {
+		return runFFmpegToStdout(cmd)
+	}
 
 	var err error
 	if params.Verbose {
 		// For verbose mode, show real-time progress
-		err = executeFFmpegWithProgress(cmd, mediaInfo)
+		err = executeFFmpegWithProgress(ctx, cmd, mediaInfo)
 	} else {
 		// For quiet mode, just run and wait
+		run := startFFmpegRun(cmd)
 		output, cmdErr := cmd.CombinedOutput()
-		if cmdErr != nil {
-			err = fmt.Errorf("audio extraction failed: %w\nOutput: %s", cmdErr, string(output))
+		if cmdErr = run.finishWithOutput(output, cmdErr); cmdErr != nil {
+			err = fmt.Errorf("audio extraction failed: %w", cmdErr)
 		}
 	}
 
@@ -1035,7 +1858,7 @@ func selectAudioCodec(outputExt, customCodec string) (string, error) {
 	switch outputExt {
 	case ".mp3":
 		return "libmp3lame", nil
-	case ".aac", ".m4a":
+	case ".aac", ".adts", ".m4a":
 		return "aac", nil
 	case ".wav":
 		return "pcm_s16le", nil
@@ -1043,14 +1866,23 @@ func selectAudioCodec(outputExt, customCodec string) (string, error) {
 		return "flac", nil
 	case ".ogg":
 		return "libvorbis", nil
+	case ".opus":
+		return "libopus", nil
+	case ".ac3":
+		return "ac3", nil
+	case ".eac3":
+		return "eac3", nil
+	case ".dts":
+		// There is no usable DTS encoder; DTS audio is only passed through
+		return "copy", nil
 	default:
 		return "", fmt.Errorf("unsupported output format: %s", outputExt)
 	}
 }
 
 // buildAudioExtractionCommand builds the FFmpeg command for audio extraction
-func buildAudioExtractionCommand(params AudioExtractionParams, codec string, mediaInfo *analyzer.MediaInfo) []string {
-	command := []string{"ffmpeg", "-i", params.InputFile}
+func buildAudioExtractionCommand(ctx context.Context, params AudioExtractionParams, codec string, mediaInfo *analyzer.MediaInfo) []string {
+	command := []string{analyzer.FFmpeg(ctx), "-i", params.InputFile}
 
 	// Disable video stream
 	command = append(command, "-vn")
@@ -1215,11 +2047,40 @@ func validateChannels(channels string) error {
 }
 
 // buildAudioExtractionCommandSecure builds the FFmpeg command for audio extraction with security validation
-func buildAudioExtractionCommandSecure(params AudioExtractionParams, codec string, mediaInfo *analyzer.MediaInfo) []string {
-	command := []string{"ffmpeg", "-i", params.InputFile}
+func buildAudioExtractionCommandSecure(ctx context.Context, params AudioExtractionParams, codec string, mediaInfo *analyzer.MediaInfo) []string {
+	command := []string{analyzer.FFmpeg(ctx)}
 
-	// Disable video stream
-	command = append(command, "-vn")
+	// Seek on the input; for audio this is sample-accurate
+	if params.Start > 0 {
+		command = append(command, "-ss", strconv.FormatFloat(params.Start.Seconds(), 'f', 3, 64))
+	}
+	command = append(command, "-i", params.InputFile)
+	// The cover is a second input (already validated)
+	if params.Cover != "" {
+		command = append(command, "-i", params.Cover)
+	}
+	if params.End > 0 {
+		command = append(command, "-t", strconv.FormatFloat((params.End-params.Start).Seconds(), 'f', 3, 64))
+	}
+
+	// Select a specific audio stream if requested (already resolved and validated); with a
+	// cover the streams are always mapped explicitly
+	position := 0
+	if params.Stream != "" {
+		number, _ := strconv.Atoi(params.Stream)
+		position = number - 1
+	}
+	if params.Stream != "" || params.Cover != "" {
+		command = append(command, "-map", audioStreamMapArg(position))
+	}
+
+	format := resolveOutputFormat(params.OutputFile, params.Container)
+	if params.Cover != "" {
+		command = append(command, coverArtArgs(format)...)
+	} else {
+		// Disable video stream
+		command = append(command, "-vn")
+	}
 
 	// Set audio codec (already validated)
 	command = append(command, "-c:a", codec)
@@ -1227,9 +2088,9 @@ func buildAudioExtractionCommandSecure(params AudioExtractionParams, codec strin
 	// Set bitrate (custom or from quality preset) - already validated
 	if params.Bitrate != "" {
 		command = append(command, "-b:a", params.Bitrate)
-	} else {
+	} else if codec != "copy" {
 		// Apply quality preset bitrates
-		bitrate := getQualityBitrate(params.Quality)
+		bitrate := audioBitrate(codec, params.Quality)
 		if bitrate != "" {
 			command = append(command, "-b:a", bitrate)
 		}
@@ -1245,6 +2106,31 @@ func buildAudioExtractionCommandSecure(params AudioExtractionParams, codec strin
 		command = append(command, "-ac", params.Channels)
 	}
 
+	// Set the sample format of lossless encoders (already checked against the codec)
+	sampleArgs, _ := sampleFormatArgs(codec, params.SampleFormat)
+	command = append(command, sampleArgs...)
+
+	// Set the channel map, volume adjustment and resampler if specified (already validated)
+	var filters []string
+	if params.ChannelMap != "" {
+		channels, _ := ParseChannelMap(params.ChannelMap)
+		filters = append(filters, channelMapFilter(channels))
+	}
+	if params.Volume != "" {
+		filters = append(filters, buildVolumeFilter(params.Volume))
+	}
+	if params.Resampler != "" {
+		filters = append(filters, resampleFilter(params.Resampler, params.SampleRate))
+	}
+	if len(filters) > 0 {
+		command = append(command, "-af", strings.Join(filters, ","))
+	}
+
+	// Cap the CPU usage of the encoder (already validated)
+	if params.Threads > 0 {
+		command = append(command, "-threads", strconv.Itoa(params.Threads))
+	}
+
 	// Set additional codec-specific options (safe, predefined values only)
 	switch codec {
 	case "libmp3lame":
@@ -1265,7 +2151,13 @@ func buildAudioExtractionCommandSecure(params AudioExtractionParams, codec strin
 		}
 	}
 
+	// Keep the tags of the input
+	command = append(command, audioMetadataArgs(format, mediaInfo, position, params.Tags, params.StripMetadata)...)
+
 	// Output file (overwrite without asking) - already validated
+	if IsStdoutPath(params.OutputFile) {
+		return append(command, pipeOutputArgs(params.Container, false)...)
+	}
 	command = append(command, "-y", params.OutputFile)
 
 	return command
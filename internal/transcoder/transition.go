@@ -0,0 +1,161 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// MergeTransitions maps the transitions merge can put between clips to xfade transitions
+var MergeTransitions = map[string]string{
+	"crossfade":  "fade",
+	"fadeblack":  "fadeblack",
+	"fadewhite":  "fadewhite",
+	"dissolve":   "dissolve",
+	"wipeleft":   "wipeleft",
+	"wiperight":  "wiperight",
+	"slideleft":  "slideleft",
+	"slideright": "slideright",
+}
+
+// MergeTransitionNames lists the transitions in a stable order for help and error messages
+func MergeTransitionNames() []string {
+	names := make([]string, 0, len(MergeTransitions))
+	for name := range MergeTransitions {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// validateMergeTransition validates the transition and its duration
+func validateMergeTransition(ctx context.Context, params MergeParams) error {
+	if _, ok := MergeTransitions[params.Transition]; !ok {
+		return fmt.Errorf("invalid transition '%s'. Valid options: %s",
+			params.Transition, strings.Join(MergeTransitionNames(), ", "))
+	}
+	if params.TransitionDuration <= 0 {
+		return fmt.Errorf("invalid transition duration: %s", params.TransitionDuration)
+	}
+	return checkFilterAvailable(ctx, "xfade")
+}
+
+// mergeWithTransitions joins the inputs in one encode, blending each clip into the next with
+// xfade and acrossfade. Each transition overlaps the end of a clip with the start of the next,
+// so the output is shorter than the inputs together by one transition per join.
+func mergeWithTransitions(ctx context.Context, params MergeParams, infos []*analyzer.MediaInfo,
+	outputFormat string, report *MergeReport) (*MergeReport, error) {
+
+	if err := checkTransitionLengths(params, infos); err != nil {
+		return nil, err
+	}
+
+	report.Reencoded = true
+	report.Duration -= time.Duration(len(infos)-1) * params.TransitionDuration
+
+	target := planMergeTarget(infos, outputFormat)
+	cmd := buildTransitionCommand(ctx, params, infos, target, outputFormat)
+	if params.Verbose {
+		color.Cyan("🎞️  %s transitions of %s, %dx%d, %s fps, %s/%s", params.Transition, params.TransitionDuration,
+			target.width, target.height, target.frameRate, target.videoCodec, target.audioCodec)
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	joinedInfo := *infos[0]
+	joinedInfo.Duration = report.Duration
+	if err := executeFFmpeg(ctx, cmd, &joinedInfo, params.Verbose); err != nil {
+		return nil, stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+	return report, nil
+}
+
+// checkTransitionLengths makes sure every clip outlasts its transitions: the first and last
+// clips overlap one transition, the clips between them two
+func checkTransitionLengths(params MergeParams, infos []*analyzer.MediaInfo) error {
+	for i, info := range infos {
+		needed := 2 * params.TransitionDuration
+		if i == 0 || i == len(infos)-1 {
+			needed = params.TransitionDuration
+		}
+		if info.Duration <= needed {
+			return fmt.Errorf("%s (%s) is too short for %s transitions", params.InputFiles[i],
+				formatDuration(info.Duration), params.TransitionDuration)
+		}
+	}
+	return nil
+}
+
+// buildTransitionCommand normalizes every input inside one filter graph and chains them with
+// xfade and acrossfade. The offset of each xfade is where the next clip starts fading in on the
+// timeline built so far.
+func buildTransitionCommand(ctx context.Context, params MergeParams, infos []*analyzer.MediaInfo,
+	target mergeTarget, outputFormat string) *exec.Cmd {
+
+	graph := NewFilterGraph()
+	duration := strconv.FormatFloat(params.TransitionDuration.Seconds(), 'f', 3, 64)
+	layout := channelLayoutName(target.channels)
+
+	args := make([]string, 0, 2*len(infos)+16)
+	for i, input := range params.InputFiles {
+		args = append(args, "-i", input)
+
+		graph.Add([]string{fmt.Sprintf("%d:v:0", i)}, target.videoFilter()+",settb=AVTB,setpts=PTS-STARTPTS",
+			[]string{fmt.Sprintf("v%d", i)})
+		if target.channels == 0 {
+			continue
+		}
+
+		audioFormat := fmt.Sprintf("aresample=%d,aformat=sample_fmts=fltp:channel_layouts=%s,asetpts=PTS-STARTPTS",
+			mergeSampleRate, layout)
+		if len(infos[i].AudioStreams) > 0 {
+			graph.Add([]string{fmt.Sprintf("%d:a:0", i)}, audioFormat, []string{fmt.Sprintf("a%d", i)})
+		} else {
+			// Silence as long as the clip, so the audio stays in step with the video
+			graph.Add(nil, fmt.Sprintf("anullsrc=r=%d:cl=%s,atrim=duration=%.3f,%s",
+				mergeSampleRate, layout, infos[i].Duration.Seconds(), audioFormat), []string{fmt.Sprintf("a%d", i)})
+		}
+	}
+
+	transition := MergeTransitions[params.Transition]
+	videoOut, audioOut := "v0", "a0"
+	var offset time.Duration
+	for i := 1; i < len(infos); i++ {
+		offset += infos[i-1].Duration - params.TransitionDuration
+		videoNext, audioNext := fmt.Sprintf("vx%d", i), fmt.Sprintf("ax%d", i)
+
+		graph.Add([]string{videoOut, fmt.Sprintf("v%d", i)},
+			fmt.Sprintf("xfade=transition=%s:duration=%s:offset=%.3f", transition, duration, offset.Seconds()),
+			[]string{videoNext})
+		videoOut = videoNext
+
+		if target.channels > 0 {
+			graph.Add([]string{audioOut, fmt.Sprintf("a%d", i)}, "acrossfade=d="+duration, []string{audioNext})
+			audioOut = audioNext
+		}
+	}
+
+	args = append(args,
+		"-filter_complex", graph.String(),
+		"-map", "["+videoOut+"]",
+		"-c:v", target.videoCodec,
+		"-b:v", getPresetVideoBitrate(params.Preset))
+	if target.channels > 0 {
+		args = append(args,
+			"-map", "["+audioOut+"]",
+			"-c:a", target.audioCodec,
+			"-b:a", getPresetAudioBitrate(params.Preset))
+	}
+	if outputFormat == "mp4" || outputFormat == "mov" {
+		args = append(args, "-movflags", "+faststart")
+	}
+
+	args = append(args, "-y", params.OutputFile)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
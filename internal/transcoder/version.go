@@ -0,0 +1,164 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+// FFmpegVersion is the release version of the local ffmpeg build
+type FFmpegVersion struct {
+	Major       int    // Major release number (e.g., 4 for 4.4.2)
+	Minor       int    // Minor release number (e.g., 4 for 4.4.2)
+	Raw         string // Version as reported by ffmpeg -version (e.g., "4.4.2-0ubuntu0.22.04.1")
+	Development bool   // Git snapshot (e.g., "N-113684-g..."), newer than every release
+}
+
+// Options that need a newer FFmpeg than some distributions still ship
+var (
+	statsPeriodVersion      = [2]int{4, 4} // -stats_period
+	fpsModeVersion          = [2]int{5, 1} // -fps_mode, which replaces the deprecated -vsync
+	forceDivisibleByVersion = [2]int{4, 2} // force_divisible_by option of the scale filter
+	segDurationVersion      = [2]int{4, 1} // -seg_duration of the DASH muxer, which replaces -min_seg_duration
+	varStreamMapVersion     = [2]int{4, 0} // -var_stream_map and -master_pl_name of the HLS muxer
+	avifMuxerVersion        = [2]int{6, 0} // AVIF muxer for animated AVIF output
+)
+
+// releaseVersionRegex matches release versions such as "4.4.2-0ubuntu0.22.04.1", "n6.1.1" and "6.0-static"
+var releaseVersionRegex = regexp.MustCompile(`^n?(\d+)\.(\d+)`)
+
+// buildQueries caches what an ffmpeg executable reports about itself, such as its version or
+// encoders, once for each executable, since callers may run different builds side by side
+type buildQueries[T any] struct {
+	mu      sync.Mutex
+	results map[string]buildQueryResult[T]
+}
+
+// buildQueryResult is the cached outcome of one query
+type buildQueryResult[T any] struct {
+	value T
+	err   error
+}
+
+// get returns the result for the ffmpeg executable of ctx, running query with it on first use.
+// A query stopped by cancelling ctx is not cached, so the next caller runs it again.
+func (q *buildQueries[T]) get(ctx context.Context, query func(ffmpeg string) (T, error)) (T, error) {
+	ffmpeg := analyzer.FFmpeg(ctx)
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if result, ok := q.results[ffmpeg]; ok {
+		return result.value, result.err
+	}
+	value, err := query(ffmpeg)
+	if ctx.Err() == nil {
+		if q.results == nil {
+			q.results = make(map[string]buildQueryResult[T])
+		}
+		q.results[ffmpeg] = buildQueryResult[T]{value: value, err: err}
+	}
+	return value, err
+}
+
+// versionInfo is the parsed version and full `ffmpeg -version` output of a build
+type versionInfo struct {
+	version FFmpegVersion
+	output  string
+}
+
+// versions caches the version of every ffmpeg executable used
+var versions buildQueries[versionInfo]
+
+// queryVersion returns the version and `ffmpeg -version` output of the ffmpeg build of ctx
+func queryVersion(ctx context.Context) (versionInfo, error) {
+	return versions.get(ctx, func(ffmpeg string) (versionInfo, error) {
+		out, err := exec.CommandContext(ctx, ffmpeg, "-version").Output()
+		if err != nil {
+			return versionInfo{}, fmt.Errorf("querying ffmpeg version: %w", err)
+		}
+		version, err := parseFFmpegVersion(string(out))
+		return versionInfo{version: version, output: string(out)}, err
+	})
+}
+
+// DetectFFmpegVersion returns the version of the local ffmpeg build
+func DetectFFmpegVersion(ctx context.Context) (FFmpegVersion, error) {
+	info, err := queryVersion(ctx)
+	return info.version, err
+}
+
+// parseFFmpegVersion reads the version from the first line of `ffmpeg -version` output
+func parseFFmpegVersion(output string) (FFmpegVersion, error) {
+	firstLine, _, _ := strings.Cut(output, "\n")
+	fields := strings.Fields(firstLine)
+	if len(fields) < 3 || fields[0] != "ffmpeg" || fields[1] != "version" {
+		return FFmpegVersion{}, fmt.Errorf("unrecognized ffmpeg -version output: %q", firstLine)
+	}
+	raw := fields[2]
+
+	if matches := releaseVersionRegex.FindStringSubmatch(raw); matches != nil {
+		major, _ := strconv.Atoi(matches[1])
+		minor, _ := strconv.Atoi(matches[2])
+		return FFmpegVersion{Major: major, Minor: minor, Raw: raw}, nil
+	}
+
+	// Snapshot builds are named after the commit (e.g., "N-113684-g9d2e1f3", "2024-02-26-git-a3ca4beeaa")
+	if strings.HasPrefix(raw, "N-") || strings.Contains(raw, "git") {
+		return FFmpegVersion{Raw: raw, Development: true}, nil
+	}
+
+	return FFmpegVersion{}, fmt.Errorf("unrecognized ffmpeg version: %s", raw)
+}
+
+// AtLeast reports whether the version is the given release or newer
+func (v FFmpegVersion) AtLeast(major, minor int) bool {
+	if v.Development {
+		return true
+	}
+	return v.Major > major || (v.Major == major && v.Minor >= minor)
+}
+
+// String returns the version as reported by ffmpeg
+func (v FFmpegVersion) String() string {
+	return v.Raw
+}
+
+// ffmpegSupports reports whether the local ffmpeg is at least the given release. When the
+// version cannot be determined the option is assumed to be supported.
+func ffmpegSupports(ctx context.Context, required [2]int) bool {
+	v, err := DetectFFmpegVersion(ctx)
+	if err != nil {
+		return true
+	}
+	return v.AtLeast(required[0], required[1])
+}
+
+// ffmpegBuiltWith reports whether the local ffmpeg was configured with an option such as
+// "--enable-libsoxr", as listed on the configuration line of `ffmpeg -version`
+func ffmpegBuiltWith(ctx context.Context, option string) bool {
+	info, _ := queryVersion(ctx)
+	for _, line := range strings.Split(info.output, "\n") {
+		if configuration, ok := strings.CutPrefix(strings.TrimSpace(line), "configuration:"); ok {
+			return slices.Contains(strings.Fields(configuration), option)
+		}
+	}
+	return false
+}
+
+// warnUnsupportedFeature warns in verbose mode when a requested feature needs a newer ffmpeg
+func warnUnsupportedFeature(ctx context.Context, feature string, required [2]int, verbose bool) {
+	if !verbose || ffmpegSupports(ctx, required) {
+		return
+	}
+	v, _ := DetectFFmpegVersion(ctx)
+	color.Yellow("⚠️  %s needs FFmpeg %d.%d or newer (found %s); the conversion may fail",
+		feature, required[0], required[1], v)
+}
@@ -0,0 +1,135 @@
+package transcoder
+
+import (
+	"context"
+	"testing"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+func TestBuildQueriesPerExecutable(t *testing.T) {
+	var queries buildQueries[string]
+	runs := 0
+	query := func(ffmpeg string) (string, error) {
+		runs++
+		return ffmpeg, nil
+	}
+
+	system := t.Context()
+	custom := analyzer.WithToolPaths(t.Context(), "/opt/ffmpeg/bin/ffmpeg", "/opt/ffmpeg/bin/ffprobe")
+	for _, tt := range []struct {
+		ctx      context.Context
+		want     string
+		wantRuns int
+	}{
+		{system, analyzer.FFmpegPath, 1},
+		{custom, "/opt/ffmpeg/bin/ffmpeg", 2},
+		{system, analyzer.FFmpegPath, 2},
+		{custom, "/opt/ffmpeg/bin/ffmpeg", 2},
+	} {
+		got, err := queries.get(tt.ctx, query)
+		if err != nil {
+			t.Fatalf("get() error = %v", err)
+		}
+		if got != tt.want {
+			t.Errorf("get() = %q, want %q", got, tt.want)
+		}
+		if runs != tt.wantRuns {
+			t.Errorf("query ran %d times, want %d", runs, tt.wantRuns)
+		}
+	}
+}
+
+func TestBuildQueriesCancelledNotCached(t *testing.T) {
+	var queries buildQueries[string]
+	runs := 0
+	query := func(ffmpeg string) (string, error) {
+		runs++
+		return ffmpeg, nil
+	}
+
+	cancelled, cancel := context.WithCancel(t.Context())
+	cancel()
+	queries.get(cancelled, query)
+	queries.get(t.Context(), query)
+	queries.get(t.Context(), query)
+	if runs != 2 {
+		t.Errorf("query ran %d times, want 2 (once cancelled, once cached)", runs)
+	}
+}
+
+func TestParseFFmpegVersion(t *testing.T) {
+	tests := []struct {
+		name    string
+		output  string
+		want    FFmpegVersion
+		wantErr bool
+	}{
+		{
+			name:   "distribution package",
+			output: "ffmpeg version 4.4.2-0ubuntu0.22.04.1 Copyright (c) 2000-2021 the FFmpeg developers\nbuilt with gcc 11",
+			want:   FFmpegVersion{Major: 4, Minor: 4, Raw: "4.4.2-0ubuntu0.22.04.1"},
+		},
+		{
+			name:   "release tag",
+			output: "ffmpeg version n6.1.1 Copyright (c) 2000-2023 the FFmpeg developers",
+			want:   FFmpegVersion{Major: 6, Minor: 1, Raw: "n6.1.1"},
+		},
+		{
+			name:   "static build",
+			output: "ffmpeg version 6.0-static https://johnvansickle.com/ffmpeg/  Copyright (c) 2000-2023",
+			want:   FFmpegVersion{Major: 6, Minor: 0, Raw: "6.0-static"},
+		},
+		{
+			name:   "git snapshot",
+			output: "ffmpeg version N-113684-g9d2e1f3 Copyright (c) 2000-2024 the FFmpeg developers",
+			want:   FFmpegVersion{Raw: "N-113684-g9d2e1f3", Development: true},
+		},
+		{
+			name:   "dated git snapshot",
+			output: "ffmpeg version 2024-02-26-git-a3ca4beeaa-full_build-www.gyan.dev Copyright (c) 2000-2024",
+			want:   FFmpegVersion{Raw: "2024-02-26-git-a3ca4beeaa-full_build-www.gyan.dev", Development: true},
+		},
+		{name: "not ffmpeg", output: "ffprobe version 6.1 Copyright (c) 2007-2023", wantErr: true},
+		{name: "unknown version", output: "ffmpeg version custom Copyright (c) 2000-2023", wantErr: true},
+		{name: "empty", output: "", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFFmpegVersion(tt.output)
+			if tt.wantErr {
+				if err == nil {
+					t.Fatalf("parseFFmpegVersion() = %+v, want an error", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("parseFFmpegVersion() error = %v", err)
+			}
+			if got != tt.want {
+				t.Errorf("parseFFmpegVersion() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFFmpegVersionAtLeast(t *testing.T) {
+	tests := []struct {
+		version      FFmpegVersion
+		major, minor int
+		want         bool
+	}{
+		{FFmpegVersion{Major: 4, Minor: 4}, 4, 4, true},
+		{FFmpegVersion{Major: 4, Minor: 4}, 5, 1, false},
+		{FFmpegVersion{Major: 6, Minor: 0}, 5, 1, true},
+		{FFmpegVersion{Major: 4, Minor: 1}, 4, 2, false},
+		{FFmpegVersion{Development: true}, 7, 0, true},
+	}
+
+	for _, tt := range tests {
+		if got := tt.version.AtLeast(tt.major, tt.minor); got != tt.want {
+			t.Errorf("%+v.AtLeast(%d, %d) = %v, want %v", tt.version, tt.major, tt.minor, got, tt.want)
+		}
+	}
+}
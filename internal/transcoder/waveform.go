@@ -0,0 +1,133 @@
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/fatih/color"
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+)
+
+var (
+	hexColorRegex   = regexp.MustCompile(`^#?([0-9a-fA-F]{6}|[0-9a-fA-F]{8})$`)
+	namedColorRegex = regexp.MustCompile(`^[a-zA-Z]+$`)
+)
+
+// WaveformParams holds parameters for drawing the waveform of an audio track as an image
+type WaveformParams struct {
+	InputFile     string // Input audio or video file path
+	OutputFile    string // Output image path; its extension selects the format (png, webp or jpg)
+	Width         int    // Image width in pixels
+	Height        int    // Image height in pixels
+	Color         string // Wave color: RRGGBB or RRGGBBAA hex, or an FFmpeg color name
+	SplitChannels bool   // Draw each channel in its own band instead of overlaid
+	Verbose       bool   // Verbose output
+}
+
+// CreateWaveform draws the whole first audio track of the input as a single waveform image
+// with FFmpeg's showwavespic filter. PNG and WebP keep the background transparent.
+func CreateWaveform(ctx context.Context, params WaveformParams) error {
+	waveColor, err := validateWaveformParams(ctx, params)
+	if err != nil {
+		return err
+	}
+
+	inputInfo, err := analyzeInputMedia(ctx, params.InputFile, params.Verbose)
+	if err != nil {
+		return err
+	}
+	if len(inputInfo.AudioStreams) == 0 {
+		return fmt.Errorf("input has no audio stream")
+	}
+
+	cmd := buildWaveformCommand(ctx, params, waveColor)
+	if params.Verbose {
+		color.Cyan("🌊 Waveform of %dx%d", params.Width, params.Height)
+		fmt.Printf("Command: %s\n\n", strings.Join(cmd.Args, " "))
+	}
+
+	// The image is drawn once the whole track is read, so there is no timeline to measure
+	// progress against
+	waveInfo := *inputInfo
+	waveInfo.Duration = 0
+	if err := executeFFmpeg(ctx, cmd, &waveInfo, params.Verbose); err != nil {
+		return stoppedBy(ctx, fmt.Errorf("ffmpeg execution failed: %w", err))
+	}
+	return nil
+}
+
+// validateWaveformParams validates paths, the image settings and the color, and returns the
+// color in the form FFmpeg filters take
+func validateWaveformParams(ctx context.Context, params WaveformParams) (string, error) {
+	if err := validateInputFile(params.InputFile); err != nil {
+		return "", err
+	}
+	if err := securityPolicy.ValidateFilePath(params.InputFile); err != nil {
+		return "", fmt.Errorf("security validation failed for input path: %w", err)
+	}
+	if err := securityPolicy.ValidateFilePath(params.OutputFile); err != nil {
+		return "", fmt.Errorf("security validation failed for output path: %w", err)
+	}
+
+	format := getFormatFromPath(params.OutputFile)
+	if !slices.Contains(FrameFormats, format) {
+		return "", fmt.Errorf("unsupported image format: %s (use %s)", format, strings.Join(FrameFormats, ", "))
+	}
+	if format == "webp" {
+		if encoders, err := AvailableEncoders(ctx); err == nil && !encoders["libwebp"] {
+			return "", fmt.Errorf("encoder libwebp is not available in this FFmpeg build (see ffmpeg -encoders)")
+		}
+	}
+
+	if params.Width < 16 || params.Width > 7680 {
+		return "", fmt.Errorf("invalid width %d (must be between 16 and 7680)", params.Width)
+	}
+	if params.Height < 16 || params.Height > 4320 {
+		return "", fmt.Errorf("invalid height %d (must be between 16 and 4320)", params.Height)
+	}
+	return parseFilterColor(params.Color)
+}
+
+// parseFilterColor accepts a hex color, with or without a leading #, or a color name, and
+// returns it as FFmpeg filters expect (e.g., "3498db" becomes "0x3498db")
+func parseFilterColor(value string) (string, error) {
+	switch {
+	case hexColorRegex.MatchString(value):
+		return "0x" + strings.ToLower(strings.TrimPrefix(value, "#")), nil
+	case namedColorRegex.MatchString(value):
+		return strings.ToLower(value), nil
+	default:
+		return "", fmt.Errorf("invalid color '%s' (use RRGGBB or RRGGBBAA hex, or a name such as white)", value)
+	}
+}
+
+// buildWaveformCommand draws the first audio track into one image
+func buildWaveformCommand(ctx context.Context, params WaveformParams, waveColor string) *exec.Cmd {
+	filter := fmt.Sprintf("showwavespic=s=%dx%d:colors=%s", params.Width, params.Height, waveColor)
+	if params.SplitChannels {
+		filter += ":split_channels=1"
+	}
+	graph := NewFilterGraph().Add([]string{"0:a:0"}, filter, []string{"waveform"})
+
+	args := []string{
+		"-i", params.InputFile,
+		"-filter_complex", graph.String(),
+		"-map", "[waveform]",
+		"-frames:v", "1",
+		"-update", "1",
+	}
+	switch getFormatFromPath(params.OutputFile) {
+	case "jpg":
+		// JPEG has no transparency; the background comes out black
+		args = append(args, "-q:v", "2")
+	case "webp":
+		args = append(args, "-c:v", "libwebp", "-lossless", "1")
+	}
+
+	args = append(args, "-y", params.OutputFile)
+	return exec.CommandContext(ctx, analyzer.FFmpeg(ctx), args...)
+}
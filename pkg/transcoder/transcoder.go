@@ -0,0 +1,311 @@
+// Package transcoder converts media, extracts audio and analyzes files with FFmpeg, for Go
+// programs that embed the transcoder instead of running the CLI. It applies the same codec
+// selection, stream copy decisions and input validation as the transcoder command.
+//
+//	t := transcoder.New(transcoder.WithFFmpegPath("/opt/ffmpeg/bin/ffmpeg"))
+//	err := t.Convert(ctx, "input.mkv", "output.mp4", transcoder.ConvertOptions{Preset: "high"})
+//
+// Cancelling ctx stops FFmpeg and ffprobe, and the method returns ctx's error.
+// A Transcoder's FFmpeg paths and progress settings only apply to its own method calls, so
+// Transcoders with different settings can be used concurrently.
+package transcoder
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+
+	"github.com/rishad1234/term-video-transcoder/internal/analyzer"
+	core "github.com/rishad1234/term-video-transcoder/internal/transcoder"
+)
+
+// Media information returned by Analyze
+type (
+	MediaInfo      = analyzer.MediaInfo
+	VideoStream    = analyzer.VideoStream
+	AudioStream    = analyzer.AudioStream
+	SubtitleStream = analyzer.SubtitleStream
+
+	// ConversionSummary compares a finished output with its input
+	ConversionSummary = core.ConversionSummary
+
+	// PreviewEstimate extrapolates the size and encoding time of a full conversion from a preview
+	PreviewEstimate = core.PreviewEstimate
+)
+
+// Progress reporting, see WithProgressFunc
+type (
+	// Progress is one progress update of a running FFmpeg process
+	Progress = core.Progress
+
+	// ProgressFunc receives progress updates from the goroutine reading FFmpeg's output
+	ProgressFunc = core.ProgressFunc
+)
+
+// Errors the methods can return, for use with errors.Is and errors.As
+type (
+	// FFmpegError is a failed FFmpeg run with the cause read from its stderr
+	FFmpegError = core.FFmpegError
+
+	// FFmpegErrorKind is the cause of an FFmpeg failure
+	FFmpegErrorKind = core.FFmpegErrorKind
+
+	// MissingToolError reports that ffmpeg or ffprobe cannot be run
+	MissingToolError = analyzer.MissingToolError
+)
+
+// ErrFileNotFound is wrapped by errors about input files that do not exist
+var ErrFileNotFound = analyzer.ErrFileNotFound
+
+// Transcoder runs conversions, extractions and analyses. Create one with New.
+type Transcoder struct {
+	ffmpegPath  string
+	ffprobePath string
+	verbose     bool
+	progress    io.Writer
+	onProgress  ProgressFunc
+}
+
+// Option configures a Transcoder
+type Option func(*Transcoder)
+
+// WithFFmpegPath sets the ffmpeg executable (default: ffmpeg from PATH)
+func WithFFmpegPath(path string) Option {
+	return func(t *Transcoder) {
+		t.ffmpegPath = path
+	}
+}
+
+// WithFFprobePath sets the ffprobe executable (default: ffprobe from PATH)
+func WithFFprobePath(path string) Option {
+	return func(t *Transcoder) {
+		t.ffprobePath = path
+	}
+}
+
+// WithVerbose prints status messages and FFmpeg's output to stdout, as the CLI does
+func WithVerbose(verbose bool) Option {
+	return func(t *Transcoder) {
+		t.verbose = verbose
+	}
+}
+
+// WithProgressEvents writes newline-delimited JSON progress events to w, in the format of
+// the CLI's --progress-format json. Without it no progress is reported.
+func WithProgressEvents(w io.Writer) Option {
+	return func(t *Transcoder) {
+		t.progress = w
+	}
+}
+
+// WithProgressFunc calls fn with the percentage, speed, ETA and frame count of every FFmpeg
+// run while it encodes, and once more with Done set when it exits. WithVerbose(true) then no
+// longer shows FFmpeg's own output.
+func WithProgressFunc(fn ProgressFunc) Option {
+	return func(t *Transcoder) {
+		t.onProgress = fn
+	}
+}
+
+// New creates a Transcoder with the given options
+func New(opts ...Option) *Transcoder {
+	t := &Transcoder{
+		ffmpegPath:  "ffmpeg",
+		ffprobePath: "ffprobe",
+		progress:    io.Discard,
+	}
+	for _, opt := range opts {
+		opt(t)
+	}
+	return t
+}
+
+// apply returns ctx carrying the Transcoder's FFmpeg paths and progress settings, which only
+// apply to the method's own runs
+func (t *Transcoder) apply(ctx context.Context) context.Context {
+	// Progress events also keep status messages off stdout; verbose output shows FFmpeg's own
+	events := t.progress
+	if t.verbose && t.progress == io.Discard && t.onProgress == nil {
+		events = nil
+	}
+	ctx = core.WithProgress(ctx, t.onProgress, events)
+	return analyzer.WithToolPaths(ctx, t.ffmpegPath, t.ffprobePath)
+}
+
+// CheckFFmpeg verifies that ffmpeg and ffprobe can be run
+func (t *Transcoder) CheckFFmpeg(ctx context.Context) error {
+	ctx = t.apply(ctx)
+	if err := analyzer.CheckFFMpeg(ctx); err != nil {
+		return err
+	}
+	return analyzer.CheckFFProbe(ctx)
+}
+
+// Analyze reads the format, duration and streams of a media file with ffprobe
+func (t *Transcoder) Analyze(ctx context.Context, path string) (*MediaInfo, error) {
+	ctx = t.apply(ctx)
+	return analyzer.AnalyzeMedia(ctx, path)
+}
+
+// Summarize reads a finished output back and compares it with its input
+func (t *Transcoder) Summarize(ctx context.Context, inputPath, outputPath string, elapsed time.Duration) (*ConversionSummary, error) {
+	ctx = t.apply(ctx)
+	return core.SummarizeConversion(ctx, inputPath, outputPath, elapsed)
+}
+
+// EstimateFromPreview extrapolates the size and encoding time of converting all of input from
+// a preview made with ConvertOptions.Preview, which took elapsed to encode
+func (t *Transcoder) EstimateFromPreview(ctx context.Context, inputPath, previewPath string, elapsed time.Duration) (*PreviewEstimate, error) {
+	ctx = t.apply(ctx)
+	return core.EstimateFromPreview(ctx, inputPath, previewPath, elapsed)
+}
+
+// ConvertOptions are the settings of a conversion. The zero value converts with the
+// medium preset, stream copying whatever the output container can hold.
+type ConvertOptions struct {
+	Preset       string // Quality preset: low, medium (default) or high
+	VideoCodec   string // Video encoder (e.g., "libx264", "copy"); chosen for the output format when empty
+	AudioCodec   string // Audio encoder (e.g., "aac", "copy"); chosen for the output format when empty
+	VideoBitrate string // Video bitrate (e.g., "2M"); from the preset when empty
+	AudioBitrate string // Audio bitrate (e.g., "192k"); from the preset when empty
+	Resolution   string // Output frame size (e.g., "1280x720")
+	Framerate    string // Output frame rate (e.g., "30")
+	Volume       string // Volume adjustment (e.g., "1.5", "+3dB")
+
+	ConstantFrameRate bool // Force constant frame rate output, detected from the input unless Framerate is set
+	Threads           int  // Threads FFmpeg may use (0 lets FFmpeg decide)
+
+	AudioStream   int    // 1-based audio stream to use (0 uses the first)
+	AudioLanguage string // Use the first audio stream with this language (e.g., "jpn")
+	NoAudio       bool   // Drop all audio streams
+
+	FixTimestamps bool   // Regenerate missing timestamps and shift negative ones to zero
+	WebOptimized  bool   // Move the MP4/MOV index to the front of the file
+	Fragmented    bool   // Write fragmented MP4
+	Target        string // Platform preset (e.g., "youtube")
+	RetryFallback bool   // Repeat a failed encode with safer settings
+
+	Preview   time.Duration // Encode only this much of the input, see EstimateFromPreview
+	PreviewAt time.Duration // Where in the input the preview starts
+
+	ExtraArgs []string // Extra FFmpeg output options; only allowlisted options and their values are accepted
+}
+
+// Convert converts input to output, whose format is taken from its extension
+func (t *Transcoder) Convert(ctx context.Context, input, output string, opts ConvertOptions) error {
+	preset, err := resolvePreset(opts.Preset)
+	if err != nil {
+		return err
+	}
+
+	params := core.CustomParameters{
+		VideoCodec:        opts.VideoCodec,
+		AudioCodec:        opts.AudioCodec,
+		VideoBitrate:      opts.VideoBitrate,
+		AudioBitrate:      opts.AudioBitrate,
+		Resolution:        opts.Resolution,
+		Framerate:         opts.Framerate,
+		Volume:            opts.Volume,
+		ConstantFrameRate: opts.ConstantFrameRate,
+		Threads:           opts.Threads,
+		AudioStream:       streamNumber(opts.AudioStream),
+		AudioLanguage:     opts.AudioLanguage,
+		NoAudio:           opts.NoAudio,
+		FixTimestamps:     opts.FixTimestamps,
+		WebOptimized:      opts.WebOptimized,
+		Fragmented:        opts.Fragmented,
+		Target:            opts.Target,
+		RetryFallback:     opts.RetryFallback,
+		Preview:           opts.Preview,
+		PreviewAt:         opts.PreviewAt,
+		ExtraArgs:         opts.ExtraArgs,
+	}
+	customParamsSet := opts.VideoCodec != "" || opts.AudioCodec != "" || opts.VideoBitrate != "" ||
+		opts.AudioBitrate != "" || opts.Resolution != "" || opts.Framerate != "" || opts.Volume != "" ||
+		opts.ConstantFrameRate || len(opts.ExtraArgs) > 0 || opts.Target != ""
+
+	ctx = t.apply(ctx)
+	return core.ConvertVideoWithCustomParams(ctx, input, output, preset, opts.Preset != "", customParamsSet, params, t.verbose)
+}
+
+// ExtractOptions are the settings of an audio extraction. The zero value extracts the
+// first audio stream with the medium preset, in a codec chosen for the output format.
+type ExtractOptions struct {
+	Quality    string // Quality preset: low, medium (default) or high
+	Codec      string // Audio encoder (e.g., "libmp3lame", "flac"); chosen for the output format when empty
+	Bitrate    string // Bitrate (e.g., "320k"); from the preset when empty
+	SampleRate string // Sample rate (e.g., "48000")
+	Channels   string // Number of channels (e.g., "2")
+	Volume     string // Volume adjustment (e.g., "1.5", "+3dB")
+	Stream     int    // 1-based audio stream to extract (0 uses the first)
+	Language   string // Extract the first audio stream with this language (e.g., "jpn")
+	Threads    int    // Threads FFmpeg may use (0 lets FFmpeg decide)
+}
+
+// ExtractAudio extracts one audio stream of input to output, whose format is taken from its extension
+func (t *Transcoder) ExtractAudio(ctx context.Context, input, output string, opts ExtractOptions) error {
+	params, err := t.extractionParams(input, output, opts)
+	if err != nil {
+		return err
+	}
+
+	ctx = t.apply(ctx)
+	return core.ExtractAudio(ctx, params)
+}
+
+// ExtractAllAudioTracks extracts every audio stream of input into its own file, named from
+// output with nameTemplate ("{name}.track{index}.{lang}" when empty). Existing files are
+// only replaced when overwrite is set. It returns the files written, also when it fails partway.
+func (t *Transcoder) ExtractAllAudioTracks(ctx context.Context, input, output, nameTemplate string, overwrite bool, opts ExtractOptions) ([]string, error) {
+	params, err := t.extractionParams(input, output, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx = t.apply(ctx)
+	return core.ExtractAllAudioTracks(ctx, params, nameTemplate, overwrite)
+}
+
+// extractionParams converts ExtractOptions to the parameters of the extraction code
+func (t *Transcoder) extractionParams(input, output string, opts ExtractOptions) (core.AudioExtractionParams, error) {
+	quality, err := resolvePreset(opts.Quality)
+	if err != nil {
+		return core.AudioExtractionParams{}, err
+	}
+
+	return core.AudioExtractionParams{
+		InputFile:  input,
+		OutputFile: output,
+		Quality:    quality,
+		Bitrate:    opts.Bitrate,
+		Codec:      opts.Codec,
+		SampleRate: opts.SampleRate,
+		Channels:   opts.Channels,
+		Volume:     opts.Volume,
+		Stream:     streamNumber(opts.Stream),
+		Language:   opts.Language,
+		Threads:    opts.Threads,
+		Verbose:    t.verbose,
+	}, nil
+}
+
+// resolvePreset checks a quality preset, defaulting to medium
+func resolvePreset(preset string) (string, error) {
+	switch preset {
+	case "":
+		return "medium", nil
+	case "low", "medium", "high":
+		return preset, nil
+	}
+	return "", fmt.Errorf("invalid preset '%s'. Valid options: low, medium, high", preset)
+}
+
+// streamNumber formats a 1-based stream number, leaving 0 (no selection) empty
+func streamNumber(stream int) string {
+	if stream == 0 {
+		return ""
+	}
+	return strconv.Itoa(stream)
+}